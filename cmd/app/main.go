@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
+
 	"github.com/amirdashtii/AutoBan/config"
 	"github.com/amirdashtii/AutoBan/internal/interface/controller"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
 	"github.com/amirdashtii/AutoBan/pkg/logger"
 
 	_ "github.com/amirdashtii/AutoBan/docs"
@@ -40,15 +43,57 @@ import (
 // @tag.name        User - Vehicles
 // @tag.description User vehicle management
 
+// @tag.name        Vehicle Transfers
+// @tag.description Vehicle ownership transfers and ownership history
+
+// @tag.name        Vehicle Members
+// @tag.description Sharing vehicles with family members and drivers
+
+// @tag.name        Vehicle Share Links
+// @tag.description Public read-only links to a vehicle's service history
+
 // @tag.name        Service Visits
 // @tag.description Service visit management operations
 
+// @tag.name        Service Items
+// @tag.description Service item management operations
+
+// @tag.name        Attachments
+// @tag.description Service visit invoice and receipt uploads
+
 // @tag.name        Oil Changes
 // @tag.description Oil change management operations
 
 // @tag.name        Oil Filters
 // @tag.description Oil filter management operations
 
+// @tag.name        Odometer Readings
+// @tag.description Odometer reading management operations
+
+// @tag.name        Reminders
+// @tag.description Maintenance reminder operations
+
+// @tag.name        Vehicle Documents
+// @tag.description Insurance and technical inspection documents
+
+// @tag.name        Vehicle Expenses
+// @tag.description Parking, tolls, fines and other running costs
+
+// @tag.name        Tires
+// @tag.description Tire sets, seasonal swaps, rotations and tread depths
+
+// @tag.name        Fuel Logs
+// @tag.description Fill-ups and fuel economy
+
+// @tag.name        Cost Reports
+// @tag.description Maintenance spending and cost of ownership reports
+
+// @tag.name        Service Centers
+// @tag.description Service center directory, nearby search and reviews
+
+// @tag.name        Search
+// @tag.description Persian and English search across the vehicle catalog
+
 // @tag.name        Types
 // @tag.description Vehicle types management
 
@@ -67,6 +112,9 @@ import (
 // @tag.name        Admin - UserVehicles
 // @tag.description Admin user vehicle management operations
 
+// @tag.name        Admin - Service Centers
+// @tag.description Admin service center directory management
+
 // @tag.name        Admin - Types
 // @tag.description Admin vehicle type management operations
 
@@ -79,6 +127,12 @@ import (
 // @tag.name        Admin - Generations
 // @tag.description Admin vehicle generation management operations
 
+// @tag.name        Admin - Catalog
+// @tag.description Bulk import and export of the vehicle catalog
+
+// @tag.name        Admin - VIN Decoder
+// @tag.description WMI table that maps VINs to catalog brands and models
+
 func main() {
 	logger.InitLogger()
 	config, err := config.GetConfig()
@@ -103,10 +157,33 @@ func main() {
 	controller.UserRoutes(r)
 	controller.AdminRoutes(r)
 	controller.VehicleRoutes(r)
+	controller.CatalogRoutes(r)
+	controller.VINRoutes(r)
+	controller.VehicleTransferRoutes(r)
+	controller.VehicleMembershipRoutes(r)
+	controller.VehicleShareLinkRoutes(r)
 	controller.ServiceVisitRoutes(r)
+	controller.ServiceItemRoutes(r)
+	controller.AttachmentRoutes(r)
 	controller.OilChangeRoutes(r)
 	controller.OilFilterRoutes(r)
+	controller.OdometerReadingRoutes(r)
+	controller.ReminderRoutes(r)
+	controller.MaintenanceScheduleRoutes(r)
+	controller.VehicleDocumentRoutes(r)
+	controller.VehicleExpenseRoutes(r)
+	controller.TireRoutes(r)
+	controller.FuelLogRoutes(r)
+	controller.CostReportRoutes(r)
+	controller.ServiceCenterRoutes(r)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Background SMS reminders for maintenance that is due soon or overdue
+	if config.Reminder.NotifierEnabled {
+		if notifier := usecase.NewReminderNotifier(); notifier != nil {
+			go notifier.Start(context.Background())
+		}
+	}
+
 	r.Run(config.Server.Address + ":" + config.Server.Port) // listen and serve on specified address and port
 }
@@ -0,0 +1,95 @@
+// Command catalog imports and exports the vehicle catalog from the command line, using the same
+// rules as the admin catalog endpoints.
+//
+//	catalog export [-format json|csv] [-output file]
+//	catalog import [-dry-run] [-prune] file
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+)
+
+func main() {
+	logger.InitLogger()
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "export":
+		export(os.Args[2:])
+	case "import":
+		importCatalog(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	fmt.Fprintln(os.Stderr, "  catalog export [-format json|csv] [-output file]")
+	fmt.Fprintln(os.Stderr, "  catalog import [-dry-run] [-prune] file")
+	os.Exit(2)
+}
+
+func export(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	format := flags.String("format", "json", "file format: json or csv")
+	output := flags.String("output", "", "file to write, standard output when empty")
+	flags.Parse(args)
+
+	file, err := usecase.NewCatalogUseCase().ExportCatalog(context.Background(), dto.ExportCatalogRequest{Format: *format})
+	if err != nil {
+		logger.Fatalf("Failed to export catalog: %v", err)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(file.Content)
+		return
+	}
+	if err := os.WriteFile(*output, file.Content, 0o644); err != nil {
+		logger.Fatalf("Failed to write %s: %v", *output, err)
+	}
+}
+
+func importCatalog(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "report the changes without importing")
+	prune := flags.Bool("prune", false, "delete rows missing from the file")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		usage()
+	}
+
+	fileName := flags.Arg(0)
+	file, err := os.Open(fileName)
+	if err != nil {
+		logger.Fatalf("Failed to open %s: %v", fileName, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		logger.Fatalf("Failed to read %s: %v", fileName, err)
+	}
+
+	request := dto.ImportCatalogRequest{DryRun: *dryRun, Prune: *prune}
+	response, err := usecase.NewCatalogUseCase().ImportCatalog(context.Background(), fileName, info.Size(), file, request)
+	if err != nil {
+		logger.Fatalf("Failed to import catalog: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(response)
+	if len(response.Errors) > 0 {
+		os.Exit(1)
+	}
+}
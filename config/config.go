@@ -35,7 +35,50 @@ type Config struct {
 	SMS struct {
 		BaseURL string `mapstructure:"base_url"`
 		XAPIKey string `mapstructure:"x_api_key"`
+
+		ReminderTemplateID string `mapstructure:"reminder_template_id"`
+		TransferTemplateID string `mapstructure:"transfer_template_id"`
 	} `mapstructure:"sms"`
+	Reminder struct {
+		DueSoonMileage int `mapstructure:"due_soon_mileage"`
+		DueSoonDays    int `mapstructure:"due_soon_days"`
+		// DocumentDueSoonDays is how long before expiry insurance and inspection documents are due soon
+		DocumentDueSoonDays int `mapstructure:"document_due_soon_days"`
+
+		NotifierEnabled     bool   `mapstructure:"notifier_enabled"`
+		ScanIntervalMinutes int    `mapstructure:"scan_interval_minutes"`
+		QuietHoursStart     int    `mapstructure:"quiet_hours_start"`
+		QuietHoursEnd       int    `mapstructure:"quiet_hours_end"`
+		Timezone            string `mapstructure:"timezone"`
+	} `mapstructure:"reminder"`
+	Storage struct {
+		Driver    string `mapstructure:"driver"`
+		LocalPath string `mapstructure:"local_path"`
+
+		S3Endpoint  string `mapstructure:"s3_endpoint"`
+		S3Region    string `mapstructure:"s3_region"`
+		S3Bucket    string `mapstructure:"s3_bucket"`
+		S3AccessKey string `mapstructure:"s3_access_key"`
+		S3SecretKey string `mapstructure:"s3_secret_key"`
+	} `mapstructure:"storage"`
+	Attachment struct {
+		MaxFileSizeMB int `mapstructure:"max_file_size_mb"`
+		UserQuotaMB   int `mapstructure:"user_quota_mb"`
+	} `mapstructure:"attachment"`
+	Export struct {
+		PDFFontPath string `mapstructure:"pdf_font_path"`
+	} `mapstructure:"export"`
+	ShareLink struct {
+		Secret             string `mapstructure:"secret"`
+		BaseURL            string `mapstructure:"base_url"`
+		RateLimitPerMinute int    `mapstructure:"rate_limit_per_minute"`
+	} `mapstructure:"share_link"`
+	Tire struct {
+		// MinTreadDepthMM is the tread depth at or below which a set should be replaced soon
+		MinTreadDepthMM float64 `mapstructure:"min_tread_depth_mm"`
+		// MaxAgeYears is the age, counted from the DOT date code, at which a set should be replaced soon
+		MaxAgeYears int `mapstructure:"max_age_years"`
+	} `mapstructure:"tire"`
 }
 
 var (
@@ -103,6 +146,36 @@ func setDefaultValues(v *viper.Viper) {
 
 	v.SetDefault("sms.base_url", "https://api.sms.ir")
 	v.SetDefault("sms.x_api_key", "Aklc5AKdy02FdA03TCwEIZeB6gJ2s0fVv80ejWhUyfS4xpbw")
+	v.SetDefault("sms.reminder_template_id", "")
+	v.SetDefault("sms.transfer_template_id", "")
+
+	v.SetDefault("reminder.due_soon_mileage", 1000)
+	v.SetDefault("reminder.due_soon_days", 14)
+	v.SetDefault("reminder.document_due_soon_days", 30)
+	v.SetDefault("reminder.notifier_enabled", false)
+	v.SetDefault("reminder.scan_interval_minutes", 60)
+	v.SetDefault("reminder.quiet_hours_start", 22)
+	v.SetDefault("reminder.quiet_hours_end", 8)
+	v.SetDefault("reminder.timezone", "Asia/Tehran")
+
+	v.SetDefault("storage.driver", "local")
+	v.SetDefault("storage.local_path", "./uploads")
+	v.SetDefault("storage.s3_endpoint", "http://localhost:9000")
+	v.SetDefault("storage.s3_region", "us-east-1")
+	v.SetDefault("storage.s3_bucket", "autoban")
+	v.SetDefault("storage.s3_access_key", "autoban")
+	v.SetDefault("storage.s3_secret_key", "autoban")
+
+	v.SetDefault("attachment.max_file_size_mb", 10)
+	v.SetDefault("attachment.user_quota_mb", 200)
+	v.SetDefault("export.pdf_font_path", "/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf")
+
+	v.SetDefault("share_link.secret", "mysharesecret")
+	v.SetDefault("share_link.base_url", "http://localhost:8080")
+	v.SetDefault("share_link.rate_limit_per_minute", 30)
+
+	v.SetDefault("tire.min_tread_depth_mm", 3.0)
+	v.SetDefault("tire.max_age_years", 6)
 }
 
 func readYAMLConfig(v *viper.Viper) {
@@ -146,4 +219,34 @@ func readEnvConfig(v *viper.Viper) {
 
 	if v.IsSet("SMS_BASE_URL") { v.Set("sms.base_url", v.GetString("SMS_BASE_URL")) }
 	if v.IsSet("SMS_X_API_KEY") { v.Set("sms.x_api_key", v.GetString("SMS_X_API_KEY")) }
+	if v.IsSet("SMS_REMINDER_TEMPLATE_ID") { v.Set("sms.reminder_template_id", v.GetString("SMS_REMINDER_TEMPLATE_ID")) }
+	if v.IsSet("SMS_TRANSFER_TEMPLATE_ID") { v.Set("sms.transfer_template_id", v.GetString("SMS_TRANSFER_TEMPLATE_ID")) }
+
+	if v.IsSet("REMINDER_DUE_SOON_MILEAGE") { v.Set("reminder.due_soon_mileage", v.GetString("REMINDER_DUE_SOON_MILEAGE")) }
+	if v.IsSet("REMINDER_DUE_SOON_DAYS") { v.Set("reminder.due_soon_days", v.GetString("REMINDER_DUE_SOON_DAYS")) }
+	if v.IsSet("REMINDER_DOCUMENT_DUE_SOON_DAYS") { v.Set("reminder.document_due_soon_days", v.GetString("REMINDER_DOCUMENT_DUE_SOON_DAYS")) }
+	if v.IsSet("REMINDER_NOTIFIER_ENABLED") { v.Set("reminder.notifier_enabled", v.GetString("REMINDER_NOTIFIER_ENABLED")) }
+	if v.IsSet("REMINDER_SCAN_INTERVAL_MINUTES") { v.Set("reminder.scan_interval_minutes", v.GetString("REMINDER_SCAN_INTERVAL_MINUTES")) }
+	if v.IsSet("REMINDER_QUIET_HOURS_START") { v.Set("reminder.quiet_hours_start", v.GetString("REMINDER_QUIET_HOURS_START")) }
+	if v.IsSet("REMINDER_QUIET_HOURS_END") { v.Set("reminder.quiet_hours_end", v.GetString("REMINDER_QUIET_HOURS_END")) }
+	if v.IsSet("REMINDER_TIMEZONE") { v.Set("reminder.timezone", v.GetString("REMINDER_TIMEZONE")) }
+
+	if v.IsSet("STORAGE_DRIVER") { v.Set("storage.driver", v.GetString("STORAGE_DRIVER")) }
+	if v.IsSet("STORAGE_LOCAL_PATH") { v.Set("storage.local_path", v.GetString("STORAGE_LOCAL_PATH")) }
+	if v.IsSet("STORAGE_S3_ENDPOINT") { v.Set("storage.s3_endpoint", v.GetString("STORAGE_S3_ENDPOINT")) }
+	if v.IsSet("STORAGE_S3_REGION") { v.Set("storage.s3_region", v.GetString("STORAGE_S3_REGION")) }
+	if v.IsSet("STORAGE_S3_BUCKET") { v.Set("storage.s3_bucket", v.GetString("STORAGE_S3_BUCKET")) }
+	if v.IsSet("STORAGE_S3_ACCESS_KEY") { v.Set("storage.s3_access_key", v.GetString("STORAGE_S3_ACCESS_KEY")) }
+	if v.IsSet("STORAGE_S3_SECRET_KEY") { v.Set("storage.s3_secret_key", v.GetString("STORAGE_S3_SECRET_KEY")) }
+
+	if v.IsSet("ATTACHMENT_MAX_FILE_SIZE_MB") { v.Set("attachment.max_file_size_mb", v.GetString("ATTACHMENT_MAX_FILE_SIZE_MB")) }
+	if v.IsSet("ATTACHMENT_USER_QUOTA_MB") { v.Set("attachment.user_quota_mb", v.GetString("ATTACHMENT_USER_QUOTA_MB")) }
+	if v.IsSet("EXPORT_PDF_FONT_PATH") { v.Set("export.pdf_font_path", v.GetString("EXPORT_PDF_FONT_PATH")) }
+
+	if v.IsSet("SHARE_LINK_SECRET") { v.Set("share_link.secret", v.GetString("SHARE_LINK_SECRET")) }
+	if v.IsSet("SHARE_LINK_BASE_URL") { v.Set("share_link.base_url", v.GetString("SHARE_LINK_BASE_URL")) }
+	if v.IsSet("SHARE_LINK_RATE_LIMIT_PER_MINUTE") { v.Set("share_link.rate_limit_per_minute", v.GetString("SHARE_LINK_RATE_LIMIT_PER_MINUTE")) }
+
+	if v.IsSet("TIRE_MIN_TREAD_DEPTH_MM") { v.Set("tire.min_tread_depth_mm", v.GetString("TIRE_MIN_TREAD_DEPTH_MM")) }
+	if v.IsSet("TIRE_MAX_AGE_YEARS") { v.Set("tire.max_age_years", v.GetString("TIRE_MAX_AGE_YEARS")) }
 }
@@ -0,0 +1,17 @@
+package entity
+
+import "github.com/google/uuid"
+
+// ServiceVisitAttachment is an uploaded invoice or receipt of a service visit. The file itself
+// lives in the storage backend under StorageKey
+type ServiceVisitAttachment struct {
+	BaseModel
+
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserVehicleID  uint64    `gorm:"not null"`
+	ServiceVisitID uuid.UUID `gorm:"type:uuid;not null;index"`
+	FileName       string    `gorm:"not null"`
+	ContentType    string    `gorm:"not null"`
+	Size           int64     `gorm:"not null"`
+	StorageKey     string    `gorm:"not null;uniqueIndex"`
+}
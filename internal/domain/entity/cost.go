@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Rial is an amount of money in Iranian Rials, the unit every cost is stored in
+type Rial int64
+
+// Toman converts the amount to Toman, the unit prices are usually quoted in (1 Toman = 10 Rials)
+func (r Rial) Toman() int64 {
+	return int64(r) / 10
+}
+
+// FormatToman renders the amount in Toman with Persian digits for display, e.g. "۱۲۵٬۰۰۰ تومان"
+func (r Rial) FormatToman() string {
+	toman := r.Toman()
+	sign := ""
+	if toman < 0 {
+		sign = "-"
+		toman = -toman
+	}
+
+	digits := strconv.FormatInt(toman, 10)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteRune('٬')
+		}
+		b.WriteRune('۰' + (digit - '0'))
+	}
+	return sign + b.String() + " تومان"
+}
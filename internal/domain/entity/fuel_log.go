@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FuelType int
+
+const (
+	GasolineFuel FuelType = iota
+	DieselFuel
+	CNGFuel
+)
+
+func (f FuelType) String() string {
+	switch f {
+	case DieselFuel:
+		return "diesel"
+	case CNGFuel:
+		return "cng"
+	default:
+		return "gasoline"
+	}
+}
+
+// Unit is the unit fill-ups of this fuel are measured in. CNG is sold by the cubic metre
+func (f FuelType) Unit() string {
+	if f == CNGFuel {
+		return "m3"
+	}
+	return "L"
+}
+
+func ParseFuelType(s string) FuelType {
+	switch strings.ToLower(s) {
+	case "diesel":
+		return DieselFuel
+	case "cng":
+		return CNGFuel
+	default:
+		return GasolineFuel
+	}
+}
+
+// AllowedFuelTypes derives the fuels a vehicle can take from VehicleGeneration.FuelType.
+// The primary fuel comes first. Bi-fuel generations accept gasoline and CNG, and
+// unknown values accept every fuel so a gap in the catalog never blocks a fill-up
+func AllowedFuelTypes(generationFuelType string) []FuelType {
+	fuel := strings.ToLower(generationFuelType)
+	contains := func(keywords ...string) bool {
+		for _, keyword := range keywords {
+			if strings.Contains(fuel, keyword) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if contains("diesel", "دیزل", "گازوئیل") {
+		return []FuelType{DieselFuel}
+	}
+	hasCNG := contains("cng", "دوگانه", "گاز")
+	hasGasoline := contains("gasoline", "petrol", "hybrid", "bi-fuel", "dual", "بنزین", "هیبرید", "دوگانه")
+	switch {
+	case hasGasoline && hasCNG:
+		return []FuelType{GasolineFuel, CNGFuel}
+	case hasCNG:
+		return []FuelType{CNGFuel}
+	case hasGasoline:
+		return []FuelType{GasolineFuel}
+	default:
+		return []FuelType{GasolineFuel, DieselFuel, CNGFuel}
+	}
+}
+
+// FuelLog is a fill-up of a user vehicle
+type FuelLog struct {
+	BaseModel
+
+	UserID        uuid.UUID `gorm:"type:uuid;not null"`
+	UserVehicleID uint64    `gorm:"not null"`
+	FillDate      time.Time `gorm:"not null"`
+	Mileage       uint      `gorm:"not null"`
+	FuelType      FuelType
+	// Litres is the amount filled, in cubic metres for CNG
+	Litres        float64 `gorm:"not null"`
+	PricePerLitre Rial
+	TotalCost     Rial
+	// IsFullTank marks a fill-up to the top, the points fuel economy is measured between
+	IsFullTank bool
+	Station    string
+	Notes      string
+}
+
+// FuelEconomy is the consumption measured between two consecutive full fill-ups of the same fuel
+type FuelEconomy struct {
+	FuelType FuelType
+	// FuelLogID is the full fill-up closing the interval
+	FuelLogID uint64
+	Distance  uint
+	Litres    float64
+	// Consumption is in litres (or cubic metres) per 100 km
+	Consumption float64
+}
+
+// CalculateFuelEconomy applies the full-to-full method to each fuel separately: everything
+// filled after a full tank, up to and including the next full tank, was burnt over the
+// distance between the two. Partial fill-ups before the first full tank are ignored.
+// On bi-fuel vehicles the distance is shared by both fuels, so each figure reads high
+func CalculateFuelEconomy(fuelLogs []FuelLog) []FuelEconomy {
+	sorted := make([]FuelLog, len(fuelLogs))
+	copy(sorted, fuelLogs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Mileage == sorted[j].Mileage {
+			return sorted[i].FillDate.Before(sorted[j].FillDate)
+		}
+		return sorted[i].Mileage < sorted[j].Mileage
+	})
+
+	economies := []FuelEconomy{}
+	lastFull := map[FuelType]*FuelLog{}
+	litresSinceFull := map[FuelType]float64{}
+	for i := range sorted {
+		fuelLog := &sorted[i]
+		previous := lastFull[fuelLog.FuelType]
+		if previous == nil {
+			if fuelLog.IsFullTank {
+				lastFull[fuelLog.FuelType] = fuelLog
+			}
+			continue
+		}
+
+		litresSinceFull[fuelLog.FuelType] += fuelLog.Litres
+		if !fuelLog.IsFullTank {
+			continue
+		}
+
+		distance := fuelLog.Mileage - previous.Mileage
+		if distance > 0 {
+			litres := litresSinceFull[fuelLog.FuelType]
+			economies = append(economies, FuelEconomy{
+				FuelType:    fuelLog.FuelType,
+				FuelLogID:   fuelLog.ID,
+				Distance:    distance,
+				Litres:      litres,
+				Consumption: litres / float64(distance) * 100,
+			})
+		}
+		lastFull[fuelLog.FuelType] = fuelLog
+		litresSinceFull[fuelLog.FuelType] = 0
+	}
+	return economies
+}
+
+// AverageConsumption is the distance-weighted consumption of the given intervals, 0 when there are none
+func AverageConsumption(economies []FuelEconomy) float64 {
+	var distance uint
+	var litres float64
+	for _, economy := range economies {
+		distance += economy.Distance
+		litres += economy.Litres
+	}
+	if distance == 0 {
+		return 0
+	}
+	return litres / float64(distance) * 100
+}
@@ -0,0 +1,220 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/amirdashtii/AutoBan/pkg/persian"
+)
+
+type LicensePlateCategory int
+
+const (
+	// PrivateLicensePlate is the white plate of personal cars
+	PrivateLicensePlate LicensePlateCategory = iota
+	TaxiLicensePlate
+	PublicTransportLicensePlate
+	AgriculturalLicensePlate
+	GovernmentLicensePlate
+	CeremonialLicensePlate
+	PoliceLicensePlate
+	MilitaryLicensePlate
+	DisabledLicensePlate
+	TemporaryLicensePlate
+	DiplomaticLicensePlate
+	MotorcycleLicensePlate
+	FreeZoneLicensePlate
+)
+
+func (c LicensePlateCategory) String() string {
+	switch c {
+	case TaxiLicensePlate:
+		return "taxi"
+	case PublicTransportLicensePlate:
+		return "public_transport"
+	case AgriculturalLicensePlate:
+		return "agricultural"
+	case GovernmentLicensePlate:
+		return "government"
+	case CeremonialLicensePlate:
+		return "ceremonial"
+	case PoliceLicensePlate:
+		return "police"
+	case MilitaryLicensePlate:
+		return "military"
+	case DisabledLicensePlate:
+		return "disabled"
+	case TemporaryLicensePlate:
+		return "temporary"
+	case DiplomaticLicensePlate:
+		return "diplomatic"
+	case MotorcycleLicensePlate:
+		return "motorcycle"
+	case FreeZoneLicensePlate:
+		return "free_zone"
+	default:
+		return "private"
+	}
+}
+
+// Label is the Persian name of the category
+func (c LicensePlateCategory) Label() string {
+	switch c {
+	case TaxiLicensePlate:
+		return "تاکسی"
+	case PublicTransportLicensePlate:
+		return "حمل‌ونقل عمومی"
+	case AgriculturalLicensePlate:
+		return "کشاورزی"
+	case GovernmentLicensePlate:
+		return "دولتی"
+	case CeremonialLicensePlate:
+		return "تشریفات"
+	case PoliceLicensePlate:
+		return "انتظامی"
+	case MilitaryLicensePlate:
+		return "نظامی"
+	case DisabledLicensePlate:
+		return "جانبازان و معلولین"
+	case TemporaryLicensePlate:
+		return "گذر موقت"
+	case DiplomaticLicensePlate:
+		return "سیاسی"
+	case MotorcycleLicensePlate:
+		return "موتورسیکلت"
+	case FreeZoneLicensePlate:
+		return "مناطق آزاد"
+	default:
+		return "شخصی"
+	}
+}
+
+// licensePlateLetters maps the letter of a car plate to the plate's category
+var licensePlateLetters = map[string]LicensePlateCategory{
+	// پلاک سفید عادی
+	"ب": PrivateLicensePlate, "ج": PrivateLicensePlate, "د": PrivateLicensePlate, "س": PrivateLicensePlate,
+	"ص": PrivateLicensePlate, "ط": PrivateLicensePlate, "ق": PrivateLicensePlate, "ل": PrivateLicensePlate,
+	"م": PrivateLicensePlate, "ن": PrivateLicensePlate, "و": PrivateLicensePlate, "ه": PrivateLicensePlate,
+	"ی": PrivateLicensePlate,
+	// پلاک زرد تاکسی، حمل‌ونقل عمومی و وسایل کشاورزی
+	"ت": TaxiLicensePlate,
+	"ع": PublicTransportLicensePlate,
+	"ک": AgriculturalLicensePlate,
+	// پلاک قرمز دولتی و تشریفات
+	"الف":     GovernmentLicensePlate,
+	"تشریفات": CeremonialLicensePlate,
+	// پلاک سبز فراجا و سپاه، آبی وزارت دفاع و ستاد کل، خاکی ارتش
+	"پ": PoliceLicensePlate,
+	"ث": MilitaryLicensePlate,
+	"ز": MilitaryLicensePlate,
+	"ف": MilitaryLicensePlate,
+	"ش": MilitaryLicensePlate,
+	// پلاک جانبازان و معلولین و گذر موقت
+	"ژ": DisabledLicensePlate,
+	"گ": TemporaryLicensePlate,
+	// پلاک آبی سیاسی و خدمات سفارت
+	"D": DiplomaticLicensePlate,
+	"S": DiplomaticLicensePlate,
+	"T": DiplomaticLicensePlate,
+}
+
+// FreeZones are the free trade zones with their own plates
+var FreeZones = []string{"کیش", "قشم", "چابهار", "ارس", "انزلی", "اروند", "ماکو"}
+
+var (
+	// e.g. 12ب34567, the region code is the number under "ایران"
+	carLicensePlatePattern = regexp.MustCompile(`^(\d{2})(الف|تشریفات|[بجدسصطقلمنوهیتعکپثزفشژگDST])(\d{3})(\d{2})$`)
+	// e.g. 12تشریفات3456
+	ceremonialLicensePlatePattern = regexp.MustCompile(`^(\d{2})(تشریفات)(\d{4})$`)
+	// e.g. 12345678, a three-digit region code above a five-digit number
+	motorcycleLicensePlatePattern = regexp.MustCompile(`^(\d{3})(\d{5})$`)
+	// e.g. 12345کیش or کیش12345
+	freeZoneLicensePlatePattern = regexp.MustCompile(`^(?:(\d{5})(` + strings.Join(FreeZones, "|") + `)|(` + strings.Join(FreeZones, "|") + `)(\d{5}))$`)
+)
+
+var ErrInvalidLicensePlate = errors.New("invalid license plate")
+
+// LicensePlate is an Iranian license plate split into its parts. Car plates read "12 ب 345 ایران 67": TwoDigits,
+// Letter, ThreeDigits and RegionCode. Motorcycle plates have a three-digit RegionCode above a five-digit Number,
+// free zone plates a five-digit Number and the FreeZone, and the old ceremonial plates a four-digit Number
+type LicensePlate struct {
+	Category    LicensePlateCategory
+	TwoDigits   string
+	Letter      string
+	ThreeDigits string
+	RegionCode  string
+	Number      string
+	FreeZone    string
+}
+
+// ParseLicensePlate reads a plate typed with Persian, Arabic or Latin digits, Arabic ي and ك, the word "ایران"
+// and any spaces, dashes or other separators between the parts
+func ParseLicensePlate(value string) (LicensePlate, error) {
+	compact := strings.ReplaceAll(persian.Normalize(value), "ایران", "")
+	compact = strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9', r >= 'A' && r <= 'Z', r >= 'آ' && r <= 'ی':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		default:
+			return -1
+		}
+	}, compact)
+
+	if m := carLicensePlatePattern.FindStringSubmatch(compact); m != nil {
+		return LicensePlate{Category: licensePlateLetters[m[2]], TwoDigits: m[1], Letter: m[2], ThreeDigits: m[3], RegionCode: m[4]}, nil
+	}
+	if m := ceremonialLicensePlatePattern.FindStringSubmatch(compact); m != nil {
+		return LicensePlate{Category: CeremonialLicensePlate, TwoDigits: m[1], Letter: m[2], Number: m[3]}, nil
+	}
+	if m := motorcycleLicensePlatePattern.FindStringSubmatch(compact); m != nil {
+		return LicensePlate{Category: MotorcycleLicensePlate, RegionCode: m[1], Number: m[2]}, nil
+	}
+	if m := freeZoneLicensePlatePattern.FindStringSubmatch(compact); m != nil {
+		if m[1] != "" {
+			return LicensePlate{Category: FreeZoneLicensePlate, Number: m[1], FreeZone: m[2]}, nil
+		}
+		return LicensePlate{Category: FreeZoneLicensePlate, Number: m[4], FreeZone: m[3]}, nil
+	}
+	return LicensePlate{}, ErrInvalidLicensePlate
+}
+
+// NormalizeLicensePlate returns the stored form of a plate, or the value unchanged when it is not a plate
+func NormalizeLicensePlate(value string) string {
+	plate, err := ParseLicensePlate(value)
+	if err != nil {
+		return value
+	}
+	return plate.String()
+}
+
+// String renders the plate in the form it is stored and compared in, e.g. "12-ب-345-67", "123-45678" for
+// motorcycles and "12345-کیش" for free zones
+func (p LicensePlate) String() string {
+	switch {
+	case p.Category == MotorcycleLicensePlate:
+		return p.RegionCode + "-" + p.Number
+	case p.Category == FreeZoneLicensePlate:
+		return p.Number + "-" + p.FreeZone
+	case p.Number != "":
+		return p.TwoDigits + "-" + p.Letter + "-" + p.Number
+	default:
+		return p.TwoDigits + "-" + p.Letter + "-" + p.ThreeDigits + "-" + p.RegionCode
+	}
+}
+
+// Display renders the plate the way it reads on the vehicle, e.g. "12 ب 345 ایران 67"
+func (p LicensePlate) Display() string {
+	switch {
+	case p.Category == MotorcycleLicensePlate:
+		return p.RegionCode + " " + p.Number
+	case p.Category == FreeZoneLicensePlate:
+		return p.Number + " " + p.FreeZone
+	case p.Number != "":
+		return p.TwoDigits + " " + p.Letter + " " + p.Number
+	default:
+		return p.TwoDigits + " " + p.Letter + " " + p.ThreeDigits + " ایران " + p.RegionCode
+	}
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// MaintenanceInterval is a manufacturer maintenance interval of a vehicle generation,
+// e.g. oil every 5,000 km or 6 months. Either limit may be left at zero
+type MaintenanceInterval struct {
+	BaseModel
+
+	GenerationID uint64 `gorm:"not null;uniqueIndex:idx_generation_item_type"`
+	// ItemType is oil_change, oil_filter or a service item type
+	ItemType        string `gorm:"not null;uniqueIndex:idx_generation_item_type"`
+	NameFa          string `gorm:"not null"`
+	NameEn          string
+	IntervalMileage uint
+	IntervalMonths  int
+}
+
+// MaintenancePlanItem is a manufacturer interval copied onto a user vehicle when it is added,
+// together with the mileage and date the plan started from
+type MaintenancePlanItem struct {
+	BaseModel
+
+	UserVehicleID         uint64 `gorm:"not null;uniqueIndex:idx_user_vehicle_item_type"`
+	MaintenanceIntervalID uint64
+	ItemType              string `gorm:"not null;uniqueIndex:idx_user_vehicle_item_type"`
+	NameFa                string `gorm:"not null"`
+	NameEn                string
+	IntervalMileage       uint
+	IntervalMonths        int
+	StartMileage          uint
+	StartDate             time.Time
+}
+
+// NextDue is one interval after the given change. A zero mileage or date means that limit is not set
+func (p *MaintenancePlanItem) NextDue(changeMileage uint, changeDate time.Time) (uint, time.Time) {
+	var nextMileage uint
+	var nextDate time.Time
+	if p.IntervalMileage > 0 {
+		nextMileage = changeMileage + p.IntervalMileage
+	}
+	if p.IntervalMonths > 0 && !changeDate.IsZero() {
+		nextDate = changeDate.AddDate(0, p.IntervalMonths, 0)
+	}
+	return nextMileage, nextDate
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// mileageEstimateWindow limits the history used for the estimate to recent driving habits
+	mileageEstimateWindow = 365 * 24 * time.Hour
+	// minMileageEstimateSpan is the shortest history an estimate is made from
+	minMileageEstimateSpan = 7 * 24 * time.Hour
+)
+
+// MileagePoint is an odometer value observed at a date
+type MileagePoint struct {
+	Date          time.Time
+	Mileage       uint
+	IsReplacement bool
+}
+
+// MileageEstimate is the average distance a vehicle is driven per day
+type MileageEstimate struct {
+	DailyMileage float64
+	LastMileage  uint
+	LastDate     time.Time
+}
+
+// EstimateDailyMileage averages the distance driven per day over the last year of history since the
+// most recent odometer replacement. It returns nil when the history is too short to tell
+func EstimateDailyMileage(points []MileagePoint) *MileageEstimate {
+	if len(points) < 2 {
+		return nil
+	}
+
+	sorted := make([]MileagePoint, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Date.Equal(sorted[j].Date) {
+			return sorted[i].Mileage < sorted[j].Mileage
+		}
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	// readings before a replaced odometer are on a different scale
+	for i := len(sorted) - 1; i > 0; i-- {
+		if sorted[i].IsReplacement {
+			sorted = sorted[i:]
+			break
+		}
+	}
+
+	// first is the oldest reading inside the window, or the newest one before it when the window holds less
+	// than minMileageEstimateSpan of history
+	last := sorted[len(sorted)-1]
+	i := sort.Search(len(sorted), func(i int) bool {
+		return last.Date.Sub(sorted[i].Date) <= mileageEstimateWindow
+	})
+	first := sorted[i]
+	if last.Date.Sub(first.Date) < minMileageEstimateSpan && i > 0 {
+		first = sorted[i-1]
+	}
+
+	span := last.Date.Sub(first.Date)
+	if span < minMileageEstimateSpan || last.Mileage <= first.Mileage {
+		return nil
+	}
+
+	return &MileageEstimate{
+		DailyMileage: float64(last.Mileage-first.Mileage) / (span.Hours() / 24),
+		LastMileage:  last.Mileage,
+		LastDate:     last.Date,
+	}
+}
+
+// ProjectDate returns the date the vehicle is expected to reach mileage, in the past if it already has
+func (e *MileageEstimate) ProjectDate(mileage uint) time.Time {
+	days := (float64(mileage) - float64(e.LastMileage)) / e.DailyMileage
+	return e.LastDate.Add(time.Duration(math.Round(days)) * 24 * time.Hour)
+}
+
+// DueAround combines the mileage projection and the calendar date into the earlier of the two.
+// Either input may be unset; the result is zero when neither is known
+func DueAround(estimate *MileageEstimate, nextMileage uint, nextDate time.Time) (projected time.Time, dueAround time.Time) {
+	if estimate != nil && nextMileage > 0 {
+		projected = estimate.ProjectDate(nextMileage)
+	}
+	dueAround = projected
+	if !nextDate.IsZero() && (dueAround.IsZero() || nextDate.Before(dueAround)) {
+		dueAround = nextDate
+	}
+	return projected, dueAround
+}
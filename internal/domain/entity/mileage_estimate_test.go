@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateDailyMileage(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+
+	tests := []struct {
+		name   string
+		points []MileagePoint
+		// want is the expected daily mileage, 0 when no estimate is expected
+		want float64
+	}{
+		{
+			name: "no history",
+		},
+		{
+			name:   "single point",
+			points: []MileagePoint{{Date: now, Mileage: 1000}},
+		},
+		{
+			name: "exactly two points",
+			points: []MileagePoint{
+				{Date: daysAgo(10), Mileage: 1000},
+				{Date: now, Mileage: 1500},
+			},
+			want: 50,
+		},
+		{
+			name: "two points under a week apart",
+			points: []MileagePoint{
+				{Date: daysAgo(3), Mileage: 1000},
+				{Date: now, Mileage: 1300},
+			},
+		},
+		{
+			name: "points outside the window are ignored",
+			points: []MileagePoint{
+				{Date: daysAgo(800), Mileage: 0},
+				{Date: daysAgo(300), Mileage: 1000},
+				{Date: now, Mileage: 4000},
+			},
+			want: 10,
+		},
+		{
+			name: "window under a week falls back to the newest point before it",
+			points: []MileagePoint{
+				{Date: daysAgo(900), Mileage: 0},
+				{Date: daysAgo(400), Mileage: 1000},
+				{Date: daysAgo(3), Mileage: 4970},
+				{Date: now, Mileage: 5000},
+			},
+			want: 10,
+		},
+		{
+			name: "replacement in the middle starts the history over",
+			points: []MileagePoint{
+				{Date: daysAgo(100), Mileage: 50000},
+				{Date: daysAgo(60), Mileage: 52000},
+				{Date: daysAgo(30), Mileage: 100, IsReplacement: true},
+				{Date: now, Mileage: 400},
+			},
+			want: 10,
+		},
+		{
+			name: "replacement under a week ago",
+			points: []MileagePoint{
+				{Date: daysAgo(100), Mileage: 50000},
+				{Date: daysAgo(3), Mileage: 100, IsReplacement: true},
+				{Date: now, Mileage: 400},
+			},
+		},
+		{
+			name: "unsorted points",
+			points: []MileagePoint{
+				{Date: now, Mileage: 1500},
+				{Date: daysAgo(10), Mileage: 1000},
+			},
+			want: 50,
+		},
+		{
+			name: "mileage did not increase",
+			points: []MileagePoint{
+				{Date: daysAgo(30), Mileage: 1000},
+				{Date: now, Mileage: 1000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate := EstimateDailyMileage(tt.points)
+			if tt.want == 0 {
+				if estimate != nil {
+					t.Fatalf("EstimateDailyMileage() = %+v, want nil", estimate)
+				}
+				return
+			}
+			if estimate == nil {
+				t.Fatalf("EstimateDailyMileage() = nil, want %v km per day", tt.want)
+			}
+			if estimate.DailyMileage != tt.want {
+				t.Errorf("DailyMileage = %v, want %v", estimate.DailyMileage, tt.want)
+			}
+			if !estimate.LastDate.Equal(now) {
+				t.Errorf("LastDate = %v, want %v", estimate.LastDate, now)
+			}
+		})
+	}
+}
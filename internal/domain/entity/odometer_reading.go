@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OdometerReadingSource int
+
+const (
+	ManualOdometerReading OdometerReadingSource = iota
+	ServiceVisitOdometerReading
+)
+
+func (s OdometerReadingSource) String() string {
+	switch s {
+	case ServiceVisitOdometerReading:
+		return "service_visit"
+	default:
+		return "manual"
+	}
+}
+
+func ParseOdometerReadingSource(s string) OdometerReadingSource {
+	switch strings.ToLower(s) {
+	case "service_visit":
+		return ServiceVisitOdometerReading
+	default:
+		return ManualOdometerReading
+	}
+}
+
+// OdometerReading is a mileage observed on a user vehicle at a given date
+type OdometerReading struct {
+	BaseModel
+
+	UserID         uuid.UUID `gorm:"type:uuid;not null"`
+	UserVehicleID  uint64    `gorm:"not null"`
+	Mileage        uint      `gorm:"not null"`
+	ReadingDate    time.Time `gorm:"not null"`
+	Source         OdometerReadingSource
+	ServiceVisitID *uuid.UUID `gorm:"type:uuid"`
+	// IsReplacement marks the first reading of a replaced odometer, which may be lower than the previous one
+	IsReplacement bool
+	Notes         string
+}
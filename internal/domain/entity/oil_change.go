@@ -23,4 +23,6 @@ type OilChange struct {
 	NextChangeDate    time.Time
 	ServiceCenter     string
 	Notes             string
+	LabourCost        Rial
+	PartsCost         Rial
 }
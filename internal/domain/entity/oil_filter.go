@@ -23,4 +23,6 @@ type OilFilter struct {
 	NextChangeDate    time.Time
 	ServiceCenter     string
 	Notes             string
+	LabourCost        Rial
+	PartsCost         Rial
 }
@@ -0,0 +1,90 @@
+package entity
+
+import "time"
+
+type ReminderStatus int
+
+const (
+	ReminderUpcoming ReminderStatus = iota
+	ReminderDueSoon
+	ReminderOverdue
+)
+
+func (s ReminderStatus) String() string {
+	switch s {
+	case ReminderUpcoming:
+		return "upcoming"
+	case ReminderDueSoon:
+		return "due_soon"
+	case ReminderOverdue:
+		return "overdue"
+	default:
+		return "unknown"
+	}
+}
+
+// ReminderThresholds defines how close to the next change an item is considered due soon
+type ReminderThresholds struct {
+	DueSoonMileage int
+	DueSoonDays    int
+}
+
+// MaintenanceReminder is the evaluated next-due state of a tracked maintenance item
+type MaintenanceReminder struct {
+	ItemType          string
+	Name              string
+	Source            string
+	SourceID          uint64
+	LastChangeMileage uint
+	LastChangeDate    time.Time
+	NextChangeMileage uint
+	NextChangeDate    time.Time
+	RemainingMileage  *int
+	RemainingDays     *int
+	Status            ReminderStatus
+}
+
+// HasSchedule reports whether a next change mileage or date is set
+func (r *MaintenanceReminder) HasSchedule() bool {
+	return r.NextChangeMileage > 0 || !r.NextChangeDate.IsZero()
+}
+
+// Evaluate computes the remaining kilometres and days and the resulting status
+func (r *MaintenanceReminder) Evaluate(currentMileage int, now time.Time, thresholds ReminderThresholds) {
+	r.Status = ReminderUpcoming
+	r.RemainingMileage = nil
+	r.RemainingDays = nil
+
+	if r.NextChangeMileage > 0 {
+		remaining := int(r.NextChangeMileage) - currentMileage
+		r.RemainingMileage = &remaining
+		r.raise(remaining, thresholds.DueSoonMileage)
+	}
+
+	if !r.NextChangeDate.IsZero() {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		due := time.Date(r.NextChangeDate.Year(), r.NextChangeDate.Month(), r.NextChangeDate.Day(), 0, 0, 0, 0, time.UTC)
+		remaining := int(due.Sub(today).Hours() / 24)
+		r.RemainingDays = &remaining
+		r.raise(remaining, thresholds.DueSoonDays)
+	}
+}
+
+// raise escalates the status, keeping the most severe of mileage and date
+func (r *MaintenanceReminder) raise(remaining, dueSoon int) {
+	status := ReminderUpcoming
+	if remaining < 0 {
+		status = ReminderOverdue
+	} else if remaining <= dueSoon {
+		status = ReminderDueSoon
+	}
+	if status > r.Status {
+		r.Status = status
+	}
+}
+
+// ReminderRecipient is a user vehicle together with the phone number its reminders are sent to
+type ReminderRecipient struct {
+	UserVehicle UserVehicle `gorm:"embedded"`
+	PhoneNumber string
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// earthRadiusKm is the mean Earth radius used for haversine distances
+const earthRadiusKm = 6371.0
+
+// ServiceCenter is a garage or service shop in the directory that service visits can reference
+type ServiceCenter struct {
+	BaseModel
+
+	Name      string `gorm:"not null"`
+	City      string `gorm:"index"`
+	Address   string
+	Phone     string
+	Latitude  float64 `gorm:"not null"`
+	Longitude float64 `gorm:"not null"`
+	// Kept in step with the center's reviews
+	AverageRating float64
+	ReviewCount   int
+}
+
+// ServiceCenterReview is a user's rating of a service center they have visited. Each user has at most one review per center
+type ServiceCenterReview struct {
+	BaseModel
+
+	ServiceCenterID uint64    `gorm:"not null;uniqueIndex:idx_service_center_review_user"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_service_center_review_user"`
+	Rating          int       `gorm:"not null"`
+	Comment         string
+}
+
+// DistanceKm is the great-circle distance from the center to the given coordinates
+func (c *ServiceCenter) DistanceKm(latitude, longitude float64) float64 {
+	return HaversineDistanceKm(c.Latitude, c.Longitude, latitude, longitude)
+}
+
+// HaversineDistanceKm returns the great-circle distance in kilometres between two coordinates
+func HaversineDistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// BoundingBox returns the latitude and longitude range that contains every point within radiusKm of the
+// coordinates, so candidates can be narrowed down with an index before computing exact distances
+func BoundingBox(latitude, longitude, radiusKm float64) (minLat, maxLat, minLng, maxLng float64) {
+	dLat := radiusKm / earthRadiusKm * 180 / math.Pi
+	minLat, maxLat = math.Max(latitude-dLat, -90), math.Min(latitude+dLat, 90)
+
+	cosLat := math.Cos(latitude * math.Pi / 180)
+	if cosLat < 1e-6 || maxLat == 90 || minLat == -90 {
+		return minLat, maxLat, -180, 180
+	}
+	dLng := dLat / cosLat
+	return minLat, maxLat, math.Max(longitude-dLng, -180), math.Min(longitude+dLng, 180)
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ServiceItemType int
+
+const (
+	OtherServiceItem ServiceItemType = iota
+	AirFilterServiceItem
+	CabinFilterServiceItem
+	FuelFilterServiceItem
+	BrakePadsServiceItem
+	BrakeFluidServiceItem
+	SparkPlugsServiceItem
+	CoolantServiceItem
+	TimingBeltServiceItem
+	TransmissionFluidServiceItem
+	BatteryServiceItem
+)
+
+func (t ServiceItemType) String() string {
+	switch t {
+	case AirFilterServiceItem:
+		return "air_filter"
+	case CabinFilterServiceItem:
+		return "cabin_filter"
+	case FuelFilterServiceItem:
+		return "fuel_filter"
+	case BrakePadsServiceItem:
+		return "brake_pads"
+	case BrakeFluidServiceItem:
+		return "brake_fluid"
+	case SparkPlugsServiceItem:
+		return "spark_plugs"
+	case CoolantServiceItem:
+		return "coolant"
+	case TimingBeltServiceItem:
+		return "timing_belt"
+	case TransmissionFluidServiceItem:
+		return "transmission_fluid"
+	case BatteryServiceItem:
+		return "battery"
+	default:
+		return "other"
+	}
+}
+
+func ParseServiceItemType(s string) ServiceItemType {
+	switch strings.ToLower(s) {
+	case "air_filter":
+		return AirFilterServiceItem
+	case "cabin_filter":
+		return CabinFilterServiceItem
+	case "fuel_filter":
+		return FuelFilterServiceItem
+	case "brake_pads":
+		return BrakePadsServiceItem
+	case "brake_fluid":
+		return BrakeFluidServiceItem
+	case "spark_plugs":
+		return SparkPlugsServiceItem
+	case "coolant":
+		return CoolantServiceItem
+	case "timing_belt":
+		return TimingBeltServiceItem
+	case "transmission_fluid":
+		return TransmissionFluidServiceItem
+	case "battery":
+		return BatteryServiceItem
+	default:
+		return OtherServiceItem
+	}
+}
+
+// ServiceItem represents a generic part or fluid replaced during a service visit
+type ServiceItem struct {
+	BaseModel
+
+	UserID            uuid.UUID       `gorm:"type:uuid;not null"`
+	UserVehicleID     uint64          `gorm:"not null"`
+	ServiceVisitID    uuid.UUID       `gorm:"type:uuid;not null"`
+	ItemType          ServiceItemType `gorm:"not null"`
+	Name              string          `gorm:"not null"`
+	Brand             string
+	PartNumber        string
+	ChangeMileage     uint      `gorm:"not null"`
+	ChangeDate        time.Time `gorm:"not null"`
+	NextChangeMileage uint
+	NextChangeDate    time.Time
+	Notes             string
+	LabourCost        Rial
+	PartsCost         Rial
+}
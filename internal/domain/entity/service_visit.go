@@ -15,8 +15,32 @@ type ServiceVisit struct {
 	ServiceMileage uint      `gorm:"not null"`
 	ServiceDate    time.Time `gorm:"not null"`
 	ServiceCenter  string
-	Notes          string
-	OilChange      OilChange `gorm:"constraint:onUpdate:CASCADE,onDelete:CASCADE"`
-	OilFilter      OilFilter `gorm:"constraint:onUpdate:CASCADE,onDelete:CASCADE"`
-	// other services
+	// Directory entry of the service center, if the visit references one
+	ServiceCenterID *uint64 `gorm:"index"`
+	Notes           string
+	// Costs not attributed to a specific item
+	LabourCost   Rial
+	PartsCost    Rial
+	OilChange    OilChange                `gorm:"constraint:onUpdate:CASCADE,onDelete:CASCADE"`
+	OilFilter    OilFilter                `gorm:"constraint:onUpdate:CASCADE,onDelete:CASCADE"`
+	ServiceItems []ServiceItem            `gorm:"constraint:onUpdate:CASCADE,onDelete:CASCADE"`
+	Attachments  []ServiceVisitAttachment `gorm:"constraint:onUpdate:CASCADE,onDelete:CASCADE"`
+}
+
+// TotalLabourCost sums the labour cost of the visit and everything done in it
+func (v *ServiceVisit) TotalLabourCost() Rial {
+	total := v.LabourCost + v.OilChange.LabourCost + v.OilFilter.LabourCost
+	for _, serviceItem := range v.ServiceItems {
+		total += serviceItem.LabourCost
+	}
+	return total
+}
+
+// TotalPartsCost sums the parts cost of the visit and everything done in it
+func (v *ServiceVisit) TotalPartsCost() Rial {
+	total := v.PartsCost + v.OilChange.PartsCost + v.OilFilter.PartsCost
+	for _, serviceItem := range v.ServiceItems {
+		total += serviceItem.PartsCost
+	}
+	return total
 }
@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TireSeason int
+
+const (
+	AllSeasonTire TireSeason = iota
+	SummerTire
+	WinterTire
+)
+
+func (s TireSeason) String() string {
+	switch s {
+	case SummerTire:
+		return "summer"
+	case WinterTire:
+		return "winter"
+	default:
+		return "all_season"
+	}
+}
+
+func ParseTireSeason(s string) TireSeason {
+	switch strings.ToLower(s) {
+	case "summer":
+		return SummerTire
+	case "winter":
+		return WinterTire
+	default:
+		return AllSeasonTire
+	}
+}
+
+// TireSet is a set of tires of a user vehicle, either mounted or in storage
+type TireSet struct {
+	BaseModel
+
+	UserID        uuid.UUID `gorm:"type:uuid;not null"`
+	UserVehicleID uint64    `gorm:"not null;index"`
+	Brand         string    `gorm:"not null"`
+	Model         string
+	// Size is the size marked on the sidewall, e.g. 205/55R16
+	Size   string `gorm:"not null"`
+	Season TireSeason
+	// DOTCode is the four-digit week and year of manufacture, e.g. 2321 for week 23 of 2021
+	DOTCode         string
+	PurchaseDate    time.Time
+	PurchaseMileage uint
+	IsMounted       bool
+	Notes           string
+
+	// Relationships
+	Mounts      []TireMount             `gorm:"foreignKey:TireSetID;constraint:OnDelete:CASCADE"`
+	Rotations   []TireRotation          `gorm:"foreignKey:TireSetID;constraint:OnDelete:CASCADE"`
+	TreadDepths []TreadDepthMeasurement `gorm:"foreignKey:TireSetID;constraint:OnDelete:CASCADE"`
+}
+
+// TireMount is a period a tire set spent on the vehicle. RemovedMileage is nil while it is still mounted
+type TireMount struct {
+	BaseModel
+
+	TireSetID      uint64    `gorm:"not null;index"`
+	MountedAt      time.Time `gorm:"not null"`
+	MountedMileage uint
+	RemovedAt      *time.Time
+	RemovedMileage *uint
+}
+
+// TireRotation is a rotation of the tires of a mounted set
+type TireRotation struct {
+	BaseModel
+
+	TireSetID    uint64    `gorm:"not null;index"`
+	RotationDate time.Time `gorm:"not null"`
+	Mileage      uint
+	// Pattern is how the tires were moved, e.g. front-to-rear or cross
+	Pattern string
+	Notes   string
+}
+
+// TreadDepthMeasurement is the tread depth of each tire of a set, in millimetres
+type TreadDepthMeasurement struct {
+	BaseModel
+
+	TireSetID  uint64    `gorm:"not null;index"`
+	MeasuredAt time.Time `gorm:"not null"`
+	Mileage    uint
+	FrontLeft  float64
+	FrontRight float64
+	RearLeft   float64
+	RearRight  float64
+}
+
+// Minimum is the shallowest tread of the set, the one that decides when it has to be replaced
+func (m *TreadDepthMeasurement) Minimum() float64 {
+	minimum := m.FrontLeft
+	for _, depth := range []float64{m.FrontRight, m.RearLeft, m.RearRight} {
+		if depth < minimum {
+			minimum = depth
+		}
+	}
+	return minimum
+}
+
+// ManufactureDate decodes the DOT date code into the Monday of the week of manufacture.
+// ok is false when the set has no valid code
+func (s *TireSet) ManufactureDate() (date time.Time, ok bool) {
+	if len(s.DOTCode) != 4 {
+		return time.Time{}, false
+	}
+	week, err := strconv.Atoi(s.DOTCode[:2])
+	if err != nil || week < 1 || week > 53 {
+		return time.Time{}, false
+	}
+	year, err := strconv.Atoi(s.DOTCode[2:])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// Week 1 is the ISO week holding January 4th
+	jan4 := time.Date(2000+year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	firstMonday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	return firstMonday.AddDate(0, 0, (week-1)*7), true
+}
+
+// Distance is the kilometres driven in the period, an open period runs up to currentMileage
+func (m *TireMount) Distance(currentMileage uint) uint {
+	end := currentMileage
+	if m.RemovedMileage != nil {
+		end = *m.RemovedMileage
+	}
+	if end < m.MountedMileage {
+		return 0
+	}
+	return end - m.MountedMileage
+}
+
+// DistanceDriven sums the kilometres driven over every period the set was mounted
+func (s *TireSet) DistanceDriven(currentMileage uint) uint {
+	var distance uint
+	for _, mount := range s.Mounts {
+		distance += mount.Distance(currentMileage)
+	}
+	return distance
+}
+
+// OpenMount is the period the set is currently mounted for, nil when it is in storage
+func (s *TireSet) OpenMount() *TireMount {
+	for i := range s.Mounts {
+		if s.Mounts[i].RemovedMileage == nil {
+			return &s.Mounts[i]
+		}
+	}
+	return nil
+}
+
+// LatestTreadDepth is the most recent tread depth measurement, nil when none was taken
+func (s *TireSet) LatestTreadDepth() *TreadDepthMeasurement {
+	var latest *TreadDepthMeasurement
+	for i := range s.TreadDepths {
+		measurement := &s.TreadDepths[i]
+		if latest == nil || measurement.MeasuredAt.After(latest.MeasuredAt) ||
+			(measurement.MeasuredAt.Equal(latest.MeasuredAt) && measurement.ID > latest.ID) {
+			latest = measurement
+		}
+	}
+	return latest
+}
+
+// TireThresholds decide when a tire set should be replaced soon
+type TireThresholds struct {
+	MinTreadDepthMM float64
+	MaxAgeYears     int
+}
+
+const (
+	TireWornReason = "tread_depth"
+	TireAgedReason = "age"
+)
+
+// ReplaceReasons lists why the set should be replaced soon: its latest tread depth is at or
+// below the minimum, or it is older than the maximum age. It is empty when the set is fine
+func (s *TireSet) ReplaceReasons(now time.Time, thresholds TireThresholds) []string {
+	reasons := []string{}
+	if latest := s.LatestTreadDepth(); latest != nil && thresholds.MinTreadDepthMM > 0 &&
+		latest.Minimum() <= thresholds.MinTreadDepthMM {
+		reasons = append(reasons, TireWornReason)
+	}
+	if manufactured, ok := s.ManufactureDate(); ok && thresholds.MaxAgeYears > 0 &&
+		!now.Before(manufactured.AddDate(thresholds.MaxAgeYears, 0, 0)) {
+		reasons = append(reasons, TireAgedReason)
+	}
+	return reasons
+}
@@ -1,7 +1,10 @@
 package entity
 
 import (
+	"math"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
@@ -71,6 +74,80 @@ type VehicleGeneration struct {
 	Seller        string
 	AssemblyType  string
 	Assembler     string
+	TireSizes     string // Comma separated, e.g. "185/65R15,195/55R16"
+	// Recommended engine oil and OEM oil filter
+	OilViscosities       string  // Comma separated, e.g. "5W-30,10W-40"
+	OilCapacity          float64 // in litres, with the filter changed
+	OilFilterPartNumbers string  // Comma separated, e.g. "HU816x,W712/75"
+}
+
+// TireSizeList splits TireSizes into the sizes listed for the generation
+func (g *VehicleGeneration) TireSizeList() []string {
+	return splitList(g.TireSizes)
+}
+
+// OilViscosityList splits OilViscosities into the viscosities recommended for the generation
+func (g *VehicleGeneration) OilViscosityList() []string {
+	return splitList(g.OilViscosities)
+}
+
+// OilFilterPartNumberList splits OilFilterPartNumbers into the OEM filters of the generation
+func (g *VehicleGeneration) OilFilterPartNumberList() []string {
+	return splitList(g.OilFilterPartNumbers)
+}
+
+// OilCapacityTolerance is how far in litres an oil change may be from the recommended capacity
+const OilCapacityTolerance = 0.5
+
+// MatchesOilViscosity reports whether viscosity is one of the recommended ones, or no recommendation is listed.
+// "5w30" matches "5W-30"
+func (g *VehicleGeneration) MatchesOilViscosity(viscosity string) bool {
+	return matchesList(g.OilViscosityList(), viscosity)
+}
+
+// MatchesOilCapacity reports whether capacity is within OilCapacityTolerance of the recommended one,
+// or no recommendation is set
+func (g *VehicleGeneration) MatchesOilCapacity(capacity float64) bool {
+	return g.OilCapacity <= 0 || math.Abs(capacity-g.OilCapacity) <= OilCapacityTolerance
+}
+
+// MatchesOilFilterPartNumber reports whether partNumber is one of the OEM filters, or none is listed.
+// "hu 816 x" matches "HU816x"
+func (g *VehicleGeneration) MatchesOilFilterPartNumber(partNumber string) bool {
+	return matchesList(g.OilFilterPartNumberList(), partNumber)
+}
+
+// splitList splits a comma separated column, dropping empty entries
+func splitList(list string) []string {
+	values := []string{}
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// matchesList compares value with each entry ignoring case, spaces and punctuation. An empty list matches anything
+func matchesList(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, entry := range list {
+		if normalizeSpec(entry) == normalizeSpec(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeSpec(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, value)
 }
 
 // UserVehicle represents vehicles owned by users
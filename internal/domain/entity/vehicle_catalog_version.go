@@ -0,0 +1,24 @@
+package entity
+
+// Levels of the vehicle catalog a VehicleCatalogChange refers to
+const (
+	CatalogLevelType       = "type"
+	CatalogLevelBrand      = "brand"
+	CatalogLevelModel      = "model"
+	CatalogLevelGeneration = "generation"
+)
+
+// VehicleCatalogVersion is the single row holding the version of the vehicle catalog. Every admin change raises it
+// by one, and the row lock taken to raise it makes changes commit in the order of their versions
+type VehicleCatalogVersion struct {
+	ID      uint64 `gorm:"primaryKey"`
+	Version uint64 `gorm:"not null;default:0"`
+}
+
+// VehicleCatalogChange is the catalog version a type, brand, model or generation last changed in. Whether the
+// row was created, updated or deleted is read from the row itself when clients sync
+type VehicleCatalogChange struct {
+	Level   string `gorm:"primaryKey;size:20"`
+	RowID   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Version uint64 `gorm:"not null;index"`
+}
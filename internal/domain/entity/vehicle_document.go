@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VehicleDocumentType int
+
+const (
+	ThirdPartyInsuranceDocument VehicleDocumentType = iota
+	BodyInsuranceDocument
+	TechnicalInspectionDocument
+)
+
+func (t VehicleDocumentType) String() string {
+	switch t {
+	case BodyInsuranceDocument:
+		return "body_insurance"
+	case TechnicalInspectionDocument:
+		return "technical_inspection"
+	default:
+		return "third_party_insurance"
+	}
+}
+
+// Label is the Persian name of the document, as used in SMS reminders
+func (t VehicleDocumentType) Label() string {
+	switch t {
+	case BodyInsuranceDocument:
+		return "بیمه بدنه"
+	case TechnicalInspectionDocument:
+		return "معاینه فنی"
+	default:
+		return "بیمه شخص ثالث"
+	}
+}
+
+func ParseVehicleDocumentType(s string) VehicleDocumentType {
+	switch strings.ToLower(s) {
+	case "body_insurance":
+		return BodyInsuranceDocument
+	case "technical_inspection":
+		return TechnicalInspectionDocument
+	default:
+		return ThirdPartyInsuranceDocument
+	}
+}
+
+// VehicleDocument is an insurance policy or technical inspection certificate of a user vehicle.
+// Its optional scan lives in the storage backend under ScanStorageKey
+type VehicleDocument struct {
+	BaseModel
+
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserVehicleID uint64    `gorm:"not null;index"`
+	DocumentType  VehicleDocumentType
+	Issuer        string
+	PolicyNumber  string
+	StartDate     time.Time `gorm:"not null"`
+	ExpiryDate    time.Time `gorm:"not null"`
+	Notes         string
+
+	ScanFileName    string
+	ScanContentType string
+	ScanSize        int64
+	ScanStorageKey  string
+}
+
+// HasScan reports whether a scan of the document was uploaded
+func (d *VehicleDocument) HasScan() bool {
+	return d.ScanStorageKey != ""
+}
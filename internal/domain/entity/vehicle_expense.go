@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExpenseCategory int
+
+const (
+	OtherExpense ExpenseCategory = iota
+	ParkingExpense
+	TollExpense
+	CarWashExpense
+	RegistrationExpense
+	FineExpense
+	// CustomExpense is filed under one of the user's own categories
+	CustomExpense
+)
+
+func (c ExpenseCategory) String() string {
+	switch c {
+	case ParkingExpense:
+		return "parking"
+	case TollExpense:
+		return "toll"
+	case CarWashExpense:
+		return "car_wash"
+	case RegistrationExpense:
+		return "registration"
+	case FineExpense:
+		return "fine"
+	case CustomExpense:
+		return "custom"
+	default:
+		return "other"
+	}
+}
+
+// Label is the Persian name of the category
+func (c ExpenseCategory) Label() string {
+	switch c {
+	case ParkingExpense:
+		return "پارکینگ"
+	case TollExpense:
+		return "عوارض"
+	case CarWashExpense:
+		return "کارواش"
+	case RegistrationExpense:
+		return "شماره‌گذاری و نقل و انتقال"
+	case FineExpense:
+		return "جریمه"
+	default:
+		return "سایر"
+	}
+}
+
+func ParseExpenseCategory(s string) ExpenseCategory {
+	switch strings.ToLower(s) {
+	case "parking":
+		return ParkingExpense
+	case "toll":
+		return TollExpense
+	case "car_wash":
+		return CarWashExpense
+	case "registration":
+		return RegistrationExpense
+	case "fine":
+		return FineExpense
+	case "custom":
+		return CustomExpense
+	default:
+		return OtherExpense
+	}
+}
+
+// UserExpenseCategory is a category a user defined for expenses the built-in categories don't cover
+type UserExpenseCategory struct {
+	BaseModel
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_user_expense_category_name"`
+	Name   string    `gorm:"not null;uniqueIndex:idx_user_expense_category_name"`
+}
+
+// VehicleExpense is a running cost of a user vehicle other than maintenance and fuel,
+// such as parking, tolls or fines
+type VehicleExpense struct {
+	BaseModel
+
+	UserID        uuid.UUID `gorm:"type:uuid;not null"`
+	UserVehicleID uint64    `gorm:"not null;index"`
+	Category      ExpenseCategory
+	// UserExpenseCategoryID is set for CustomExpense only
+	UserExpenseCategoryID *uint64
+	UserExpenseCategory   *UserExpenseCategory `gorm:"foreignKey:UserExpenseCategoryID"`
+	Amount                Rial                 `gorm:"not null"`
+	ExpenseDate           time.Time            `gorm:"not null"`
+	// Mileage is the odometer reading at the time, 0 when unknown
+	Mileage uint
+	Notes   string
+}
+
+// CategoryName is the Persian name of the built-in category or the name of the user's own
+func (e *VehicleExpense) CategoryName() string {
+	if e.Category == CustomExpense && e.UserExpenseCategory != nil {
+		return e.UserExpenseCategory.Name
+	}
+	return e.Category.Label()
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// VehicleRole is what a user may do with a vehicle. Roles are ordered, each one allowing
+// everything the roles below it do
+type VehicleRole int
+
+const (
+	ViewerVehicleRole VehicleRole = iota
+	EditorVehicleRole
+	OwnerVehicleRole
+)
+
+func (r VehicleRole) String() string {
+	switch r {
+	case EditorVehicleRole:
+		return "editor"
+	case OwnerVehicleRole:
+		return "owner"
+	default:
+		return "viewer"
+	}
+}
+
+func ParseVehicleRole(s string) VehicleRole {
+	switch strings.ToLower(s) {
+	case "editor":
+		return EditorVehicleRole
+	case "owner":
+		return OwnerVehicleRole
+	default:
+		return ViewerVehicleRole
+	}
+}
+
+// Allows reports whether the role includes required
+func (r VehicleRole) Allows(required VehicleRole) bool {
+	return r >= required
+}
+
+type VehicleMembershipStatus int
+
+const (
+	InvitedVehicleMembership VehicleMembershipStatus = iota
+	AcceptedVehicleMembership
+)
+
+func (s VehicleMembershipStatus) String() string {
+	switch s {
+	case AcceptedVehicleMembership:
+		return "accepted"
+	default:
+		return "invited"
+	}
+}
+
+// VehicleMembership shares a user vehicle with another user as an editor or viewer. The owner of the
+// vehicle is the user vehicle's UserID and has no membership. Invitations are addressed to a phone
+// number, so they can be sent before the invitee registers, and take effect once accepted
+type VehicleMembership struct {
+	BaseModel
+
+	UserVehicleID uint64 `gorm:"not null;index"`
+	PhoneNumber   string `gorm:"not null;index"`
+	// Set when the invitation is accepted
+	UserID      *uuid.UUID              `gorm:"type:uuid;index"`
+	Role        VehicleRole             `gorm:"not null;default:0"`
+	Status      VehicleMembershipStatus `gorm:"not null;default:0"`
+	InvitedByID uuid.UUID               `gorm:"type:uuid;not null"`
+}
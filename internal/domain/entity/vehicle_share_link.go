@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VehicleShareLink opens a read-only view of a vehicle's service history to anyone holding the link,
+// until it expires or the owner revokes it. The link carries Nonce and its signature, so links can
+// be checked without a database lookup and cannot be guessed
+type VehicleShareLink struct {
+	BaseModel
+
+	UserVehicleID uint64    `gorm:"not null;index"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null"`
+	Nonce         string    `gorm:"not null;uniqueIndex"`
+	ExpiresAt     time.Time `gorm:"not null"`
+	RevokedAt     *time.Time
+
+	// Fields left out of the shared view
+	HideVIN          bool `gorm:"not null;default:false"`
+	HideLicensePlate bool `gorm:"not null;default:false"`
+	HideCosts        bool `gorm:"not null;default:false"`
+
+	ViewCount    int64 `gorm:"not null;default:0"`
+	LastViewedAt *time.Time
+}
+
+// Status is revoked, expired or active at now
+func (l *VehicleShareLink) Status(now time.Time) string {
+	switch {
+	case l.RevokedAt != nil:
+		return "revoked"
+	case !now.Before(l.ExpiresAt):
+		return "expired"
+	default:
+		return "active"
+	}
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VehicleTransferStatus int
+
+const (
+	PendingVehicleTransfer VehicleTransferStatus = iota
+	CompletedVehicleTransfer
+	CancelledVehicleTransfer
+)
+
+func (s VehicleTransferStatus) String() string {
+	switch s {
+	case CompletedVehicleTransfer:
+		return "completed"
+	case CancelledVehicleTransfer:
+		return "cancelled"
+	default:
+		return "pending"
+	}
+}
+
+func ParseVehicleTransferStatus(s string) VehicleTransferStatus {
+	switch strings.ToLower(s) {
+	case "completed":
+		return CompletedVehicleTransfer
+	case "cancelled":
+		return CancelledVehicleTransfer
+	default:
+		return PendingVehicleTransfer
+	}
+}
+
+// VehicleTransfer hands a user vehicle, with its whole history, over to another user once the
+// recipient confirms the code sent to their phone
+type VehicleTransfer struct {
+	BaseModel
+
+	UserVehicleID uint64                `gorm:"not null;index"`
+	FromUserID    uuid.UUID             `gorm:"type:uuid;not null;index"`
+	ToUserID      uuid.UUID             `gorm:"type:uuid;not null;index"`
+	ToPhoneNumber string                `gorm:"not null"`
+	Status        VehicleTransferStatus `gorm:"not null;default:0"`
+	// Only a hash of the confirmation code is stored
+	CodeHash    string    `gorm:"not null"`
+	Attempts    int       `gorm:"not null;default:0"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	CompletedAt *time.Time
+}
+
+// SetCode stores the hash of the confirmation code
+func (t *VehicleTransfer) SetCode(code string) {
+	t.CodeHash = hashTransferCode(code)
+}
+
+// CheckCode reports whether code is the confirmation code of the transfer
+func (t *VehicleTransfer) CheckCode(code string) bool {
+	return subtle.ConstantTimeCompare([]byte(t.CodeHash), []byte(hashTransferCode(code))) == 1
+}
+
+// IsOpen reports whether the transfer is pending and has not expired at now
+func (t *VehicleTransfer) IsOpen(now time.Time) bool {
+	return t.Status == PendingVehicleTransfer && now.Before(t.ExpiresAt)
+}
+
+func hashTransferCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// VehicleOwnership is a period during which a user owned a vehicle. EndedAt is nil for the current owner
+type VehicleOwnership struct {
+	BaseModel
+
+	UserVehicleID uint64    `gorm:"not null;index"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null"`
+	StartedAt     time.Time `gorm:"not null"`
+	EndedAt       *time.Time
+	// Transfer the vehicle was received through, if any
+	TransferID *uint64
+}
@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/amirdashtii/AutoBan/pkg/persian"
+)
+
+// vinPattern is 17 letters and digits without I, O and Q, which VINs leave out to avoid confusing them with 1 and 0
+var vinPattern = regexp.MustCompile(`^[A-HJ-NPR-Z0-9]{17}$`)
+
+// vinCodePattern is the VIN alphabet for parts of a VIN such as a WMI or VDS prefix
+var vinCodePattern = regexp.MustCompile(`^[A-HJ-NPR-Z0-9]*$`)
+
+// vinWeights are the ISO 3779 check digit weights of each VIN position
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// vinModelYearCodes are the model year characters in order, starting from 1980 and repeating every 30 years
+const vinModelYearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+// VehicleWMI maps a World Manufacturer Identifier, the first three characters of a VIN, to a catalog brand.
+// Manufacturers that build several brands or models under one WMI, such as IKCO (NAA) and SAIPA (NAS), get a
+// row per VDSPrefix, the start of the vehicle descriptor section that tells their models apart
+type VehicleWMI struct {
+	BaseModel
+
+	WMI          string  `gorm:"size:3;not null;uniqueIndex:idx_vehicle_wmi_vds_prefix"`
+	VDSPrefix    string  `gorm:"size:5;not null;default:'';uniqueIndex:idx_vehicle_wmi_vds_prefix"`
+	Manufacturer string  `gorm:"not null"`
+	BrandID      uint64  `gorm:"not null;index"`
+	ModelID      *uint64 `gorm:"index"`
+}
+
+// Matches reports whether the VIN starts with the WMI and VDS prefix of the row
+func (w *VehicleWMI) Matches(vin VIN) bool {
+	return vin.WMI() == w.WMI && strings.HasPrefix(vin.VDS(), w.VDSPrefix)
+}
+
+// VIN is a Vehicle Identification Number in the ISO 3779 layout: the WMI in positions 1-3, the vehicle descriptor
+// section in 4-8, a check digit in 9, the model year in 10, the plant in 11 and the serial number in 12-17
+type VIN string
+
+// NormalizeVIN upper-cases a VIN or part of one and converts Persian and Arabic digits, dropping spaces and dashes
+func NormalizeVIN(value string) string {
+	value = strings.ToUpper(persian.ToLatinDigits(value))
+	return strings.NewReplacer(" ", "", "-", "").Replace(value)
+}
+
+// IsValidVINCode reports whether the value only uses characters allowed in a VIN
+func IsValidVINCode(value string) bool {
+	return vinCodePattern.MatchString(value)
+}
+
+// Valid reports whether the VIN has 17 characters of the VIN alphabet
+func (v VIN) Valid() bool {
+	return vinPattern.MatchString(string(v))
+}
+
+func (v VIN) WMI() string {
+	return string(v[:3])
+}
+
+func (v VIN) VDS() string {
+	return string(v[3:8])
+}
+
+func (v VIN) CheckDigit() string {
+	return string(v[8])
+}
+
+func (v VIN) VIS() string {
+	return string(v[9:])
+}
+
+func (v VIN) ModelYearCode() string {
+	return string(v[9])
+}
+
+func (v VIN) PlantCode() string {
+	return string(v[10])
+}
+
+func (v VIN) SerialNumber() string {
+	return string(v[11:])
+}
+
+// CheckDigitValid reports whether position 9 holds the ISO 3779 check digit. It is mandatory in North America
+// only, and many manufacturers elsewhere, Iranian ones included, use the position for something else
+func (v VIN) CheckDigitValid() bool {
+	sum := 0
+	for i := range len(v) {
+		sum += vinTransliteration(v[i]) * vinWeights[i]
+	}
+	expected := byte('0' + sum%11)
+	if sum%11 == 10 {
+		expected = 'X'
+	}
+	return v[8] == expected
+}
+
+// ModelYear decodes position 10 to the latest year with that code that is not after maxYear, or 0 when the
+// position does not hold a model year code. The codes repeat every 30 years, so callers pass next year to
+// allow for vehicles sold ahead of their model year
+func (v VIN) ModelYear(maxYear int) int {
+	index := strings.IndexByte(vinModelYearCodes, v[9])
+	if index < 0 {
+		return 0
+	}
+	year := 1980 + index
+	for year+30 <= maxYear {
+		year += 30
+	}
+	return year
+}
+
+// vinTransliteration is the ISO 3779 value of a VIN character
+func vinTransliteration(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'A' && c <= 'H':
+		return int(c-'A') + 1
+	case c >= 'J' && c <= 'N':
+		return int(c-'J') + 1
+	case c == 'P':
+		return 7
+	case c == 'R':
+		return 9
+	case c >= 'S' && c <= 'Z':
+		return int(c-'S') + 2
+	default:
+		return 0
+	}
+}
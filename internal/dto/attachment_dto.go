@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/google/uuid"
+
+// AttachmentResponse - Service visit attachment response
+// @Description Invoice or receipt attached to a service visit
+type AttachmentResponse struct {
+	// Attachment ID
+	ID uint64 `json:"id" example:"1"`
+	// Service visit ID
+	ServiceVisitID uuid.UUID `json:"service_visit_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	// Original file name
+	FileName string `json:"file_name" example:"invoice.pdf"`
+	// Content type (image/jpeg, image/png, image/webp, application/pdf)
+	ContentType string `json:"content_type" example:"application/pdf"`
+	// Size in bytes
+	Size int64 `json:"size" example:"245760"`
+	// Download URL
+	URL string `json:"url" example:"/api/v1/user/vehicles/1/service-visits/123e4567-e89b-12d3-a456-426614174000/attachments/1"`
+	// Upload date
+	UploadedAt string `json:"uploaded_at" example:"2024-01-15"`
+}
+
+// ListAttachmentsResponse - List of service visit attachments
+// @Description Attachments of a service visit with the user's storage usage
+type ListAttachmentsResponse struct {
+	// Attachments
+	Attachments []AttachmentResponse `json:"attachments"`
+	// Bytes used by all of the user's attachments
+	UsedBytes int64 `json:"used_bytes" example:"1048576"`
+	// Bytes the user may store
+	QuotaBytes int64 `json:"quota_bytes" example:"209715200"`
+}
@@ -0,0 +1,195 @@
+package dto
+
+import "time"
+
+// Catalog - The vehicle catalog
+// @Description The whole vehicle catalog as a tree of types, brands, models and generations. Rows are identified by their English name within their parent
+type Catalog struct {
+	// Vehicle types
+	Types []CatalogType `json:"types"`
+}
+
+// CatalogType - Vehicle type of the catalog
+// @Description Vehicle type with its brands
+type CatalogType struct {
+	// English name, unique in the catalog
+	NameEn string `json:"name_en" validate:"required,max=100" example:"Car"`
+	// Persian name
+	NameFa string `json:"name_fa" validate:"required,max=100" example:"خودرو"`
+	// English description
+	DescriptionEn string `json:"description_en" example:""`
+	// Persian description
+	DescriptionFa string `json:"description_fa" example:""`
+	// Brands
+	Brands []CatalogBrand `json:"brands"`
+}
+
+// CatalogBrand - Vehicle brand of the catalog
+// @Description Vehicle brand with its models
+type CatalogBrand struct {
+	// English name, unique within the type
+	NameEn string `json:"name_en" validate:"required,max=100" example:"SAIPA"`
+	// Persian name
+	NameFa string `json:"name_fa" validate:"required,max=100" example:"سایپا"`
+	// English description
+	DescriptionEn string `json:"description_en" example:""`
+	// Persian description
+	DescriptionFa string `json:"description_fa" example:""`
+	// Models
+	Models []CatalogModel `json:"models"`
+}
+
+// CatalogModel - Vehicle model of the catalog
+// @Description Vehicle model with its generations
+type CatalogModel struct {
+	// English name, unique within the brand
+	NameEn string `json:"name_en" validate:"required,max=100" example:"Pride"`
+	// Persian name
+	NameFa string `json:"name_fa" validate:"required,max=100" example:"پراید"`
+	// English description
+	DescriptionEn string `json:"description_en" example:""`
+	// Persian description
+	DescriptionFa string `json:"description_fa" example:""`
+	// Generations
+	Generations []CatalogGeneration `json:"generations"`
+}
+
+// CatalogGeneration - Vehicle generation of the catalog
+// @Description Vehicle generation with its specifications
+type CatalogGeneration struct {
+	// English name, unique within the model
+	NameEn string `json:"name_en" validate:"required,max=100" example:"131"`
+	// Persian name
+	NameFa string `json:"name_fa" validate:"required,max=100" example:"۱۳۱"`
+	// English description
+	DescriptionEn string `json:"description_en" example:""`
+	// Persian description
+	DescriptionFa string `json:"description_fa" example:""`
+	// First year of production
+	StartYear int `json:"start_year" validate:"omitempty,year" example:"2003"`
+	// Last year of production, 0 if still in production
+	EndYear int `json:"end_year" validate:"omitempty,year" example:"2020"`
+	// Body style in Persian
+	BodyStyleFa string `json:"body_style_fa" example:"سدان"`
+	// Body style in English
+	BodyStyleEn string `json:"body_style_en" example:"Sedan"`
+	// Engine
+	Engine string `json:"engine" example:"M13"`
+	// Engine volume in cc
+	EngineVolume int `json:"engine_volume" validate:"gte=0" example:"1300"`
+	// Number of cylinders
+	Cylinders int `json:"cylinders" validate:"gte=0" example:"4"`
+	// Drivetrain in Persian
+	DrivetrainFa string `json:"drivetrain_fa" example:"دیفرانسیل جلو"`
+	// Drivetrain in English
+	DrivetrainEn string `json:"drivetrain_en" example:"FWD"`
+	// Gearbox
+	Gearbox string `json:"gearbox" example:"Manual"`
+	// Fuel type
+	FuelType string `json:"fuel_type" example:"Gasoline"`
+	// Battery
+	Battery string `json:"battery" example:""`
+	// Seller
+	Seller string `json:"seller" example:"SAIPA"`
+	// Assembly type
+	AssemblyType string `json:"assembly_type" example:""`
+	// Assembler
+	Assembler string `json:"assembler" example:"SAIPA"`
+	// Tire sizes
+	TireSizes []string `json:"tire_sizes" example:"155/80R13"`
+	// Recommended engine oil viscosities
+	OilViscosities []string `json:"oil_viscosities" example:"10W-40"`
+	// Recommended engine oil capacity in litres
+	OilCapacity float64 `json:"oil_capacity" validate:"gte=0,lte=50" example:"3.5"`
+	// OEM oil filter part numbers
+	OilFilterPartNumbers []string `json:"oil_filter_part_numbers" example:"W712/75"`
+}
+
+// ExportCatalogRequest - Query for a catalog export
+// @Description Format of a catalog export
+type ExportCatalogRequest struct {
+	// File format: json (default) or csv
+	Format string `form:"format" validate:"omitempty,oneof=json csv" example:"json"`
+}
+
+// ImportCatalogRequest - Options of a catalog import
+// @Description Options of a catalog import
+type ImportCatalogRequest struct {
+	// Report what would be created, updated or deleted without saving anything
+	DryRun bool `form:"dry_run" example:"true"`
+	// Delete the rows of the catalog that are missing from the file
+	Prune bool `form:"prune" example:"false"`
+}
+
+// CatalogImportError - A row of a catalog import file that could not be imported
+// @Description A row of a catalog import file that could not be imported
+type CatalogImportError struct {
+	// Row number in a CSV or XLSX file, counting the header as row 1
+	Row int `json:"row,omitempty" example:"3"`
+	// Path of the catalog row, e.g. "Car > SAIPA > Pride"
+	Path string `json:"path,omitempty" example:"Car > SAIPA > Pride"`
+	// What is wrong with the row
+	Error string `json:"error" example:"persian name is required"`
+}
+
+// CatalogChange - A change a catalog import makes
+// @Description A catalog row that an import creates, updates or deletes
+type CatalogChange struct {
+	// What happens to the row (create, update, delete)
+	Action string `json:"action" example:"update"`
+	// Level of the row (type, brand, model, generation)
+	Level string `json:"level" example:"generation"`
+	// Path of the row
+	Path string `json:"path" example:"Car > SAIPA > Pride > 131"`
+	// Fields that change, for updates
+	Fields []string `json:"fields,omitempty" example:"end_year,tire_sizes"`
+}
+
+// ImportCatalogResponse - Result of a catalog import
+// @Description Result of a catalog import. Nothing is imported unless every row is valid
+type ImportCatalogResponse struct {
+	// Whether the import was a dry run
+	DryRun bool `json:"dry_run" example:"false"`
+	// Whether the changes were saved
+	Imported bool `json:"imported" example:"true"`
+	// Number of rows created
+	Created int `json:"created" example:"12"`
+	// Number of rows updated
+	Updated int `json:"updated" example:"3"`
+	// Number of rows deleted
+	Deleted int `json:"deleted" example:"0"`
+	// Number of rows left as they are
+	Unchanged int `json:"unchanged" example:"1800"`
+	// Columns of a CSV or XLSX file that are not recognised and were ignored
+	IgnoredColumns []string `json:"ignored_columns,omitempty"`
+	// Errors of the rows that could not be imported
+	Errors []CatalogImportError `json:"errors"`
+	// Rows that are created, updated or deleted
+	Changes []CatalogChange `json:"changes"`
+}
+
+// CatalogTrashItem - A soft-deleted row of the catalog
+// @Description A deleted type, brand, model or generation that can be restored
+type CatalogTrashItem struct {
+	// Level of the row (type, brand, model, generation)
+	Level string `json:"level" example:"generation"`
+	// ID of the row
+	ID uint64 `json:"id" example:"12"`
+	// ID of the type, brand or model the row is under, 0 for types
+	ParentID uint64 `json:"parent_id" example:"4"`
+	// Whether the parent is deleted too, in which case it has to be restored first
+	ParentDeleted bool `json:"parent_deleted" example:"false"`
+	// Persian name
+	NameFa string `json:"name_fa" example:"۲۰۶ تیپ ۲"`
+	// English name
+	NameEn string `json:"name_en" example:"206 Type 2"`
+	// When the row was deleted
+	DeletedAt time.Time `json:"deleted_at" example:"2024-01-01T10:00:00Z"`
+}
+
+// ListCatalogTrashResponse - The catalog trash
+// @Description Deleted types, brands, models and generations, most recently deleted first
+type ListCatalogTrashResponse struct {
+	// Deleted rows
+	Items []CatalogTrashItem `json:"items"`
+}
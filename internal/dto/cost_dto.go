@@ -0,0 +1,63 @@
+package dto
+
+// CostResponse - Cost breakdown
+// @Description Cost breakdown in Rial, with the total also given in Toman for display
+type CostResponse struct {
+	// Labour cost in Rial
+	LabourCost int64 `json:"labour_cost" example:"2000000"`
+	// Parts cost in Rial
+	PartsCost int64 `json:"parts_cost" example:"10500000"`
+	// Total cost in Rial
+	TotalCost int64 `json:"total_cost" example:"12500000"`
+	// Total cost in Toman
+	TotalCostToman int64 `json:"total_cost_toman" example:"1250000"`
+	// Total cost formatted in Toman for display
+	TotalCostDisplay string `json:"total_cost_display" example:"۱٬۲۵۰٬۰۰۰ تومان"`
+}
+
+// PeriodCostResponse - Spending in a month or year
+// @Description Spending in a month (yyyy-mm) or year (yyyy)
+type PeriodCostResponse struct {
+	// Period, yyyy-mm for months and yyyy for years
+	Period string `json:"period" example:"2024-01"`
+	// Number of service visits in the period
+	VisitCount int `json:"visit_count" example:"2"`
+	// Cost
+	Cost CostResponse `json:"cost"`
+}
+
+// CategoryCostResponse - Spending on a category of maintenance
+// @Description Spending on a category of maintenance
+type CategoryCostResponse struct {
+	// Category (general, oil_change, oil_filter, air_filter, brake_pads, ...)
+	Category string `json:"category" example:"oil_change"`
+	// Cost
+	Cost CostResponse `json:"cost"`
+}
+
+// VehicleCostReportResponse - Maintenance spending of a user vehicle
+// @Description Maintenance spending of a user vehicle by month, year and category
+type VehicleCostReportResponse struct {
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Number of service visits
+	VisitCount int `json:"visit_count" example:"6"`
+	// Total cost
+	Total CostResponse `json:"total"`
+	// Lowest service mileage
+	FirstMileage uint `json:"first_mileage" example:"10000"`
+	// Highest service mileage
+	LastMileage uint `json:"last_mileage" example:"40000"`
+	// Distance between the first and last service visit
+	Distance uint `json:"distance" example:"30000"`
+	// Cost per kilometre in Rial, omitted when the distance is zero
+	CostPerKm float64 `json:"cost_per_km,omitempty" example:"4166.7"`
+	// Cost per kilometre formatted in Toman for display
+	CostPerKmDisplay string `json:"cost_per_km_display,omitempty" example:"۴۱۷ تومان"`
+	// Spending per month
+	ByMonth []PeriodCostResponse `json:"by_month"`
+	// Spending per year
+	ByYear []PeriodCostResponse `json:"by_year"`
+	// Spending per category, highest first
+	ByCategory []CategoryCostResponse `json:"by_category"`
+}
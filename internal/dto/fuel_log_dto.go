@@ -0,0 +1,140 @@
+package dto
+
+// CreateFuelLogRequest - Request to record a fill-up
+// @Description Request to record a fill-up of a user vehicle. Give the total cost, the price per litre or both
+type CreateFuelLogRequest struct {
+	// Fill-up date, defaults to today
+	FillDate string `json:"fill_date" validate:"omitempty,date" example:"2024-01-15"`
+	// Odometer mileage at the fill-up
+	Mileage uint `json:"mileage" validate:"required" example:"45200"`
+	// Fuel type (gasoline, diesel, cng), defaults to the vehicle's primary fuel
+	FuelType string `json:"fuel_type" validate:"omitempty,oneof=gasoline diesel cng" example:"gasoline"`
+	// Amount filled in litres, cubic metres for CNG
+	Litres float64 `json:"litres" validate:"required,gt=0" example:"40"`
+	// Price per litre in Rial
+	PricePerLitre int64 `json:"price_per_litre" validate:"omitempty,min=0" example:"15000"`
+	// Total cost in Rial
+	TotalCost int64 `json:"total_cost" validate:"omitempty,min=0" example:"600000"`
+	// Whether the tank was filled to the top
+	IsFullTank bool `json:"is_full_tank" example:"true"`
+	// Fuel station
+	Station string `json:"station" validate:"max=100" example:"جایگاه شماره ۱۲"`
+	// Notes
+	Notes string `json:"notes" example:"سفر شمال"`
+}
+
+// UpdateFuelLogRequest - Request to update a fill-up
+// @Description Request to update a fill-up
+type UpdateFuelLogRequest struct {
+	// Fill-up date
+	FillDate *string `json:"fill_date" validate:"omitempty,date" example:"2024-01-15"`
+	// Odometer mileage at the fill-up
+	Mileage *uint `json:"mileage" validate:"omitempty,min=1" example:"45200"`
+	// Fuel type (gasoline, diesel, cng)
+	FuelType *string `json:"fuel_type" validate:"omitempty,oneof=gasoline diesel cng" example:"gasoline"`
+	// Amount filled in litres, cubic metres for CNG
+	Litres *float64 `json:"litres" validate:"omitempty,gt=0" example:"40"`
+	// Price per litre in Rial
+	PricePerLitre *int64 `json:"price_per_litre" validate:"omitempty,min=0" example:"15000"`
+	// Total cost in Rial
+	TotalCost *int64 `json:"total_cost" validate:"omitempty,min=0" example:"600000"`
+	// Whether the tank was filled to the top
+	IsFullTank *bool `json:"is_full_tank" example:"true"`
+	// Fuel station
+	Station *string `json:"station" validate:"omitempty,max=100" example:"جایگاه شماره ۱۲"`
+	// Notes
+	Notes *string `json:"notes" example:"سفر شمال"`
+}
+
+// FuelLogResponse - Fill-up response
+// @Description Fill-up information
+type FuelLogResponse struct {
+	// Fuel log ID
+	ID uint64 `json:"id" example:"1"`
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Fill-up date
+	FillDate string `json:"fill_date" example:"2024-01-15"`
+	// Odometer mileage at the fill-up
+	Mileage uint `json:"mileage" example:"45200"`
+	// Fuel type (gasoline, diesel, cng)
+	FuelType string `json:"fuel_type" example:"gasoline"`
+	// Unit of the amount (L, m3)
+	Unit string `json:"unit" example:"L"`
+	// Amount filled
+	Litres float64 `json:"litres" example:"40"`
+	// Price per litre in Rial
+	PricePerLitre int64 `json:"price_per_litre" example:"15000"`
+	// Total cost in Rial
+	TotalCost int64 `json:"total_cost" example:"600000"`
+	// Total cost formatted in Toman for display
+	TotalCostDisplay string `json:"total_cost_display" example:"۶۰٬۰۰۰ تومان"`
+	// Whether the tank was filled to the top
+	IsFullTank bool `json:"is_full_tank" example:"true"`
+	// Consumption per 100 km since the previous full fill-up, only on full fill-ups
+	ConsumptionPer100Km *float64 `json:"consumption_per_100km,omitempty" example:"8.4"`
+	// Fuel station
+	Station string `json:"station" example:"جایگاه شماره ۱۲"`
+	// Notes
+	Notes string `json:"notes" example:"سفر شمال"`
+}
+
+// ListFuelLogsResponse - List of fill-ups
+// @Description List of fill-ups, newest first
+type ListFuelLogsResponse struct {
+	// Fill-ups
+	FuelLogs []FuelLogResponse `json:"fuel_logs"`
+}
+
+// FuelEconomyResponse - Fuel economy of one fuel
+// @Description Fuel economy of one fuel, measured between consecutive full fill-ups
+type FuelEconomyResponse struct {
+	// Fuel type (gasoline, diesel, cng)
+	FuelType string `json:"fuel_type" example:"gasoline"`
+	// Unit of the amounts (L, m3)
+	Unit string `json:"unit" example:"L"`
+	// Number of fill-ups
+	FillUpCount int `json:"fill_up_count" example:"12"`
+	// Total amount filled
+	TotalLitres float64 `json:"total_litres" example:"480"`
+	// Total cost in Rial
+	TotalCost int64 `json:"total_cost" example:"7200000"`
+	// Number of full-to-full intervals measured
+	IntervalCount int `json:"interval_count" example:"10"`
+	// Consumption per 100 km over all intervals
+	AverageConsumption float64 `json:"average_consumption" example:"8.6"`
+	// Consumption per 100 km over the most recent intervals
+	RollingAverageConsumption float64 `json:"rolling_average_consumption" example:"8.2"`
+	// Consumption per 100 km of the latest interval
+	LastConsumption float64 `json:"last_consumption" example:"8.4"`
+}
+
+// MonthlyFuelSpendResponse - Fuel spending in a month
+// @Description Fuel spending in a month (yyyy-mm)
+type MonthlyFuelSpendResponse struct {
+	// Month, yyyy-mm
+	Month string `json:"month" example:"2024-01"`
+	// Number of fill-ups
+	FillUpCount int `json:"fill_up_count" example:"4"`
+	// Total cost in Rial
+	TotalCost int64 `json:"total_cost" example:"2400000"`
+	// Total cost in Toman
+	TotalCostToman int64 `json:"total_cost_toman" example:"240000"`
+	// Total cost formatted in Toman for display
+	TotalCostDisplay string `json:"total_cost_display" example:"۲۴۰٬۰۰۰ تومان"`
+}
+
+// FuelLogSummaryResponse - Fuel economy and spending of a user vehicle
+// @Description Fuel economy per fuel and monthly fuel spending of a user vehicle
+type FuelLogSummaryResponse struct {
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Fuels the vehicle's generation takes, primary first
+	FuelTypes []string `json:"fuel_types" example:"gasoline,cng"`
+	// Number of full-to-full intervals the rolling average covers
+	RollingWindow int `json:"rolling_window" example:"3"`
+	// Fuel economy per fuel
+	Economy []FuelEconomyResponse `json:"economy"`
+	// Fuel spending per month
+	Monthly []MonthlyFuelSpendResponse `json:"monthly"`
+}
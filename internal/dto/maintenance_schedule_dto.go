@@ -0,0 +1,87 @@
+package dto
+
+// CreateMaintenanceIntervalRequest - Request to add a manufacturer maintenance interval
+// @Description Request to add a manufacturer maintenance interval to a vehicle generation. At least one of interval_mileage and interval_months is required
+type CreateMaintenanceIntervalRequest struct {
+	// Item type (oil_change, oil_filter, air_filter, cabin_filter, fuel_filter, brake_pads, brake_fluid, spark_plugs, coolant, timing_belt, transmission_fluid, battery)
+	ItemType string `json:"item_type" validate:"required,maintenance_item_type" example:"oil_change"`
+	// Persian name
+	NameFa string `json:"name_fa" validate:"required" example:"تعویض روغن موتور"`
+	// English name
+	NameEn string `json:"name_en" example:"Engine oil change"`
+	// Kilometres between changes
+	IntervalMileage uint `json:"interval_mileage" validate:"omitempty,min=1" example:"5000"`
+	// Months between changes
+	IntervalMonths int `json:"interval_months" validate:"omitempty,min=1" example:"6"`
+}
+
+// UpdateMaintenanceIntervalRequest - Request to update a manufacturer maintenance interval
+// @Description Request to update a manufacturer maintenance interval. Existing vehicle plans keep their copy until regenerated
+type UpdateMaintenanceIntervalRequest struct {
+	// Persian name
+	NameFa *string `json:"name_fa" example:"تعویض روغن موتور"`
+	// English name
+	NameEn *string `json:"name_en" example:"Engine oil change"`
+	// Kilometres between changes, 0 to clear
+	IntervalMileage *uint `json:"interval_mileage" example:"5000"`
+	// Months between changes, 0 to clear
+	IntervalMonths *int `json:"interval_months" validate:"omitempty,min=0" example:"6"`
+}
+
+// MaintenanceIntervalResponse - Manufacturer maintenance interval response
+// @Description Manufacturer maintenance interval of a vehicle generation
+type MaintenanceIntervalResponse struct {
+	// Interval ID
+	ID uint64 `json:"id" example:"1"`
+	// Generation ID
+	GenerationID uint64 `json:"generation_id" example:"1"`
+	// Item type
+	ItemType string `json:"item_type" example:"oil_change"`
+	// Persian name
+	NameFa string `json:"name_fa" example:"تعویض روغن موتور"`
+	// English name
+	NameEn string `json:"name_en" example:"Engine oil change"`
+	// Kilometres between changes
+	IntervalMileage uint `json:"interval_mileage,omitempty" example:"5000"`
+	// Months between changes
+	IntervalMonths int `json:"interval_months,omitempty" example:"6"`
+}
+
+// ListMaintenanceIntervalsResponse - List of manufacturer maintenance intervals
+// @Description Manufacturer maintenance intervals of a vehicle generation
+type ListMaintenanceIntervalsResponse struct {
+	// Intervals
+	Intervals []MaintenanceIntervalResponse `json:"intervals"`
+}
+
+// MaintenancePlanItemResponse - Maintenance plan item response
+// @Description Manufacturer interval applied to a user vehicle
+type MaintenancePlanItemResponse struct {
+	// Plan item ID
+	ID uint64 `json:"id" example:"1"`
+	// Item type
+	ItemType string `json:"item_type" example:"oil_change"`
+	// Persian name
+	NameFa string `json:"name_fa" example:"تعویض روغن موتور"`
+	// English name
+	NameEn string `json:"name_en" example:"Engine oil change"`
+	// Kilometres between changes
+	IntervalMileage uint `json:"interval_mileage,omitempty" example:"5000"`
+	// Months between changes
+	IntervalMonths int `json:"interval_months,omitempty" example:"6"`
+	// Mileage the plan started from, used until the item is first recorded
+	StartMileage uint `json:"start_mileage" example:"12000"`
+	// Date the plan started from
+	StartDate string `json:"start_date" example:"2024-01-15"`
+}
+
+// MaintenancePlanResponse - Maintenance plan of a user vehicle
+// @Description Manufacturer maintenance plan of a user vehicle, generated from its generation's intervals
+type MaintenancePlanResponse struct {
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Generation ID
+	GenerationID uint64 `json:"generation_id" example:"1"`
+	// Plan items
+	Items []MaintenancePlanItemResponse `json:"items"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+// CreateOdometerReadingRequest - Request to record an odometer reading
+// @Description Request to record an odometer reading for a user vehicle
+type CreateOdometerReadingRequest struct {
+	// Odometer mileage
+	Mileage uint `json:"mileage" validate:"required" example:"45200"`
+	// Reading date, defaults to today
+	ReadingDate string `json:"reading_date" validate:"omitempty,date" example:"2024-01-15"`
+	// Set when the odometer was replaced, allowing a reading lower than the previous one
+	IsReplacement bool `json:"is_replacement" example:"false"`
+	// Notes
+	Notes string `json:"notes" example:"کیلومتر شمار تعویض شد"`
+}
+
+// OdometerReadingResponse - Odometer reading response
+// @Description Odometer reading information
+type OdometerReadingResponse struct {
+	// Reading ID
+	ID uint64 `json:"id" example:"1"`
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Odometer mileage
+	Mileage uint `json:"mileage" example:"45200"`
+	// Reading date
+	ReadingDate string `json:"reading_date" example:"2024-01-15"`
+	// Source (manual, service_visit)
+	Source string `json:"source" example:"manual"`
+	// Service visit that recorded this reading
+	ServiceVisitID string `json:"service_visit_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	// Whether this is the first reading of a replaced odometer
+	IsReplacement bool `json:"is_replacement" example:"false"`
+	// Notes
+	Notes string `json:"notes" example:"کیلومتر شمار تعویض شد"`
+}
+
+// ListOdometerReadingsResponse - List of odometer readings
+// @Description List of odometer readings, newest first
+type ListOdometerReadingsResponse struct {
+	// Odometer readings
+	OdometerReadings []OdometerReadingResponse `json:"odometer_readings"`
+}
@@ -85,6 +85,14 @@ type OilChangeResponse struct {
 	ServiceCenter string `json:"service_center" example:"اتوبان سرویس"`
 	// Notes
 	Notes string `json:"notes" example:"تعویض روغن"`
+	// Cost
+	Cost *CostResponse `json:"cost"`
+	// Average kilometres driven per day (last oil change only)
+	EstimatedDailyMileage float64 `json:"estimated_daily_mileage,omitempty" example:"42.5"`
+	// Date the next change mileage is expected to be reached (last oil change only)
+	ProjectedNextChangeDate string `json:"projected_next_change_date,omitempty" example:"2021-03-10"`
+	// Earlier of the next change date and the projected date (last oil change only)
+	DueAround string `json:"due_around,omitempty" example:"2021-01-01"`
 }
 
 // ListOilChangesResponse - Oil change list response
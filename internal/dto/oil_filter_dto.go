@@ -79,6 +79,14 @@ type OilFilterResponse struct {
 	ServiceCenter string `json:"service_center"`
 	// Additional notes
 	Notes string `json:"notes"`
+	// Cost
+	Cost *CostResponse `json:"cost"`
+	// Average kilometres driven per day (last oil filter change only)
+	EstimatedDailyMileage float64 `json:"estimated_daily_mileage,omitempty"`
+	// Date the next change mileage is expected to be reached (last oil filter change only)
+	ProjectedNextChangeDate string `json:"projected_next_change_date,omitempty"`
+	// Earlier of the next change date and the projected date (last oil filter change only)
+	DueAround string `json:"due_around,omitempty"`
 }
 
 // ListOilFiltersResponse represents the response for listing oil filter changes
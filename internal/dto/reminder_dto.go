@@ -0,0 +1,73 @@
+package dto
+
+// MaintenanceReminderResponse - Next-due state of a tracked maintenance item
+// @Description Next-due state of a tracked maintenance item
+type MaintenanceReminderResponse struct {
+	// Item type (oil_change, oil_filter, air_filter, ..., third_party_insurance, technical_inspection)
+	ItemType string `json:"item_type" example:"oil_change"`
+	// Item name
+	Name string `json:"name" example:"تکتاز"`
+	// Record the reminder is based on (oil_change, oil_filter, service_item, vehicle_document, or maintenance_plan for items not recorded yet)
+	Source string `json:"source" example:"oil_change"`
+	// ID of the source record
+	SourceID uint64 `json:"source_id" example:"1"`
+	// Mileage at last change
+	LastChangeMileage uint `json:"last_change_mileage" example:"10000"`
+	// Date of last change
+	LastChangeDate string `json:"last_change_date" example:"2024-01-15"`
+	// Next change mileage
+	NextChangeMileage uint `json:"next_change_mileage,omitempty" example:"15000"`
+	// Next change date
+	NextChangeDate string `json:"next_change_date,omitempty" example:"2024-07-15"`
+	// Kilometres remaining until next change (negative when overdue)
+	RemainingMileage *int `json:"remaining_mileage,omitempty" example:"800"`
+	// Days remaining until next change (negative when overdue)
+	RemainingDays *int `json:"remaining_days,omitempty" example:"10"`
+	// Status (upcoming, due_soon, overdue)
+	Status string `json:"status" example:"due_soon"`
+}
+
+// VehicleRemindersResponse - Maintenance reminders of a user vehicle
+// @Description Maintenance reminders of a user vehicle
+type VehicleRemindersResponse struct {
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Current mileage of the vehicle
+	CurrentMileage int `json:"current_mileage" example:"14200"`
+	// Reminders ordered by urgency
+	Reminders []MaintenanceReminderResponse `json:"reminders"`
+}
+
+// MaintenanceForecastResponse - Projected due date of a tracked maintenance item
+// @Description Projected due date of a tracked maintenance item
+type MaintenanceForecastResponse struct {
+	// Item type (oil_change, oil_filter, air_filter, ..., third_party_insurance, technical_inspection)
+	ItemType string `json:"item_type" example:"oil_change"`
+	// Item name
+	Name string `json:"name" example:"تکتاز"`
+	// Record the forecast is based on (oil_change, oil_filter, service_item, vehicle_document, or maintenance_plan for items not recorded yet)
+	Source string `json:"source" example:"oil_change"`
+	// ID of the source record
+	SourceID uint64 `json:"source_id" example:"1"`
+	// Next change mileage
+	NextChangeMileage uint `json:"next_change_mileage,omitempty" example:"15000"`
+	// Next change date
+	NextChangeDate string `json:"next_change_date,omitempty" example:"2024-07-15"`
+	// Date the next change mileage is expected to be reached
+	ProjectedNextChangeDate string `json:"projected_next_change_date,omitempty" example:"2024-06-20"`
+	// Earlier of the next change date and the projected date
+	DueAround string `json:"due_around,omitempty" example:"2024-06-20"`
+}
+
+// VehicleForecastResponse - Maintenance forecast of a user vehicle
+// @Description Maintenance forecast of a user vehicle based on its average daily mileage
+type VehicleForecastResponse struct {
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Current mileage of the vehicle
+	CurrentMileage int `json:"current_mileage" example:"14200"`
+	// Average kilometres driven per day, omitted when the mileage history is too short
+	EstimatedDailyMileage float64 `json:"estimated_daily_mileage,omitempty" example:"42.5"`
+	// Forecasts ordered by due-around date
+	Items []MaintenanceForecastResponse `json:"items"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+// ExportServiceBookRequest - Query for a vehicle service book export
+// @Description Format, calendar and language of a service book export
+type ExportServiceBookRequest struct {
+	// File format: pdf (default) or csv
+	Format string `form:"format" validate:"omitempty,oneof=pdf csv" example:"pdf"`
+	// Calendar dates are written in: gregorian (default) or jalali
+	Calendar string `form:"calendar" validate:"omitempty,oneof=gregorian jalali" example:"jalali"`
+	// Language of labels: fa (default, right-to-left) or en
+	Language string `form:"language" validate:"omitempty,oneof=fa en" example:"fa"`
+}
+
+// ExportFile is a generated file ready to be downloaded
+type ExportFile struct {
+	// File name suggested to the client
+	FileName string
+	// MIME type
+	ContentType string
+	// File content
+	Content []byte
+}
@@ -0,0 +1,115 @@
+package dto
+
+// CreateServiceCenterRequest - Request to add a service center to the directory
+// @Description Request to add a service center to the directory
+type CreateServiceCenterRequest struct {
+	// Name
+	Name string `json:"name" validate:"required" example:"اتوبان سرویس"`
+	// City
+	City string `json:"city" example:"تهران"`
+	// Street address
+	Address string `json:"address" example:"خیابان ولیعصر، پلاک ۱۲"`
+	// Phone number
+	Phone string `json:"phone" example:"02188776655"`
+	// Latitude
+	Latitude *float64 `json:"latitude" validate:"required,latitude" example:"35.7219"`
+	// Longitude
+	Longitude *float64 `json:"longitude" validate:"required,longitude" example:"51.3347"`
+}
+
+// UpdateServiceCenterRequest - Request to update a service center
+// @Description Request to update a service center in the directory
+type UpdateServiceCenterRequest struct {
+	// Name
+	Name *string `json:"name" example:"اتوبان سرویس"`
+	// City
+	City *string `json:"city" example:"تهران"`
+	// Street address
+	Address *string `json:"address" example:"خیابان ولیعصر، پلاک ۱۲"`
+	// Phone number
+	Phone *string `json:"phone" example:"02188776655"`
+	// Latitude
+	Latitude *float64 `json:"latitude" validate:"omitempty,latitude" example:"35.7219"`
+	// Longitude
+	Longitude *float64 `json:"longitude" validate:"omitempty,longitude" example:"51.3347"`
+}
+
+// NearbyServiceCentersRequest - Query for service centers around a location
+// @Description Query for service centers around a location
+type NearbyServiceCentersRequest struct {
+	// Latitude of the search origin
+	Latitude *float64 `form:"latitude" validate:"required,latitude" example:"35.7219"`
+	// Longitude of the search origin
+	Longitude *float64 `form:"longitude" validate:"required,longitude" example:"51.3347"`
+	// Search radius in kilometres (default 10, max 100)
+	RadiusKm float64 `form:"radius_km" validate:"omitempty,gt=0,max=100" example:"10"`
+	// Maximum number of results (default 20, max 100)
+	Limit int `form:"limit" validate:"omitempty,min=1,max=100" example:"20"`
+}
+
+// ServiceCenterResponse - Service center response
+// @Description Service center in the directory
+type ServiceCenterResponse struct {
+	// Service center ID
+	ID uint64 `json:"id" example:"1"`
+	// Name
+	Name string `json:"name" example:"اتوبان سرویس"`
+	// City
+	City string `json:"city" example:"تهران"`
+	// Street address
+	Address string `json:"address" example:"خیابان ولیعصر، پلاک ۱۲"`
+	// Phone number
+	Phone string `json:"phone" example:"02188776655"`
+	// Latitude
+	Latitude float64 `json:"latitude" example:"35.7219"`
+	// Longitude
+	Longitude float64 `json:"longitude" example:"51.3347"`
+	// Average rating from 1 to 5, 0 when not reviewed yet
+	AverageRating float64 `json:"average_rating" example:"4.5"`
+	// Number of reviews
+	ReviewCount int `json:"review_count" example:"12"`
+	// Distance from the search origin in kilometres (nearby search only)
+	DistanceKm *float64 `json:"distance_km,omitempty" example:"2.4"`
+}
+
+// ListServiceCentersResponse - List of service centers
+// @Description List of service centers
+type ListServiceCentersResponse struct {
+	// Service centers
+	ServiceCenters []ServiceCenterResponse `json:"service_centers"`
+}
+
+// ReviewServiceCenterRequest - Request to rate a service center
+// @Description Request to rate a service center. Only users with a recorded visit at the center may review it; reviewing again replaces the previous review
+type ReviewServiceCenterRequest struct {
+	// Rating from 1 to 5
+	Rating int `json:"rating" validate:"required,min=1,max=5" example:"5"`
+	// Comment
+	Comment string `json:"comment" validate:"max=1000" example:"سریع و منصف"`
+}
+
+// ServiceCenterReviewResponse - Service center review response
+// @Description User review of a service center
+type ServiceCenterReviewResponse struct {
+	// Review ID
+	ID uint64 `json:"id" example:"1"`
+	// Service center ID
+	ServiceCenterID uint64 `json:"service_center_id" example:"1"`
+	// Rating from 1 to 5
+	Rating int `json:"rating" example:"5"`
+	// Comment
+	Comment string `json:"comment" example:"سریع و منصف"`
+	// Date of the review
+	ReviewedAt string `json:"reviewed_at" example:"2024-01-15"`
+}
+
+// ListServiceCenterReviewsResponse - List of service center reviews
+// @Description Reviews of a service center with its rating summary
+type ListServiceCenterReviewsResponse struct {
+	// Reviews, most recent first
+	Reviews []ServiceCenterReviewResponse `json:"reviews"`
+	// Average rating from 1 to 5, 0 when not reviewed yet
+	AverageRating float64 `json:"average_rating" example:"4.5"`
+	// Number of reviews
+	ReviewCount int `json:"review_count" example:"12"`
+}
@@ -0,0 +1,83 @@
+package dto
+
+// CreateServiceItemRequest - Request to add a service item to a service visit
+// @Description Request to add a service item (air filter, brake pads, coolant, ...) to a service visit
+type CreateServiceItemRequest struct {
+	// Item type (air_filter, cabin_filter, fuel_filter, brake_pads, brake_fluid, spark_plugs, coolant, timing_belt, transmission_fluid, battery, other)
+	ItemType string `json:"item_type" validate:"required,service_item_type" example:"air_filter"`
+	// Item name
+	Name string `json:"name" validate:"required" example:"فیلتر هوا"`
+	// Item brand
+	Brand string `json:"brand" example:"Mann"`
+	// Part number
+	PartNumber string `json:"part_number" example:"C25114"`
+	// Next change mileage
+	NextChangeMileage uint `json:"next_change_mileage" validate:"omitempty,min=0" example:"30000"`
+	// Next change date
+	NextChangeDate string `json:"next_change_date" validate:"omitempty,date" example:"2025-01-15"`
+	// Notes
+	Notes string `json:"notes" example:"تعویض فیلتر هوا"`
+	// Labour cost in Rial
+	LabourCost int64 `json:"labour_cost" validate:"omitempty,min=0" example:"2000000"`
+	// Parts cost in Rial
+	PartsCost int64 `json:"parts_cost" validate:"omitempty,min=0" example:"10500000"`
+}
+
+// UpdateServiceItemRequest - Request to update a service item
+// @Description Request to update a service item
+type UpdateServiceItemRequest struct {
+	// Item type
+	ItemType *string `json:"item_type" validate:"omitempty,service_item_type" example:"air_filter"`
+	// Item name
+	Name *string `json:"name" example:"فیلتر هوا"`
+	// Item brand
+	Brand *string `json:"brand" example:"Mann"`
+	// Part number
+	PartNumber *string `json:"part_number" example:"C25114"`
+	// Next change mileage
+	NextChangeMileage *uint `json:"next_change_mileage" validate:"omitempty,min=0" example:"30000"`
+	// Next change date
+	NextChangeDate *string `json:"next_change_date" validate:"omitempty,date" example:"2025-01-15"`
+	// Notes
+	Notes *string `json:"notes" example:"تعویض فیلتر هوا"`
+	// Labour cost in Rial
+	LabourCost *int64 `json:"labour_cost" validate:"omitempty,min=0" example:"2000000"`
+	// Parts cost in Rial
+	PartsCost *int64 `json:"parts_cost" validate:"omitempty,min=0" example:"10500000"`
+}
+
+// ServiceItemResponse - Service item response
+// @Description Service item response
+type ServiceItemResponse struct {
+	// ID
+	ID uint64 `json:"id" example:"1"`
+	// Service visit ID
+	ServiceVisitID string `json:"service_visit_id" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	// Item type
+	ItemType string `json:"item_type" example:"air_filter"`
+	// Item name
+	Name string `json:"name" example:"فیلتر هوا"`
+	// Item brand
+	Brand string `json:"brand" example:"Mann"`
+	// Part number
+	PartNumber string `json:"part_number" example:"C25114"`
+	// Change mileage
+	ChangeMileage uint `json:"change_mileage" example:"15000"`
+	// Change date
+	ChangeDate string `json:"change_date" example:"2024-01-15"`
+	// Next change mileage
+	NextChangeMileage uint `json:"next_change_mileage" example:"30000"`
+	// Next change date
+	NextChangeDate string `json:"next_change_date" example:"2025-01-15"`
+	// Notes
+	Notes string `json:"notes" example:"تعویض فیلتر هوا"`
+	// Cost
+	Cost *CostResponse `json:"cost"`
+}
+
+// ListServiceItemsResponse - Service item list response
+// @Description Service item list response
+type ListServiceItemsResponse struct {
+	// Service items
+	ServiceItems []ServiceItemResponse `json:"service_items"`
+}
@@ -23,6 +23,10 @@ type ServiceVisitOilChange struct {
 	NextChangeDate string `json:"next_change_date" validate:"omitempty,date" example:"2021-01-01"`
 	// Notes
 	Notes string `json:"notes" example:"تعویض روغن"`
+	// Labour cost in Rial
+	LabourCost int64 `json:"labour_cost" validate:"omitempty,min=0" example:"2000000"`
+	// Parts cost in Rial
+	PartsCost int64 `json:"parts_cost" validate:"omitempty,min=0" example:"10500000"`
 }
 
 // ServiceVisitOilFilter - add oil filter to create service visit request
@@ -42,6 +46,10 @@ type ServiceVisitOilFilter struct {
 	NextChangeDate string `json:"next_change_date" validate:"omitempty,date" example:"2024-07-15"`
 	// Additional notes
 	Notes string `json:"notes" example:"Changed with oil change"`
+	// Labour cost in Rial
+	LabourCost int64 `json:"labour_cost" validate:"omitempty,min=0" example:"2000000"`
+	// Parts cost in Rial
+	PartsCost int64 `json:"parts_cost" validate:"omitempty,min=0" example:"10500000"`
 }
 
 // CreateServiceVisitRequest represents the request for creating a new service visit
@@ -55,13 +63,20 @@ type CreateServiceVisitRequest struct {
 	ServiceDate string `json:"service_date" validate:"required,date" example:"2024-01-15"`
 	// Service center where service was performed
 	ServiceCenter string `json:"service_center" example:"Auto Service Center"`
+	// ID of the service center in the directory (optional). Its name is used when service_center is empty
+	ServiceCenterID *uint64 `json:"service_center_id,omitempty" example:"1"`
 	// Additional notes
 	Notes string `json:"notes" example:"Regular maintenance service"`
+	// Labour cost in Rial not attributed to a specific item
+	LabourCost int64 `json:"labour_cost" validate:"omitempty,min=0" example:"1000000"`
+	// Parts cost in Rial not attributed to a specific item
+	PartsCost int64 `json:"parts_cost" validate:"omitempty,min=0" example:"0"`
 	// Oil change information (optional)
 	OilChange *ServiceVisitOilChange `json:"oil_change,omitempty"`
 	// Oil filter information (optional)
 	OilFilter *ServiceVisitOilFilter `json:"oil_filter,omitempty"`
-	// Other services can be added here in the future
+	// Other service items such as air filter, brake pads or coolant (optional)
+	ServiceItems []CreateServiceItemRequest `json:"service_items,omitempty"`
 }
 
 // UpdateServiceVisitOilChange - add oil change to update service visit request
@@ -83,6 +98,10 @@ type UpdateServiceVisitOilChange struct {
 	NextChangeDate *string `json:"next_change_date" validate:"omitempty,date" example:"2021-01-01"`
 	// Notes
 	Notes *string `json:"notes" example:"تعویض روغن"`
+	// Labour cost in Rial
+	LabourCost *int64 `json:"labour_cost" validate:"omitempty,min=0" example:"2000000"`
+	// Parts cost in Rial
+	PartsCost *int64 `json:"parts_cost" validate:"omitempty,min=0" example:"10500000"`
 }
 
 // UpdateServiceVisitOilFilter - add oil filter to update service visit request
@@ -102,6 +121,10 @@ type UpdateServiceVisitOilFilter struct {
 	NextChangeDate *string `json:"next_change_date" validate:"omitempty,date" example:"2024-07-15"`
 	// Additional notes
 	Notes *string `json:"notes" example:"Changed with oil change"`
+	// Labour cost in Rial
+	LabourCost *int64 `json:"labour_cost" validate:"omitempty,min=0" example:"2000000"`
+	// Parts cost in Rial
+	PartsCost *int64 `json:"parts_cost" validate:"omitempty,min=0" example:"10500000"`
 }
 
 // UpdateServiceVisitRequest represents the request for updating service visit
@@ -113,8 +136,14 @@ type UpdateServiceVisitRequest struct {
 	ServiceDate *string `json:"service_date" validate:"omitempty,date" example:"2024-01-15"`
 	// Service center where service was performed
 	ServiceCenter *string `json:"service_center" example:"Auto Service Center"`
+	// ID of the service center in the directory, 0 to unlink
+	ServiceCenterID *uint64 `json:"service_center_id" example:"1"`
 	// Additional notes
 	Notes *string `json:"notes" example:"Regular maintenance service"`
+	// Labour cost in Rial not attributed to a specific item
+	LabourCost *int64 `json:"labour_cost" validate:"omitempty,min=0" example:"1000000"`
+	// Parts cost in Rial not attributed to a specific item
+	PartsCost *int64 `json:"parts_cost" validate:"omitempty,min=0" example:"0"`
 	// Oil change information (optional)
 	OilChange *UpdateServiceVisitOilChange `json:"oil_change,omitempty"`
 	// Oil filter information (optional)
@@ -143,6 +172,8 @@ type ServiceVisitOilChangeResponse struct {
 	NextChangeDate string `json:"next_change_date" example:"2021-01-01"`
 	// Notes
 	Notes string `json:"notes" example:"تعویض روغن"`
+	// Cost
+	Cost *CostResponse `json:"cost"`
 }
 
 // ServiceVisitOilFilterResponse - Oil filter response
@@ -164,6 +195,8 @@ type ServiceVisitOilFilterResponse struct {
 	NextChangeDate string `json:"next_change_date"`
 	// Additional notes
 	Notes string `json:"notes"`
+	// Cost
+	Cost *CostResponse `json:"cost"`
 }
 
 // ServiceVisitResponse represents the response for service visit data
@@ -179,13 +212,40 @@ type ServiceVisitResponse struct {
 	ServiceDate string `json:"service_date"`
 	// Service center where service was performed
 	ServiceCenter string `json:"service_center"`
+	// ID of the service center in the directory, if referenced
+	ServiceCenterID *uint64 `json:"service_center_id,omitempty"`
 	// Additional notes
 	Notes string `json:"notes"`
+	// Cost of the whole visit including its oil change, oil filter and service items
+	Cost *CostResponse `json:"cost"`
 	// Oil change information (if performed)
 	OilChange *ServiceVisitOilChangeResponse `json:"oil_change,omitempty"`
 	// Oil filter information (if performed)
 	OilFilter *ServiceVisitOilFilterResponse `json:"oil_filter,omitempty"`
-	// Other services can be added here in the future
+	// Other service items performed in this visit
+	ServiceItems []ServiceItemResponse `json:"service_items,omitempty"`
+	// Invoices and receipts attached to this visit
+	Attachments []AttachmentResponse `json:"attachments,omitempty"`
+	// Oil and filter specs that differ from the generation's recommendation, only returned on create and update.
+	// They do not stop the visit from being saved
+	Warnings []ServiceVisitWarning `json:"warnings,omitempty"`
+}
+
+// ServiceVisitWarning - Mismatch with the recommended specs
+// @Description Oil change or oil filter spec of a service visit that differs from the recommendation for the vehicle's generation
+type ServiceVisitWarning struct {
+	// Machine-readable code (oil_viscosity_mismatch, oil_capacity_mismatch, oil_filter_mismatch)
+	Code string `json:"code" example:"oil_viscosity_mismatch"`
+	// Field of the request the warning is about
+	Field string `json:"field" example:"oil_change.oil_viscosity"`
+	// Value recorded for the visit
+	Value string `json:"value" example:"20W-50"`
+	// Values recommended for the generation
+	Recommended []string `json:"recommended" example:"5W-30,10W-40"`
+	// Message in English
+	MessageEn string `json:"message_en" example:"Oil viscosity is not one of the recommended viscosities"`
+	// Message in Persian
+	MessageFa string `json:"message_fa" example:"گرانروی روغن جزو گرانروی‌های توصیه‌شده نیست"`
 }
 
 // ListServiceVisitsResponse represents the response for listing service visits
@@ -194,3 +254,40 @@ type ListServiceVisitsResponse struct {
 	// List of service visits
 	ServiceVisits []ServiceVisitResponse `json:"service_visits"`
 }
+
+// ImportServiceVisitsRequest - Options of a service history import
+// @Description Options of a service history import
+type ImportServiceVisitsRequest struct {
+	// Validate the file and report what would be imported without saving anything
+	DryRun bool `form:"dry_run" example:"true"`
+}
+
+// ServiceVisitImportRowError - A row of an import file that could not be imported
+// @Description A row of an import file that could not be imported
+type ServiceVisitImportRowError struct {
+	// Row number in the file, counting the header as row 1
+	Row int `json:"row" example:"3"`
+	// Column the error refers to, if any
+	Column string `json:"column,omitempty" example:"service_mileage"`
+	// What is wrong with the row
+	Error string `json:"error" example:"service mileage is required"`
+}
+
+// ImportServiceVisitsResponse - Result of a service history import
+// @Description Result of a service history import. Nothing is imported unless every row is valid
+type ImportServiceVisitsResponse struct {
+	// Whether the import was a dry run
+	DryRun bool `json:"dry_run" example:"false"`
+	// Whether the service visits were saved
+	Imported bool `json:"imported" example:"true"`
+	// Number of non-empty rows in the file, excluding the header
+	TotalRows int `json:"total_rows" example:"42"`
+	// Number of rows without errors
+	ValidRows int `json:"valid_rows" example:"42"`
+	// Columns of the file that are not recognised and were ignored
+	IgnoredColumns []string `json:"ignored_columns,omitempty"`
+	// Errors of the rows that could not be imported
+	Errors []ServiceVisitImportRowError `json:"errors"`
+	// Service visits that were imported, or would be in a dry run, in chronological order
+	ServiceVisits []ServiceVisitResponse `json:"service_visits"`
+}
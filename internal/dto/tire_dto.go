@@ -0,0 +1,195 @@
+package dto
+
+// CreateTireSetRequest - Request to add a tire set
+// @Description Request to add a set of tires to a user vehicle. A set created as mounted takes the place of the set currently on the vehicle
+type CreateTireSetRequest struct {
+	// Tire brand
+	Brand string `json:"brand" validate:"required,max=50" example:"Kumho"`
+	// Tire model
+	Model string `json:"model" validate:"max=50" example:"Solus TA31"`
+	// Size marked on the sidewall
+	Size string `json:"size" validate:"required,max=30" example:"205/55R16"`
+	// Season (all_season, summer, winter), defaults to all_season
+	Season string `json:"season" validate:"omitempty,oneof=all_season summer winter" example:"all_season"`
+	// DOT date code, week and year of manufacture
+	DOTCode string `json:"dot_code" validate:"omitempty,len=4,numeric" example:"2321"`
+	// Purchase date, defaults to today
+	PurchaseDate string `json:"purchase_date" validate:"omitempty,date" example:"2024-01-15"`
+	// Odometer mileage at purchase
+	PurchaseMileage uint `json:"purchase_mileage" example:"45200"`
+	// Whether the set is mounted on the vehicle at the purchase mileage
+	Mounted bool `json:"mounted" example:"true"`
+	// Notes
+	Notes string `json:"notes" example:"خرید از بازار لاستیک"`
+}
+
+// UpdateTireSetRequest - Request to update a tire set
+// @Description Request to update the details of a tire set. Use the mount and unmount endpoints to put it on or take it off the vehicle
+type UpdateTireSetRequest struct {
+	// Tire brand
+	Brand *string `json:"brand" validate:"omitempty,min=1,max=50" example:"Kumho"`
+	// Tire model
+	Model *string `json:"model" validate:"omitempty,max=50" example:"Solus TA31"`
+	// Size marked on the sidewall
+	Size *string `json:"size" validate:"omitempty,min=1,max=30" example:"205/55R16"`
+	// Season (all_season, summer, winter)
+	Season *string `json:"season" validate:"omitempty,oneof=all_season summer winter" example:"winter"`
+	// DOT date code, week and year of manufacture
+	DOTCode *string `json:"dot_code" validate:"omitempty,len=4,numeric" example:"2321"`
+	// Purchase date
+	PurchaseDate *string `json:"purchase_date" validate:"omitempty,date" example:"2024-01-15"`
+	// Odometer mileage at purchase
+	PurchaseMileage *uint `json:"purchase_mileage" example:"45200"`
+	// Notes
+	Notes *string `json:"notes" example:"خرید از بازار لاستیک"`
+}
+
+// TireMountRequest - Request to mount or unmount a tire set
+// @Description Odometer mileage and date of a seasonal swap. Mounting a set takes the set currently on the vehicle off at the same mileage
+type TireMountRequest struct {
+	// Odometer mileage at the swap
+	Mileage uint `json:"mileage" validate:"required" example:"52000"`
+	// Swap date, defaults to today
+	Date string `json:"date" validate:"omitempty,date" example:"2024-11-20"`
+}
+
+// CreateTireRotationRequest - Request to record a tire rotation
+// @Description Request to record a rotation of the tires of a set
+type CreateTireRotationRequest struct {
+	// Odometer mileage at the rotation
+	Mileage uint `json:"mileage" validate:"required" example:"50000"`
+	// Rotation date, defaults to today
+	RotationDate string `json:"rotation_date" validate:"omitempty,date" example:"2024-06-10"`
+	// How the tires were moved
+	Pattern string `json:"pattern" validate:"max=50" example:"front-to-rear"`
+	// Notes
+	Notes string `json:"notes" example:"همراه با بالانس"`
+}
+
+// CreateTreadDepthRequest - Request to record tread depths
+// @Description Request to record the tread depth of each tire of a set, in millimetres
+type CreateTreadDepthRequest struct {
+	// Odometer mileage at the measurement
+	Mileage uint `json:"mileage" example:"50000"`
+	// Measurement date, defaults to today
+	MeasuredAt string `json:"measured_at" validate:"omitempty,date" example:"2024-06-10"`
+	// Front left tread depth in mm
+	FrontLeft float64 `json:"front_left" validate:"required,gt=0,lte=20" example:"5.5"`
+	// Front right tread depth in mm
+	FrontRight float64 `json:"front_right" validate:"required,gt=0,lte=20" example:"5.4"`
+	// Rear left tread depth in mm
+	RearLeft float64 `json:"rear_left" validate:"required,gt=0,lte=20" example:"6.1"`
+	// Rear right tread depth in mm
+	RearRight float64 `json:"rear_right" validate:"required,gt=0,lte=20" example:"6"`
+}
+
+// TireMountResponse - Tire mount period response
+// @Description A period the tire set spent on the vehicle
+type TireMountResponse struct {
+	// Mount ID
+	ID uint64 `json:"id" example:"1"`
+	// Date mounted
+	MountedAt string `json:"mounted_at" example:"2024-03-20"`
+	// Odometer mileage when mounted
+	MountedMileage uint `json:"mounted_mileage" example:"45200"`
+	// Date removed, empty while mounted
+	RemovedAt string `json:"removed_at,omitempty" example:"2024-11-20"`
+	// Odometer mileage when removed, empty while mounted
+	RemovedMileage *uint `json:"removed_mileage,omitempty" example:"52000"`
+	// Kilometres driven in this period
+	Distance uint `json:"distance" example:"6800"`
+}
+
+// TireRotationResponse - Tire rotation response
+// @Description Rotation of the tires of a set
+type TireRotationResponse struct {
+	// Rotation ID
+	ID uint64 `json:"id" example:"1"`
+	// Rotation date
+	RotationDate string `json:"rotation_date" example:"2024-06-10"`
+	// Odometer mileage at the rotation
+	Mileage uint `json:"mileage" example:"50000"`
+	// How the tires were moved
+	Pattern string `json:"pattern" example:"front-to-rear"`
+	// Notes
+	Notes string `json:"notes" example:"همراه با بالانس"`
+}
+
+// TreadDepthResponse - Tread depth measurement response
+// @Description Tread depth of each tire of a set, in millimetres
+type TreadDepthResponse struct {
+	// Measurement ID
+	ID uint64 `json:"id" example:"1"`
+	// Measurement date
+	MeasuredAt string `json:"measured_at" example:"2024-06-10"`
+	// Odometer mileage at the measurement
+	Mileage uint `json:"mileage,omitempty" example:"50000"`
+	// Front left tread depth in mm
+	FrontLeft float64 `json:"front_left" example:"5.5"`
+	// Front right tread depth in mm
+	FrontRight float64 `json:"front_right" example:"5.4"`
+	// Rear left tread depth in mm
+	RearLeft float64 `json:"rear_left" example:"6.1"`
+	// Rear right tread depth in mm
+	RearRight float64 `json:"rear_right" example:"6"`
+	// Shallowest tread of the set in mm
+	Minimum float64 `json:"minimum" example:"5.4"`
+}
+
+// TireSetResponse - Tire set response
+// @Description Tire set of a user vehicle with the kilometres driven on it and whether it should be replaced soon
+type TireSetResponse struct {
+	// Tire set ID
+	ID uint64 `json:"id" example:"1"`
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Tire brand
+	Brand string `json:"brand" example:"Kumho"`
+	// Tire model
+	Model string `json:"model" example:"Solus TA31"`
+	// Size marked on the sidewall
+	Size string `json:"size" example:"205/55R16"`
+	// Season (all_season, summer, winter)
+	Season string `json:"season" example:"all_season"`
+	// DOT date code
+	DOTCode string `json:"dot_code" example:"2321"`
+	// Date of manufacture decoded from the DOT date code
+	ManufactureDate string `json:"manufacture_date,omitempty" example:"2021-06-07"`
+	// Purchase date
+	PurchaseDate string `json:"purchase_date" example:"2024-01-15"`
+	// Odometer mileage at purchase
+	PurchaseMileage uint `json:"purchase_mileage" example:"45200"`
+	// Whether the set is on the vehicle
+	IsMounted bool `json:"is_mounted" example:"true"`
+	// Kilometres driven on the set over every period it was mounted
+	DistanceDriven uint `json:"distance_driven" example:"6800"`
+	// Latest tread depth measurement
+	LatestTreadDepth *TreadDepthResponse `json:"latest_tread_depth,omitempty"`
+	// Whether the set should be replaced soon
+	ReplaceSoon bool `json:"replace_soon" example:"false"`
+	// Why the set should be replaced soon (tread_depth, age)
+	ReplaceReasons []string `json:"replace_reasons"`
+	// Notes
+	Notes string `json:"notes" example:"خرید از بازار لاستیک"`
+}
+
+// TireSetDetailResponse - Tire set with its history
+// @Description Tire set with its mount periods, rotations and tread depth measurements, newest first
+type TireSetDetailResponse struct {
+	TireSetResponse
+	// Periods the set spent on the vehicle
+	Mounts []TireMountResponse `json:"mounts"`
+	// Rotations
+	Rotations []TireRotationResponse `json:"rotations"`
+	// Tread depth measurements
+	TreadDepths []TreadDepthResponse `json:"tread_depths"`
+}
+
+// ListTireSetsResponse - Tire sets of a vehicle
+// @Description Tire sets of a user vehicle, mounted set first, with the tire sizes of its generation as suggestions
+type ListTireSetsResponse struct {
+	// Tire sets
+	TireSets []TireSetResponse `json:"tire_sets"`
+	// Tire sizes listed for the vehicle's generation
+	SuggestedSizes []string `json:"suggested_sizes" example:"185/65R15,195/55R16"`
+}
@@ -0,0 +1,82 @@
+package dto
+
+// CreateVehicleDocumentRequest - Request to record an insurance policy or inspection certificate
+// @Description Request to record a third-party insurance (بیمه شخص ثالث), body insurance or technical inspection (معاینه فنی) document of a user vehicle
+type CreateVehicleDocumentRequest struct {
+	// Document type (third_party_insurance, body_insurance, technical_inspection)
+	DocumentType string `json:"document_type" validate:"required,oneof=third_party_insurance body_insurance technical_inspection" example:"third_party_insurance"`
+	// Insurer or inspection centre
+	Issuer string `json:"issuer" validate:"max=100" example:"بیمه ایران"`
+	// Policy or certificate number
+	PolicyNumber string `json:"policy_number" validate:"max=50" example:"1402/1234/567890"`
+	// Date the document takes effect
+	StartDate string `json:"start_date" validate:"required,date" example:"2024-01-15"`
+	// Date the document expires
+	ExpiryDate string `json:"expiry_date" validate:"required,date" example:"2025-01-15"`
+	// Notes
+	Notes string `json:"notes" example:"تخفیف عدم خسارت ۳۰٪"`
+}
+
+// UpdateVehicleDocumentRequest - Request to update a vehicle document
+// @Description Request to update a vehicle document
+type UpdateVehicleDocumentRequest struct {
+	// Document type (third_party_insurance, body_insurance, technical_inspection)
+	DocumentType *string `json:"document_type" validate:"omitempty,oneof=third_party_insurance body_insurance technical_inspection" example:"third_party_insurance"`
+	// Insurer or inspection centre
+	Issuer *string `json:"issuer" validate:"omitempty,max=100" example:"بیمه ایران"`
+	// Policy or certificate number
+	PolicyNumber *string `json:"policy_number" validate:"omitempty,max=50" example:"1402/1234/567890"`
+	// Date the document takes effect
+	StartDate *string `json:"start_date" validate:"omitempty,date" example:"2024-01-15"`
+	// Date the document expires
+	ExpiryDate *string `json:"expiry_date" validate:"omitempty,date" example:"2025-01-15"`
+	// Notes
+	Notes *string `json:"notes" example:"تخفیف عدم خسارت ۳۰٪"`
+}
+
+// VehicleDocumentScanResponse - Scan of a vehicle document
+// @Description Uploaded scan of a vehicle document
+type VehicleDocumentScanResponse struct {
+	// Original file name
+	FileName string `json:"file_name" example:"insurance.jpg"`
+	// Content type
+	ContentType string `json:"content_type" example:"image/jpeg"`
+	// Size in bytes
+	Size int64 `json:"size" example:"245760"`
+	// Download URL
+	URL string `json:"url" example:"/api/v1/user/vehicles/1/documents/1/scan"`
+}
+
+// VehicleDocumentResponse - Vehicle document response
+// @Description Insurance policy or technical inspection certificate of a user vehicle
+type VehicleDocumentResponse struct {
+	// Vehicle document ID
+	ID uint64 `json:"id" example:"1"`
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Document type (third_party_insurance, body_insurance, technical_inspection)
+	DocumentType string `json:"document_type" example:"third_party_insurance"`
+	// Insurer or inspection centre
+	Issuer string `json:"issuer" example:"بیمه ایران"`
+	// Policy or certificate number
+	PolicyNumber string `json:"policy_number" example:"1402/1234/567890"`
+	// Date the document takes effect
+	StartDate string `json:"start_date" example:"2024-01-15"`
+	// Date the document expires
+	ExpiryDate string `json:"expiry_date" example:"2025-01-15"`
+	// Days remaining until expiry (negative once expired)
+	RemainingDays int `json:"remaining_days" example:"20"`
+	// Status (upcoming, due_soon, overdue)
+	Status string `json:"status" example:"due_soon"`
+	// Notes
+	Notes string `json:"notes" example:"تخفیف عدم خسارت ۳۰٪"`
+	// Scan of the document, omitted when none was uploaded
+	Scan *VehicleDocumentScanResponse `json:"scan,omitempty"`
+}
+
+// ListVehicleDocumentsResponse - List of vehicle documents
+// @Description Documents of a user vehicle, latest expiry first
+type ListVehicleDocumentsResponse struct {
+	// Documents
+	Documents []VehicleDocumentResponse `json:"documents"`
+}
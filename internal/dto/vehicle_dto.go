@@ -180,6 +180,14 @@ type CreateVehicleGenerationRequest struct {
 	AssemblyType string `json:"assembly_type" example:"CKD"`
 	// Assembler of the vehicle generation
 	Assembler string `json:"assembler" example:"Toyota"`
+	// Tire sizes of the vehicle generation
+	TireSizes []string `json:"tire_sizes" example:"185/65R15,195/55R16"`
+	// Recommended engine oil viscosities
+	OilViscosities []string `json:"oil_viscosities" example:"5W-30,10W-40"`
+	// Recommended engine oil capacity in litres, with the filter changed
+	OilCapacity float64 `json:"oil_capacity" validate:"omitempty,gt=0,lte=50" example:"4.2"`
+	// OEM oil filter part numbers
+	OilFilterPartNumbers []string `json:"oil_filter_part_numbers" example:"HU816x,W712/75"`
 }
 
 // UpdateVehicleGenerationRequest represents the request for updating vehicle generation
@@ -223,6 +231,14 @@ type UpdateVehicleGenerationRequest struct {
 	AssemblyType *string `json:"assembly_type" example:"CKD"`
 	// Assembler of the vehicle generation
 	Assembler *string `json:"assembler" example:"Toyota"`
+	// Tire sizes of the vehicle generation
+	TireSizes *[]string `json:"tire_sizes" example:"185/65R15,195/55R16"`
+	// Recommended engine oil viscosities
+	OilViscosities *[]string `json:"oil_viscosities" example:"5W-30,10W-40"`
+	// Recommended engine oil capacity in litres, with the filter changed. 0 clears it
+	OilCapacity *float64 `json:"oil_capacity" validate:"omitempty,gte=0,lte=50" example:"4.2"`
+	// OEM oil filter part numbers
+	OilFilterPartNumbers *[]string `json:"oil_filter_part_numbers" example:"HU816x,W712/75"`
 }
 
 // VehicleGenerationResponse represents the response for vehicle generation data
@@ -269,6 +285,14 @@ type VehicleGenerationResponse struct {
 	AssemblyType string `json:"assembly_type"`
 	// Assembler of the vehicle generation
 	Assembler string `json:"assembler"`
+	// Tire sizes of the vehicle generation
+	TireSizes []string `json:"tire_sizes"`
+	// Recommended engine oil viscosities
+	OilViscosities []string `json:"oil_viscosities"`
+	// Recommended engine oil capacity in litres, with the filter changed
+	OilCapacity float64 `json:"oil_capacity"`
+	// OEM oil filter part numbers
+	OilFilterPartNumbers []string `json:"oil_filter_part_numbers"`
 }
 
 // UserVehicle
@@ -329,8 +353,10 @@ type UserVehicleResponse struct {
 	ProductionYear int `json:"production_year"`
 	// Color of the user vehicle
 	Color string `json:"color"`
-	// License plate of the user vehicle
+	// License plate of the user vehicle, e.g. 12-ب-345-67
 	LicensePlate string `json:"license_plate"`
+	// Parts of the license plate, missing when the stored plate can not be parsed
+	LicensePlateDetails *LicensePlateResponse `json:"license_plate_details,omitempty"`
 	// VIN of the user vehicle
 	VIN string `json:"vin"`
 	// Current mileage of the user vehicle
@@ -344,6 +370,29 @@ type UserVehicleResponse struct {
 	Generation *VehicleGenerationResponse `json:"generation,omitempty"`
 }
 
+// LicensePlateResponse represents an Iranian license plate split into its parts
+// @Description License plate parts
+type LicensePlateResponse struct {
+	// Category of the plate: private, taxi, public_transport, agricultural, government, ceremonial, police, military, disabled, temporary, diplomatic, motorcycle or free_zone
+	Category string `json:"category" example:"private"`
+	// Persian name of the category
+	CategoryLabel string `json:"category_label" example:"شخصی"`
+	// Two-digit number before the letter
+	TwoDigits string `json:"two_digits,omitempty" example:"12"`
+	// Letter of the plate
+	Letter string `json:"letter,omitempty" example:"ب"`
+	// Three-digit number after the letter
+	ThreeDigits string `json:"three_digits,omitempty" example:"345"`
+	// Region code under "ایران", three digits on motorcycle plates
+	RegionCode string `json:"region_code,omitempty" example:"67"`
+	// Number of motorcycle, free zone and ceremonial plates
+	Number string `json:"number,omitempty"`
+	// Free trade zone of free zone plates
+	FreeZone string `json:"free_zone,omitempty"`
+	// The plate the way it reads on the vehicle
+	Display string `json:"display" example:"12 ب 345 ایران 67"`
+}
+
 // ListVehicleTypesResponse represents the response for listing vehicle types
 // @Description List of vehicle types
 type ListVehicleTypesResponse struct {
@@ -444,4 +493,102 @@ type CompleteVehicleHierarchyResponse struct {
 	TotalModels int `json:"total_models"`
 	// Total count of vehicle generations
 	TotalGenerations int `json:"total_generations"`
+	// Catalog version of the hierarchy, to sync changes from with /vehicles/changes
+	Version uint64 `json:"version" example:"42"`
+}
+
+// SearchVehiclesRequest - Query for searching the vehicle catalog
+// @Description Query for searching brands, models and generations by Persian or English name
+type SearchVehiclesRequest struct {
+	// Text to search for, in Persian or English
+	Query string `form:"q" validate:"required,max=100" example:"پژو ۲۰۶"`
+	// Maximum number of results (default 20, max 50)
+	Limit int `form:"limit" validate:"omitempty,min=1,max=50" example:"20"`
+}
+
+// VehicleCatalogPathItem represents one level of a vehicle catalog path
+// @Description Vehicle type, brand, model or generation on the path to a search result
+type VehicleCatalogPathItem struct {
+	// ID of the catalog row
+	ID uint64 `json:"id" example:"1"`
+	// Persian name
+	NameFa string `json:"name_fa" example:"پژو"`
+	// English name
+	NameEn string `json:"name_en" example:"Peugeot"`
+}
+
+// VehicleSearchResult represents a brand, model or generation matching a search
+// @Description Brand, model or generation matching a search, with its full path in the catalog
+type VehicleSearchResult struct {
+	// Level of the match: brand, model or generation
+	Level string `json:"level" example:"model"`
+	// ID of the matching brand, model or generation
+	ID uint64 `json:"id" example:"12"`
+	// Persian name
+	NameFa string `json:"name_fa" example:"۲۰۶"`
+	// English name
+	NameEn string `json:"name_en" example:"206"`
+	// Relevance between 0 and 1, higher is better
+	Score float64 `json:"score" example:"0.95"`
+	// Vehicle type of the match
+	Type VehicleCatalogPathItem `json:"type"`
+	// Brand of the match
+	Brand VehicleCatalogPathItem `json:"brand"`
+	// Model of the match, empty for brands
+	Model *VehicleCatalogPathItem `json:"model,omitempty"`
+	// The matching generation, empty for brands and models
+	Generation *VehicleCatalogPathItem `json:"generation,omitempty"`
+}
+
+// SearchVehiclesResponse represents the results of a vehicle catalog search
+// @Description Vehicle catalog search results, best match first
+type SearchVehiclesResponse struct {
+	// Query after normalization
+	Query string `json:"query" example:"پژو 206"`
+	// Matching brands, models and generations
+	Results []VehicleSearchResult `json:"results"`
+}
+
+// ListVehicleCatalogChangesRequest represents the request for the catalog changes since a version
+// @Description Catalog changes request
+type ListVehicleCatalogChangesRequest struct {
+	// Catalog version the client has, 0 for the whole catalog
+	Since uint64 `form:"since" example:"40"`
+}
+
+// VehicleCatalogTombstone represents a catalog row deleted since the client's version
+// @Description Deleted type, brand, model or generation. Deleting a row also removes its children from the catalog
+type VehicleCatalogTombstone struct {
+	// Level of the row: type, brand, model or generation
+	Level string `json:"level" example:"generation"`
+	// ID of the row
+	ID uint64 `json:"id" example:"12"`
+}
+
+// ListVehicleCatalogChangesResponse represents the catalog changes since a version
+// @Description Types, brands, models and generations created or updated since the client's version, and the ones deleted since then
+type ListVehicleCatalogChangesResponse struct {
+	// Catalog version the changes are since
+	Since uint64 `json:"since" example:"40"`
+	// Current catalog version, to send as since on the next sync
+	Version uint64 `json:"version" example:"42"`
+	// Whether the response is the whole catalog, which replaces the client's copy. Sent when since is 0 or ahead of the catalog
+	Full bool `json:"full" example:"false"`
+	// Created or updated vehicle types
+	Types []VehicleTypeResponse `json:"types"`
+	// Created or updated brands
+	Brands []VehicleBrandResponse `json:"brands"`
+	// Created or updated models
+	Models []VehicleModelResponse `json:"models"`
+	// Created or updated generations
+	Generations []VehicleGenerationResponse `json:"generations"`
+	// Deleted rows
+	Deleted []VehicleCatalogTombstone `json:"deleted"`
+}
+
+// DeleteVehicleCatalogRowRequest represents the options of deleting a type, brand, model or generation
+// @Description Catalog deletion options
+type DeleteVehicleCatalogRowRequest struct {
+	// Generation to move the user vehicles under the deleted row to. Without it the deletion is refused while user vehicles use the row
+	ReassignToGenerationID uint64 `form:"reassign_to_generation_id" example:"7"`
 }
@@ -0,0 +1,155 @@
+package dto
+
+// CreateVehicleExpenseRequest - Request to record a vehicle expense
+// @Description Request to record a running cost of a user vehicle such as parking, tolls, car washes, registration fees or fines. Give either a built-in category or the id of one of your own categories
+type CreateVehicleExpenseRequest struct {
+	// Built-in category (parking, toll, car_wash, registration, fine, other)
+	Category string `json:"category" validate:"omitempty,oneof=parking toll car_wash registration fine other" example:"parking"`
+	// ID of one of your own expense categories
+	CustomCategoryID *uint64 `json:"custom_category_id" example:"1"`
+	// Amount in Rial
+	Amount int64 `json:"amount" validate:"required,gt=0" example:"500000"`
+	// Expense date, defaults to today
+	ExpenseDate string `json:"expense_date" validate:"omitempty,date" example:"2024-01-15"`
+	// Odometer mileage at the time
+	Mileage uint `json:"mileage" example:"45200"`
+	// Notes
+	Notes string `json:"notes" example:"پارکینگ فرودگاه امام"`
+}
+
+// UpdateVehicleExpenseRequest - Request to update a vehicle expense
+// @Description Request to update a vehicle expense. Setting a built-in category clears the custom one and the other way round
+type UpdateVehicleExpenseRequest struct {
+	// Built-in category (parking, toll, car_wash, registration, fine, other)
+	Category *string `json:"category" validate:"omitempty,oneof=parking toll car_wash registration fine other" example:"parking"`
+	// ID of one of your own expense categories
+	CustomCategoryID *uint64 `json:"custom_category_id" example:"1"`
+	// Amount in Rial
+	Amount *int64 `json:"amount" validate:"omitempty,gt=0" example:"500000"`
+	// Expense date
+	ExpenseDate *string `json:"expense_date" validate:"omitempty,date" example:"2024-01-15"`
+	// Odometer mileage at the time
+	Mileage *uint `json:"mileage" example:"45200"`
+	// Notes
+	Notes *string `json:"notes" example:"پارکینگ فرودگاه امام"`
+}
+
+// VehicleExpenseResponse - Vehicle expense response
+// @Description Running cost of a user vehicle
+type VehicleExpenseResponse struct {
+	// Vehicle expense ID
+	ID uint64 `json:"id" example:"1"`
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Category (parking, toll, car_wash, registration, fine, other, or custom for your own categories)
+	Category string `json:"category" example:"parking"`
+	// ID of your own category, only for custom
+	CustomCategoryID *uint64 `json:"custom_category_id,omitempty" example:"1"`
+	// Name of the category for display
+	CategoryName string `json:"category_name" example:"پارکینگ"`
+	// Amount in Rial
+	Amount int64 `json:"amount" example:"500000"`
+	// Amount formatted in Toman for display
+	AmountDisplay string `json:"amount_display" example:"۵۰٬۰۰۰ تومان"`
+	// Expense date
+	ExpenseDate string `json:"expense_date" example:"2024-01-15"`
+	// Odometer mileage at the time
+	Mileage uint `json:"mileage,omitempty" example:"45200"`
+	// Notes
+	Notes string `json:"notes" example:"پارکینگ فرودگاه امام"`
+}
+
+// ListVehicleExpensesResponse - List of vehicle expenses
+// @Description Expenses of a user vehicle, newest first
+type ListVehicleExpensesResponse struct {
+	// Expenses
+	Expenses []VehicleExpenseResponse `json:"expenses"`
+}
+
+// ExpenseCategoryRequest - Request to create or rename an expense category
+// @Description Request to create or rename one of your own expense categories
+type ExpenseCategoryRequest struct {
+	// Category name
+	Name string `json:"name" validate:"required,max=50" example:"بیمه بدنه اقساطی"`
+}
+
+// ExpenseCategoryResponse - Expense category response
+// @Description One of your own expense categories
+type ExpenseCategoryResponse struct {
+	// Category ID
+	ID uint64 `json:"id" example:"1"`
+	// Category name
+	Name string `json:"name" example:"بیمه بدنه اقساطی"`
+}
+
+// ListExpenseCategoriesResponse - Expense categories
+// @Description Built-in expense categories followed by your own
+type ListExpenseCategoriesResponse struct {
+	// Built-in categories with their display names
+	BuiltIn []BuiltInExpenseCategoryResponse `json:"built_in"`
+	// Your own categories
+	Custom []ExpenseCategoryResponse `json:"custom"`
+}
+
+// BuiltInExpenseCategoryResponse - Built-in expense category
+// @Description Built-in expense category
+type BuiltInExpenseCategoryResponse struct {
+	// Category key
+	Category string `json:"category" example:"parking"`
+	// Name for display
+	Name string `json:"name" example:"پارکینگ"`
+}
+
+// OwnershipCostResponse - Total cost of ownership
+// @Description Everything spent on a vehicle, in Rial with the total also given in Toman for display
+type OwnershipCostResponse struct {
+	// Service visit costs in Rial
+	Maintenance int64 `json:"maintenance" example:"12500000"`
+	// Fuel costs in Rial
+	Fuel int64 `json:"fuel" example:"2400000"`
+	// Other expenses in Rial
+	Expenses int64 `json:"expenses" example:"800000"`
+	// Total in Rial
+	Total int64 `json:"total" example:"15700000"`
+	// Total in Toman
+	TotalToman int64 `json:"total_toman" example:"1570000"`
+	// Total formatted in Toman for display
+	TotalDisplay string `json:"total_display" example:"۱٬۵۷۰٬۰۰۰ تومان"`
+}
+
+// MonthlyOwnershipCostResponse - Total cost of ownership in a month
+// @Description Everything spent on a vehicle in a month (yyyy-mm)
+type MonthlyOwnershipCostResponse struct {
+	// Month, yyyy-mm
+	Month string `json:"month" example:"2024-01"`
+	// Cost
+	Cost OwnershipCostResponse `json:"cost"`
+}
+
+// ExpenseCategoryCostResponse - Spending on an expense category
+// @Description Spending on an expense category
+type ExpenseCategoryCostResponse struct {
+	// Category (parking, toll, ..., or custom)
+	Category string `json:"category" example:"parking"`
+	// ID of your own category, only for custom
+	CustomCategoryID *uint64 `json:"custom_category_id,omitempty" example:"1"`
+	// Name of the category for display
+	CategoryName string `json:"category_name" example:"پارکینگ"`
+	// Total in Rial
+	Total int64 `json:"total" example:"800000"`
+	// Total formatted in Toman for display
+	TotalDisplay string `json:"total_display" example:"۸۰٬۰۰۰ تومان"`
+}
+
+// VehicleOwnershipCostResponse - Total cost of ownership of a user vehicle
+// @Description Service visit costs, fuel and other expenses of a user vehicle per month
+type VehicleOwnershipCostResponse struct {
+	// User vehicle ID
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Totals over all months
+	Total OwnershipCostResponse `json:"total"`
+	// Spending per month, oldest first
+	ByMonth []MonthlyOwnershipCostResponse `json:"by_month"`
+	// Other expenses per category, highest first
+	ExpensesByCategory []ExpenseCategoryCostResponse `json:"expenses_by_category"`
+}
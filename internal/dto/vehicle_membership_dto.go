@@ -0,0 +1,67 @@
+package dto
+
+// InviteVehicleMemberRequest - Request to share a vehicle
+// @Description Request to share a vehicle with the user of the phone number. Editors can log and change services, viewers can only read the history
+type InviteVehicleMemberRequest struct {
+	// Phone number of the invitee
+	PhoneNumber string `json:"phone_number" validate:"required,iranphone" example:"09123456789"`
+	// Role of the invitee: editor or viewer
+	Role string `json:"role" validate:"required,oneof=editor viewer" example:"editor"`
+}
+
+// UpdateVehicleMemberRequest - Request to change the role of a vehicle member
+// @Description Request to change the role of a vehicle member
+type UpdateVehicleMemberRequest struct {
+	// New role: editor or viewer
+	Role string `json:"role" validate:"required,oneof=editor viewer" example:"viewer"`
+}
+
+// VehicleMemberResponse - A user a vehicle is shared with
+// @Description A user a vehicle is shared with, or the owner of the vehicle
+type VehicleMemberResponse struct {
+	// ID of the membership, empty for the owner
+	ID uint64 `json:"id,omitempty" example:"1"`
+	// Phone number of the member
+	PhoneNumber string `json:"phone_number" example:"09123456789"`
+	// Name of the member, empty until the invitation is accepted
+	Name string `json:"name,omitempty" example:"مریم رضایی"`
+	// Role: owner, editor or viewer
+	Role string `json:"role" example:"editor"`
+	// Status: invited or accepted
+	Status string `json:"status" example:"accepted"`
+}
+
+// ListVehicleMembersResponse - Users a vehicle is shared with
+// @Description The owner of the vehicle and the users it is shared with
+type ListVehicleMembersResponse struct {
+	// Owner of the vehicle
+	Owner VehicleMemberResponse `json:"owner"`
+	// Editors and viewers, including pending invitations
+	Members []VehicleMemberResponse `json:"members"`
+}
+
+// VehicleMembershipResponse - A vehicle shared with the current user
+// @Description A vehicle shared with the current user, or an invitation to one
+type VehicleMembershipResponse struct {
+	// ID of the membership
+	ID uint64 `json:"id" example:"1"`
+	// ID of the shared user vehicle
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Name of the vehicle
+	VehicleName string `json:"vehicle_name" example:"پژو ۲۰۶ خانواده"`
+	// Name of the owner
+	OwnerName string `json:"owner_name" example:"علی رضایی"`
+	// Role: editor or viewer
+	Role string `json:"role" example:"editor"`
+	// Status: invited or accepted
+	Status string `json:"status" example:"invited"`
+	// Time of the invitation
+	CreatedAt string `json:"created_at" example:"2024-01-15T10:00:00Z"`
+}
+
+// ListVehicleMembershipsResponse - Vehicles shared with the current user
+// @Description Vehicles shared with the current user and pending invitations
+type ListVehicleMembershipsResponse struct {
+	// Memberships and invitations
+	Memberships []VehicleMembershipResponse `json:"memberships"`
+}
@@ -0,0 +1,76 @@
+package dto
+
+// CreateVehicleShareLinkRequest - Request to share a vehicle's service history by link
+// @Description Request to create a read-only link to a vehicle's service history that anyone can open without logging in
+type CreateVehicleShareLinkRequest struct {
+	// Days until the link expires, 30 when left empty
+	ExpiresInDays int `json:"expires_in_days" validate:"omitempty,min=1,max=365" example:"30"`
+	// Leave the VIN out of the shared view
+	HideVIN bool `json:"hide_vin" example:"true"`
+	// Leave the licence plate out of the shared view
+	HideLicensePlate bool `json:"hide_license_plate" example:"true"`
+	// Leave the costs of the service visits out of the shared view
+	HideCosts bool `json:"hide_costs" example:"false"`
+}
+
+// VehicleShareLinkResponse - A link to a vehicle's service history
+// @Description A read-only link to a vehicle's service history
+type VehicleShareLinkResponse struct {
+	// ID of the link
+	ID uint64 `json:"id" example:"1"`
+	// Public address of the shared view
+	URL string `json:"url" example:"https://api.autoban.ir/api/v1/shared/vehicles/3f2a9c1e5b7d4a6c8e0f1a2b3c4d5e6f.9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b8c"`
+	// Time the link expires
+	ExpiresAt string `json:"expires_at" example:"2024-02-14T10:00:00Z"`
+	// Time the link was revoked
+	RevokedAt string `json:"revoked_at,omitempty" example:"2024-01-20T10:00:00Z"`
+	// VIN is left out of the shared view
+	HideVIN bool `json:"hide_vin" example:"true"`
+	// Licence plate is left out of the shared view
+	HideLicensePlate bool `json:"hide_license_plate" example:"true"`
+	// Costs are left out of the shared view
+	HideCosts bool `json:"hide_costs" example:"false"`
+	// Number of times the link was opened
+	ViewCount int64 `json:"view_count" example:"3"`
+	// Time the link was last opened
+	LastViewedAt string `json:"last_viewed_at,omitempty" example:"2024-01-16T18:30:00Z"`
+	// Status: active, expired or revoked
+	Status string `json:"status" example:"active"`
+	// Time the link was created
+	CreatedAt string `json:"created_at" example:"2024-01-15T10:00:00Z"`
+}
+
+// ListVehicleShareLinksResponse - Links to a vehicle's service history
+// @Description Links to a vehicle's service history, newest first
+type ListVehicleShareLinksResponse struct {
+	// Links
+	ShareLinks []VehicleShareLinkResponse `json:"share_links"`
+}
+
+// SharedVehicleResponse - A vehicle as shown by a share link
+// @Description A vehicle as shown by a share link, without the fields the owner chose to hide
+type SharedVehicleResponse struct {
+	// Name of the vehicle
+	Name string `json:"name" example:"پژو ۲۰۶ خانواده"`
+	// Production year
+	ProductionYear int `json:"production_year" example:"1398"`
+	// Color
+	Color string `json:"color" example:"سفید"`
+	// Current mileage
+	CurrentMileage int `json:"current_mileage" example:"125000"`
+	// Licence plate, unless hidden
+	LicensePlate string `json:"license_plate,omitempty" example:"12-ب-345-67"`
+	// VIN, unless hidden
+	VIN string `json:"vin,omitempty" example:"NAAM11CA0KK123456"`
+}
+
+// SharedVehicleHistoryResponse - Service history opened by a share link
+// @Description Read-only service history of a vehicle opened by a share link
+type SharedVehicleHistoryResponse struct {
+	// Vehicle
+	Vehicle SharedVehicleResponse `json:"vehicle"`
+	// Service visits, newest first. Costs are empty when hidden by the owner
+	ServiceVisits []ServiceVisitResponse `json:"service_visits"`
+	// Time the link expires
+	ExpiresAt string `json:"expires_at" example:"2024-02-14T10:00:00Z"`
+}
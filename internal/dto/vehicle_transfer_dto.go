@@ -0,0 +1,63 @@
+package dto
+
+// StartVehicleTransferRequest - Request to transfer a vehicle to another user
+// @Description Request to transfer a vehicle, with its whole service history, to the user registered with the phone number
+type StartVehicleTransferRequest struct {
+	// Phone number of the recipient
+	PhoneNumber string `json:"phone_number" validate:"required,iranphone" example:"09123456789"`
+}
+
+// ConfirmVehicleTransferRequest - Request to confirm a vehicle transfer
+// @Description Request of the recipient to confirm a vehicle transfer with the code sent to their phone
+type ConfirmVehicleTransferRequest struct {
+	// Confirmation code sent by SMS
+	Code string `json:"code" validate:"required,len=6,numeric" example:"123456"`
+}
+
+// VehicleTransferResponse - Vehicle transfer
+// @Description Vehicle transfer
+type VehicleTransferResponse struct {
+	// ID of the transfer
+	ID uint64 `json:"id" example:"1"`
+	// ID of the transferred user vehicle
+	UserVehicleID uint64 `json:"user_vehicle_id" example:"1"`
+	// Name of the vehicle
+	VehicleName string `json:"vehicle_name" example:"پژو ۲۰۶ من"`
+	// Phone number of the recipient
+	ToPhoneNumber string `json:"to_phone_number" example:"09123456789"`
+	// Status: pending, completed or cancelled
+	Status string `json:"status" example:"pending"`
+	// Time the confirmation code expires
+	ExpiresAt string `json:"expires_at" example:"2024-01-15T10:30:00Z"`
+	// Time the recipient confirmed the transfer
+	CompletedAt string `json:"completed_at,omitempty" example:"2024-01-15T10:10:00Z"`
+	// Time the transfer was started
+	CreatedAt string `json:"created_at" example:"2024-01-15T10:00:00Z"`
+}
+
+// ListVehicleTransfersResponse - List of vehicle transfers
+// @Description List of vehicle transfers
+type ListVehicleTransfersResponse struct {
+	// Vehicle transfers
+	Transfers []VehicleTransferResponse `json:"transfers"`
+}
+
+// VehicleOwnershipResponse - A period of ownership of a vehicle
+// @Description A period during which a user owned the vehicle
+type VehicleOwnershipResponse struct {
+	// Name of the owner
+	OwnerName string `json:"owner_name" example:"علی رضایی"`
+	// Whether the owner is the requesting user
+	IsCurrentUser bool `json:"is_current_user" example:"true"`
+	// Date the ownership started
+	StartedAt string `json:"started_at" example:"2020-05-01"`
+	// Date the ownership ended, empty for the current owner
+	EndedAt string `json:"ended_at,omitempty" example:"2024-01-15"`
+}
+
+// ListVehicleOwnershipsResponse - Ownership history of a vehicle
+// @Description Owners of the vehicle, oldest first
+type ListVehicleOwnershipsResponse struct {
+	// Ownership periods
+	Ownerships []VehicleOwnershipResponse `json:"ownerships"`
+}
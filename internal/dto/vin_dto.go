@@ -0,0 +1,101 @@
+package dto
+
+// DecodeVINRequest - Query for decoding a VIN
+// @Description Query for decoding a Vehicle Identification Number
+type DecodeVINRequest struct {
+	// VIN to decode. Persian digits, spaces and dashes are accepted
+	VIN string `form:"vin" validate:"required,iranian_vin" example:"NAAN01CA9JK123456"`
+}
+
+// DecodeVINResponse - Decoded VIN
+// @Description Sections of a VIN with the catalog brand, model and generation they point to
+type DecodeVINResponse struct {
+	// Normalized VIN
+	VIN string `json:"vin" example:"NAAN01CA9JK123456"`
+	// World Manufacturer Identifier, positions 1-3
+	WMI string `json:"wmi" example:"NAA"`
+	// Vehicle descriptor section, positions 4-8
+	VDS string `json:"vds" example:"N01CA"`
+	// Check digit, position 9
+	CheckDigit string `json:"check_digit" example:"9"`
+	// Whether position 9 holds the ISO 3779 check digit. Only mandatory in North America
+	CheckDigitValid bool `json:"check_digit_valid" example:"false"`
+	// Vehicle identifier section, positions 10-17
+	VIS string `json:"vis" example:"JK123456"`
+	// Model year code, position 10
+	ModelYearCode string `json:"model_year_code" example:"J"`
+	// Decoded model year, 0 when position 10 is not a model year code
+	ModelYear int `json:"model_year" example:"2018"`
+	// Plant code, position 11
+	PlantCode string `json:"plant_code" example:"K"`
+	// Serial number, positions 12-17
+	SerialNumber string `json:"serial_number" example:"123456"`
+	// Manufacturer from the WMI table, empty when the WMI is unknown
+	Manufacturer string `json:"manufacturer" example:"Iran Khodro (IKCO)"`
+	// Vehicle type of the brand
+	Type *VehicleCatalogPathItem `json:"type,omitempty"`
+	// Catalog brand of the WMI
+	Brand *VehicleCatalogPathItem `json:"brand,omitempty"`
+	// Catalog model, when the WMI table narrows the VIN down to one
+	Model *VehicleCatalogPathItem `json:"model,omitempty"`
+	// Generations of the model produced in the model year
+	Generations []VehicleCatalogPathItem `json:"generations"`
+	// Suggested generation for adding the vehicle
+	GenerationID *uint64 `json:"generation_id,omitempty" example:"3"`
+	// Suggested production year for adding the vehicle
+	ProductionYear *int `json:"production_year,omitempty" example:"2018"`
+}
+
+// CreateVehicleWMIRequest - Request to add a WMI to the VIN decoder
+// @Description Request to map a World Manufacturer Identifier, optionally narrowed by the start of the VDS, to a catalog brand and model
+type CreateVehicleWMIRequest struct {
+	// World Manufacturer Identifier, the first 3 characters of the VIN
+	WMI string `json:"wmi" validate:"required,len=3" example:"NAA"`
+	// Start of the vehicle descriptor section (positions 4-8) that identifies the brand or model, empty to match every VIN of the WMI
+	VDSPrefix string `json:"vds_prefix" validate:"max=5" example:"N01"`
+	// Manufacturer name
+	Manufacturer string `json:"manufacturer" validate:"required,max=100" example:"Iran Khodro (IKCO)"`
+	// Catalog brand
+	BrandID uint64 `json:"brand_id" validate:"required" example:"1"`
+	// Catalog model of the brand, if the WMI and VDS prefix identify one
+	ModelID *uint64 `json:"model_id" example:"12"`
+}
+
+// UpdateVehicleWMIRequest - Request to update a WMI of the VIN decoder
+// @Description Request to update a WMI mapping of the VIN decoder
+type UpdateVehicleWMIRequest struct {
+	// World Manufacturer Identifier, the first 3 characters of the VIN
+	WMI *string `json:"wmi" validate:"omitempty,len=3" example:"NAA"`
+	// Start of the vehicle descriptor section (positions 4-8), empty to match every VIN of the WMI
+	VDSPrefix *string `json:"vds_prefix" validate:"omitempty,max=5" example:"N01"`
+	// Manufacturer name
+	Manufacturer *string `json:"manufacturer" validate:"omitempty,max=100" example:"Iran Khodro (IKCO)"`
+	// Catalog brand
+	BrandID *uint64 `json:"brand_id" example:"1"`
+	// Catalog model of the brand, 0 to clear it
+	ModelID *uint64 `json:"model_id" example:"12"`
+}
+
+// VehicleWMIResponse - WMI mapping
+// @Description World Manufacturer Identifier mapped to a catalog brand and model
+type VehicleWMIResponse struct {
+	// WMI mapping ID
+	ID uint64 `json:"id" example:"1"`
+	// World Manufacturer Identifier
+	WMI string `json:"wmi" example:"NAA"`
+	// Start of the vehicle descriptor section, empty for every VIN of the WMI
+	VDSPrefix string `json:"vds_prefix" example:"N01"`
+	// Manufacturer name
+	Manufacturer string `json:"manufacturer" example:"Iran Khodro (IKCO)"`
+	// Catalog brand
+	BrandID uint64 `json:"brand_id" example:"1"`
+	// Catalog model
+	ModelID *uint64 `json:"model_id,omitempty" example:"12"`
+}
+
+// ListVehicleWMIsResponse - List of WMI mappings
+// @Description WMI mappings of the VIN decoder
+type ListVehicleWMIsResponse struct {
+	// WMI mappings
+	WMIs []VehicleWMIResponse `json:"wmis"`
+}
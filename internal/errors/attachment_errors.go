@@ -0,0 +1,15 @@
+package errors
+
+// Attachment service errors
+var (
+    ErrInvalidAttachmentID           = NewWithCode("INVALID_ATTACHMENT_ID", "invalid attachment id", "شناسه پیوست نامعتبر است")
+    ErrAttachmentFileRequired        = NewWithCode("ATTACHMENT_FILE_REQUIRED", "attachment file is required", "فایل پیوست الزامی است")
+    ErrAttachmentTypeNotAllowed      = NewWithCode("ATTACHMENT_TYPE_NOT_ALLOWED", "only JPEG, PNG, WebP images and PDF files are allowed", "فقط تصاویر JPEG، PNG، WebP و فایل PDF مجاز است")
+    ErrAttachmentTooLarge            = NewWithCode("ATTACHMENT_TOO_LARGE", "attachment is too large", "حجم فایل پیوست بیش از حد مجاز است")
+    ErrAttachmentQuotaExceeded       = NewWithCode("ATTACHMENT_QUOTA_EXCEEDED", "attachment storage quota exceeded", "فضای ذخیره‌سازی پیوست‌ها پر شده است")
+    ErrFailedToUploadAttachment      = NewWithCode("UPLOAD_ATTACHMENT_FAILED", "failed to upload attachment", "خطای بارگذاری پیوست")
+    ErrFailedToGetAttachment         = NewWithCode("GET_ATTACHMENT_FAILED", "failed to get attachment", "خطای دریافت پیوست")
+    ErrFailedToListAttachments       = NewWithCode("LIST_ATTACHMENTS_FAILED", "failed to list attachments", "خطای فهرست پیوست‌ها")
+    ErrFailedToDeleteAttachment      = NewWithCode("DELETE_ATTACHMENT_FAILED", "failed to delete attachment", "خطای حذف پیوست")
+    ErrAttachmentNotOwned            = NewWithCode("ATTACHMENT_NOT_OWNED", "attachment not owned", "پیوست متعلق به کاربر نیست")
+)
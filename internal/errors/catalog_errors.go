@@ -0,0 +1,22 @@
+package errors
+
+// Catalog import and export errors
+var (
+    ErrInvalidCatalogExportRequest = NewWithCode("INVALID_CATALOG_EXPORT", "invalid catalog export request", "درخواست خروجی کاتالوگ خودرو معتبر نیست")
+    ErrCatalogImportFileRequired   = NewWithCode("CATALOG_IMPORT_FILE_REQUIRED", "catalog import file is required", "فایل کاتالوگ خودرو الزامی است")
+    ErrCatalogImportFileTooLarge   = NewWithCode("CATALOG_IMPORT_FILE_TOO_LARGE", "catalog import file is too large", "حجم فایل کاتالوگ خودرو بیش از حد مجاز است")
+    ErrInvalidCatalogImportFile    = NewWithCode("INVALID_CATALOG_IMPORT_FILE", "catalog import file must be a valid JSON, CSV or XLSX file", "فایل کاتالوگ خودرو باید JSON، CSV یا XLSX معتبر باشد")
+    ErrCatalogImportMissingColumns = NewWithCode("CATALOG_IMPORT_MISSING_COLUMNS", "catalog import file must have a type_name_en column", "فایل کاتالوگ خودرو باید ستون type_name_en داشته باشد")
+    ErrFailedToExportCatalog       = NewWithCode("EXPORT_CATALOG_FAILED", "failed to export catalog", "خطای تهیه خروجی کاتالوگ خودرو")
+    ErrFailedToImportCatalog       = NewWithCode("IMPORT_CATALOG_FAILED", "failed to import catalog", "خطای ورود کاتالوگ خودرو")
+)
+
+// Catalog trash errors
+var (
+    ErrInvalidCatalogTrashLevel   = NewWithCode("INVALID_CATALOG_TRASH_LEVEL", "level must be type, brand, model or generation", "سطح باید type، brand، model یا generation باشد")
+    ErrInvalidCatalogTrashRowID   = NewWithCode("INVALID_CATALOG_TRASH_ROW_ID", "invalid catalog row id", "شناسه مورد کاتالوگ نامعتبر است")
+    ErrCatalogTrashItemNotFound   = NewWithCode("CATALOG_TRASH_ITEM_NOT_FOUND", "catalog entry not found in trash", "مورد کاتالوگ در سطل زباله یافت نشد")
+    ErrCatalogTrashParentDeleted  = NewWithCode("CATALOG_TRASH_PARENT_DELETED", "the parent of the catalog entry is deleted, restore it first", "والد این مورد کاتالوگ حذف شده است، ابتدا آن را بازیابی کنید")
+    ErrFailedToListCatalogTrash   = NewWithCode("LIST_CATALOG_TRASH_FAILED", "failed to list catalog trash", "خطای فهرست سطل زباله کاتالوگ")
+    ErrFailedToRestoreCatalogItem = NewWithCode("RESTORE_CATALOG_ITEM_FAILED", "failed to restore catalog entry", "خطای بازیابی مورد کاتالوگ")
+)
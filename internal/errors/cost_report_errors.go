@@ -0,0 +1,6 @@
+package errors
+
+// Cost report service errors
+var (
+    ErrFailedToGetCostReport = NewWithCode("GET_COST_REPORT_FAILED", "failed to get cost report", "خطای دریافت گزارش هزینه‌ها")
+)
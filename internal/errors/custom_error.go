@@ -36,6 +36,8 @@ type CustomError struct {
 	Fields []FieldError `json:"fields,omitempty"`
 	// Not serialized by default; used internally to hint status mapping if needed
 	statusHint int `json:"-"`
+	// Not serialized; the shared error a copy was made from, so Is still matches it
+	origin *CustomError
 }
 
 // Error implements the error interface
@@ -105,9 +107,19 @@ func Copy(e *CustomError) *CustomError {
 	dup.Details = nil
 	dup.Fields = nil
 	dup.statusHint = 0
+	dup.origin = e
+	if e.origin != nil {
+		dup.origin = e.origin
+	}
 	return &dup
 }
 
+// Is reports whether e is a copy of target, so a copy carrying details maps like the shared error
+func (e *CustomError) Is(target error) bool {
+	origin, ok := target.(*CustomError)
+	return ok && e.origin != nil && e.origin == origin
+}
+
 // Is compares errors in a robust way (preserved from original)
 func Is(err, target error) bool {
 	if err == nil || target == nil {
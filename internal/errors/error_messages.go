@@ -10,4 +10,5 @@ var (
     ErrInvalidPassword     = NewWithCode("INVALID_PASSWORD", "invalid password", "رمز عبور نامعتبر است")
     ErrTokenNotFound       = NewWithCode("TOKEN_NOT_FOUND", "authentication token not found", "توکن احراز هویت یافت نشد")
     ErrAccessDenied        = NewWithCode("ACCESS_DENIED", "access denied", "شما دسترسی لازم برای این عملیات را ندارید")
+    ErrTooManyRequests     = NewWithCode("TOO_MANY_REQUESTS", "too many requests, try again later", "تعداد درخواست‌ها بیش از حد مجاز است، بعدا تلاش کنید")
 )
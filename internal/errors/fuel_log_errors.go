@@ -0,0 +1,16 @@
+package errors
+
+// Fuel log service errors
+var (
+    ErrInvalidFuelLogCreateRequest = NewWithCode("INVALID_FUEL_LOG_CREATE", "invalid fuel log create request", "درخواست ثبت سوخت‌گیری معتبر نیست")
+    ErrInvalidFuelLogUpdateRequest = NewWithCode("INVALID_FUEL_LOG_UPDATE", "invalid fuel log update request", "درخواست به‌روزرسانی سوخت‌گیری معتبر نیست")
+    ErrInvalidFuelLogID            = NewWithCode("INVALID_FUEL_LOG_ID", "invalid fuel log id", "شناسه سوخت‌گیری نامعتبر است")
+    ErrFuelTypeNotSupported        = NewWithCode("FUEL_TYPE_NOT_SUPPORTED", "fuel type not supported by the vehicle", "این نوع سوخت برای خودرو مجاز نیست")
+    ErrFailedToCreateFuelLog       = NewWithCode("CREATE_FUEL_LOG_FAILED", "failed to create fuel log", "خطای ثبت سوخت‌گیری")
+    ErrFailedToGetFuelLog          = NewWithCode("GET_FUEL_LOG_FAILED", "failed to get fuel log", "خطای دریافت سوخت‌گیری")
+    ErrFailedToListFuelLogs        = NewWithCode("LIST_FUEL_LOGS_FAILED", "failed to list fuel logs", "خطای فهرست سوخت‌گیری‌ها")
+    ErrFailedToUpdateFuelLog       = NewWithCode("UPDATE_FUEL_LOG_FAILED", "failed to update fuel log", "خطای به‌روزرسانی سوخت‌گیری")
+    ErrFailedToDeleteFuelLog       = NewWithCode("DELETE_FUEL_LOG_FAILED", "failed to delete fuel log", "خطای حذف سوخت‌گیری")
+    ErrFailedToGetFuelSummary      = NewWithCode("GET_FUEL_SUMMARY_FAILED", "failed to get fuel summary", "خطای دریافت خلاصه مصرف سوخت")
+    ErrFuelLogNotOwned             = NewWithCode("FUEL_LOG_NOT_OWNED", "fuel log not owned", "سوخت‌گیری متعلق به کاربر نیست")
+)
@@ -0,0 +1,16 @@
+package errors
+
+// Maintenance schedule service errors
+var (
+    ErrInvalidMaintenanceIntervalCreateRequest = NewWithCode("INVALID_MAINTENANCE_INTERVAL_CREATE", "invalid maintenance interval create request", "درخواست ایجاد بازه سرویس معتبر نیست")
+    ErrInvalidMaintenanceIntervalUpdateRequest = NewWithCode("INVALID_MAINTENANCE_INTERVAL_UPDATE", "invalid maintenance interval update request", "درخواست به‌روزرسانی بازه سرویس معتبر نیست")
+    ErrInvalidMaintenanceIntervalID            = NewWithCode("INVALID_MAINTENANCE_INTERVAL_ID", "invalid maintenance interval id", "شناسه بازه سرویس نامعتبر است")
+    ErrMaintenanceIntervalExists               = NewWithCode("MAINTENANCE_INTERVAL_EXISTS", "generation already has an interval for this item", "برای این قطعه قبلا بازه سرویس تعریف شده است")
+    ErrMaintenanceIntervalNotFound             = NewWithCode("MAINTENANCE_INTERVAL_NOT_FOUND", "maintenance interval not found", "بازه سرویس یافت نشد")
+    ErrFailedToCreateMaintenanceInterval       = NewWithCode("CREATE_MAINTENANCE_INTERVAL_FAILED", "failed to create maintenance interval", "خطای ایجاد بازه سرویس")
+    ErrFailedToListMaintenanceIntervals        = NewWithCode("LIST_MAINTENANCE_INTERVALS_FAILED", "failed to list maintenance intervals", "خطای فهرست بازه‌های سرویس")
+    ErrFailedToUpdateMaintenanceInterval       = NewWithCode("UPDATE_MAINTENANCE_INTERVAL_FAILED", "failed to update maintenance interval", "خطای به‌روزرسانی بازه سرویس")
+    ErrFailedToDeleteMaintenanceInterval       = NewWithCode("DELETE_MAINTENANCE_INTERVAL_FAILED", "failed to delete maintenance interval", "خطای حذف بازه سرویس")
+    ErrFailedToGetMaintenancePlan              = NewWithCode("GET_MAINTENANCE_PLAN_FAILED", "failed to get maintenance plan", "خطای دریافت برنامه سرویس")
+    ErrFailedToGenerateMaintenancePlan         = NewWithCode("GENERATE_MAINTENANCE_PLAN_FAILED", "failed to generate maintenance plan", "خطای ایجاد برنامه سرویس")
+)
@@ -0,0 +1,15 @@
+package errors
+
+// Odometer reading service errors
+var (
+    ErrInvalidOdometerReadingCreateRequest = NewWithCode("INVALID_ODOMETER_READING_CREATE", "invalid odometer reading create request", "درخواست ثبت کیلومتر معتبر نیست")
+    ErrInvalidOdometerReadingID            = NewWithCode("INVALID_ODOMETER_READING_ID", "invalid odometer reading id", "شناسه کیلومتر نامعتبر است")
+    ErrFailedToCreateOdometerReading       = NewWithCode("CREATE_ODOMETER_READING_FAILED", "failed to create odometer reading", "خطای ثبت کیلومتر")
+    ErrFailedToGetOdometerReading          = NewWithCode("GET_ODOMETER_READING_FAILED", "failed to get odometer reading", "خطای دریافت کیلومتر")
+    ErrFailedToListOdometerReadings        = NewWithCode("LIST_ODOMETER_READINGS_FAILED", "failed to list odometer readings", "خطای فهرست کیلومترها")
+    ErrFailedToDeleteOdometerReading       = NewWithCode("DELETE_ODOMETER_READING_FAILED", "failed to delete odometer reading", "خطای حذف کیلومتر")
+    ErrOdometerReadingNotOwned             = NewWithCode("ODOMETER_READING_NOT_OWNED", "odometer reading not owned", "کیلومتر متعلق به کاربر نیست")
+    ErrOdometerReadingTooLow               = NewWithCode("ODOMETER_READING_TOO_LOW", "odometer reading is lower than the previous reading", "کیلومتر وارد شده کمتر از کیلومتر قبلی است")
+    ErrOdometerReadingTooHigh              = NewWithCode("ODOMETER_READING_TOO_HIGH", "odometer reading is higher than the next reading", "کیلومتر وارد شده بیشتر از کیلومتر بعدی است")
+    ErrOdometerReadingManagedByServiceVisit = NewWithCode("ODOMETER_READING_MANAGED_BY_SERVICE_VISIT", "odometer reading belongs to a service visit", "این کیلومتر مربوط به یک مراجعه سرویس است")
+)
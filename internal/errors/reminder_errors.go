@@ -0,0 +1,7 @@
+package errors
+
+// Reminder service errors
+var (
+    ErrFailedToGetReminders = NewWithCode("GET_REMINDERS_FAILED", "failed to get maintenance reminders", "خطای دریافت یادآوری‌های سرویس")
+    ErrFailedToGetForecast  = NewWithCode("GET_FORECAST_FAILED", "failed to get maintenance forecast", "خطای دریافت پیش‌بینی سرویس")
+)
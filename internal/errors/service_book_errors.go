@@ -0,0 +1,7 @@
+package errors
+
+// Service book export errors
+var (
+    ErrInvalidServiceBookExportRequest = NewWithCode("INVALID_SERVICE_BOOK_EXPORT", "invalid service book export request", "درخواست خروجی دفترچه سرویس معتبر نیست")
+    ErrFailedToExportServiceBook       = NewWithCode("EXPORT_SERVICE_BOOK_FAILED", "failed to export service book", "خطای تهیه خروجی دفترچه سرویس")
+)
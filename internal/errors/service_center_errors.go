@@ -0,0 +1,21 @@
+package errors
+
+// Service center service errors
+var (
+    ErrInvalidServiceCenterID                = NewWithCode("INVALID_SERVICE_CENTER_ID", "invalid service center id", "شناسه مرکز خدمات نامعتبر است")
+    ErrInvalidServiceCenterCreateRequest     = NewWithCode("INVALID_SERVICE_CENTER_CREATE", "invalid service center create request", "درخواست ایجاد مرکز خدمات معتبر نیست")
+    ErrInvalidServiceCenterUpdateRequest     = NewWithCode("INVALID_SERVICE_CENTER_UPDATE", "invalid service center update request", "درخواست به‌روزرسانی مرکز خدمات معتبر نیست")
+    ErrInvalidNearbyServiceCentersRequest    = NewWithCode("INVALID_NEARBY_SERVICE_CENTERS", "invalid nearby service centers request", "درخواست جستجوی مراکز خدمات نزدیک معتبر نیست")
+    ErrInvalidServiceCenterReviewRequest     = NewWithCode("INVALID_SERVICE_CENTER_REVIEW", "invalid service center review request", "درخواست ثبت نظر معتبر نیست")
+    ErrServiceCenterNotFound                 = NewWithCode("SERVICE_CENTER_NOT_FOUND", "service center not found", "مرکز خدمات یافت نشد")
+    ErrServiceCenterNotVisited               = NewWithCode("SERVICE_CENTER_NOT_VISITED", "only users with a recorded visit can review this service center", "فقط کاربرانی که در این مرکز سرویس ثبت کرده‌اند می‌توانند نظر بدهند")
+    ErrServiceCenterReviewNotFound           = NewWithCode("SERVICE_CENTER_REVIEW_NOT_FOUND", "service center review not found", "نظر یافت نشد")
+    ErrFailedToCreateServiceCenter           = NewWithCode("CREATE_SERVICE_CENTER_FAILED", "failed to create service center", "خطای ایجاد مرکز خدمات")
+    ErrFailedToGetServiceCenter              = NewWithCode("GET_SERVICE_CENTER_FAILED", "failed to get service center", "خطای دریافت مرکز خدمات")
+    ErrFailedToListServiceCenters            = NewWithCode("LIST_SERVICE_CENTERS_FAILED", "failed to list service centers", "خطای فهرست مراکز خدمات")
+    ErrFailedToUpdateServiceCenter           = NewWithCode("UPDATE_SERVICE_CENTER_FAILED", "failed to update service center", "خطای به‌روزرسانی مرکز خدمات")
+    ErrFailedToDeleteServiceCenter           = NewWithCode("DELETE_SERVICE_CENTER_FAILED", "failed to delete service center", "خطای حذف مرکز خدمات")
+    ErrFailedToReviewServiceCenter           = NewWithCode("REVIEW_SERVICE_CENTER_FAILED", "failed to review service center", "خطای ثبت نظر")
+    ErrFailedToListServiceCenterReviews      = NewWithCode("LIST_SERVICE_CENTER_REVIEWS_FAILED", "failed to list service center reviews", "خطای فهرست نظرات")
+    ErrFailedToDeleteServiceCenterReview     = NewWithCode("DELETE_SERVICE_CENTER_REVIEW_FAILED", "failed to delete service center review", "خطای حذف نظر")
+)
@@ -0,0 +1,14 @@
+package errors
+
+// Service Item service errors
+var (
+    ErrInvalidServiceItemCreateRequest = NewWithCode("INVALID_SERVICE_ITEM_CREATE", "invalid service item create request", "درخواست ساخت آیتم سرویس معتبر نیست")
+    ErrInvalidServiceItemUpdateRequest = NewWithCode("INVALID_SERVICE_ITEM_UPDATE", "invalid service item update request", "درخواست به روز رسانی آیتم سرویس معتبر نیست")
+    ErrInvalidServiceItemID            = NewWithCode("INVALID_SERVICE_ITEM_ID", "invalid service item id", "شناسه آیتم سرویس نامعتبر است")
+    ErrFailedToCreateServiceItem       = NewWithCode("CREATE_SERVICE_ITEM_FAILED", "failed to create service item", "خطای ساخت آیتم سرویس")
+    ErrFailedToGetServiceItem          = NewWithCode("GET_SERVICE_ITEM_FAILED", "failed to get service item", "خطای دریافت آیتم سرویس")
+    ErrFailedToListServiceItems        = NewWithCode("LIST_SERVICE_ITEMS_FAILED", "failed to list service items", "خطای فهرست آیتم‌های سرویس")
+    ErrFailedToUpdateServiceItem       = NewWithCode("UPDATE_SERVICE_ITEM_FAILED", "failed to update service item", "خطای به روز رسانی آیتم سرویس")
+    ErrFailedToDeleteServiceItem       = NewWithCode("DELETE_SERVICE_ITEM_FAILED", "failed to delete service item", "خطای حذف آیتم سرویس")
+    ErrServiceItemNotOwned             = NewWithCode("SERVICE_ITEM_NOT_OWNED", "service item not owned", "آیتم سرویس متعلق به کاربر نیست")
+)
@@ -10,4 +10,15 @@ var (
     ErrFailedToListServiceVisits        = NewWithCode("LIST_SERVICE_VISITS_FAILED", "failed to list service visits", "خطای فهرست بازدیدهای سرویس")
     ErrFailedToUpdateServiceVisit       = NewWithCode("UPDATE_SERVICE_VISIT_FAILED", "failed to update service visit", "خطای به روز رسانی بازدید سرویس")
     ErrFailedToDeleteServiceVisit       = NewWithCode("DELETE_SERVICE_VISIT_FAILED", "failed to delete service visit", "خطای حذف بازدید سرویس")
-) 
\ No newline at end of file
+) 
+
+// Service visit import errors
+var (
+    ErrServiceVisitImportFileRequired   = NewWithCode("SERVICE_VISIT_IMPORT_FILE_REQUIRED", "a CSV or XLSX file is required", "ارسال فایل CSV یا XLSX الزامی است")
+    ErrServiceVisitImportFileTooLarge   = NewWithCode("SERVICE_VISIT_IMPORT_FILE_TOO_LARGE", "import file is too large", "حجم فایل ورودی بیش از حد مجاز است")
+    ErrInvalidServiceVisitImportFile    = NewWithCode("INVALID_SERVICE_VISIT_IMPORT_FILE", "import file must be a valid CSV or XLSX file", "فایل ورودی باید یک فایل CSV یا XLSX معتبر باشد")
+    ErrServiceVisitImportFileEmpty      = NewWithCode("SERVICE_VISIT_IMPORT_FILE_EMPTY", "import file has no rows", "فایل ورودی هیچ ردیفی ندارد")
+    ErrServiceVisitImportTooManyRows    = NewWithCode("SERVICE_VISIT_IMPORT_TOO_MANY_ROWS", "import file has too many rows", "تعداد ردیف‌های فایل ورودی بیش از حد مجاز است")
+    ErrServiceVisitImportMissingColumns = NewWithCode("SERVICE_VISIT_IMPORT_MISSING_COLUMNS", "import file must have service_date and service_mileage columns", "فایل ورودی باید ستون‌های service_date و service_mileage را داشته باشد")
+    ErrFailedToImportServiceVisits      = NewWithCode("IMPORT_SERVICE_VISITS_FAILED", "failed to import service visits", "خطای وارد کردن بازدیدهای سرویس")
+)
@@ -0,0 +1,30 @@
+package errors
+
+// Tire errors
+var (
+    ErrInvalidTireSetCreateRequest  = NewWithCode("INVALID_TIRE_SET_CREATE", "invalid tire set create request", "درخواست ثبت دست لاستیک معتبر نیست")
+    ErrInvalidTireSetUpdateRequest  = NewWithCode("INVALID_TIRE_SET_UPDATE", "invalid tire set update request", "درخواست به‌روزرسانی دست لاستیک معتبر نیست")
+    ErrInvalidTireSetID             = NewWithCode("INVALID_TIRE_SET_ID", "invalid tire set id", "شناسه دست لاستیک نامعتبر است")
+    ErrInvalidTireMountRequest      = NewWithCode("INVALID_TIRE_MOUNT", "invalid tire mount request", "درخواست نصب یا باز کردن لاستیک معتبر نیست")
+    ErrInvalidTireRotationRequest   = NewWithCode("INVALID_TIRE_ROTATION", "invalid tire rotation request", "درخواست ثبت جابجایی لاستیک معتبر نیست")
+    ErrInvalidTireRotationID        = NewWithCode("INVALID_TIRE_ROTATION_ID", "invalid tire rotation id", "شناسه جابجایی لاستیک نامعتبر است")
+    ErrInvalidTreadDepthRequest     = NewWithCode("INVALID_TREAD_DEPTH", "invalid tread depth request", "درخواست ثبت عمق آج معتبر نیست")
+    ErrInvalidTreadDepthID          = NewWithCode("INVALID_TREAD_DEPTH_ID", "invalid tread depth id", "شناسه اندازه‌گیری عمق آج نامعتبر است")
+    ErrTireSetAlreadyMounted        = NewWithCode("TIRE_SET_ALREADY_MOUNTED", "tire set is already mounted", "این دست لاستیک هم‌اکنون روی خودرو است")
+    ErrTireSetNotMounted            = NewWithCode("TIRE_SET_NOT_MOUNTED", "tire set is not mounted", "این دست لاستیک روی خودرو نیست")
+    ErrTireMileageBeforeMount       = NewWithCode("TIRE_MILEAGE_BEFORE_MOUNT", "mileage is lower than when the tire set was mounted", "کیلومتر کمتر از کیلومتر نصب لاستیک است")
+    ErrFailedToCreateTireSet        = NewWithCode("CREATE_TIRE_SET_FAILED", "failed to create tire set", "خطای ثبت دست لاستیک")
+    ErrFailedToGetTireSet           = NewWithCode("GET_TIRE_SET_FAILED", "failed to get tire set", "خطای دریافت دست لاستیک")
+    ErrFailedToListTireSets         = NewWithCode("LIST_TIRE_SETS_FAILED", "failed to list tire sets", "خطای فهرست لاستیک‌ها")
+    ErrFailedToUpdateTireSet        = NewWithCode("UPDATE_TIRE_SET_FAILED", "failed to update tire set", "خطای به‌روزرسانی دست لاستیک")
+    ErrFailedToDeleteTireSet        = NewWithCode("DELETE_TIRE_SET_FAILED", "failed to delete tire set", "خطای حذف دست لاستیک")
+    ErrFailedToMountTireSet         = NewWithCode("MOUNT_TIRE_SET_FAILED", "failed to mount tire set", "خطای نصب دست لاستیک")
+    ErrFailedToUnmountTireSet       = NewWithCode("UNMOUNT_TIRE_SET_FAILED", "failed to unmount tire set", "خطای باز کردن دست لاستیک")
+    ErrFailedToCreateTireRotation   = NewWithCode("CREATE_TIRE_ROTATION_FAILED", "failed to create tire rotation", "خطای ثبت جابجایی لاستیک")
+    ErrFailedToDeleteTireRotation   = NewWithCode("DELETE_TIRE_ROTATION_FAILED", "failed to delete tire rotation", "خطای حذف جابجایی لاستیک")
+    ErrFailedToCreateTreadDepth     = NewWithCode("CREATE_TREAD_DEPTH_FAILED", "failed to create tread depth measurement", "خطای ثبت عمق آج")
+    ErrFailedToDeleteTreadDepth     = NewWithCode("DELETE_TREAD_DEPTH_FAILED", "failed to delete tread depth measurement", "خطای حذف عمق آج")
+    ErrTireSetNotOwned              = NewWithCode("TIRE_SET_NOT_OWNED", "tire set not owned", "دست لاستیک متعلق به کاربر نیست")
+    ErrTireRotationNotFound         = NewWithCode("TIRE_ROTATION_NOT_FOUND", "tire rotation not found", "جابجایی لاستیک یافت نشد")
+    ErrTreadDepthNotFound           = NewWithCode("TREAD_DEPTH_NOT_FOUND", "tread depth measurement not found", "اندازه‌گیری عمق آج یافت نشد")
+)
@@ -0,0 +1,17 @@
+package errors
+
+// Vehicle document errors
+var (
+    ErrInvalidVehicleDocumentCreateRequest = NewWithCode("INVALID_VEHICLE_DOCUMENT_CREATE", "invalid vehicle document create request", "درخواست ثبت مدرک خودرو معتبر نیست")
+    ErrInvalidVehicleDocumentUpdateRequest = NewWithCode("INVALID_VEHICLE_DOCUMENT_UPDATE", "invalid vehicle document update request", "درخواست به‌روزرسانی مدرک خودرو معتبر نیست")
+    ErrInvalidVehicleDocumentID            = NewWithCode("INVALID_VEHICLE_DOCUMENT_ID", "invalid vehicle document id", "شناسه مدرک خودرو نامعتبر است")
+    ErrVehicleDocumentExpiryBeforeStart    = NewWithCode("VEHICLE_DOCUMENT_EXPIRY_BEFORE_START", "expiry date must be after the start date", "تاریخ انقضا باید بعد از تاریخ شروع باشد")
+    ErrVehicleDocumentScanNotFound         = NewWithCode("VEHICLE_DOCUMENT_SCAN_NOT_FOUND", "the vehicle document has no scan", "برای این مدرک تصویری بارگذاری نشده است")
+    ErrFailedToCreateVehicleDocument       = NewWithCode("CREATE_VEHICLE_DOCUMENT_FAILED", "failed to create vehicle document", "خطای ثبت مدرک خودرو")
+    ErrFailedToGetVehicleDocument          = NewWithCode("GET_VEHICLE_DOCUMENT_FAILED", "failed to get vehicle document", "خطای دریافت مدرک خودرو")
+    ErrFailedToListVehicleDocuments        = NewWithCode("LIST_VEHICLE_DOCUMENTS_FAILED", "failed to list vehicle documents", "خطای فهرست مدارک خودرو")
+    ErrFailedToUpdateVehicleDocument       = NewWithCode("UPDATE_VEHICLE_DOCUMENT_FAILED", "failed to update vehicle document", "خطای به‌روزرسانی مدرک خودرو")
+    ErrFailedToDeleteVehicleDocument       = NewWithCode("DELETE_VEHICLE_DOCUMENT_FAILED", "failed to delete vehicle document", "خطای حذف مدرک خودرو")
+    ErrFailedToUploadVehicleDocumentScan   = NewWithCode("UPLOAD_VEHICLE_DOCUMENT_SCAN_FAILED", "failed to upload vehicle document scan", "خطای بارگذاری تصویر مدرک خودرو")
+    ErrVehicleDocumentNotOwned             = NewWithCode("VEHICLE_DOCUMENT_NOT_OWNED", "vehicle document not owned", "مدرک خودرو متعلق به کاربر نیست")
+)
@@ -65,4 +65,29 @@ var (
     ErrInvalidVehicleID                = NewWithCode("INVALID_VEHICLE_ID", "invalid vehicle id", "شناسه وسیله نقلیه نامعتبر است")
     ErrInvalidPurchaseDate             = NewWithCode("INVALID_PURCHASE_DATE", "invalid purchase date", "تاریخ خرید نامعتبر است")
     ErrUserVehicleNotOwned             = NewWithCode("USER_VEHICLE_NOT_OWNED", "user vehicle not owned by user", "این وسیله نقلیه متعلق به شما نیست")
-) 
\ No newline at end of file
+) 
+
+// Vehicle - Search
+var (
+    ErrInvalidVehicleSearchRequest = NewWithCode("INVALID_VEHICLE_SEARCH", "invalid vehicle search request", "درخواست جستجوی ماشین معتبر نیست")
+    ErrFailedToSearchVehicles      = NewWithCode("SEARCH_VEHICLES_FAILED", "failed to search vehicles", "خطای جستجوی ماشین")
+)
+
+// Vehicle - Catalog Deletion
+var (
+    ErrVehicleCatalogInUse              = NewWithCode("VEHICLE_CATALOG_IN_USE", "user vehicles use this catalog entry, reassign them to another generation to delete it", "وسایل نقلیه کاربران از این مورد کاتالوگ استفاده می‌کنند، برای حذف آن را به گنریشن دیگری منتقل کنید")
+    ErrInvalidReassignGeneration        = NewWithCode("INVALID_REASSIGN_GENERATION", "user vehicles can only be reassigned to a generation in the catalog outside the deleted entry", "وسایل نقلیه فقط به گنریشنی از کاتالوگ خارج از مورد حذف شده منتقل می‌شوند")
+    ErrFailedToCheckVehicleCatalogUsage = NewWithCode("CHECK_VEHICLE_CATALOG_USAGE_FAILED", "failed to check user vehicles of the catalog entry", "خطای بررسی وسایل نقلیه کاربران مورد کاتالوگ")
+)
+
+// Vehicle - Catalog Sync
+var (
+    ErrFailedToGetVehicleCatalogVersion  = NewWithCode("GET_VEHICLE_CATALOG_VERSION_FAILED", "failed to get vehicle catalog version", "خطای دریافت نسخه کاتالوگ خودرو")
+    ErrFailedToListVehicleCatalogChanges = NewWithCode("LIST_VEHICLE_CATALOG_CHANGES_FAILED", "failed to list vehicle catalog changes", "خطای فهرست تغییرات کاتالوگ خودرو")
+)
+
+// Vehicle - License Plates
+var (
+    ErrLicensePlateAlreadyRegistered = NewWithCode("LICENSE_PLATE_ALREADY_REGISTERED", "license plate is already registered for another vehicle", "این پلاک برای وسیله نقلیه دیگری ثبت شده است")
+    ErrFailedToCheckLicensePlate     = NewWithCode("CHECK_LICENSE_PLATE_FAILED", "failed to check license plate", "خطای بررسی پلاک")
+)
@@ -0,0 +1,27 @@
+package errors
+
+// Vehicle expense errors
+var (
+    ErrInvalidVehicleExpenseCreateRequest = NewWithCode("INVALID_VEHICLE_EXPENSE_CREATE", "invalid vehicle expense create request", "درخواست ثبت هزینه خودرو معتبر نیست")
+    ErrInvalidVehicleExpenseUpdateRequest = NewWithCode("INVALID_VEHICLE_EXPENSE_UPDATE", "invalid vehicle expense update request", "درخواست به‌روزرسانی هزینه خودرو معتبر نیست")
+    ErrInvalidVehicleExpenseID            = NewWithCode("INVALID_VEHICLE_EXPENSE_ID", "invalid vehicle expense id", "شناسه هزینه خودرو نامعتبر است")
+    ErrFailedToCreateVehicleExpense       = NewWithCode("CREATE_VEHICLE_EXPENSE_FAILED", "failed to create vehicle expense", "خطای ثبت هزینه خودرو")
+    ErrFailedToGetVehicleExpense          = NewWithCode("GET_VEHICLE_EXPENSE_FAILED", "failed to get vehicle expense", "خطای دریافت هزینه خودرو")
+    ErrFailedToListVehicleExpenses        = NewWithCode("LIST_VEHICLE_EXPENSES_FAILED", "failed to list vehicle expenses", "خطای فهرست هزینه‌های خودرو")
+    ErrFailedToUpdateVehicleExpense       = NewWithCode("UPDATE_VEHICLE_EXPENSE_FAILED", "failed to update vehicle expense", "خطای به‌روزرسانی هزینه خودرو")
+    ErrFailedToDeleteVehicleExpense       = NewWithCode("DELETE_VEHICLE_EXPENSE_FAILED", "failed to delete vehicle expense", "خطای حذف هزینه خودرو")
+    ErrVehicleExpenseNotOwned             = NewWithCode("VEHICLE_EXPENSE_NOT_OWNED", "vehicle expense not owned", "هزینه خودرو متعلق به کاربر نیست")
+
+    ErrInvalidExpenseCategoryRequest      = NewWithCode("INVALID_EXPENSE_CATEGORY", "invalid expense category request", "درخواست دسته‌بندی هزینه معتبر نیست")
+    ErrInvalidExpenseCategoryID           = NewWithCode("INVALID_EXPENSE_CATEGORY_ID", "invalid expense category id", "شناسه دسته‌بندی هزینه نامعتبر است")
+    ErrExpenseCategoryRequired            = NewWithCode("EXPENSE_CATEGORY_REQUIRED", "give either a category or a custom category id", "یکی از دسته‌بندی یا شناسه دسته‌بندی شخصی را وارد کنید")
+    ErrExpenseCategoryExists              = NewWithCode("EXPENSE_CATEGORY_EXISTS", "an expense category with this name already exists", "دسته‌بندی هزینه با این نام وجود دارد")
+    ErrExpenseCategoryInUse               = NewWithCode("EXPENSE_CATEGORY_IN_USE", "the expense category is used by expenses", "این دسته‌بندی در هزینه‌ها استفاده شده است")
+    ErrExpenseCategoryNotFound            = NewWithCode("EXPENSE_CATEGORY_NOT_FOUND", "expense category not found", "دسته‌بندی هزینه یافت نشد")
+    ErrFailedToCreateExpenseCategory      = NewWithCode("CREATE_EXPENSE_CATEGORY_FAILED", "failed to create expense category", "خطای ایجاد دسته‌بندی هزینه")
+    ErrFailedToListExpenseCategories      = NewWithCode("LIST_EXPENSE_CATEGORIES_FAILED", "failed to list expense categories", "خطای فهرست دسته‌بندی‌های هزینه")
+    ErrFailedToUpdateExpenseCategory      = NewWithCode("UPDATE_EXPENSE_CATEGORY_FAILED", "failed to update expense category", "خطای به‌روزرسانی دسته‌بندی هزینه")
+    ErrFailedToDeleteExpenseCategory      = NewWithCode("DELETE_EXPENSE_CATEGORY_FAILED", "failed to delete expense category", "خطای حذف دسته‌بندی هزینه")
+
+    ErrFailedToGetOwnershipCost           = NewWithCode("GET_OWNERSHIP_COST_FAILED", "failed to get total cost of ownership", "خطای دریافت هزینه کل مالکیت")
+)
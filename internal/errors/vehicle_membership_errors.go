@@ -0,0 +1,18 @@
+package errors
+
+// Vehicle membership errors
+var (
+    ErrInvalidVehicleMembershipRequest       = NewWithCode("INVALID_VEHICLE_MEMBERSHIP", "invalid vehicle membership request", "درخواست اشتراک خودرو معتبر نیست")
+    ErrInvalidVehicleMembershipUpdateRequest = NewWithCode("INVALID_VEHICLE_MEMBERSHIP_UPDATE", "invalid vehicle membership update request", "درخواست ویرایش اشتراک خودرو معتبر نیست")
+    ErrInvalidVehicleMembershipID            = NewWithCode("INVALID_VEHICLE_MEMBERSHIP_ID", "invalid vehicle membership id", "شناسه اشتراک خودرو نامعتبر است")
+    ErrVehicleMembershipToOwner              = NewWithCode("VEHICLE_MEMBERSHIP_TO_OWNER", "the owner of a vehicle cannot be invited to it", "امکان دعوت مالک خودرو وجود ندارد")
+    ErrVehicleMembershipExists               = NewWithCode("VEHICLE_MEMBERSHIP_EXISTS", "this phone number is already invited to the vehicle", "این شماره تلفن قبلا به این خودرو دعوت شده است")
+    ErrVehicleMembershipAlreadyAccepted      = NewWithCode("VEHICLE_MEMBERSHIP_ALREADY_ACCEPTED", "the vehicle invitation is already accepted", "این دعوت قبلا پذیرفته شده است")
+    ErrVehicleMembershipNotFound             = NewWithCode("VEHICLE_MEMBERSHIP_NOT_FOUND", "vehicle membership not found", "اشتراک خودرو یافت نشد")
+    ErrVehicleRoleNotAllowed                 = NewWithCode("VEHICLE_ROLE_NOT_ALLOWED", "your role on this vehicle does not allow this action", "نقش شما در این خودرو اجازه این کار را نمی‌دهد")
+    ErrFailedToCreateVehicleMembership       = NewWithCode("CREATE_VEHICLE_MEMBERSHIP_FAILED", "failed to create vehicle membership", "خطای ایجاد اشتراک خودرو")
+    ErrFailedToGetVehicleMembership          = NewWithCode("GET_VEHICLE_MEMBERSHIP_FAILED", "failed to get vehicle membership", "خطای دریافت اشتراک خودرو")
+    ErrFailedToListVehicleMemberships        = NewWithCode("LIST_VEHICLE_MEMBERSHIPS_FAILED", "failed to list vehicle memberships", "خطای فهرست اشتراک‌های خودرو")
+    ErrFailedToUpdateVehicleMembership       = NewWithCode("UPDATE_VEHICLE_MEMBERSHIP_FAILED", "failed to update vehicle membership", "خطای به روز رسانی اشتراک خودرو")
+    ErrFailedToDeleteVehicleMembership       = NewWithCode("DELETE_VEHICLE_MEMBERSHIP_FAILED", "failed to delete vehicle membership", "خطای حذف اشتراک خودرو")
+)
@@ -0,0 +1,16 @@
+package errors
+
+// Vehicle share link errors
+var (
+    ErrInvalidVehicleShareLinkRequest   = NewWithCode("INVALID_VEHICLE_SHARE_LINK", "invalid vehicle share link request", "درخواست لینک اشتراک خودرو معتبر نیست")
+    ErrInvalidVehicleShareLinkID        = NewWithCode("INVALID_VEHICLE_SHARE_LINK_ID", "invalid vehicle share link id", "شناسه لینک اشتراک خودرو نامعتبر است")
+    ErrVehicleShareLinkAlreadyRevoked   = NewWithCode("VEHICLE_SHARE_LINK_ALREADY_REVOKED", "the vehicle share link is already revoked", "این لینک اشتراک قبلا لغو شده است")
+    ErrVehicleShareLinkNotFound         = NewWithCode("VEHICLE_SHARE_LINK_NOT_FOUND", "vehicle share link not found", "لینک اشتراک خودرو یافت نشد")
+    ErrVehicleShareLinkExpired          = NewWithCode("VEHICLE_SHARE_LINK_EXPIRED", "the vehicle share link has expired", "لینک اشتراک خودرو منقضی شده است")
+    ErrVehicleShareLinkRevoked          = NewWithCode("VEHICLE_SHARE_LINK_REVOKED", "the vehicle share link has been revoked", "لینک اشتراک خودرو لغو شده است")
+    ErrFailedToCreateVehicleShareLink   = NewWithCode("CREATE_VEHICLE_SHARE_LINK_FAILED", "failed to create vehicle share link", "خطای ایجاد لینک اشتراک خودرو")
+    ErrFailedToGetVehicleShareLink      = NewWithCode("GET_VEHICLE_SHARE_LINK_FAILED", "failed to get vehicle share link", "خطای دریافت لینک اشتراک خودرو")
+    ErrFailedToListVehicleShareLinks    = NewWithCode("LIST_VEHICLE_SHARE_LINKS_FAILED", "failed to list vehicle share links", "خطای فهرست لینک‌های اشتراک خودرو")
+    ErrFailedToUpdateVehicleShareLink   = NewWithCode("UPDATE_VEHICLE_SHARE_LINK_FAILED", "failed to update vehicle share link", "خطای به روز رسانی لینک اشتراک خودرو")
+    ErrFailedToGetSharedVehicleHistory  = NewWithCode("GET_SHARED_VEHICLE_HISTORY_FAILED", "failed to get shared vehicle history", "خطای دریافت سوابق خودروی اشتراکی")
+)
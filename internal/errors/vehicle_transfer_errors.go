@@ -0,0 +1,24 @@
+package errors
+
+// Vehicle transfer errors
+var (
+    ErrInvalidVehicleTransferRequest      = NewWithCode("INVALID_VEHICLE_TRANSFER", "invalid vehicle transfer request", "درخواست انتقال خودرو معتبر نیست")
+    ErrInvalidVehicleTransferConfirmation = NewWithCode("INVALID_VEHICLE_TRANSFER_CONFIRMATION", "invalid vehicle transfer confirmation request", "درخواست تایید انتقال خودرو معتبر نیست")
+    ErrInvalidVehicleTransferID           = NewWithCode("INVALID_VEHICLE_TRANSFER_ID", "invalid vehicle transfer id", "شناسه انتقال خودرو نامعتبر است")
+    ErrInvalidVehicleTransferCode         = NewWithCode("INVALID_VEHICLE_TRANSFER_CODE", "invalid vehicle transfer code", "کد تایید انتقال خودرو نادرست است")
+    ErrVehicleTransferToSelf              = NewWithCode("VEHICLE_TRANSFER_TO_SELF", "a vehicle cannot be transferred to its owner", "امکان انتقال خودرو به مالک فعلی آن وجود ندارد")
+    ErrVehicleTransferAlreadyPending      = NewWithCode("VEHICLE_TRANSFER_ALREADY_PENDING", "the vehicle already has a pending transfer", "برای این خودرو یک انتقال در انتظار تایید وجود دارد")
+    ErrVehicleTransferNotOpen             = NewWithCode("VEHICLE_TRANSFER_NOT_OPEN", "the vehicle transfer is no longer pending", "این انتقال خودرو دیگر در انتظار تایید نیست")
+    ErrVehicleTransferTooManyAttempts     = NewWithCode("VEHICLE_TRANSFER_TOO_MANY_ATTEMPTS", "too many wrong codes, the vehicle transfer was cancelled", "به دلیل ورود کد نادرست بیش از حد مجاز، انتقال خودرو لغو شد")
+    ErrVehicleTransferNotOwned            = NewWithCode("VEHICLE_TRANSFER_NOT_OWNED", "vehicle transfer not owned by user", "این انتقال خودرو متعلق به کاربر نیست")
+    ErrVehicleTransferNotFound            = NewWithCode("VEHICLE_TRANSFER_NOT_FOUND", "vehicle transfer not found", "انتقال خودرو یافت نشد")
+    ErrVehicleTransfersNotConfigured      = NewWithCode("VEHICLE_TRANSFERS_NOT_CONFIGURED", "vehicle transfers are not available, no SMS template is configured for the transfer code", "انتقال خودرو در دسترس نیست، قالب پیامک کد تایید انتقال تنظیم نشده است")
+    ErrVehicleTransferRecipientNotFound   = NewWithCode("VEHICLE_TRANSFER_RECIPIENT_NOT_FOUND", "no user is registered with this phone number", "کاربری با این شماره تلفن ثبت نشده است")
+    ErrFailedToCreateVehicleTransfer      = NewWithCode("CREATE_VEHICLE_TRANSFER_FAILED", "failed to create vehicle transfer", "خطای ایجاد انتقال خودرو")
+    ErrFailedToGetVehicleTransfer         = NewWithCode("GET_VEHICLE_TRANSFER_FAILED", "failed to get vehicle transfer", "خطای دریافت انتقال خودرو")
+    ErrFailedToListVehicleTransfers       = NewWithCode("LIST_VEHICLE_TRANSFERS_FAILED", "failed to list vehicle transfers", "خطای فهرست انتقال‌های خودرو")
+    ErrFailedToUpdateVehicleTransfer      = NewWithCode("UPDATE_VEHICLE_TRANSFER_FAILED", "failed to update vehicle transfer", "خطای به روز رسانی انتقال خودرو")
+    ErrFailedToSendVehicleTransferCode    = NewWithCode("SEND_VEHICLE_TRANSFER_CODE_FAILED", "failed to send vehicle transfer code", "خطای ارسال کد تایید انتقال خودرو")
+    ErrFailedToCompleteVehicleTransfer    = NewWithCode("COMPLETE_VEHICLE_TRANSFER_FAILED", "failed to complete vehicle transfer", "خطای تکمیل انتقال خودرو")
+    ErrFailedToListVehicleOwnerships      = NewWithCode("LIST_VEHICLE_OWNERSHIPS_FAILED", "failed to list vehicle ownership history", "خطای دریافت سوابق مالکیت خودرو")
+)
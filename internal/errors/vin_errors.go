@@ -0,0 +1,19 @@
+package errors
+
+// VIN decoder errors
+var (
+    ErrInvalidVIN                     = NewWithCode("INVALID_VIN", "VIN must be 17 letters and digits without I, O and Q", "شماره شاسی (VIN) باید ۱۷ حرف و رقم لاتین بدون I، O و Q باشد")
+    ErrInvalidVehicleWMIID            = NewWithCode("INVALID_VEHICLE_WMI_ID", "invalid WMI id", "شناسه WMI نامعتبر است")
+    ErrInvalidVehicleWMICreateRequest = NewWithCode("INVALID_VEHICLE_WMI_CREATE", "invalid WMI create request", "درخواست ثبت WMI معتبر نیست")
+    ErrInvalidVehicleWMIUpdateRequest = NewWithCode("INVALID_VEHICLE_WMI_UPDATE", "invalid WMI update request", "درخواست به‌روزرسانی WMI معتبر نیست")
+    ErrVehicleWMIAlreadyExists        = NewWithCode("VEHICLE_WMI_ALREADY_EXISTS", "a WMI with this VDS prefix already exists", "این WMI با همین پیشوند VDS قبلاً ثبت شده است")
+    ErrVehicleWMIBrandNotFound        = NewWithCode("VEHICLE_WMI_BRAND_NOT_FOUND", "brand not found", "برند ماشین یافت نشد")
+    ErrVehicleWMIModelNotInBrand      = NewWithCode("VEHICLE_WMI_MODEL_NOT_IN_BRAND", "model does not belong to the brand", "مدل ماشین متعلق به این برند نیست")
+    ErrVehicleWMINotFound             = NewWithCode("VEHICLE_WMI_NOT_FOUND", "WMI not found", "WMI یافت نشد")
+    ErrFailedToDecodeVIN              = NewWithCode("DECODE_VIN_FAILED", "failed to decode VIN", "خطای رمزگشایی شماره شاسی")
+    ErrFailedToListVehicleWMIs        = NewWithCode("LIST_VEHICLE_WMIS_FAILED", "failed to list WMIs", "خطای فهرست WMI")
+    ErrFailedToGetVehicleWMI          = NewWithCode("GET_VEHICLE_WMI_FAILED", "failed to get WMI", "خطای دریافت WMI")
+    ErrFailedToCreateVehicleWMI       = NewWithCode("CREATE_VEHICLE_WMI_FAILED", "failed to create WMI", "خطای ثبت WMI")
+    ErrFailedToUpdateVehicleWMI       = NewWithCode("UPDATE_VEHICLE_WMI_FAILED", "failed to update WMI", "خطای به‌روزرسانی WMI")
+    ErrFailedToDeleteVehicleWMI       = NewWithCode("DELETE_VEHICLE_WMI_FAILED", "failed to delete WMI", "خطای حذف WMI")
+)
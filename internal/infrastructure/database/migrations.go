@@ -1,6 +1,9 @@
 package database
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/amirdashtii/AutoBan/internal/domain/entity"
 	"github.com/amirdashtii/AutoBan/pkg/logger"
 	"gorm.io/gorm"
@@ -18,16 +21,46 @@ func RunMigrations(db *gorm.DB) error {
 		&entity.VehicleBrand{},
 		&entity.VehicleModel{},
 		&entity.VehicleGeneration{},
+		&entity.VehicleWMI{},
+		&entity.VehicleCatalogVersion{},
+		&entity.VehicleCatalogChange{},
 		&entity.UserVehicle{},
 		&entity.ServiceVisit{},
 		&entity.OilChange{},
 		&entity.OilFilter{},
+		&entity.ServiceItem{},
+		&entity.ServiceVisitAttachment{},
+		&entity.OdometerReading{},
+		&entity.FuelLog{},
+		&entity.MaintenanceInterval{},
+		&entity.MaintenancePlanItem{},
+		&entity.ServiceCenter{},
+		&entity.ServiceCenterReview{},
+		&entity.VehicleTransfer{},
+		&entity.VehicleOwnership{},
+		&entity.VehicleMembership{},
+		&entity.VehicleShareLink{},
+		&entity.VehicleDocument{},
+		&entity.UserExpenseCategory{},
+		&entity.VehicleExpense{},
+		&entity.TireSet{},
+		&entity.TireMount{},
+		&entity.TireRotation{},
+		&entity.TreadDepthMeasurement{},
+		&schemaMigration{},
 	)
 	if err != nil {
 		logger.Error(err, "Failed to run auto migrations")
 		return err
 	}
 
+	// Plates are compared in their stored form, so older rows are brought to it before the unique index
+	err = runOnce(db, "normalize_license_plates", normalizeLicensePlates)
+	if err != nil {
+		logger.Error(err, "Failed to normalize license plates")
+		return err
+	}
+
 	// Create performance indexes
 	err = createPerformanceIndexes(db)
 	if err != nil {
@@ -39,6 +72,58 @@ func RunMigrations(db *gorm.DB) error {
 	return nil
 }
 
+// schemaMigration records a one-off data migration that has been applied
+type schemaMigration struct {
+	Name      string `gorm:"primaryKey"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// runOnce applies a one-off data migration in a transaction and records it, so it is skipped on later startups
+func runOnce(db *gorm.DB, name string, migrate func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var applied int64
+		err := tx.Model(&schemaMigration{}).Where("name = ?", name).Count(&applied).Error
+		if err != nil {
+			return err
+		}
+		if applied > 0 {
+			return nil
+		}
+
+		logger.Info(fmt.Sprintf("Applying migration %s...", name))
+		err = migrate(tx)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{Name: name, AppliedAt: time.Now()}).Error
+	})
+}
+
+// normalizeLicensePlates rewrites the plates of user vehicles into the form they are stored in. Plates that can not
+// be parsed are left as they are
+func normalizeLicensePlates(db *gorm.DB) error {
+	var userVehicles []entity.UserVehicle
+	err := db.Select("id", "license_plate").Where("license_plate <> ''").Find(&userVehicles).Error
+	if err != nil {
+		return err
+	}
+	for _, userVehicle := range userVehicles {
+		licensePlate := entity.NormalizeLicensePlate(userVehicle.LicensePlate)
+		if licensePlate == userVehicle.LicensePlate {
+			continue
+		}
+		err = db.Model(&userVehicle).UpdateColumn("license_plate", licensePlate).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // createPerformanceIndexes creates indexes for better query performance
 func createPerformanceIndexes(db *gorm.DB) error {
 	logger.Info("Creating performance indexes...")
@@ -91,6 +176,14 @@ func createPerformanceIndexes(db *gorm.DB) error {
 		return err
 	}
 
+	// A plate may belong to one active vehicle. Plates registered twice before this index existed keep it from
+	// being created; they are logged so they can be cleared up before the next startup
+	if err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_user_vehicles_active_license_plate ON user_vehicles(license_plate) WHERE license_plate <> '' AND deleted_at IS NULL").Error; err != nil {
+		logger.Error(err, "Failed to create unique index on user_vehicles.license_plate")
+		logDuplicateLicensePlates(db)
+		return err
+	}
+
 	// Service visits indexes
 	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_service_visits_user_vehicle_id ON service_visits(user_vehicle_id)").Error; err != nil {
 		logger.Error(err, "Failed to create index on service_visits.user_vehicle_id")
@@ -129,6 +222,40 @@ func createPerformanceIndexes(db *gorm.DB) error {
 		return err
 	}
 
+	// Service items indexes
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_service_items_service_visit_id ON service_items(service_visit_id)").Error; err != nil {
+		logger.Error(err, "Failed to create index on service_items.service_visit_id")
+		return err
+	}
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_service_items_user_vehicle_id ON service_items(user_vehicle_id)").Error; err != nil {
+		logger.Error(err, "Failed to create index on service_items.user_vehicle_id")
+		return err
+	}
+
+	// Odometer readings indexes
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_odometer_readings_user_vehicle_id_reading_date ON odometer_readings(user_vehicle_id, reading_date DESC)").Error; err != nil {
+		logger.Error(err, "Failed to create index on odometer_readings.user_vehicle_id")
+		return err
+	}
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_odometer_readings_service_visit_id ON odometer_readings(service_visit_id)").Error; err != nil {
+		logger.Error(err, "Failed to create index on odometer_readings.service_visit_id")
+		return err
+	}
+
+	// Fuel logs indexes
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_fuel_logs_user_vehicle_id_fill_date ON fuel_logs(user_vehicle_id, fill_date DESC)").Error; err != nil {
+		logger.Error(err, "Failed to create index on fuel_logs.user_vehicle_id")
+		return err
+	}
+
+	// Service centers indexes
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_service_centers_latitude_longitude ON service_centers(latitude, longitude)").Error; err != nil {
+		logger.Error(err, "Failed to create index on service_centers.latitude, service_centers.longitude")
+		return err
+	}
+
 	// Sessions indexes (for Redis-like behavior in case of fallback)
 	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions(user_id)").Error; err != nil {
 		logger.Error(err, "Failed to create index on sessions.user_id")
@@ -159,3 +286,20 @@ func createPerformanceIndexes(db *gorm.DB) error {
 	logger.Info("Performance indexes created successfully")
 	return nil
 }
+
+// logDuplicateLicensePlates lists the plates registered for more than one active vehicle
+func logDuplicateLicensePlates(db *gorm.DB) {
+	var licensePlates []string
+	err := db.Model(&entity.UserVehicle{}).
+		Where("license_plate <> ''").
+		Group("license_plate").
+		Having("COUNT(*) > 1").
+		Pluck("license_plate", &licensePlates).Error
+	if err != nil {
+		logger.Error(err, "Failed to find duplicate license plates")
+		return
+	}
+	for _, licensePlate := range licensePlates {
+		logger.Warn(fmt.Sprintf("License plate %s is registered for more than one active vehicle", licensePlate))
+	}
+}
@@ -12,37 +12,67 @@ import (
 // SMSService interface for SMS operations
 type SMSService interface {
 	SendVerificationCode(ctx context.Context, phoneNumber, code string) error
+	SendMaintenanceReminder(ctx context.Context, phoneNumber, vehicleName, itemName, status string) error
+	SendVehicleTransferCode(ctx context.Context, phoneNumber, vehicleName, code string) error
 }
 
 // smsService implements SMSService interface
 type smsService struct {
-	client HTTPClient
-	apiKey string
+	client             HTTPClient
+	apiKey             string
+	reminderTemplateID string
+	transferTemplateID string
 }
 
 // NewSMSService creates a new SMS service
-func NewSMSService(baseURL, apiKey string) SMSService {
+func NewSMSService(baseURL, apiKey, reminderTemplateID, transferTemplateID string) SMSService {
 	client := NewClient(baseURL, 30*time.Second)
 	return &smsService{
-		client: client,
-		apiKey: apiKey,
+		client:             client,
+		apiKey:             apiKey,
+		reminderTemplateID: reminderTemplateID,
+		transferTemplateID: transferTemplateID,
 	}
 }
 
 // SendVerificationCode sends verification code via SMS
 func (s *smsService) SendVerificationCode(ctx context.Context, phoneNumber, code string) error {
+	return s.sendTemplate(ctx, phoneNumber, "123456", map[string]string{
+		"code": code,
+	})
+}
+
+// SendMaintenanceReminder sends a maintenance reminder via SMS
+func (s *smsService) SendMaintenanceReminder(ctx context.Context, phoneNumber, vehicleName, itemName, status string) error {
+	return s.sendTemplate(ctx, phoneNumber, s.reminderTemplateID, map[string]string{
+		"vehicle": vehicleName,
+		"item":    itemName,
+		"status":  status,
+	})
+}
+
+// SendVehicleTransferCode sends the recipient of a vehicle transfer the code that confirms it
+func (s *smsService) SendVehicleTransferCode(ctx context.Context, phoneNumber, vehicleName, code string) error {
+	return s.sendTemplate(ctx, phoneNumber, s.transferTemplateID, map[string]string{
+		"vehicle": vehicleName,
+		"code":    code,
+	})
+}
+
+// sendTemplate sends a template message with the given parameters
+func (s *smsService) sendTemplate(ctx context.Context, phoneNumber, templateID string, parameters map[string]string) error {
 	request := dto.SmsIrRequest{
 		Mobile:     phoneNumber,
-		TemplateId: "123456",
-		Parameters: []struct {
+		TemplateId: templateID,
+	}
+	for name, value := range parameters {
+		request.Parameters = append(request.Parameters, struct {
 			Name  string `json:"name" validate:"required"`
 			Value string `json:"value" validate:"required"`
 		}{
-			{
-				Name:  "code",
-				Value: code,
-			},
-		},
+			Name:  name,
+			Value: value,
+		})
 	}
 
 	headers := map[string]string{
@@ -58,7 +88,7 @@ func (s *smsService) SendVerificationCode(ctx context.Context, phoneNumber, code
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return err
+		return errors.ErrInternalServerError
 	}
 
 	var response dto.SmsIrResponse
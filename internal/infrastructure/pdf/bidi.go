@@ -0,0 +1,265 @@
+package pdf
+
+import (
+	"unicode"
+)
+
+// PDF fonts only map code points to glyphs, so Persian text has to be converted to its
+// presentation forms and laid out in visual order before it is drawn.
+
+// arabicForms holds the isolated, final, initial and medial presentation forms of a letter.
+// Right-joining letters, which never connect to the following letter, have no initial or medial form
+type arabicForms struct {
+	isolated, final, initial, medial rune
+}
+
+func (f arabicForms) dualJoining() bool {
+	return f.initial != 0
+}
+
+var arabicLetters = map[rune]arabicForms{
+	'ء': {0xFE80, 0, 0, 0},
+	'آ': {0xFE81, 0xFE82, 0, 0},
+	'أ': {0xFE83, 0xFE84, 0, 0},
+	'ؤ': {0xFE85, 0xFE86, 0, 0},
+	'إ': {0xFE87, 0xFE88, 0, 0},
+	'ئ': {0xFE89, 0xFE8A, 0xFE8B, 0xFE8C},
+	'ا': {0xFE8D, 0xFE8E, 0, 0},
+	'ب': {0xFE8F, 0xFE90, 0xFE91, 0xFE92},
+	'ة': {0xFE93, 0xFE94, 0, 0},
+	'ت': {0xFE95, 0xFE96, 0xFE97, 0xFE98},
+	'ث': {0xFE99, 0xFE9A, 0xFE9B, 0xFE9C},
+	'ج': {0xFE9D, 0xFE9E, 0xFE9F, 0xFEA0},
+	'ح': {0xFEA1, 0xFEA2, 0xFEA3, 0xFEA4},
+	'خ': {0xFEA5, 0xFEA6, 0xFEA7, 0xFEA8},
+	'د': {0xFEA9, 0xFEAA, 0, 0},
+	'ذ': {0xFEAB, 0xFEAC, 0, 0},
+	'ر': {0xFEAD, 0xFEAE, 0, 0},
+	'ز': {0xFEAF, 0xFEB0, 0, 0},
+	'س': {0xFEB1, 0xFEB2, 0xFEB3, 0xFEB4},
+	'ش': {0xFEB5, 0xFEB6, 0xFEB7, 0xFEB8},
+	'ص': {0xFEB9, 0xFEBA, 0xFEBB, 0xFEBC},
+	'ض': {0xFEBD, 0xFEBE, 0xFEBF, 0xFEC0},
+	'ط': {0xFEC1, 0xFEC2, 0xFEC3, 0xFEC4},
+	'ظ': {0xFEC5, 0xFEC6, 0xFEC7, 0xFEC8},
+	'ع': {0xFEC9, 0xFECA, 0xFECB, 0xFECC},
+	'غ': {0xFECD, 0xFECE, 0xFECF, 0xFED0},
+	'ـ': {0x0640, 0x0640, 0x0640, 0x0640},
+	'ف': {0xFED1, 0xFED2, 0xFED3, 0xFED4},
+	'ق': {0xFED5, 0xFED6, 0xFED7, 0xFED8},
+	'ك': {0xFED9, 0xFEDA, 0xFEDB, 0xFEDC},
+	'ل': {0xFEDD, 0xFEDE, 0xFEDF, 0xFEE0},
+	'م': {0xFEE1, 0xFEE2, 0xFEE3, 0xFEE4},
+	'ن': {0xFEE5, 0xFEE6, 0xFEE7, 0xFEE8},
+	'ه': {0xFEE9, 0xFEEA, 0xFEEB, 0xFEEC},
+	'و': {0xFEED, 0xFEEE, 0, 0},
+	'ى': {0xFEEF, 0xFEF0, 0, 0},
+	'ي': {0xFEF1, 0xFEF2, 0xFEF3, 0xFEF4},
+	'پ': {0xFB56, 0xFB57, 0xFB58, 0xFB59},
+	'چ': {0xFB7A, 0xFB7B, 0xFB7C, 0xFB7D},
+	'ژ': {0xFB8A, 0xFB8B, 0, 0},
+	'ک': {0xFB8E, 0xFB8F, 0xFB90, 0xFB91},
+	'گ': {0xFB92, 0xFB93, 0xFB94, 0xFB95},
+	'ی': {0xFBFC, 0xFBFD, 0xFBFE, 0xFBFF},
+}
+
+// lamAlefLigatures maps the alef that follows a lam to the isolated and final forms of their ligature
+var lamAlefLigatures = map[rune][2]rune{
+	'آ': {0xFEF5, 0xFEF6},
+	'أ': {0xFEF7, 0xFEF8},
+	'إ': {0xFEF9, 0xFEFA},
+	'ا': {0xFEFB, 0xFEFC},
+}
+
+var mirroredBrackets = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'«': '»', '»': '«',
+}
+
+// isTransparent reports whether r is a diacritic that does not affect the joining of its neighbours
+func isTransparent(r rune) bool {
+	return (r >= 0x064B && r <= 0x065F) || r == 0x0670
+}
+
+// shape replaces Persian and Arabic letters with the presentation form their neighbours call for
+func shape(text string) []rune {
+	runes := []rune(text)
+	shaped := make([]rune, 0, len(runes))
+
+	// neighbour finds the closest letter before (step -1) or after (step 1) i, skipping diacritics
+	neighbour := func(i, step int) (arabicForms, bool) {
+		for j := i + step; j >= 0 && j < len(runes); j += step {
+			if isTransparent(runes[j]) {
+				continue
+			}
+			forms, ok := arabicLetters[runes[j]]
+			return forms, ok
+		}
+		return arabicForms{}, false
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		forms, ok := arabicLetters[r]
+		if !ok {
+			// The zero width non-joiner has done its job once the letters around it are shaped
+			if r != '‌' {
+				shaped = append(shaped, r)
+			}
+			continue
+		}
+
+		previous, hasPrevious := neighbour(i, -1)
+		joinsPrevious := hasPrevious && previous.dualJoining()
+
+		if r == 'ل' && i+1 < len(runes) {
+			if ligature, ok := lamAlefLigatures[runes[i+1]]; ok {
+				if joinsPrevious {
+					shaped = append(shaped, ligature[1])
+				} else {
+					shaped = append(shaped, ligature[0])
+				}
+				i++
+				continue
+			}
+		}
+
+		_, joinsNext := neighbour(i, 1)
+		joinsNext = joinsNext && forms.dualJoining()
+
+		switch {
+		case joinsPrevious && joinsNext:
+			shaped = append(shaped, forms.medial)
+		case joinsPrevious && forms.final != 0:
+			shaped = append(shaped, forms.final)
+		case joinsNext:
+			shaped = append(shaped, forms.initial)
+		default:
+			shaped = append(shaped, forms.isolated)
+		}
+	}
+	return shaped
+}
+
+type direction int
+
+const (
+	neutral direction = iota
+	leftToRight
+	rightToLeft
+)
+
+func isRightToLeft(r rune) bool {
+	return (r >= 0x0590 && r <= 0x08FF) || (r >= 0xFB1D && r <= 0xFDFF) || (r >= 0xFE70 && r <= 0xFEFF)
+}
+
+func runeDirection(r rune) direction {
+	switch {
+	case isRightToLeft(r) && !unicode.IsDigit(r):
+		return rightToLeft
+	case unicode.IsLetter(r) || unicode.IsDigit(r):
+		return leftToRight
+	default:
+		return neutral
+	}
+}
+
+// isNumberSeparator reports whether r keeps a number together when it sits between two digits, as in 1402/10/25 or 12,500
+func isNumberSeparator(r rune) bool {
+	switch r {
+	case '/', ',', '.', ':', '-', '٫', '٬':
+		return true
+	}
+	return false
+}
+
+// Visual shapes the text and returns it in the left to right order it is drawn in. Runs of Latin
+// letters and numbers keep their order inside right-to-left text, which is enough for labels and
+// table cells without implementing the full Unicode bidirectional algorithm
+func Visual(text string, rtl bool) string {
+	runes := shape(text)
+	if len(runes) == 0 {
+		return ""
+	}
+
+	base := leftToRight
+	if rtl {
+		base = rightToLeft
+	}
+
+	directions := make([]direction, len(runes))
+	for i, r := range runes {
+		directions[i] = runeDirection(r)
+	}
+	for i, r := range runes {
+		if directions[i] == neutral && isNumberSeparator(r) && i > 0 && i+1 < len(runes) &&
+			unicode.IsDigit(runes[i-1]) && unicode.IsDigit(runes[i+1]) {
+			directions[i] = leftToRight
+		}
+	}
+	// Neutrals take the direction of the text around them, or the base direction between two directions
+	for i := 0; i < len(runes); i++ {
+		if directions[i] != neutral {
+			continue
+		}
+		j := i
+		for j < len(runes) && directions[j] == neutral {
+			j++
+		}
+		before, after := base, base
+		if i > 0 {
+			before = directions[i-1]
+		}
+		if j < len(runes) {
+			after = directions[j]
+		}
+		resolved := base
+		if before == after {
+			resolved = before
+		}
+		for k := i; k < j; k++ {
+			directions[k] = resolved
+		}
+		i = j - 1
+	}
+
+	// Split into runs of one direction, reverse the right-to-left runs and order the runs by the base direction
+	type run struct {
+		runes     []rune
+		direction direction
+	}
+	runs := []run{}
+	for i, r := range runes {
+		if len(runs) == 0 || runs[len(runs)-1].direction != directions[i] {
+			runs = append(runs, run{direction: directions[i]})
+		}
+		runs[len(runs)-1].runes = append(runs[len(runs)-1].runes, r)
+	}
+	for _, run := range runs {
+		if run.direction != rightToLeft {
+			continue
+		}
+		for i, j := 0, len(run.runes)-1; i < j; i, j = i+1, j-1 {
+			run.runes[i], run.runes[j] = run.runes[j], run.runes[i]
+		}
+		for i, r := range run.runes {
+			if mirrored, ok := mirroredBrackets[r]; ok {
+				run.runes[i] = mirrored
+			}
+		}
+	}
+	if rtl {
+		for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+			runs[i], runs[j] = runs[j], runs[i]
+		}
+	}
+
+	visual := make([]rune, 0, len(runes))
+	for _, run := range runs {
+		visual = append(visual, run.runes...)
+	}
+	return string(visual)
+}
@@ -0,0 +1,32 @@
+package pdf
+
+import "testing"
+
+func TestVisual(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		rtl  bool
+		want string
+	}{
+		{"empty", "", true, ""},
+		{"lam alef after a joining letter", "سلام", true, "ﻡﻼﺳ"},
+		{"lam alef on its own", "لا", true, "ﻻ"},
+		{"lam alef after a non-joining letter", "الان", true, "ﻥﻻﺍ"},
+		{"lam alef inside a word", "کلاس", true, "ﺱﻼﮐ"},
+		{"zero width non-joiner", "می‌شود", true, "ﺩﻮﺷﯽﻣ"},
+		{"latin after persian", "روغن 10W-40", true, "10W-40 ﻦﻏﻭﺭ"},
+		{"persian date", "تاریخ ۱۴۰۲/۱۰/۲۵", true, "۱۴۰۲/۱۰/۲۵ ﺦﯾﺭﺎﺗ"},
+		{"amount before persian", "12,500 تومان", true, "ﻥﺎﻣﻮﺗ 12,500"},
+		{"mirrored brackets", "(ب)", true, "(ﺏ)"},
+		{"persian in left to right text", "Oil فیلتر", false, "Oil ﺮﺘﻠﯿﻓ"},
+		{"latin only", "Castrol 10W-40", false, "Castrol 10W-40"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Visual(tt.text, tt.rtl); got != tt.want {
+				t.Errorf("Visual(%q, %v) = %+q, want %+q", tt.text, tt.rtl, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package pdf
+
+import (
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	fontFamily = "body"
+	lineHeight = 6.0
+	pageMargin = 15.0
+)
+
+// Column is a table column. Width is relative to the other columns of the table
+type Column struct {
+	Header string
+	Width  float64
+}
+
+// Document is an A4 report of titles, labelled fields and tables. In right-to-left documents
+// text is right aligned and tables start from the right edge of the page
+type Document struct {
+	pdf *fpdf.Fpdf
+	rtl bool
+}
+
+// NewDocument starts a document whose text is drawn with the TrueType font at fontPath,
+// which must cover every script the document contains
+func NewDocument(fontPath string, rtl bool) *Document {
+	pdf := fpdf.New("P", "mm", "A4", filepath.Dir(fontPath))
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+	pdf.AddUTF8Font(fontFamily, "", filepath.Base(fontPath))
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pageMargin + 2)
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 5, strconv.Itoa(pdf.PageNo())+" / {nb}", "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+	pdf.SetFont(fontFamily, "", 10)
+	return &Document{pdf: pdf, rtl: rtl}
+}
+
+// Title writes the document title
+func (d *Document) Title(text string) {
+	d.pdf.SetFont(fontFamily, "", 18)
+	d.pdf.CellFormat(0, 12, Visual(text, d.rtl), "", 1, d.align(), false, 0, "")
+	d.pdf.SetFont(fontFamily, "", 10)
+	d.pdf.Ln(2)
+}
+
+// Heading starts a section
+func (d *Document) Heading(text string) {
+	d.pdf.Ln(3)
+	d.pdf.SetFont(fontFamily, "", 13)
+	d.pdf.SetFillColor(235, 240, 245)
+	d.pdf.CellFormat(0, 9, Visual(text, d.rtl), "", 1, d.align(), true, 0, "")
+	d.pdf.SetFont(fontFamily, "", 10)
+	d.pdf.Ln(1)
+}
+
+// Field writes a label and its value on one line. Fields without a value are skipped
+func (d *Document) Field(label, value string) {
+	if value == "" {
+		return
+	}
+	labelWidth := 45.0
+	valueWidth := d.contentWidth() - labelWidth
+	d.pdf.SetTextColor(90, 90, 90)
+	if d.rtl {
+		x := d.pdf.GetX()
+		d.pdf.SetX(x + valueWidth)
+		d.pdf.CellFormat(labelWidth, lineHeight, Visual(label, d.rtl), "", 0, "R", false, 0, "")
+		d.pdf.SetX(x)
+		d.pdf.SetTextColor(0, 0, 0)
+		d.pdf.CellFormat(valueWidth, lineHeight, Visual(value, d.rtl), "", 1, "R", false, 0, "")
+		return
+	}
+	d.pdf.CellFormat(labelWidth, lineHeight, Visual(label, d.rtl), "", 0, "L", false, 0, "")
+	d.pdf.SetTextColor(0, 0, 0)
+	d.pdf.CellFormat(valueWidth, lineHeight, Visual(value, d.rtl), "", 1, "L", false, 0, "")
+}
+
+// Paragraph writes text wrapped to the page width
+func (d *Document) Paragraph(text string) {
+	for _, line := range d.wrap(text, d.contentWidth()) {
+		d.pdf.CellFormat(0, lineHeight, Visual(line, d.rtl), "", 1, d.align(), false, 0, "")
+	}
+}
+
+// Table writes a table with a header row. Cells are wrapped to their column width and the header
+// is repeated when the table continues on a new page
+func (d *Document) Table(columns []Column, rows [][]string) {
+	totalWidth := 0.0
+	for _, column := range columns {
+		totalWidth += column.Width
+	}
+	widths := make([]float64, len(columns))
+	for i, column := range columns {
+		widths[i] = column.Width / totalWidth * d.contentWidth()
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+	d.pdf.SetFillColor(220, 228, 236)
+	d.tableRow(widths, headers, true)
+	for _, row := range rows {
+		if d.rowHeight(widths, row) > d.remainingHeight() {
+			d.pdf.AddPage()
+			d.tableRow(widths, headers, true)
+		}
+		d.tableRow(widths, row, false)
+	}
+}
+
+// Write renders the document
+func (d *Document) Write(w io.Writer) error {
+	return d.pdf.Output(w)
+}
+
+func (d *Document) tableRow(widths []float64, cells []string, header bool) {
+	height := d.rowHeight(widths, cells)
+	left, top := d.pdf.GetX(), d.pdf.GetY()
+
+	x := left
+	if d.rtl {
+		x = left + d.contentWidth()
+	}
+	for i, width := range widths {
+		if d.rtl {
+			x -= width
+		}
+		d.pdf.Rect(x, top, width, height, d.rectStyle(header))
+		lines := d.wrap(cells[i], width-2)
+		for j, line := range lines {
+			d.pdf.SetXY(x+1, top+float64(j)*lineHeight)
+			d.pdf.CellFormat(width-2, lineHeight, Visual(line, d.rtl), "", 0, d.align(), false, 0, "")
+		}
+		if !d.rtl {
+			x += width
+		}
+	}
+	d.pdf.SetXY(left, top+height)
+}
+
+func (d *Document) rowHeight(widths []float64, cells []string) float64 {
+	lines := 1
+	for i, width := range widths {
+		lines = max(lines, len(d.wrap(cells[i], width-2)))
+	}
+	return float64(lines) * lineHeight
+}
+
+func (d *Document) rectStyle(header bool) string {
+	if header {
+		return "FD"
+	}
+	return "D"
+}
+
+// wrap breaks text into lines no wider than width, measuring words in their shaped form.
+// Explicit line breaks are kept
+func (d *Document) wrap(text string, width float64) []string {
+	lines := []string{}
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line != "" && d.pdf.GetStringWidth(Visual(candidate, d.rtl)) > width {
+				lines = append(lines, line)
+				candidate = word
+			}
+			line = candidate
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (d *Document) contentWidth() float64 {
+	pageWidth, _ := d.pdf.GetPageSize()
+	left, _, right, _ := d.pdf.GetMargins()
+	return pageWidth - left - right
+}
+
+func (d *Document) remainingHeight() float64 {
+	_, pageHeight := d.pdf.GetPageSize()
+	_, _, _, bottom := d.pdf.GetMargins()
+	return pageHeight - bottom - d.pdf.GetY()
+}
+
+func (d *Document) align() string {
+	if d.rtl {
+		return "R"
+	}
+	return "L"
+}
@@ -0,0 +1,81 @@
+// Package spreadsheet reads the rows of CSV and XLSX files uploaded for bulk imports.
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither CSV nor XLSX
+var ErrUnsupportedFormat = errors.New("unsupported spreadsheet format")
+
+// zipSignature starts every XLSX file, which is a zip archive
+var zipSignature = []byte("PK\x03\x04")
+
+// ReadRows returns the rows of a CSV file or of the first sheet of an XLSX workbook. The format is
+// detected from the content and falls back to the file extension. Cells are trimmed and XLSX cells are
+// returned unformatted, so dates are Excel serial numbers and numbers have no thousands separators
+func ReadRows(fileName string, content io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	switch extension := strings.ToLower(filepath.Ext(fileName)); {
+	case bytes.HasPrefix(data, zipSignature):
+		rows, err = readXLSX(data)
+	case extension == ".csv" || extension == ".txt":
+		rows, err = readCSV(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+	}
+	return rows, nil
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	// Spreadsheet applications add a byte order mark to UTF-8 CSV files
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	workbook, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer workbook.Close()
+
+	sheets := workbook.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	return workbook.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+}
+
+// IsBlank reports whether every cell of the row is empty
+func IsBlank(row []string) bool {
+	for _, cell := range row {
+		if cell != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Client is the subset of the S3 API the storage needs. Any S3-compatible service can satisfy
+// it, including a local stand-in such as MinIO or an in-process fake
+type S3Client interface {
+	PutObject(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) error
+	GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+	DeleteObject(ctx context.Context, bucket, key string) error
+}
+
+// s3Storage keeps objects in a bucket of an S3-compatible service
+type s3Storage struct {
+	client S3Client
+	bucket string
+}
+
+func NewS3Storage(client S3Client, bucket string) Storage {
+	return &s3Storage{client: client, bucket: bucket}
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	return s.client.PutObject(ctx, s.bucket, key, body, size, contentType)
+}
+
+func (s *s3Storage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.client.GetObject(ctx, s.bucket, key)
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	return s.client.DeleteObject(ctx, s.bucket, key)
+}
+
+// s3HTTPClient talks to an S3-compatible endpoint with path-style URLs and AWS Signature V4
+type s3HTTPClient struct {
+	endpoint   string
+	region     string
+	accessKey  string
+	secretKey  string
+	httpClient *http.Client
+}
+
+func NewS3Client(endpoint, region, accessKey, secretKey string) S3Client {
+	return &s3HTTPClient{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		region:     region,
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (c *s3HTTPClient) PutObject(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) error {
+	req, err := c.newRequest(ctx, http.MethodPut, bucket, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *s3HTTPClient) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, bucket, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (c *s3HTTPClient) DeleteObject(ctx context.Context, bucket, key string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, bucket, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *s3HTTPClient) newRequest(ctx context.Context, method, bucket, key string, body io.Reader) (*http.Request, error) {
+	path := "/" + bucket + "/" + key
+	endpoint, err := url.Parse(c.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = path
+	endpoint.RawPath = escapeS3Path(path)
+	return http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+}
+
+// do signs and sends the request, turning error statuses into errors
+func (c *s3HTTPClient) do(req *http.Request) (*http.Response, error) {
+	c.sign(req, time.Now().UTC())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("storage: s3 %s %s returned %d: %s", req.Method, req.URL.Path, resp.StatusCode, message)
+	}
+	return resp, nil
+}
+
+// sign adds an AWS Signature V4 Authorization header. The payload is left unsigned so bodies can be streamed
+func (c *s3HTTPClient) sign(req *http.Request, now time.Time) {
+	const payloadHash = "UNSIGNED-PAYLOAD"
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.region + "/s3/aws4_request"
+	signature := signatureV4(c.secretKey, c.region, "s3", now, canonicalRequest)
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+c.accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// signatureV4 signs the canonical request with a key derived from the secret key for the day, region and service
+func signatureV4(secretKey, region, service string, now time.Time, canonicalRequest string) string {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapeS3Path URI-encodes every path segment the way Signature V4 expects: every byte but letters, digits
+// and -._~ is percent-encoded
+func escapeS3Path(path string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var escaped strings.Builder
+	for i := 0; i < len(path); i++ {
+		b := path[i]
+		if 'A' <= b && b <= 'Z' || 'a' <= b && b <= 'z' || '0' <= b && b <= '9' ||
+			b == '-' || b == '.' || b == '_' || b == '~' || b == '/' {
+			escaped.WriteByte(b)
+			continue
+		}
+		escaped.WriteByte('%')
+		escaped.WriteByte(hexDigits[b>>4])
+		escaped.WriteByte(hexDigits[b&0xF])
+	}
+	return escaped.String()
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/amirdashtii/AutoBan/config"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+)
+
+// ErrObjectNotFound is returned by Open when no object is stored under the key
+var ErrObjectNotFound = errors.New("storage: object not found")
+
+// Storage is a blob store for uploaded files, addressed by slash separated keys
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+var (
+	storage     Storage
+	storageOnce sync.Once
+)
+
+// GetStorage returns the singleton storage backend selected by the storage.driver setting
+func GetStorage() Storage {
+	storageOnce.Do(func() {
+		storage = NewStorage()
+	})
+	return storage
+}
+
+// NewStorage builds the backend selected by storage.driver: "local" (default) or "s3"
+func NewStorage() Storage {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		logger.Error(err, "Failed to get config")
+		return nil
+	}
+
+	switch cfg.Storage.Driver {
+	case "s3":
+		logger.Info("Using S3 storage at " + cfg.Storage.S3Endpoint)
+		client := NewS3Client(cfg.Storage.S3Endpoint, cfg.Storage.S3Region, cfg.Storage.S3AccessKey, cfg.Storage.S3SecretKey)
+		return NewS3Storage(client, cfg.Storage.S3Bucket)
+	default:
+		logger.Info("Using local storage at " + cfg.Storage.LocalPath)
+		return NewLocalStorage(cfg.Storage.LocalPath)
+	}
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type AttachmentController struct {
+	attachmentUseCase usecase.AttachmentUseCase
+}
+
+func NewAttachmentController() *AttachmentController {
+	attachmentUseCase := usecase.NewAttachmentUseCase()
+	return &AttachmentController{attachmentUseCase: attachmentUseCase}
+}
+
+func AttachmentRoutes(router *gin.Engine) {
+	c := NewAttachmentController()
+	attachmentGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/service-visits/:visit_id/attachments")
+	attachmentGroup.Use(middleware.AuthMiddleware())
+	attachmentGroup.Use(middleware.RequireActiveUser())
+	{
+		attachmentGroup.POST("", c.UploadAttachment)
+		attachmentGroup.GET("", c.ListAttachments)
+		attachmentGroup.GET("/:attachment_id", c.DownloadAttachment)
+		attachmentGroup.DELETE("/:attachment_id", c.DeleteAttachment)
+	}
+}
+
+// UploadAttachment godoc
+// @Summary Upload an attachment to a service visit
+// @Description Upload a photo (JPEG, PNG, WebP) or PDF of an invoice or receipt. Size and per-user quota limits apply, uploads by members count against the owner's quota
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param visit_id path string true "Service visit ID"
+// @Param file formData file true "Invoice or receipt file"
+// @Success 201 {object} dto.AttachmentResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/{visit_id}/attachments [post]
+func (c *AttachmentController) UploadAttachment(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	visitID := ctx.Param("visit_id")
+	userID := ctx.GetString("user_id")
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		logger.Error(err, "Failed to get attachment file")
+		respondError(ctx, errors.ErrAttachmentFileRequired)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error(err, "Failed to open attachment file")
+		respondError(ctx, errors.ErrFailedToUploadAttachment)
+		return
+	}
+	defer file.Close()
+
+	response, err := c.attachmentUseCase.UploadAttachment(ctx, userID, vehicleID, visitID, fileHeader.Filename, fileHeader.Size, file)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListAttachments godoc
+// @Summary List attachments of a service visit
+// @Description Get all invoices and receipts uploaded for a service visit, with the vehicle owner's storage usage
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param visit_id path string true "Service visit ID"
+// @Success 200 {object} dto.ListAttachmentsResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/{visit_id}/attachments [get]
+func (c *AttachmentController) ListAttachments(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	visitID := ctx.Param("visit_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.attachmentUseCase.ListAttachments(ctx, userID, vehicleID, visitID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DownloadAttachment godoc
+// @Summary Download an attachment
+// @Description Download the file of an invoice or receipt uploaded for a service visit
+// @Tags Attachments
+// @Produce application/pdf,image/jpeg,image/png,image/webp
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param visit_id path string true "Service visit ID"
+// @Param attachment_id path int true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/{visit_id}/attachments/{attachment_id} [get]
+func (c *AttachmentController) DownloadAttachment(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	visitID := ctx.Param("visit_id")
+	attachmentID := ctx.Param("attachment_id")
+	userID := ctx.GetString("user_id")
+
+	attachment, content, err := c.attachmentUseCase.DownloadAttachment(ctx, userID, vehicleID, visitID, attachmentID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	defer content.Close()
+
+	extraHeaders := map[string]string{
+		"Content-Disposition": fmt.Sprintf("inline; filename=%q", attachment.FileName),
+	}
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, extraHeaders)
+}
+
+// DeleteAttachment godoc
+// @Summary Delete an attachment
+// @Description Delete an invoice or receipt and its stored file
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param visit_id path string true "Service visit ID"
+// @Param attachment_id path int true "Attachment ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/{visit_id}/attachments/{attachment_id} [delete]
+func (c *AttachmentController) DeleteAttachment(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	visitID := ctx.Param("visit_id")
+	attachmentID := ctx.Param("attachment_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.attachmentUseCase.DeleteAttachment(ctx, userID, vehicleID, visitID, attachmentID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type CatalogController struct {
+	catalogUseCase usecase.CatalogUseCase
+}
+
+func NewCatalogController() *CatalogController {
+	catalogUseCase := usecase.NewCatalogUseCase()
+	return &CatalogController{catalogUseCase: catalogUseCase}
+}
+
+func CatalogRoutes(router *gin.Engine) {
+	c := NewCatalogController()
+
+	adminCatalogGroup := router.Group("/api/v1/admin/vehicles/catalog")
+	adminCatalogGroup.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+	{
+		adminCatalogGroup.GET("/export", c.ExportCatalog)
+		adminCatalogGroup.POST("/import", c.ImportCatalog)
+		adminCatalogGroup.GET("/trash", c.ListCatalogTrash)
+		adminCatalogGroup.POST("/trash/:level/:row_id/restore", c.RestoreCatalogItem)
+	}
+}
+
+// ExportCatalog godoc
+// @Summary     Export the vehicle catalog
+// @Description Download every vehicle type, brand, model and generation as a JSON tree or as a CSV file with one row per generation. The file can be edited and imported again
+// @Tags        Admin - Catalog
+// @Produce     application/json,text/csv
+// @Security    BearerAuth
+// @Param       format query string false "File format" Enums(json, csv) default(json)
+// @Success     200 {object} dto.Catalog
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/catalog/export [get]
+func (c *CatalogController) ExportCatalog(ctx *gin.Context) {
+	var request dto.ExportCatalogRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		respondError(ctx, errors.ErrInvalidCatalogExportRequest)
+		return
+	}
+	file, err := c.catalogUseCase.ExportCatalog(ctx, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.FileName))
+	ctx.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// ImportCatalog godoc
+// @Summary     Import the vehicle catalog
+// @Description Import vehicle types, brands, models and generations from a JSON file in the export format, or from a CSV or XLSX file whose header row names the export columns (type_name_en, brand_name_en, model_name_en, generation_name_en, ...). Rows are matched by English name within their parent, case-insensitively, and created or updated. Set prune to delete the rows missing from the file, unless user vehicles are on a generation it would delete, and dry_run to only see what would be created, updated or deleted. Nothing is imported unless every row is valid
+// @Tags        Admin - Catalog
+// @Accept      multipart/form-data
+// @Produce     json
+// @Security    BearerAuth
+// @Param       file formData file true "JSON, CSV or XLSX file"
+// @Param       dry_run query bool false "Report the changes without importing"
+// @Param       prune query bool false "Delete rows missing from the file"
+// @Success     200 {object} dto.ImportCatalogResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/catalog/import [post]
+func (c *CatalogController) ImportCatalog(ctx *gin.Context) {
+	var request dto.ImportCatalogRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		logger.Error(err, "Failed to bind query")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		logger.Error(err, "Failed to get import file")
+		respondError(ctx, errors.ErrCatalogImportFileRequired)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error(err, "Failed to open import file")
+		respondError(ctx, errors.ErrFailedToImportCatalog)
+		return
+	}
+	defer file.Close()
+
+	response, err := c.catalogUseCase.ImportCatalog(ctx, fileHeader.Filename, fileHeader.Size, file, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ListCatalogTrash godoc
+// @Summary     List the catalog trash
+// @Description List the deleted vehicle types, brands, models and generations, most recently deleted first. parent_deleted marks the rows whose parent has to be restored first
+// @Tags        Admin - Catalog
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} dto.ListCatalogTrashResponse
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/catalog/trash [get]
+func (c *CatalogController) ListCatalogTrash(ctx *gin.Context) {
+	response, err := c.catalogUseCase.ListCatalogTrash(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RestoreCatalogItem godoc
+// @Summary     Restore a deleted catalog row
+// @Description Restore a deleted vehicle type, brand, model or generation from the trash, along with the rows under it that were not deleted on their own
+// @Tags        Admin - Catalog
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       level path string true "Catalog level" Enums(type, brand, model, generation)
+// @Param       row_id path int true "Row ID"
+// @Success     204 "No Content"
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     409 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/catalog/trash/{level}/{row_id}/restore [post]
+func (c *CatalogController) RestoreCatalogItem(ctx *gin.Context) {
+	err := c.catalogUseCase.RestoreCatalogItem(ctx, ctx.Param("level"), ctx.Param("row_id"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
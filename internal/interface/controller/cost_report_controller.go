@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type CostReportController struct {
+	costReportUseCase usecase.CostReportUseCase
+}
+
+func NewCostReportController() *CostReportController {
+	costReportUseCase := usecase.NewCostReportUseCase()
+	return &CostReportController{costReportUseCase: costReportUseCase}
+}
+
+func CostReportRoutes(router *gin.Engine) {
+	c := NewCostReportController()
+
+	// Maintenance spending (requires authentication)
+	costReportGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/cost-report")
+	costReportGroup.Use(middleware.AuthMiddleware())
+	costReportGroup.Use(middleware.RequireActiveUser())
+	{
+		costReportGroup.GET("", c.GetVehicleCostReport)
+	}
+
+	// Total cost of ownership (requires authentication)
+	ownershipCostGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/ownership-cost")
+	ownershipCostGroup.Use(middleware.AuthMiddleware())
+	ownershipCostGroup.Use(middleware.RequireActiveUser())
+	{
+		ownershipCostGroup.GET("", c.GetVehicleOwnershipCost)
+	}
+}
+
+// GetVehicleCostReport godoc
+// @Summary Maintenance cost report
+// @Description Break down the maintenance spending of a user vehicle by month, year and category, with the cost per kilometre between the first and last service mileage. Amounts are in Rial with Toman totals for display
+// @Tags Cost Reports
+// @Produce json
+// @Security    BearerAuth
+// @Param vehicle_id path int true "Vehicle ID"
+// @Success 200 {object} dto.VehicleCostReportResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/cost-report [get]
+func (c *CostReportController) GetVehicleCostReport(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	vehicleID := ctx.Param("vehicle_id")
+
+	response, err := c.costReportUseCase.GetVehicleCostReport(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetVehicleOwnershipCost godoc
+// @Summary Total cost of ownership
+// @Description Combine service visit costs, fuel and other expenses of a user vehicle into the total cost of ownership per month, with the other expenses broken down by category. Amounts are in Rial with Toman totals for display
+// @Tags Cost Reports
+// @Produce json
+// @Security    BearerAuth
+// @Param vehicle_id path int true "Vehicle ID"
+// @Success 200 {object} dto.VehicleOwnershipCostResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/ownership-cost [get]
+func (c *CostReportController) GetVehicleOwnershipCost(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	vehicleID := ctx.Param("vehicle_id")
+
+	response, err := c.costReportUseCase.GetVehicleOwnershipCost(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
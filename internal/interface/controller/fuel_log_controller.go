@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type FuelLogController struct {
+	fuelLogUseCase usecase.FuelLogUseCase
+}
+
+func NewFuelLogController() *FuelLogController {
+	fuelLogUseCase := usecase.NewFuelLogUseCase()
+	return &FuelLogController{fuelLogUseCase: fuelLogUseCase}
+}
+
+func FuelLogRoutes(router *gin.Engine) {
+	c := NewFuelLogController()
+	fuelLogGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/fuel-logs")
+	fuelLogGroup.Use(middleware.AuthMiddleware())
+	fuelLogGroup.Use(middleware.RequireActiveUser())
+	{
+		fuelLogGroup.POST("", c.CreateFuelLog)
+		fuelLogGroup.GET("", c.ListFuelLogs)
+		fuelLogGroup.GET("/summary", c.GetFuelLogSummary)
+		fuelLogGroup.GET("/:fuel_log_id", c.GetFuelLog)
+		fuelLogGroup.PUT("/:fuel_log_id", c.UpdateFuelLog)
+		fuelLogGroup.DELETE("/:fuel_log_id", c.DeleteFuelLog)
+	}
+}
+
+// CreateFuelLog godoc
+// @Summary Record a fill-up
+// @Description Record a fill-up of a vehicle. The fuel type must be one the vehicle's generation takes and defaults to its primary fuel
+// @Tags Fuel Logs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param fuel_log body dto.CreateFuelLogRequest true "Fill-up data"
+// @Success 201 {object} dto.FuelLogResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/fuel-logs [post]
+func (c *FuelLogController) CreateFuelLog(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.CreateFuelLogRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.fuelLogUseCase.CreateFuelLog(ctx, userID, vehicleID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListFuelLogs godoc
+// @Summary List fill-ups
+// @Description Get the fill-ups of a vehicle, newest first. Full fill-ups carry the consumption since the previous full fill-up of the same fuel
+// @Tags Fuel Logs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} dto.ListFuelLogsResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/fuel-logs [get]
+func (c *FuelLogController) ListFuelLogs(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.fuelLogUseCase.ListFuelLogs(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetFuelLogSummary godoc
+// @Summary Fuel economy summary
+// @Description Get the average and rolling L/100km per fuel, measured between consecutive full fill-ups, and the fuel spending per month
+// @Tags Fuel Logs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} dto.FuelLogSummaryResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/fuel-logs/summary [get]
+func (c *FuelLogController) GetFuelLogSummary(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.fuelLogUseCase.GetFuelLogSummary(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetFuelLog godoc
+// @Summary Get fill-up by ID
+// @Description Get a specific fill-up of a vehicle
+// @Tags Fuel Logs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param fuel_log_id path int true "Fuel log ID"
+// @Success 200 {object} dto.FuelLogResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/fuel-logs/{fuel_log_id} [get]
+func (c *FuelLogController) GetFuelLog(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	fuelLogID := ctx.Param("fuel_log_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.fuelLogUseCase.GetFuelLog(ctx, userID, vehicleID, fuelLogID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateFuelLog godoc
+// @Summary Update fill-up
+// @Description Update a fill-up of a vehicle
+// @Tags Fuel Logs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param fuel_log_id path int true "Fuel log ID"
+// @Param fuel_log body dto.UpdateFuelLogRequest true "Updated fill-up data"
+// @Success 200 {object} dto.FuelLogResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/fuel-logs/{fuel_log_id} [put]
+func (c *FuelLogController) UpdateFuelLog(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	fuelLogID := ctx.Param("fuel_log_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.UpdateFuelLogRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.fuelLogUseCase.UpdateFuelLog(ctx, userID, vehicleID, fuelLogID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteFuelLog godoc
+// @Summary Delete fill-up
+// @Description Delete a fill-up of a vehicle
+// @Tags Fuel Logs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param fuel_log_id path int true "Fuel log ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/fuel-logs/{fuel_log_id} [delete]
+func (c *FuelLogController) DeleteFuelLog(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	fuelLogID := ctx.Param("fuel_log_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.fuelLogUseCase.DeleteFuelLog(ctx, userID, vehicleID, fuelLogID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
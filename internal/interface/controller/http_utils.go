@@ -34,11 +34,100 @@ func httpStatusFromError(err error) int {
 		customerr.Is(err, customerr.ErrInvalidVehicleGenerationID) ||
 		customerr.Is(err, customerr.ErrInvalidVehicleGenerationCreateRequest) ||
 		customerr.Is(err, customerr.ErrInvalidVehicleGenerationUpdateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleSearchRequest) ||
+		customerr.Is(err, customerr.ErrLicensePlateAlreadyRegistered) ||
+		customerr.Is(err, customerr.ErrInvalidVIN) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleWMIID) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleWMICreateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleWMIUpdateRequest) ||
+		customerr.Is(err, customerr.ErrVehicleWMIAlreadyExists) ||
+		customerr.Is(err, customerr.ErrVehicleWMIBrandNotFound) ||
+		customerr.Is(err, customerr.ErrVehicleWMIModelNotInBrand) ||
 		customerr.Is(err, customerr.ErrInvalidUserVehicleID) ||
 		customerr.Is(err, customerr.ErrInvalidUserVehicleCreateRequest) ||
 		customerr.Is(err, customerr.ErrInvalidUserVehicleUpdateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidServiceBookExportRequest) ||
 		customerr.Is(err, customerr.ErrInvalidDate) ||
-		customerr.Is(err, customerr.ErrUserVehicleIDRequired) {
+		customerr.Is(err, customerr.ErrUserVehicleIDRequired) ||
+		customerr.Is(err, customerr.ErrInvalidServiceItemCreateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidServiceItemUpdateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidServiceItemID) ||
+		customerr.Is(err, customerr.ErrInvalidOdometerReadingCreateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidOdometerReadingID) ||
+		customerr.Is(err, customerr.ErrOdometerReadingTooLow) ||
+		customerr.Is(err, customerr.ErrOdometerReadingTooHigh) ||
+		customerr.Is(err, customerr.ErrOdometerReadingManagedByServiceVisit) ||
+		customerr.Is(err, customerr.ErrInvalidFuelLogCreateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidFuelLogUpdateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidFuelLogID) ||
+		customerr.Is(err, customerr.ErrFuelTypeNotSupported) ||
+		customerr.Is(err, customerr.ErrInvalidMaintenanceIntervalCreateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidMaintenanceIntervalUpdateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidMaintenanceIntervalID) ||
+		customerr.Is(err, customerr.ErrMaintenanceIntervalExists) ||
+		customerr.Is(err, customerr.ErrInvalidAttachmentID) ||
+		customerr.Is(err, customerr.ErrAttachmentFileRequired) ||
+		customerr.Is(err, customerr.ErrAttachmentTypeNotAllowed) ||
+		customerr.Is(err, customerr.ErrAttachmentTooLarge) ||
+		customerr.Is(err, customerr.ErrServiceVisitImportFileRequired) ||
+		customerr.Is(err, customerr.ErrServiceVisitImportFileTooLarge) ||
+		customerr.Is(err, customerr.ErrInvalidServiceVisitImportFile) ||
+		customerr.Is(err, customerr.ErrServiceVisitImportFileEmpty) ||
+		customerr.Is(err, customerr.ErrServiceVisitImportTooManyRows) ||
+		customerr.Is(err, customerr.ErrServiceVisitImportMissingColumns) ||
+		customerr.Is(err, customerr.ErrInvalidCatalogExportRequest) ||
+		customerr.Is(err, customerr.ErrInvalidCatalogTrashLevel) ||
+		customerr.Is(err, customerr.ErrInvalidCatalogTrashRowID) ||
+		customerr.Is(err, customerr.ErrInvalidReassignGeneration) ||
+		customerr.Is(err, customerr.ErrCatalogImportFileRequired) ||
+		customerr.Is(err, customerr.ErrCatalogImportFileTooLarge) ||
+		customerr.Is(err, customerr.ErrInvalidCatalogImportFile) ||
+		customerr.Is(err, customerr.ErrCatalogImportMissingColumns) ||
+		customerr.Is(err, customerr.ErrInvalidServiceCenterID) ||
+		customerr.Is(err, customerr.ErrInvalidServiceCenterCreateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidServiceCenterUpdateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidNearbyServiceCentersRequest) ||
+		customerr.Is(err, customerr.ErrInvalidServiceCenterReviewRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleTransferRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleTransferConfirmation) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleTransferID) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleTransferCode) ||
+		customerr.Is(err, customerr.ErrVehicleTransferToSelf) ||
+		customerr.Is(err, customerr.ErrVehicleTransferAlreadyPending) ||
+		customerr.Is(err, customerr.ErrVehicleTransferNotOpen) ||
+		customerr.Is(err, customerr.ErrVehicleTransferTooManyAttempts) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleMembershipRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleMembershipUpdateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleMembershipID) ||
+		customerr.Is(err, customerr.ErrVehicleMembershipToOwner) ||
+		customerr.Is(err, customerr.ErrVehicleMembershipExists) ||
+		customerr.Is(err, customerr.ErrVehicleMembershipAlreadyAccepted) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleShareLinkRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleShareLinkID) ||
+		customerr.Is(err, customerr.ErrVehicleShareLinkAlreadyRevoked) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleDocumentCreateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleDocumentUpdateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleDocumentID) ||
+		customerr.Is(err, customerr.ErrVehicleDocumentExpiryBeforeStart) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleExpenseCreateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleExpenseUpdateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidVehicleExpenseID) ||
+		customerr.Is(err, customerr.ErrInvalidExpenseCategoryRequest) ||
+		customerr.Is(err, customerr.ErrInvalidExpenseCategoryID) ||
+		customerr.Is(err, customerr.ErrExpenseCategoryRequired) ||
+		customerr.Is(err, customerr.ErrExpenseCategoryExists) ||
+		customerr.Is(err, customerr.ErrExpenseCategoryInUse) ||
+		customerr.Is(err, customerr.ErrInvalidTireSetCreateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidTireSetUpdateRequest) ||
+		customerr.Is(err, customerr.ErrInvalidTireSetID) ||
+		customerr.Is(err, customerr.ErrInvalidTireMountRequest) ||
+		customerr.Is(err, customerr.ErrInvalidTireRotationRequest) ||
+		customerr.Is(err, customerr.ErrInvalidTireRotationID) ||
+		customerr.Is(err, customerr.ErrInvalidTreadDepthRequest) ||
+		customerr.Is(err, customerr.ErrInvalidTreadDepthID) ||
+		customerr.Is(err, customerr.ErrTireSetAlreadyMounted) ||
+		customerr.Is(err, customerr.ErrTireSetNotMounted) ||
+		customerr.Is(err, customerr.ErrTireMileageBeforeMount) {
 		return http.StatusBadRequest
 	}
 
@@ -53,15 +142,52 @@ func httpStatusFromError(err error) int {
 		customerr.Is(err, customerr.ErrUserNotActive) ||
 		customerr.Is(err, customerr.ErrUserVehicleNotOwned) ||
 		customerr.Is(err, customerr.ErrOilFilterNotOwned) ||
-		customerr.Is(err, customerr.ErrOilChangeNotOwned) {
+		customerr.Is(err, customerr.ErrOilChangeNotOwned) ||
+		customerr.Is(err, customerr.ErrServiceItemNotOwned) ||
+		customerr.Is(err, customerr.ErrOdometerReadingNotOwned) ||
+		customerr.Is(err, customerr.ErrFuelLogNotOwned) ||
+		customerr.Is(err, customerr.ErrAttachmentNotOwned) ||
+		customerr.Is(err, customerr.ErrAttachmentQuotaExceeded) ||
+		customerr.Is(err, customerr.ErrServiceCenterNotVisited) ||
+		customerr.Is(err, customerr.ErrVehicleTransferNotOwned) ||
+		customerr.Is(err, customerr.ErrVehicleRoleNotAllowed) ||
+		customerr.Is(err, customerr.ErrVehicleDocumentNotOwned) ||
+		customerr.Is(err, customerr.ErrVehicleExpenseNotOwned) ||
+		customerr.Is(err, customerr.ErrTireSetNotOwned) {
 		return http.StatusForbidden
 	}
 
 	// 404 Not Found
-	if customerr.Is(err, customerr.ErrUserNotFound) {
+	if customerr.Is(err, customerr.ErrUserNotFound) ||
+		customerr.Is(err, customerr.ErrMaintenanceIntervalNotFound) ||
+		customerr.Is(err, customerr.ErrServiceCenterNotFound) ||
+		customerr.Is(err, customerr.ErrVehicleWMINotFound) ||
+		customerr.Is(err, customerr.ErrServiceCenterReviewNotFound) ||
+		customerr.Is(err, customerr.ErrVehicleTransferNotFound) ||
+		customerr.Is(err, customerr.ErrVehicleTransferRecipientNotFound) ||
+		customerr.Is(err, customerr.ErrVehicleMembershipNotFound) ||
+		customerr.Is(err, customerr.ErrVehicleShareLinkNotFound) ||
+		customerr.Is(err, customerr.ErrVehicleShareLinkExpired) ||
+		customerr.Is(err, customerr.ErrVehicleShareLinkRevoked) ||
+		customerr.Is(err, customerr.ErrVehicleDocumentScanNotFound) ||
+		customerr.Is(err, customerr.ErrExpenseCategoryNotFound) ||
+		customerr.Is(err, customerr.ErrTireRotationNotFound) ||
+		customerr.Is(err, customerr.ErrTreadDepthNotFound) ||
+		customerr.Is(err, customerr.ErrCatalogTrashItemNotFound) {
 		return http.StatusNotFound
 	}
 
+	// 409 Conflict
+	if customerr.Is(err, customerr.ErrVehicleCatalogInUse) ||
+		customerr.Is(err, customerr.ErrCatalogTrashParentDeleted) {
+		return http.StatusConflict
+	}
+
+	// 503 Service Unavailable: features this deployment is not configured for
+	if customerr.Is(err, customerr.ErrVehicleTransfersNotConfigured) {
+		return http.StatusServiceUnavailable
+	}
+
 	// Default: 500
 	return http.StatusInternalServerError
 }
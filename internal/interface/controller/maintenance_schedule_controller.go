@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceScheduleController struct {
+	maintenanceScheduleUseCase usecase.MaintenanceScheduleUseCase
+}
+
+func NewMaintenanceScheduleController() *MaintenanceScheduleController {
+	maintenanceScheduleUseCase := usecase.NewMaintenanceScheduleUseCase()
+	return &MaintenanceScheduleController{maintenanceScheduleUseCase: maintenanceScheduleUseCase}
+}
+
+func MaintenanceScheduleRoutes(router *gin.Engine) {
+	c := NewMaintenanceScheduleController()
+
+	// Public manufacturer intervals of a generation
+	intervalGroup := router.Group("/api/v1/vehicles/types/:type_id/brands/:brand_id/models/:model_id/generations/:generation_id/maintenance-intervals")
+	{
+		intervalGroup.GET("", c.ListMaintenanceIntervals)
+	}
+
+	// User vehicle maintenance plan (requires authentication)
+	planGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/maintenance-plan")
+	planGroup.Use(middleware.AuthMiddleware())
+	planGroup.Use(middleware.RequireActiveUser())
+	{
+		planGroup.GET("", c.GetMaintenancePlan)
+		planGroup.POST("/regenerate", c.RegenerateMaintenancePlan)
+	}
+
+	// Admin routes for managing manufacturer intervals
+	adminIntervalGroup := router.Group("/api/v1/admin/vehicles/types/:type_id/brands/:brand_id/models/:model_id/generations/:generation_id/maintenance-intervals")
+	adminIntervalGroup.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+	{
+		adminIntervalGroup.POST("", c.CreateMaintenanceInterval)
+		adminIntervalGroup.PUT("/:interval_id", c.UpdateMaintenanceInterval)
+		adminIntervalGroup.DELETE("/:interval_id", c.DeleteMaintenanceInterval)
+	}
+}
+
+// @Summary     List manufacturer maintenance intervals
+// @Description Get the manufacturer maintenance intervals of a vehicle generation
+// @Tags        Generations
+// @Accept      json
+// @Produce     json
+// @Param       type_id path string true "Vehicle Type ID"
+// @Param       brand_id path string true "Vehicle Brand ID"
+// @Param       model_id path string true "Vehicle Model ID"
+// @Param       generation_id path string true "Vehicle Generation ID"
+// @Success     200 {object} dto.ListMaintenanceIntervalsResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /vehicles/types/{type_id}/brands/{brand_id}/models/{model_id}/generations/{generation_id}/maintenance-intervals [get]
+func (c *MaintenanceScheduleController) ListMaintenanceIntervals(ctx *gin.Context) {
+	generationID := ctx.Param("generation_id")
+	intervals, err := c.maintenanceScheduleUseCase.ListMaintenanceIntervals(ctx, generationID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, intervals)
+}
+
+// @Summary     Create a manufacturer maintenance interval
+// @Description Add a maintenance interval to a vehicle generation, e.g. oil every 5,000 km or 6 months. Vehicles added afterwards get it in their maintenance plan
+// @Tags        Admin - Generations
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       type_id path string true "Vehicle Type ID"
+// @Param       brand_id path string true "Vehicle Brand ID"
+// @Param       model_id path string true "Vehicle Model ID"
+// @Param       generation_id path string true "Vehicle Generation ID"
+// @Param       interval body dto.CreateMaintenanceIntervalRequest true "Maintenance interval"
+// @Success     201 {object} dto.MaintenanceIntervalResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/types/{type_id}/brands/{brand_id}/models/{model_id}/generations/{generation_id}/maintenance-intervals [post]
+func (c *MaintenanceScheduleController) CreateMaintenanceInterval(ctx *gin.Context) {
+	generationID := ctx.Param("generation_id")
+
+	var request dto.CreateMaintenanceIntervalRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	interval, err := c.maintenanceScheduleUseCase.CreateMaintenanceInterval(ctx, generationID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, interval)
+}
+
+// @Summary     Update a manufacturer maintenance interval
+// @Description Update a maintenance interval of a vehicle generation. Existing vehicle plans keep their copy until regenerated
+// @Tags        Admin - Generations
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       type_id path string true "Vehicle Type ID"
+// @Param       brand_id path string true "Vehicle Brand ID"
+// @Param       model_id path string true "Vehicle Model ID"
+// @Param       generation_id path string true "Vehicle Generation ID"
+// @Param       interval_id path string true "Maintenance Interval ID"
+// @Param       interval body dto.UpdateMaintenanceIntervalRequest true "Maintenance interval"
+// @Success     200 {object} dto.MaintenanceIntervalResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/types/{type_id}/brands/{brand_id}/models/{model_id}/generations/{generation_id}/maintenance-intervals/{interval_id} [put]
+func (c *MaintenanceScheduleController) UpdateMaintenanceInterval(ctx *gin.Context) {
+	generationID := ctx.Param("generation_id")
+	intervalID := ctx.Param("interval_id")
+
+	var request dto.UpdateMaintenanceIntervalRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	interval, err := c.maintenanceScheduleUseCase.UpdateMaintenanceInterval(ctx, generationID, intervalID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interval)
+}
+
+// @Summary     Delete a manufacturer maintenance interval
+// @Description Delete a maintenance interval of a vehicle generation
+// @Tags        Admin - Generations
+// @Security    BearerAuth
+// @Param       type_id path string true "Vehicle Type ID"
+// @Param       brand_id path string true "Vehicle Brand ID"
+// @Param       model_id path string true "Vehicle Model ID"
+// @Param       generation_id path string true "Vehicle Generation ID"
+// @Param       interval_id path string true "Maintenance Interval ID"
+// @Success     204 "No Content"
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/types/{type_id}/brands/{brand_id}/models/{model_id}/generations/{generation_id}/maintenance-intervals/{interval_id} [delete]
+func (c *MaintenanceScheduleController) DeleteMaintenanceInterval(ctx *gin.Context) {
+	generationID := ctx.Param("generation_id")
+	intervalID := ctx.Param("interval_id")
+	err := c.maintenanceScheduleUseCase.DeleteMaintenanceInterval(ctx, generationID, intervalID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary     Get maintenance plan
+// @Description Get the manufacturer maintenance plan of a user vehicle, generated from its generation's intervals when the vehicle was added
+// @Tags        Reminders
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       vehicle_id path string true "Vehicle ID"
+// @Success     200 {object} dto.MaintenancePlanResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /user/vehicles/{vehicle_id}/maintenance-plan [get]
+func (c *MaintenanceScheduleController) GetMaintenancePlan(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	vehicleID := ctx.Param("vehicle_id")
+
+	response, err := c.maintenanceScheduleUseCase.GetMaintenancePlan(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// @Summary     Regenerate maintenance plan
+// @Description Rebuild the maintenance plan of a user vehicle from its generation's current intervals, starting from the current mileage and today
+// @Tags        Reminders
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       vehicle_id path string true "Vehicle ID"
+// @Success     200 {object} dto.MaintenancePlanResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /user/vehicles/{vehicle_id}/maintenance-plan/regenerate [post]
+func (c *MaintenanceScheduleController) RegenerateMaintenancePlan(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	vehicleID := ctx.Param("vehicle_id")
+
+	response, err := c.maintenanceScheduleUseCase.RegenerateMaintenancePlan(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type OdometerReadingController struct {
+	odometerReadingUseCase usecase.OdometerReadingUseCase
+}
+
+func NewOdometerReadingController() *OdometerReadingController {
+	odometerReadingUseCase := usecase.NewOdometerReadingUseCase()
+	return &OdometerReadingController{odometerReadingUseCase: odometerReadingUseCase}
+}
+
+func OdometerReadingRoutes(router *gin.Engine) {
+	c := NewOdometerReadingController()
+	odometerReadingGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/odometer-readings")
+	odometerReadingGroup.Use(middleware.AuthMiddleware())
+	odometerReadingGroup.Use(middleware.RequireActiveUser())
+	{
+		odometerReadingGroup.POST("", c.CreateOdometerReading)
+		odometerReadingGroup.GET("", c.ListOdometerReadings)
+		odometerReadingGroup.GET("/:reading_id", c.GetOdometerReading)
+		odometerReadingGroup.DELETE("/:reading_id", c.DeleteOdometerReading)
+	}
+}
+
+// CreateOdometerReading godoc
+// @Summary Record an odometer reading
+// @Description Record the vehicle's odometer and update its current mileage. A reading lower than the one before its date or higher than the one after it is rejected unless is_replacement is set
+// @Tags Odometer Readings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param odometer_reading body dto.CreateOdometerReadingRequest true "Odometer reading data"
+// @Success 201 {object} dto.OdometerReadingResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/odometer-readings [post]
+func (c *OdometerReadingController) CreateOdometerReading(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.CreateOdometerReadingRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.odometerReadingUseCase.CreateOdometerReading(ctx, userID, vehicleID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListOdometerReadings godoc
+// @Summary List odometer readings
+// @Description Get the odometer history of a vehicle, newest first
+// @Tags Odometer Readings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} dto.ListOdometerReadingsResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/odometer-readings [get]
+func (c *OdometerReadingController) ListOdometerReadings(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.odometerReadingUseCase.ListOdometerReadings(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetOdometerReading godoc
+// @Summary Get odometer reading by ID
+// @Description Get a specific odometer reading of a vehicle
+// @Tags Odometer Readings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param reading_id path int true "Odometer reading ID"
+// @Success 200 {object} dto.OdometerReadingResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/odometer-readings/{reading_id} [get]
+func (c *OdometerReadingController) GetOdometerReading(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	readingID := ctx.Param("reading_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.odometerReadingUseCase.GetOdometerReading(ctx, userID, vehicleID, readingID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteOdometerReading godoc
+// @Summary Delete odometer reading
+// @Description Delete a manual odometer reading and resync the vehicle's current mileage. Readings recorded by a service visit are removed with the visit
+// @Tags Odometer Readings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param reading_id path int true "Odometer reading ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/odometer-readings/{reading_id} [delete]
+func (c *OdometerReadingController) DeleteOdometerReading(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	readingID := ctx.Param("reading_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.odometerReadingUseCase.DeleteOdometerReading(ctx, userID, vehicleID, readingID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderController struct {
+	reminderUseCase usecase.ReminderUseCase
+}
+
+func NewReminderController() *ReminderController {
+	reminderUseCase := usecase.NewReminderUseCase()
+	return &ReminderController{reminderUseCase: reminderUseCase}
+}
+
+func ReminderRoutes(router *gin.Engine) {
+	c := NewReminderController()
+
+	// Maintenance reminders (requires authentication)
+	reminderGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/reminders")
+	reminderGroup.Use(middleware.AuthMiddleware())
+	reminderGroup.Use(middleware.RequireActiveUser())
+	{
+		reminderGroup.GET("", c.GetVehicleReminders)
+	}
+
+	// Maintenance forecast (requires authentication)
+	forecastGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/forecast")
+	forecastGroup.Use(middleware.AuthMiddleware())
+	forecastGroup.Use(middleware.RequireActiveUser())
+	{
+		forecastGroup.GET("", c.GetVehicleForecast)
+	}
+}
+
+// GetVehicleReminders godoc
+// @Summary Maintenance reminders
+// @Description Get every tracked maintenance item of a user vehicle as upcoming, due soon or overdue with the kilometres and days remaining
+// @Tags Reminders
+// @Produce json
+// @Security    BearerAuth
+// @Param vehicle_id path int true "Vehicle ID"
+// @Success 200 {object} dto.VehicleRemindersResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/reminders [get]
+func (c *ReminderController) GetVehicleReminders(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	vehicleID := ctx.Param("vehicle_id")
+
+	response, err := c.reminderUseCase.GetVehicleReminders(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetVehicleForecast godoc
+// @Summary Maintenance forecast
+// @Description Estimate how far the vehicle is driven per day from its odometer readings and service visits, and project the date each next change mileage will be reached
+// @Tags Reminders
+// @Produce json
+// @Security    BearerAuth
+// @Param vehicle_id path int true "Vehicle ID"
+// @Success 200 {object} dto.VehicleForecastResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/forecast [get]
+func (c *ReminderController) GetVehicleForecast(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	vehicleID := ctx.Param("vehicle_id")
+
+	response, err := c.reminderUseCase.GetVehicleForecast(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,287 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceCenterController struct {
+	serviceCenterUseCase usecase.ServiceCenterUseCase
+}
+
+func NewServiceCenterController() *ServiceCenterController {
+	serviceCenterUseCase := usecase.NewServiceCenterUseCase()
+	return &ServiceCenterController{serviceCenterUseCase: serviceCenterUseCase}
+}
+
+func ServiceCenterRoutes(router *gin.Engine) {
+	c := NewServiceCenterController()
+
+	// Public directory
+	serviceCenterGroup := router.Group("/api/v1/service-centers")
+	{
+		serviceCenterGroup.GET("", c.ListServiceCenters)
+		serviceCenterGroup.GET("/nearby", c.FindNearbyServiceCenters)
+		serviceCenterGroup.GET("/:center_id", c.GetServiceCenter)
+		serviceCenterGroup.GET("/:center_id/reviews", c.ListServiceCenterReviews)
+	}
+
+	// User reviews (requires authentication)
+	reviewGroup := router.Group("/api/v1/service-centers/:center_id/reviews")
+	reviewGroup.Use(middleware.AuthMiddleware())
+	reviewGroup.Use(middleware.RequireActiveUser())
+	{
+		reviewGroup.PUT("/me", c.ReviewServiceCenter)
+		reviewGroup.DELETE("/me", c.DeleteServiceCenterReview)
+	}
+
+	// Admin routes for managing the directory
+	adminServiceCenterGroup := router.Group("/api/v1/admin/service-centers")
+	adminServiceCenterGroup.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+	{
+		adminServiceCenterGroup.POST("", c.CreateServiceCenter)
+		adminServiceCenterGroup.PUT("/:center_id", c.UpdateServiceCenter)
+		adminServiceCenterGroup.DELETE("/:center_id", c.DeleteServiceCenter)
+	}
+}
+
+// @Summary     List service centers
+// @Description List service centers in the directory, optionally filtered by city and name
+// @Tags        Service Centers
+// @Accept      json
+// @Produce     json
+// @Param       city query string false "City"
+// @Param       name query string false "Part of the name"
+// @Success     200 {object} dto.ListServiceCentersResponse
+// @Failure     500 {object} errors.CustomError
+// @Router      /service-centers [get]
+func (c *ServiceCenterController) ListServiceCenters(ctx *gin.Context) {
+	serviceCenters, err := c.serviceCenterUseCase.ListServiceCenters(ctx, ctx.Query("city"), ctx.Query("name"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, serviceCenters)
+}
+
+// @Summary     Find nearby service centers
+// @Description Find service centers within a radius of a location, nearest first
+// @Tags        Service Centers
+// @Accept      json
+// @Produce     json
+// @Param       latitude query number true "Latitude"
+// @Param       longitude query number true "Longitude"
+// @Param       radius_km query number false "Search radius in kilometres (default 10, max 100)"
+// @Param       limit query int false "Maximum number of results (default 20, max 100)"
+// @Success     200 {object} dto.ListServiceCentersResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /service-centers/nearby [get]
+func (c *ServiceCenterController) FindNearbyServiceCenters(ctx *gin.Context) {
+	var request dto.NearbyServiceCentersRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		logger.Error(err, "Failed to bind query")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	serviceCenters, err := c.serviceCenterUseCase.FindNearbyServiceCenters(ctx, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, serviceCenters)
+}
+
+// @Summary     Get service center
+// @Description Get a service center of the directory with its rating
+// @Tags        Service Centers
+// @Accept      json
+// @Produce     json
+// @Param       center_id path int true "Service center ID"
+// @Success     200 {object} dto.ServiceCenterResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /service-centers/{center_id} [get]
+func (c *ServiceCenterController) GetServiceCenter(ctx *gin.Context) {
+	centerID := ctx.Param("center_id")
+	serviceCenter, err := c.serviceCenterUseCase.GetServiceCenter(ctx, centerID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, serviceCenter)
+}
+
+// @Summary     List service center reviews
+// @Description Get the reviews of a service center, most recent first
+// @Tags        Service Centers
+// @Accept      json
+// @Produce     json
+// @Param       center_id path int true "Service center ID"
+// @Success     200 {object} dto.ListServiceCenterReviewsResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /service-centers/{center_id}/reviews [get]
+func (c *ServiceCenterController) ListServiceCenterReviews(ctx *gin.Context) {
+	centerID := ctx.Param("center_id")
+	reviews, err := c.serviceCenterUseCase.ListServiceCenterReviews(ctx, centerID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, reviews)
+}
+
+// @Summary     Review a service center
+// @Description Rate a service center from 1 to 5. Only users with a recorded visit at the center may review it; reviewing again replaces the previous review
+// @Tags        Service Centers
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       center_id path int true "Service center ID"
+// @Param       review body dto.ReviewServiceCenterRequest true "Review"
+// @Success     200 {object} dto.ServiceCenterReviewResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /service-centers/{center_id}/reviews/me [put]
+func (c *ServiceCenterController) ReviewServiceCenter(ctx *gin.Context) {
+	centerID := ctx.Param("center_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.ReviewServiceCenterRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	review, err := c.serviceCenterUseCase.ReviewServiceCenter(ctx, userID, centerID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, review)
+}
+
+// @Summary     Delete my service center review
+// @Description Delete the current user's review of a service center
+// @Tags        Service Centers
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       center_id path int true "Service center ID"
+// @Success     204 "No Content"
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /service-centers/{center_id}/reviews/me [delete]
+func (c *ServiceCenterController) DeleteServiceCenterReview(ctx *gin.Context) {
+	centerID := ctx.Param("center_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.serviceCenterUseCase.DeleteServiceCenterReview(ctx, userID, centerID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary     Create a service center
+// @Description Add a service center to the directory
+// @Tags        Admin - Service Centers
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       service_center body dto.CreateServiceCenterRequest true "Service center"
+// @Success     201 {object} dto.ServiceCenterResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/service-centers [post]
+func (c *ServiceCenterController) CreateServiceCenter(ctx *gin.Context) {
+	var request dto.CreateServiceCenterRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	serviceCenter, err := c.serviceCenterUseCase.CreateServiceCenter(ctx, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, serviceCenter)
+}
+
+// @Summary     Update a service center
+// @Description Update a service center of the directory
+// @Tags        Admin - Service Centers
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       center_id path int true "Service center ID"
+// @Param       service_center body dto.UpdateServiceCenterRequest true "Service center"
+// @Success     200 {object} dto.ServiceCenterResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/service-centers/{center_id} [put]
+func (c *ServiceCenterController) UpdateServiceCenter(ctx *gin.Context) {
+	centerID := ctx.Param("center_id")
+
+	var request dto.UpdateServiceCenterRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	serviceCenter, err := c.serviceCenterUseCase.UpdateServiceCenter(ctx, centerID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, serviceCenter)
+}
+
+// @Summary     Delete a service center
+// @Description Remove a service center from the directory. Visits that reference it keep their link and free-text name
+// @Tags        Admin - Service Centers
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       center_id path int true "Service center ID"
+// @Success     204 "No Content"
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/service-centers/{center_id} [delete]
+func (c *ServiceCenterController) DeleteServiceCenter(ctx *gin.Context) {
+	centerID := ctx.Param("center_id")
+	err := c.serviceCenterUseCase.DeleteServiceCenter(ctx, centerID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceItemController struct {
+	serviceItemUseCase usecase.ServiceItemUseCase
+}
+
+func NewServiceItemController() *ServiceItemController {
+	serviceItemUseCase := usecase.NewServiceItemUseCase()
+	return &ServiceItemController{serviceItemUseCase: serviceItemUseCase}
+}
+
+func ServiceItemRoutes(router *gin.Engine) {
+	c := NewServiceItemController()
+	serviceItemGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/service-visits/:visit_id/items")
+	serviceItemGroup.Use(middleware.AuthMiddleware())
+	serviceItemGroup.Use(middleware.RequireActiveUser())
+	{
+		serviceItemGroup.POST("", c.CreateServiceItem)
+		serviceItemGroup.GET("", c.ListServiceItems)
+		serviceItemGroup.GET("/:item_id", c.GetServiceItem)
+		serviceItemGroup.PUT("/:item_id", c.UpdateServiceItem)
+		serviceItemGroup.DELETE("/:item_id", c.DeleteServiceItem)
+	}
+}
+
+// CreateServiceItem godoc
+// @Summary Add a service item to a service visit
+// @Description Add a service item such as air filter, brake pads or coolant to an existing service visit
+// @Tags Service Items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param visit_id path string true "Service visit ID"
+// @Param service_item body dto.CreateServiceItemRequest true "Service item data"
+// @Success 201 {object} dto.ServiceItemResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/{visit_id}/items [post]
+func (c *ServiceItemController) CreateServiceItem(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	visitID := ctx.Param("visit_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.CreateServiceItemRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.serviceItemUseCase.CreateServiceItem(ctx, userID, vehicleID, visitID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListServiceItems godoc
+// @Summary List service items of a service visit
+// @Description Get all service items recorded for a specific service visit
+// @Tags Service Items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param visit_id path string true "Service visit ID"
+// @Success 200 {object} dto.ListServiceItemsResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/{visit_id}/items [get]
+func (c *ServiceItemController) ListServiceItems(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	visitID := ctx.Param("visit_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.serviceItemUseCase.ListServiceItems(ctx, userID, vehicleID, visitID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetServiceItem godoc
+// @Summary Get service item by ID
+// @Description Get a specific service item of a service visit
+// @Tags Service Items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param visit_id path string true "Service visit ID"
+// @Param item_id path int true "Service item ID"
+// @Success 200 {object} dto.ServiceItemResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/{visit_id}/items/{item_id} [get]
+func (c *ServiceItemController) GetServiceItem(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	visitID := ctx.Param("visit_id")
+	itemID := ctx.Param("item_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.serviceItemUseCase.GetServiceItem(ctx, userID, vehicleID, visitID, itemID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateServiceItem godoc
+// @Summary Update service item
+// @Description Update an existing service item of a service visit
+// @Tags Service Items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param visit_id path string true "Service visit ID"
+// @Param item_id path int true "Service item ID"
+// @Param service_item body dto.UpdateServiceItemRequest true "Updated service item data"
+// @Success 200 {object} dto.ServiceItemResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/{visit_id}/items/{item_id} [put]
+func (c *ServiceItemController) UpdateServiceItem(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	visitID := ctx.Param("visit_id")
+	itemID := ctx.Param("item_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.UpdateServiceItemRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.serviceItemUseCase.UpdateServiceItem(ctx, userID, vehicleID, visitID, itemID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteServiceItem godoc
+// @Summary Delete service item
+// @Description Delete a service item from a service visit
+// @Tags Service Items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param visit_id path string true "Service visit ID"
+// @Param item_id path int true "Service item ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/{visit_id}/items/{item_id} [delete]
+func (c *ServiceItemController) DeleteServiceItem(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	visitID := ctx.Param("visit_id")
+	itemID := ctx.Param("item_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.serviceItemUseCase.DeleteServiceItem(ctx, userID, vehicleID, visitID, itemID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
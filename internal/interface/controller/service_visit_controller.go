@@ -29,6 +29,8 @@ func ServiceVisitRoutes(router *gin.Engine) {
 		userVehicleGroup.POST("", c.CreateServiceVisit)
 		userVehicleGroup.GET("", c.ListServiceVisits)
 		userVehicleGroup.GET("/last", c.GetLastServiceVisit)
+		userVehicleGroup.GET("/defaults", c.GetServiceVisitDefaults)
+		userVehicleGroup.POST("/import", c.ImportServiceVisits)
 		userVehicleGroup.GET("/:visit_id", c.GetServiceVisit)
 		userVehicleGroup.PUT("/:visit_id", c.UpdateServiceVisit)
 		userVehicleGroup.DELETE("/:visit_id", c.DeleteServiceVisit)
@@ -37,7 +39,7 @@ func ServiceVisitRoutes(router *gin.Engine) {
 
 // CreateServiceVisit godoc
 // @Summary Create a new service visit
-// @Description Create a new service visit record for a user vehicle with optional services
+// @Description Create a new service visit record for a user vehicle with optional services. Specs are saved as sent; those that differ from the recommendation of the vehicle's generation come back as non-blocking warnings
 // @Tags Service Visits
 // @Accept json
 // @Produce json
@@ -127,7 +129,7 @@ func (c *ServiceVisitController) ListServiceVisits(ctx *gin.Context) {
 
 // UpdateServiceVisit godoc
 // @Summary Update service visit
-// @Description Update an existing service visit record
+// @Description Update an existing service visit record. Oil and filter specs that differ from the recommendation for the vehicle's generation come back as non-blocking warnings
 // @Tags Service Visits
 // @Accept json
 // @Produce json
@@ -217,3 +219,82 @@ func (c *ServiceVisitController) GetLastServiceVisit(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, response)
 }
+
+// GetServiceVisitDefaults godoc
+// @Summary Get defaults for a new service visit
+// @Description Get a service visit create request pre-filled with today's date, the current mileage and the oil viscosity, oil capacity and oil filter part number recommended for the vehicle's generation
+// @Tags Service Visits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} dto.CreateServiceVisitRequest
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/defaults [get]
+func (c *ServiceVisitController) GetServiceVisitDefaults(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.serviceVisitUseCase.GetServiceVisitDefaults(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ImportServiceVisits godoc
+// @Summary Import service history from a CSV or XLSX file
+// @Description Import past service visits from a spreadsheet whose header row names the service visit fields: service_date, service_mileage, service_center, service_center_id, notes, labour_cost, parts_cost, oil_* and filter_* for the oil change and filter (e.g. oil_name, filter_part_number) and service_items as "item_type:name" entries separated by ";". Dates may be Gregorian or Jalali (YYYY/MM/DD). Every row is validated and reported; nothing is imported unless all rows are valid. Set dry_run to only validate the file
+// @Tags Service Visits
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param file formData file true "CSV or XLSX file"
+// @Param dry_run query bool false "Validate without importing"
+// @Success 200 {object} dto.ImportServiceVisitsResponse "Dry run, or rows with errors"
+// @Success 201 {object} dto.ImportServiceVisitsResponse "Imported"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/service-visits/import [post]
+func (c *ServiceVisitController) ImportServiceVisits(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.ImportServiceVisitsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		logger.Error(err, "Failed to bind query")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		logger.Error(err, "Failed to get import file")
+		respondError(ctx, errors.ErrServiceVisitImportFileRequired)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error(err, "Failed to open import file")
+		respondError(ctx, errors.ErrFailedToImportServiceVisits)
+		return
+	}
+	defer file.Close()
+
+	response, err := c.serviceVisitUseCase.ImportServiceVisits(ctx, userID, vehicleID, fileHeader.Filename, fileHeader.Size, file, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if response.Imported {
+		ctx.JSON(http.StatusCreated, response)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
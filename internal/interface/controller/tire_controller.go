@@ -0,0 +1,403 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type TireController struct {
+	tireUseCase usecase.TireUseCase
+}
+
+func NewTireController() *TireController {
+	tireUseCase := usecase.NewTireUseCase()
+	return &TireController{tireUseCase: tireUseCase}
+}
+
+func TireRoutes(router *gin.Engine) {
+	c := NewTireController()
+	tireGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/tires")
+	tireGroup.Use(middleware.AuthMiddleware())
+	tireGroup.Use(middleware.RequireActiveUser())
+	{
+		tireGroup.POST("", c.CreateTireSet)
+		tireGroup.GET("", c.ListTireSets)
+		tireGroup.GET("/:tire_set_id", c.GetTireSet)
+		tireGroup.PUT("/:tire_set_id", c.UpdateTireSet)
+		tireGroup.DELETE("/:tire_set_id", c.DeleteTireSet)
+
+		// Seasonal swaps
+		tireGroup.POST("/:tire_set_id/mount", c.MountTireSet)
+		tireGroup.POST("/:tire_set_id/unmount", c.UnmountTireSet)
+
+		// Rotations and tread depths
+		tireGroup.POST("/:tire_set_id/rotations", c.CreateTireRotation)
+		tireGroup.DELETE("/:tire_set_id/rotations/:rotation_id", c.DeleteTireRotation)
+		tireGroup.POST("/:tire_set_id/tread-depths", c.CreateTreadDepth)
+		tireGroup.DELETE("/:tire_set_id/tread-depths/:measurement_id", c.DeleteTreadDepth)
+	}
+}
+
+// CreateTireSet godoc
+// @Summary Add a tire set
+// @Description Add a set of tires to a vehicle. A set created as mounted goes on at its purchase mileage and takes the place of the set currently on the vehicle
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param tire_set body dto.CreateTireSetRequest true "Tire set data"
+// @Success 201 {object} dto.TireSetDetailResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires [post]
+func (c *TireController) CreateTireSet(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.CreateTireSetRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.tireUseCase.CreateTireSet(ctx, userID, vehicleID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListTireSets godoc
+// @Summary List tire sets
+// @Description Get the tire sets of a vehicle, mounted set first, with the kilometres driven on each and a replace-soon warning based on tread depth or age. The tire sizes listed for the vehicle's generation come along as suggestions
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} dto.ListTireSetsResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires [get]
+func (c *TireController) ListTireSets(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.tireUseCase.ListTireSets(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetTireSet godoc
+// @Summary Get tire set by ID
+// @Description Get a tire set of a vehicle with its mount periods, rotations and tread depth measurements
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param tire_set_id path int true "Tire set ID"
+// @Success 200 {object} dto.TireSetDetailResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires/{tire_set_id} [get]
+func (c *TireController) GetTireSet(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	tireSetID := ctx.Param("tire_set_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.tireUseCase.GetTireSet(ctx, userID, vehicleID, tireSetID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateTireSet godoc
+// @Summary Update tire set
+// @Description Update the details of a tire set
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param tire_set_id path int true "Tire set ID"
+// @Param tire_set body dto.UpdateTireSetRequest true "Updated tire set data"
+// @Success 200 {object} dto.TireSetDetailResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires/{tire_set_id} [put]
+func (c *TireController) UpdateTireSet(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	tireSetID := ctx.Param("tire_set_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.UpdateTireSetRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.tireUseCase.UpdateTireSet(ctx, userID, vehicleID, tireSetID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteTireSet godoc
+// @Summary Delete tire set
+// @Description Delete a tire set with its mount periods, rotations and tread depth measurements
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param tire_set_id path int true "Tire set ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires/{tire_set_id} [delete]
+func (c *TireController) DeleteTireSet(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	tireSetID := ctx.Param("tire_set_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.tireUseCase.DeleteTireSet(ctx, userID, vehicleID, tireSetID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// MountTireSet godoc
+// @Summary Mount tire set
+// @Description Put a tire set on the vehicle, for example for a seasonal swap. The set currently on the vehicle comes off at the same mileage
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param tire_set_id path int true "Tire set ID"
+// @Param swap body dto.TireMountRequest true "Mileage and date of the swap"
+// @Success 200 {object} dto.TireSetDetailResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires/{tire_set_id}/mount [post]
+func (c *TireController) MountTireSet(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	tireSetID := ctx.Param("tire_set_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.TireMountRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.tireUseCase.MountTireSet(ctx, userID, vehicleID, tireSetID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UnmountTireSet godoc
+// @Summary Unmount tire set
+// @Description Take a tire set off the vehicle and put it in storage
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param tire_set_id path int true "Tire set ID"
+// @Param swap body dto.TireMountRequest true "Mileage and date the set came off"
+// @Success 200 {object} dto.TireSetDetailResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires/{tire_set_id}/unmount [post]
+func (c *TireController) UnmountTireSet(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	tireSetID := ctx.Param("tire_set_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.TireMountRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.tireUseCase.UnmountTireSet(ctx, userID, vehicleID, tireSetID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// CreateTireRotation godoc
+// @Summary Record a tire rotation
+// @Description Record a rotation of the tires of a set
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param tire_set_id path int true "Tire set ID"
+// @Param rotation body dto.CreateTireRotationRequest true "Rotation data"
+// @Success 201 {object} dto.TireRotationResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires/{tire_set_id}/rotations [post]
+func (c *TireController) CreateTireRotation(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	tireSetID := ctx.Param("tire_set_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.CreateTireRotationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.tireUseCase.CreateTireRotation(ctx, userID, vehicleID, tireSetID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// DeleteTireRotation godoc
+// @Summary Delete tire rotation
+// @Description Delete a rotation of a tire set
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param tire_set_id path int true "Tire set ID"
+// @Param rotation_id path int true "Rotation ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires/{tire_set_id}/rotations/{rotation_id} [delete]
+func (c *TireController) DeleteTireRotation(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	tireSetID := ctx.Param("tire_set_id")
+	rotationID := ctx.Param("rotation_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.tireUseCase.DeleteTireRotation(ctx, userID, vehicleID, tireSetID, rotationID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// CreateTreadDepth godoc
+// @Summary Record tread depths
+// @Description Record the tread depth of each tire of a set in millimetres. All four depths are required. The shallowest tread of the latest measurement decides the replace-soon warning
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param tire_set_id path int true "Tire set ID"
+// @Param measurement body dto.CreateTreadDepthRequest true "Tread depths"
+// @Success 201 {object} dto.TreadDepthResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires/{tire_set_id}/tread-depths [post]
+func (c *TireController) CreateTreadDepth(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	tireSetID := ctx.Param("tire_set_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.CreateTreadDepthRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.tireUseCase.CreateTreadDepth(ctx, userID, vehicleID, tireSetID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// DeleteTreadDepth godoc
+// @Summary Delete tread depth measurement
+// @Description Delete a tread depth measurement of a tire set
+// @Tags Tires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param tire_set_id path int true "Tire set ID"
+// @Param measurement_id path int true "Measurement ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/tires/{tire_set_id}/tread-depths/{measurement_id} [delete]
+func (c *TireController) DeleteTreadDepth(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	tireSetID := ctx.Param("tire_set_id")
+	measurementID := ctx.Param("measurement_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.tireUseCase.DeleteTreadDepth(ctx, userID, vehicleID, tireSetID, measurementID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
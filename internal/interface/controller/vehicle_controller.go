@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/amirdashtii/AutoBan/internal/dto"
 	"github.com/amirdashtii/AutoBan/internal/errors"
@@ -29,6 +31,12 @@ func VehicleRoutes(router *gin.Engine) {
 		// Complete hierarchy
 		vehicleGroup.GET("/hierarchy", c.GetCompleteHierarchy)
 
+		// Catalog changes since a version, for offline copies of the catalog
+		vehicleGroup.GET("/changes", c.ListCatalogChanges)
+
+		// Search across brands, models and generations
+		vehicleGroup.GET("/search", c.SearchVehicles)
+
 		// Vehicle Types
 		vehicleGroup.GET("/types", c.ListTypes)
 		vehicleGroup.GET("/types/:type_id", c.GetType)
@@ -56,6 +64,7 @@ func VehicleRoutes(router *gin.Engine) {
 		userVehicles.GET("/:vehicle_id", c.GetUserVehicle)
 		userVehicles.PUT("/:vehicle_id", c.UpdateUserVehicle)
 		userVehicles.DELETE("/:vehicle_id", c.DeleteUserVehicle)
+		userVehicles.GET("/:vehicle_id/service-book", c.ExportServiceBook)
 	}
 
 	// Admin routes for managing vehicle catalog
@@ -87,25 +96,107 @@ func VehicleRoutes(router *gin.Engine) {
 // Public endpoints
 
 // @Summary     Get complete vehicle hierarchy
-// @Description Get the complete vehicle hierarchy including all types, brands, models, and generations
+// @Description Get the complete vehicle hierarchy including all types, brands, models, and generations. The ETag is the catalog version; send it back in If-None-Match to get 304 while the catalog has not changed
 // @Tags        Hierarchy
 // @Accept      json
 // @Produce     json
+// @Param       If-None-Match header string false "ETag of the hierarchy the client has"
 // @Success     200 {object} dto.CompleteVehicleHierarchyResponse
+// @Success     304 "Not Modified"
 // @Failure     400 {object} errors.CustomError
 // @Failure     401 {object} errors.CustomError
 // @Failure     403 {object} errors.CustomError
 // @Failure     500 {object} errors.CustomError
 // @Router      /vehicles/hierarchy [get]
 func (c *VehicleController) GetCompleteHierarchy(ctx *gin.Context) {
+	version, err := c.vehicleUseCase.GetVehicleCatalogVersion(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if etagMatches(ctx.GetHeader("If-None-Match"), catalogETag(version)) {
+		ctx.Header("ETag", catalogETag(version))
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
 	hierarchy, err := c.vehicleUseCase.GetCompleteVehicleHierarchy(ctx)
 	if err != nil {
 		respondError(ctx, err)
 		return
 	}
+	ctx.Header("ETag", catalogETag(hierarchy.Version))
 	ctx.JSON(http.StatusOK, hierarchy)
 }
 
+// @Summary     List catalog changes
+// @Description Types, brands, models and generations created, updated or deleted since a catalog version, to keep an offline copy of the catalog. Start with since=0, which returns the whole catalog, and send the returned version as since on the next sync. Deleting a row also removes its children
+// @Tags        Hierarchy
+// @Accept      json
+// @Produce     json
+// @Param       since query int false "Catalog version the client has"
+// @Success     200 {object} dto.ListVehicleCatalogChangesResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /vehicles/changes [get]
+func (c *VehicleController) ListCatalogChanges(ctx *gin.Context) {
+	var request dto.ListVehicleCatalogChangesRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		logger.Error(err, "Failed to bind query")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	changes, err := c.vehicleUseCase.ListVehicleCatalogChanges(ctx, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, changes)
+}
+
+func catalogETag(version uint64) string {
+	return fmt.Sprintf(`"catalog-%d"`, version)
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, ignoring weak validators' W/ prefix
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// @Summary     Search the vehicle catalog
+// @Description Search brands, models and generations by Persian or English name, best match first. Arabic ي and ك, Persian and Arabic digits and zero-width non-joiners in the query are normalized, and small typos are tolerated. Every result carries its type, brand, model and generation
+// @Tags        Search
+// @Accept      json
+// @Produce     json
+// @Param       q query string true "Text to search for"
+// @Param       limit query int false "Maximum number of results (default 20, max 50)"
+// @Success     200 {object} dto.SearchVehiclesResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /vehicles/search [get]
+func (c *VehicleController) SearchVehicles(ctx *gin.Context) {
+	var request dto.SearchVehiclesRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		logger.Error(err, "Failed to bind query")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	results, err := c.vehicleUseCase.SearchVehicles(ctx, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, results)
+}
+
 // @Summary     List all vehicle types
 // @Description Get a list of all available vehicle types
 // @Tags        Types
@@ -424,6 +515,39 @@ func (c *VehicleController) DeleteUserVehicle(ctx *gin.Context) {
 
 }
 
+// @Summary     Export service book
+// @Description Download the vehicle's service book, with its details and every service visit in chronological order, as a PDF or CSV. Persian books are laid out right to left and dates can be written in the Jalali calendar
+// @Tags        User - Vehicles
+// @Produce     application/pdf,text/csv
+// @Security    BearerAuth
+// @Param       vehicle_id path string true "Vehicle ID"
+// @Param       format query string false "File format" Enums(pdf, csv) default(pdf)
+// @Param       calendar query string false "Calendar of dates" Enums(gregorian, jalali) default(gregorian)
+// @Param       language query string false "Language of labels" Enums(fa, en) default(fa)
+// @Success     200 {file} file
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /user/vehicles/{vehicle_id}/service-book [get]
+func (c *VehicleController) ExportServiceBook(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	vehicleID := ctx.Param("vehicle_id")
+
+	var request dto.ExportServiceBookRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		respondError(ctx, errors.ErrInvalidServiceBookExportRequest)
+		return
+	}
+	file, err := c.vehicleUseCase.ExportServiceBook(ctx, userID, vehicleID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.FileName))
+	ctx.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
 // Admin endpoints
 
 // @Summary     Create a new vehicle type
@@ -486,21 +610,31 @@ func (c *VehicleController) UpdateVehicleType(ctx *gin.Context) {
 }
 
 // @Summary     Delete a vehicle type
-// @Description Delete a vehicle type
+// @Description Delete a vehicle type. User vehicles on the generations under it block the deletion with 409 and the number of them in details.user_vehicles, unless reassign_to_generation_id moves them to another generation first
 // @Tags        Admin - Types
 // @Accept      json
 // @Security    BearerAuth
 // @Param       type_id path string true "Vehicle Type ID"
+// @Param       reassign_to_generation_id query int false "Generation to move the affected user vehicles to"
 // @Success     204
 // @Failure     400 {object} errors.CustomError
 // @Failure     401 {object} errors.CustomError
 // @Failure     403 {object} errors.CustomError
 // @Failure     404 {object} errors.CustomError
+// @Failure     409 {object} errors.CustomError
 // @Failure     500 {object} errors.CustomError
 // @Router      /admin/vehicles/types/{type_id} [delete]
 func (c *VehicleController) DeleteVehicleType(ctx *gin.Context) {
 	typeID := ctx.Param("type_id")
-	err := c.vehicleUseCase.DeleteVehicleType(ctx, typeID)
+
+	var request dto.DeleteVehicleCatalogRowRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		logger.Error(err, "Failed to bind query")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	err := c.vehicleUseCase.DeleteVehicleType(ctx, typeID, request)
 	if err != nil {
 		respondError(ctx, err)
 		return
@@ -570,23 +704,33 @@ func (c *VehicleController) UpdateBrand(ctx *gin.Context) {
 }
 
 // @Summary     Delete a vehicle brand
-// @Description Delete a vehicle brand
+// @Description Delete a vehicle brand. User vehicles on the generations under it block the deletion with 409 and the number of them in details.user_vehicles, unless reassign_to_generation_id moves them to another generation first
 // @Tags        Admin - Brands
 // @Accept      json
 // @Security    BearerAuth
 // @Param       type_id path string true "Vehicle Type ID"
 // @Param       brand_id path string true "Vehicle Brand ID"
+// @Param       reassign_to_generation_id query int false "Generation to move the affected user vehicles to"
 // @Success     204
 // @Failure     400 {object} errors.CustomError
 // @Failure     401 {object} errors.CustomError
 // @Failure     403 {object} errors.CustomError
 // @Failure     404 {object} errors.CustomError
+// @Failure     409 {object} errors.CustomError
 // @Failure     500 {object} errors.CustomError
 // @Router      /admin/vehicles/types/{type_id}/brands/{brand_id} [delete]
 func (c *VehicleController) DeleteBrand(ctx *gin.Context) {
 	typeID := ctx.Param("type_id")
 	brandID := ctx.Param("brand_id")
-	err := c.vehicleUseCase.DeleteBrand(ctx, typeID, brandID)
+
+	var request dto.DeleteVehicleCatalogRowRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		logger.Error(err, "Failed to bind query")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	err := c.vehicleUseCase.DeleteBrand(ctx, typeID, brandID, request)
 	if err != nil {
 		respondError(ctx, err)
 		return
@@ -660,25 +804,35 @@ func (c *VehicleController) UpdateModel(ctx *gin.Context) {
 }
 
 // @Summary     Delete a vehicle model
-// @Description Delete a vehicle model
+// @Description Delete a vehicle model. User vehicles on the generations under it block the deletion with 409 and the number of them in details.user_vehicles, unless reassign_to_generation_id moves them to another generation first
 // @Tags        Admin - Models
 // @Accept      json
 // @Security    BearerAuth
 // @Param       type_id path string true "Vehicle Type ID"
 // @Param       brand_id path string true "Vehicle Brand ID"
 // @Param       model_id path string true "Vehicle Model ID"
+// @Param       reassign_to_generation_id query int false "Generation to move the affected user vehicles to"
 // @Success     204
 // @Failure     400 {object} errors.CustomError
 // @Failure     401 {object} errors.CustomError
 // @Failure     403 {object} errors.CustomError
 // @Failure     404 {object} errors.CustomError
+// @Failure     409 {object} errors.CustomError
 // @Failure     500 {object} errors.CustomError
 // @Router      /admin/vehicles/types/{type_id}/brands/{brand_id}/models/{model_id} [delete]
 func (c *VehicleController) DeleteModel(ctx *gin.Context) {
 	typeID := ctx.Param("type_id")
 	brandID := ctx.Param("brand_id")
 	modelID := ctx.Param("model_id")
-	err := c.vehicleUseCase.DeleteModel(ctx, typeID, brandID, modelID)
+
+	var request dto.DeleteVehicleCatalogRowRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		logger.Error(err, "Failed to bind query")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	err := c.vehicleUseCase.DeleteModel(ctx, typeID, brandID, modelID, request)
 	if err != nil {
 		respondError(ctx, err)
 		return
@@ -756,7 +910,7 @@ func (c *VehicleController) UpdateGeneration(ctx *gin.Context) {
 }
 
 // @Summary     Delete a vehicle generation
-// @Description Delete a vehicle generation
+// @Description Delete a vehicle generation. User vehicles on the generation block the deletion with 409 and the number of them in details.user_vehicles, unless reassign_to_generation_id moves them to another generation first
 // @Tags        Admin - Generations
 // @Accept      json
 // @Security    BearerAuth
@@ -764,11 +918,13 @@ func (c *VehicleController) UpdateGeneration(ctx *gin.Context) {
 // @Param       brand_id path string true "Vehicle Brand ID"
 // @Param       model_id path string true "Vehicle Model ID"
 // @Param       generation_id path string true "Vehicle Generation ID"
+// @Param       reassign_to_generation_id query int false "Generation to move the affected user vehicles to"
 // @Success     204
 // @Failure     400 {object} errors.CustomError
 // @Failure     401 {object} errors.CustomError
 // @Failure     403 {object} errors.CustomError
 // @Failure     404 {object} errors.CustomError
+// @Failure     409 {object} errors.CustomError
 // @Failure     500 {object} errors.CustomError
 // @Router      /admin/vehicles/types/{type_id}/brands/{brand_id}/models/{model_id}/generations/{generation_id} [delete]
 func (c *VehicleController) DeleteGeneration(ctx *gin.Context) {
@@ -776,7 +932,15 @@ func (c *VehicleController) DeleteGeneration(ctx *gin.Context) {
 	brandID := ctx.Param("brand_id")
 	modelID := ctx.Param("model_id")
 	generationID := ctx.Param("generation_id")
-	err := c.vehicleUseCase.DeleteGeneration(ctx, typeID, brandID, modelID, generationID)
+
+	var request dto.DeleteVehicleCatalogRowRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		logger.Error(err, "Failed to bind query")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	err := c.vehicleUseCase.DeleteGeneration(ctx, typeID, brandID, modelID, generationID, request)
 	if err != nil {
 		respondError(ctx, err)
 		return
@@ -0,0 +1,296 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type VehicleDocumentController struct {
+	vehicleDocumentUseCase usecase.VehicleDocumentUseCase
+}
+
+func NewVehicleDocumentController() *VehicleDocumentController {
+	vehicleDocumentUseCase := usecase.NewVehicleDocumentUseCase()
+	return &VehicleDocumentController{vehicleDocumentUseCase: vehicleDocumentUseCase}
+}
+
+func VehicleDocumentRoutes(router *gin.Engine) {
+	c := NewVehicleDocumentController()
+	documentGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/documents")
+	documentGroup.Use(middleware.AuthMiddleware())
+	documentGroup.Use(middleware.RequireActiveUser())
+	{
+		documentGroup.POST("", c.CreateVehicleDocument)
+		documentGroup.GET("", c.ListVehicleDocuments)
+		documentGroup.GET("/:document_id", c.GetVehicleDocument)
+		documentGroup.PUT("/:document_id", c.UpdateVehicleDocument)
+		documentGroup.DELETE("/:document_id", c.DeleteVehicleDocument)
+		documentGroup.PUT("/:document_id/scan", c.UploadVehicleDocumentScan)
+		documentGroup.GET("/:document_id/scan", c.DownloadVehicleDocumentScan)
+		documentGroup.DELETE("/:document_id/scan", c.DeleteVehicleDocumentScan)
+	}
+}
+
+// CreateVehicleDocument godoc
+// @Summary Record a vehicle document
+// @Description Record a third-party insurance, body insurance or technical inspection document. The owner is reminded before it expires
+// @Tags Vehicle Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param document body dto.CreateVehicleDocumentRequest true "Document data"
+// @Success 201 {object} dto.VehicleDocumentResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/documents [post]
+func (c *VehicleDocumentController) CreateVehicleDocument(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.CreateVehicleDocumentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleDocumentUseCase.CreateVehicleDocument(ctx, userID, vehicleID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListVehicleDocuments godoc
+// @Summary List vehicle documents
+// @Description Get the insurance and inspection documents of a vehicle, latest expiry first, with the days left until each expires
+// @Tags Vehicle Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} dto.ListVehicleDocumentsResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/documents [get]
+func (c *VehicleDocumentController) ListVehicleDocuments(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleDocumentUseCase.ListVehicleDocuments(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetVehicleDocument godoc
+// @Summary Get a vehicle document
+// @Description Get the details of an insurance or inspection document
+// @Tags Vehicle Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param document_id path int true "Document ID"
+// @Success 200 {object} dto.VehicleDocumentResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/documents/{document_id} [get]
+func (c *VehicleDocumentController) GetVehicleDocument(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	documentID := ctx.Param("document_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleDocumentUseCase.GetVehicleDocument(ctx, userID, vehicleID, documentID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateVehicleDocument godoc
+// @Summary Update a vehicle document
+// @Description Update an insurance or inspection document
+// @Tags Vehicle Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param document_id path int true "Document ID"
+// @Param document body dto.UpdateVehicleDocumentRequest true "Document data"
+// @Success 200 {object} dto.VehicleDocumentResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/documents/{document_id} [put]
+func (c *VehicleDocumentController) UpdateVehicleDocument(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	documentID := ctx.Param("document_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.UpdateVehicleDocumentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleDocumentUseCase.UpdateVehicleDocument(ctx, userID, vehicleID, documentID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteVehicleDocument godoc
+// @Summary Delete a vehicle document
+// @Description Delete an insurance or inspection document and its scan
+// @Tags Vehicle Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param document_id path int true "Document ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/documents/{document_id} [delete]
+func (c *VehicleDocumentController) DeleteVehicleDocument(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	documentID := ctx.Param("document_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.vehicleDocumentUseCase.DeleteVehicleDocument(ctx, userID, vehicleID, documentID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// UploadVehicleDocumentScan godoc
+// @Summary Upload the scan of a vehicle document
+// @Description Upload a photo (JPEG, PNG, WebP) or PDF of the document, replacing any earlier scan. Scans count towards the attachment quota
+// @Tags Vehicle Documents
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param document_id path int true "Document ID"
+// @Param file formData file true "Scan of the document"
+// @Success 200 {object} dto.VehicleDocumentResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/documents/{document_id}/scan [put]
+func (c *VehicleDocumentController) UploadVehicleDocumentScan(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	documentID := ctx.Param("document_id")
+	userID := ctx.GetString("user_id")
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle document scan file")
+		respondError(ctx, errors.ErrAttachmentFileRequired)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error(err, "Failed to open vehicle document scan file")
+		respondError(ctx, errors.ErrFailedToUploadVehicleDocumentScan)
+		return
+	}
+	defer file.Close()
+
+	response, err := c.vehicleDocumentUseCase.UploadVehicleDocumentScan(ctx, userID, vehicleID, documentID, fileHeader.Filename, fileHeader.Size, file)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DownloadVehicleDocumentScan godoc
+// @Summary Download the scan of a vehicle document
+// @Description Download the uploaded scan of an insurance or inspection document
+// @Tags Vehicle Documents
+// @Produce application/pdf,image/jpeg,image/png,image/webp
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param document_id path int true "Document ID"
+// @Success 200 {file} file
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/documents/{document_id}/scan [get]
+func (c *VehicleDocumentController) DownloadVehicleDocumentScan(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	documentID := ctx.Param("document_id")
+	userID := ctx.GetString("user_id")
+
+	scan, content, err := c.vehicleDocumentUseCase.DownloadVehicleDocumentScan(ctx, userID, vehicleID, documentID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	defer content.Close()
+
+	extraHeaders := map[string]string{
+		"Content-Disposition": fmt.Sprintf("inline; filename=%q", scan.FileName),
+	}
+	ctx.DataFromReader(http.StatusOK, scan.Size, scan.ContentType, content, extraHeaders)
+}
+
+// DeleteVehicleDocumentScan godoc
+// @Summary Delete the scan of a vehicle document
+// @Description Delete the uploaded scan of a document, keeping the document itself
+// @Tags Vehicle Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param document_id path int true "Document ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/documents/{document_id}/scan [delete]
+func (c *VehicleDocumentController) DeleteVehicleDocumentScan(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	documentID := ctx.Param("document_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.vehicleDocumentUseCase.DeleteVehicleDocumentScan(ctx, userID, vehicleID, documentID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
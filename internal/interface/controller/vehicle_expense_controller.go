@@ -0,0 +1,316 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type VehicleExpenseController struct {
+	vehicleExpenseUseCase usecase.VehicleExpenseUseCase
+}
+
+func NewVehicleExpenseController() *VehicleExpenseController {
+	vehicleExpenseUseCase := usecase.NewVehicleExpenseUseCase()
+	return &VehicleExpenseController{vehicleExpenseUseCase: vehicleExpenseUseCase}
+}
+
+func VehicleExpenseRoutes(router *gin.Engine) {
+	c := NewVehicleExpenseController()
+
+	// Expenses of a vehicle
+	expenseGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/expenses")
+	expenseGroup.Use(middleware.AuthMiddleware())
+	expenseGroup.Use(middleware.RequireActiveUser())
+	{
+		expenseGroup.POST("", c.CreateVehicleExpense)
+		expenseGroup.GET("", c.ListVehicleExpenses)
+		expenseGroup.GET("/:expense_id", c.GetVehicleExpense)
+		expenseGroup.PUT("/:expense_id", c.UpdateVehicleExpense)
+		expenseGroup.DELETE("/:expense_id", c.DeleteVehicleExpense)
+	}
+
+	// Expense categories of the current user
+	categoryGroup := router.Group("/api/v1/user/expense-categories")
+	categoryGroup.Use(middleware.AuthMiddleware())
+	categoryGroup.Use(middleware.RequireActiveUser())
+	{
+		categoryGroup.GET("", c.ListExpenseCategories)
+		categoryGroup.POST("", c.CreateExpenseCategory)
+		categoryGroup.PUT("/:category_id", c.UpdateExpenseCategory)
+		categoryGroup.DELETE("/:category_id", c.DeleteExpenseCategory)
+	}
+}
+
+// CreateVehicleExpense godoc
+// @Summary Record an expense
+// @Description Record a running cost of a vehicle such as parking, a toll, a car wash, registration fees or a fine. Give either a built-in category or the id of one of your own categories
+// @Tags Vehicle Expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param expense body dto.CreateVehicleExpenseRequest true "Expense data"
+// @Success 201 {object} dto.VehicleExpenseResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/expenses [post]
+func (c *VehicleExpenseController) CreateVehicleExpense(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.CreateVehicleExpenseRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleExpenseUseCase.CreateVehicleExpense(ctx, userID, vehicleID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListVehicleExpenses godoc
+// @Summary List expenses
+// @Description Get the expenses of a vehicle, newest first
+// @Tags Vehicle Expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} dto.ListVehicleExpensesResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/expenses [get]
+func (c *VehicleExpenseController) ListVehicleExpenses(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleExpenseUseCase.ListVehicleExpenses(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetVehicleExpense godoc
+// @Summary Get expense by ID
+// @Description Get a specific expense of a vehicle
+// @Tags Vehicle Expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param expense_id path int true "Expense ID"
+// @Success 200 {object} dto.VehicleExpenseResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/expenses/{expense_id} [get]
+func (c *VehicleExpenseController) GetVehicleExpense(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	expenseID := ctx.Param("expense_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleExpenseUseCase.GetVehicleExpense(ctx, userID, vehicleID, expenseID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateVehicleExpense godoc
+// @Summary Update expense
+// @Description Update an expense of a vehicle. Setting a built-in category clears the custom one and the other way round
+// @Tags Vehicle Expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param expense_id path int true "Expense ID"
+// @Param expense body dto.UpdateVehicleExpenseRequest true "Updated expense data"
+// @Success 200 {object} dto.VehicleExpenseResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/expenses/{expense_id} [put]
+func (c *VehicleExpenseController) UpdateVehicleExpense(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	expenseID := ctx.Param("expense_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.UpdateVehicleExpenseRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleExpenseUseCase.UpdateVehicleExpense(ctx, userID, vehicleID, expenseID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteVehicleExpense godoc
+// @Summary Delete expense
+// @Description Delete an expense of a vehicle
+// @Tags Vehicle Expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param expense_id path int true "Expense ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/expenses/{expense_id} [delete]
+func (c *VehicleExpenseController) DeleteVehicleExpense(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	expenseID := ctx.Param("expense_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.vehicleExpenseUseCase.DeleteVehicleExpense(ctx, userID, vehicleID, expenseID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListExpenseCategories godoc
+// @Summary List expense categories
+// @Description Get the built-in expense categories and your own
+// @Tags Vehicle Expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ListExpenseCategoriesResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/expense-categories [get]
+func (c *VehicleExpenseController) ListExpenseCategories(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleExpenseUseCase.ListExpenseCategories(ctx, userID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// CreateExpenseCategory godoc
+// @Summary Create expense category
+// @Description Define your own expense category for costs the built-in categories don't cover
+// @Tags Vehicle Expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category body dto.ExpenseCategoryRequest true "Category data"
+// @Success 201 {object} dto.ExpenseCategoryResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/expense-categories [post]
+func (c *VehicleExpenseController) CreateExpenseCategory(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	var request dto.ExpenseCategoryRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleExpenseUseCase.CreateExpenseCategory(ctx, userID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// UpdateExpenseCategory godoc
+// @Summary Rename expense category
+// @Description Rename one of your own expense categories
+// @Tags Vehicle Expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category_id path int true "Category ID"
+// @Param category body dto.ExpenseCategoryRequest true "Category data"
+// @Success 200 {object} dto.ExpenseCategoryResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/expense-categories/{category_id} [put]
+func (c *VehicleExpenseController) UpdateExpenseCategory(ctx *gin.Context) {
+	categoryID := ctx.Param("category_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.ExpenseCategoryRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleExpenseUseCase.UpdateExpenseCategory(ctx, userID, categoryID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteExpenseCategory godoc
+// @Summary Delete expense category
+// @Description Delete one of your own expense categories. A category still used by expenses can't be deleted
+// @Tags Vehicle Expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category_id path int true "Category ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/expense-categories/{category_id} [delete]
+func (c *VehicleExpenseController) DeleteExpenseCategory(ctx *gin.Context) {
+	categoryID := ctx.Param("category_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.vehicleExpenseUseCase.DeleteExpenseCategory(ctx, userID, categoryID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,244 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type VehicleMembershipController struct {
+	vehicleMembershipUseCase usecase.VehicleMembershipUseCase
+}
+
+func NewVehicleMembershipController() *VehicleMembershipController {
+	vehicleMembershipUseCase := usecase.NewVehicleMembershipUseCase()
+	return &VehicleMembershipController{vehicleMembershipUseCase: vehicleMembershipUseCase}
+}
+
+func VehicleMembershipRoutes(router *gin.Engine) {
+	c := NewVehicleMembershipController()
+	memberGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/members")
+	memberGroup.Use(middleware.AuthMiddleware())
+	memberGroup.Use(middleware.RequireActiveUser())
+	{
+		memberGroup.POST("", c.InviteVehicleMember)
+		memberGroup.GET("", c.ListVehicleMembers)
+		memberGroup.PUT("/:membership_id", c.UpdateVehicleMember)
+		memberGroup.DELETE("/:membership_id", c.RemoveVehicleMember)
+	}
+
+	membershipGroup := router.Group("/api/v1/user/vehicle-memberships")
+	membershipGroup.Use(middleware.AuthMiddleware())
+	membershipGroup.Use(middleware.RequireActiveUser())
+	{
+		membershipGroup.GET("", c.ListVehicleMemberships)
+		membershipGroup.POST("/:membership_id/accept", c.AcceptVehicleMembership)
+		membershipGroup.DELETE("/:membership_id", c.LeaveVehicleMembership)
+	}
+}
+
+// InviteVehicleMember godoc
+// @Summary Share a vehicle
+// @Description Invite the user of a phone number to a vehicle as an editor, who can log and change services, or a viewer, who can only read the history. Only the owner can invite
+// @Tags Vehicle Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param member body dto.InviteVehicleMemberRequest true "Invitee and role"
+// @Success 201 {object} dto.VehicleMemberResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/members [post]
+func (c *VehicleMembershipController) InviteVehicleMember(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.InviteVehicleMemberRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleMembershipUseCase.InviteVehicleMember(ctx, userID, vehicleID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListVehicleMembers godoc
+// @Summary List vehicle members
+// @Description Get the owner of a vehicle and the users it is shared with, including pending invitations. Phone numbers are masked unless the caller is the owner
+// @Tags Vehicle Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} dto.ListVehicleMembersResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/members [get]
+func (c *VehicleMembershipController) ListVehicleMembers(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleMembershipUseCase.ListVehicleMembers(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateVehicleMember godoc
+// @Summary Change a member's role
+// @Description Change the role of a vehicle member. Only the owner can change roles
+// @Tags Vehicle Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param membership_id path int true "Membership ID"
+// @Param member body dto.UpdateVehicleMemberRequest true "New role"
+// @Success 200 {object} dto.VehicleMemberResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/members/{membership_id} [put]
+func (c *VehicleMembershipController) UpdateVehicleMember(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	membershipID := ctx.Param("membership_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.UpdateVehicleMemberRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleMembershipUseCase.UpdateVehicleMember(ctx, userID, vehicleID, membershipID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RemoveVehicleMember godoc
+// @Summary Remove a vehicle member
+// @Description Stop sharing a vehicle with a member or withdraw an invitation. Only the owner can remove members
+// @Tags Vehicle Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param membership_id path int true "Membership ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/members/{membership_id} [delete]
+func (c *VehicleMembershipController) RemoveVehicleMember(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	membershipID := ctx.Param("membership_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.vehicleMembershipUseCase.RemoveVehicleMember(ctx, userID, vehicleID, membershipID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListVehicleMemberships godoc
+// @Summary List shared vehicles
+// @Description Get the vehicles shared with the current user and the invitations sent to their phone number
+// @Tags Vehicle Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ListVehicleMembershipsResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicle-memberships [get]
+func (c *VehicleMembershipController) ListVehicleMemberships(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleMembershipUseCase.ListVehicleMemberships(ctx, userID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// AcceptVehicleMembership godoc
+// @Summary Accept a vehicle invitation
+// @Description Accept an invitation to a vehicle sent to the current user's phone number
+// @Tags Vehicle Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param membership_id path int true "Membership ID"
+// @Success 200 {object} dto.VehicleMembershipResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicle-memberships/{membership_id}/accept [post]
+func (c *VehicleMembershipController) AcceptVehicleMembership(ctx *gin.Context) {
+	membershipID := ctx.Param("membership_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleMembershipUseCase.AcceptVehicleMembership(ctx, userID, membershipID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// LeaveVehicleMembership godoc
+// @Summary Leave a shared vehicle
+// @Description Decline an invitation to a vehicle or stop being a member of it
+// @Tags Vehicle Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param membership_id path int true "Membership ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicle-memberships/{membership_id} [delete]
+func (c *VehicleMembershipController) LeaveVehicleMembership(ctx *gin.Context) {
+	membershipID := ctx.Param("membership_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.vehicleMembershipUseCase.LeaveVehicleMembership(ctx, userID, membershipID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
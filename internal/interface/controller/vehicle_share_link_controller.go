@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/config"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type VehicleShareLinkController struct {
+	vehicleShareLinkUseCase usecase.VehicleShareLinkUseCase
+}
+
+func NewVehicleShareLinkController() *VehicleShareLinkController {
+	vehicleShareLinkUseCase := usecase.NewVehicleShareLinkUseCase()
+	return &VehicleShareLinkController{vehicleShareLinkUseCase: vehicleShareLinkUseCase}
+}
+
+func VehicleShareLinkRoutes(router *gin.Engine) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		logger.Fatalf("Failed to load config: %v", err)
+		return
+	}
+
+	c := NewVehicleShareLinkController()
+	shareLinkGroup := router.Group("/api/v1/user/vehicles/:vehicle_id/share-links")
+	shareLinkGroup.Use(middleware.AuthMiddleware())
+	shareLinkGroup.Use(middleware.RequireActiveUser())
+	{
+		shareLinkGroup.POST("", c.CreateVehicleShareLink)
+		shareLinkGroup.GET("", c.ListVehicleShareLinks)
+		shareLinkGroup.DELETE("/:link_id", c.RevokeVehicleShareLink)
+	}
+
+	// Shared views need no login, so they are rate limited per client instead
+	sharedGroup := router.Group("/api/v1/shared/vehicles")
+	sharedGroup.Use(middleware.RateLimit("shared_vehicles", cfg.ShareLink.RateLimitPerMinute, time.Minute))
+	{
+		sharedGroup.GET("/:token", c.GetSharedVehicleHistory)
+	}
+}
+
+// CreateVehicleShareLink godoc
+// @Summary Share a vehicle's service history
+// @Description Create a signed, read-only link to the vehicle and its service visits that anyone can open without logging in. The link expires after expires_in_days (30 by default) and can hide the VIN, licence plate and costs. Only the owner can share a vehicle
+// @Tags Vehicle Share Links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param share_link body dto.CreateVehicleShareLinkRequest true "Share link"
+// @Success 201 {object} dto.VehicleShareLinkResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/share-links [post]
+func (c *VehicleShareLinkController) CreateVehicleShareLink(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.CreateVehicleShareLinkRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleShareLinkUseCase.CreateVehicleShareLink(ctx, userID, vehicleID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListVehicleShareLinks godoc
+// @Summary List vehicle share links
+// @Description Get the share links of the vehicle, newest first, with their status and how many times they were opened
+// @Tags Vehicle Share Links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} dto.ListVehicleShareLinksResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/share-links [get]
+func (c *VehicleShareLinkController) ListVehicleShareLinks(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleShareLinkUseCase.ListVehicleShareLinks(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RevokeVehicleShareLink godoc
+// @Summary Revoke a vehicle share link
+// @Description Revoke a share link so it can no longer be opened
+// @Tags Vehicle Share Links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param link_id path int true "Share link ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/share-links/{link_id} [delete]
+func (c *VehicleShareLinkController) RevokeVehicleShareLink(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	linkID := ctx.Param("link_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.vehicleShareLinkUseCase.RevokeVehicleShareLink(ctx, userID, vehicleID, linkID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetSharedVehicleHistory godoc
+// @Summary Open a vehicle share link
+// @Description Get the vehicle and its service visits behind a share link. No login is needed. Fields the owner hid are left out, attachments are never included and requests are rate limited per IP
+// @Tags Vehicle Share Links
+// @Accept json
+// @Produce json
+// @Param token path string true "Share link token"
+// @Success 200 {object} dto.SharedVehicleHistoryResponse
+// @Failure 404 {object} errors.CustomError
+// @Failure 429 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /shared/vehicles/{token} [get]
+func (c *VehicleShareLinkController) GetSharedVehicleHistory(ctx *gin.Context) {
+	token := ctx.Param("token")
+
+	response, err := c.vehicleShareLinkUseCase.GetSharedVehicleHistory(ctx, token)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
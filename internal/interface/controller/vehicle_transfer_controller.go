@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type VehicleTransferController struct {
+	vehicleTransferUseCase usecase.VehicleTransferUseCase
+}
+
+func NewVehicleTransferController() *VehicleTransferController {
+	vehicleTransferUseCase := usecase.NewVehicleTransferUseCase()
+	return &VehicleTransferController{vehicleTransferUseCase: vehicleTransferUseCase}
+}
+
+func VehicleTransferRoutes(router *gin.Engine) {
+	c := NewVehicleTransferController()
+	vehicleGroup := router.Group("/api/v1/user/vehicles/:vehicle_id")
+	vehicleGroup.Use(middleware.AuthMiddleware())
+	vehicleGroup.Use(middleware.RequireActiveUser())
+	{
+		vehicleGroup.POST("/transfers", c.StartVehicleTransfer)
+		vehicleGroup.DELETE("/transfers/:transfer_id", c.CancelVehicleTransfer)
+		vehicleGroup.GET("/ownership-history", c.GetVehicleOwnershipHistory)
+	}
+
+	transferGroup := router.Group("/api/v1/user/vehicle-transfers")
+	transferGroup.Use(middleware.AuthMiddleware())
+	transferGroup.Use(middleware.RequireActiveUser())
+	{
+		transferGroup.GET("/incoming", c.ListIncomingVehicleTransfers)
+		transferGroup.POST("/:transfer_id/confirm", c.ConfirmVehicleTransfer)
+	}
+}
+
+// StartVehicleTransfer godoc
+// @Summary Transfer a vehicle
+// @Description Start transferring a vehicle, with all of its service history, to the user registered with the phone number. The recipient gets a confirmation code by SMS that expires after 30 minutes. Unavailable until sms.transfer_template_id is configured
+// @Tags Vehicle Transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param transfer body dto.StartVehicleTransferRequest true "Recipient"
+// @Success 201 {object} dto.VehicleTransferResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Failure 503 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/transfers [post]
+func (c *VehicleTransferController) StartVehicleTransfer(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.StartVehicleTransferRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleTransferUseCase.StartVehicleTransfer(ctx, userID, vehicleID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// CancelVehicleTransfer godoc
+// @Summary Cancel a vehicle transfer
+// @Description Cancel a transfer of a vehicle that the recipient has not confirmed yet
+// @Tags Vehicle Transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Param transfer_id path int true "Transfer ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/transfers/{transfer_id} [delete]
+func (c *VehicleTransferController) CancelVehicleTransfer(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	transferID := ctx.Param("transfer_id")
+	userID := ctx.GetString("user_id")
+
+	err := c.vehicleTransferUseCase.CancelVehicleTransfer(ctx, userID, vehicleID, transferID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetVehicleOwnershipHistory godoc
+// @Summary Vehicle ownership history
+// @Description Get who owned the vehicle and from when to when, oldest owner first
+// @Tags Vehicle Transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_id path string true "Vehicle ID"
+// @Success 200 {object} dto.ListVehicleOwnershipsResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 403 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicles/{vehicle_id}/ownership-history [get]
+func (c *VehicleTransferController) GetVehicleOwnershipHistory(ctx *gin.Context) {
+	vehicleID := ctx.Param("vehicle_id")
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleTransferUseCase.GetVehicleOwnershipHistory(ctx, userID, vehicleID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ListIncomingVehicleTransfers godoc
+// @Summary List incoming vehicle transfers
+// @Description Get the pending transfers of vehicles to the current user
+// @Tags Vehicle Transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ListVehicleTransfersResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicle-transfers/incoming [get]
+func (c *VehicleTransferController) ListIncomingVehicleTransfers(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	response, err := c.vehicleTransferUseCase.ListIncomingVehicleTransfers(ctx, userID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ConfirmVehicleTransfer godoc
+// @Summary Confirm a vehicle transfer
+// @Description Confirm a transfer with the code sent by SMS. The vehicle and its service visits, oil changes and oil filters move to the current user. The transfer is cancelled after 5 wrong codes
+// @Tags Vehicle Transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param transfer_id path int true "Transfer ID"
+// @Param confirmation body dto.ConfirmVehicleTransferRequest true "Confirmation code"
+// @Success 200 {object} dto.VehicleTransferResponse
+// @Failure 400 {object} errors.CustomError
+// @Failure 401 {object} errors.CustomError
+// @Failure 404 {object} errors.CustomError
+// @Failure 500 {object} errors.CustomError
+// @Router /user/vehicle-transfers/{transfer_id}/confirm [post]
+func (c *VehicleTransferController) ConfirmVehicleTransfer(ctx *gin.Context) {
+	transferID := ctx.Param("transfer_id")
+	userID := ctx.GetString("user_id")
+
+	var request dto.ConfirmVehicleTransferRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	response, err := c.vehicleTransferUseCase.ConfirmVehicleTransfer(ctx, userID, transferID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
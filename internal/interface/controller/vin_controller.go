@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/middleware"
+	"github.com/amirdashtii/AutoBan/internal/usecase"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type VINController struct {
+	vinUseCase usecase.VINUseCase
+}
+
+func NewVINController() *VINController {
+	vinUseCase := usecase.NewVINUseCase()
+	return &VINController{vinUseCase: vinUseCase}
+}
+
+func VINRoutes(router *gin.Engine) {
+	c := NewVINController()
+
+	// Decoding a VIN while adding a vehicle (requires authentication)
+	userVehicles := router.Group("/api/v1/user/vehicles")
+	userVehicles.Use(middleware.AuthMiddleware())
+	userVehicles.Use(middleware.RequireActiveUser())
+	{
+		userVehicles.GET("/decode-vin", c.DecodeVIN)
+	}
+
+	// Admin routes for managing the WMI table
+	adminWMIGroup := router.Group("/api/v1/admin/vehicles/wmis")
+	adminWMIGroup.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+	{
+		adminWMIGroup.GET("", c.ListVehicleWMIs)
+		adminWMIGroup.POST("", c.CreateVehicleWMI)
+		adminWMIGroup.PUT("/:wmi_id", c.UpdateVehicleWMI)
+		adminWMIGroup.DELETE("/:wmi_id", c.DeleteVehicleWMI)
+	}
+}
+
+// @Summary     Decode a VIN
+// @Description Split a VIN into its WMI, vehicle descriptor section, check digit, model year, plant code and serial number, and look up the catalog brand and model of the WMI. Suggests the generation_id and production_year to add the vehicle with
+// @Tags        User - Vehicles
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       vin query string true "VIN"
+// @Success     200 {object} dto.DecodeVINResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /user/vehicles/decode-vin [get]
+func (c *VINController) DecodeVIN(ctx *gin.Context) {
+	var request dto.DecodeVINRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		logger.Error(err, "Failed to bind query")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	decoded, err := c.vinUseCase.DecodeVIN(ctx, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, decoded)
+}
+
+// @Summary     List WMIs
+// @Description List the World Manufacturer Identifiers the VIN decoder maps to catalog brands and models
+// @Tags        Admin - VIN Decoder
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} dto.ListVehicleWMIsResponse
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/wmis [get]
+func (c *VINController) ListVehicleWMIs(ctx *gin.Context) {
+	wmis, err := c.vinUseCase.ListVehicleWMIs(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, wmis)
+}
+
+// @Summary     Create a WMI
+// @Description Map a World Manufacturer Identifier to a catalog brand. Manufacturers with several brands or models under one WMI, such as IKCO (NAA) and SAIPA (NAS), get one mapping per VDS prefix; the longest matching prefix wins
+// @Tags        Admin - VIN Decoder
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       wmi body dto.CreateVehicleWMIRequest true "WMI"
+// @Success     201 {object} dto.VehicleWMIResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/wmis [post]
+func (c *VINController) CreateVehicleWMI(ctx *gin.Context) {
+	var request dto.CreateVehicleWMIRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	wmi, err := c.vinUseCase.CreateVehicleWMI(ctx, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, wmi)
+}
+
+// @Summary     Update a WMI
+// @Description Update a WMI mapping of the VIN decoder
+// @Tags        Admin - VIN Decoder
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       wmi_id path int true "WMI mapping ID"
+// @Param       wmi body dto.UpdateVehicleWMIRequest true "WMI"
+// @Success     200 {object} dto.VehicleWMIResponse
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/wmis/{wmi_id} [put]
+func (c *VINController) UpdateVehicleWMI(ctx *gin.Context) {
+	wmiID := ctx.Param("wmi_id")
+
+	var request dto.UpdateVehicleWMIRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		logger.Error(err, "Failed to bind JSON")
+		respondError(ctx, errors.ErrBadRequest)
+		return
+	}
+
+	wmi, err := c.vinUseCase.UpdateVehicleWMI(ctx, wmiID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, wmi)
+}
+
+// @Summary     Delete a WMI
+// @Description Remove a WMI mapping from the VIN decoder
+// @Tags        Admin - VIN Decoder
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       wmi_id path int true "WMI mapping ID"
+// @Success     204 "No Content"
+// @Failure     400 {object} errors.CustomError
+// @Failure     401 {object} errors.CustomError
+// @Failure     403 {object} errors.CustomError
+// @Failure     404 {object} errors.CustomError
+// @Failure     500 {object} errors.CustomError
+// @Router      /admin/vehicles/wmis/{wmi_id} [delete]
+func (c *VINController) DeleteVehicleWMI(ctx *gin.Context) {
+	wmiID := ctx.Param("wmi_id")
+	err := c.vinUseCase.DeleteVehicleWMI(ctx, wmiID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
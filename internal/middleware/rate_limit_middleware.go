@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit allows each client IP at most limit requests per window to the routes it guards. Counters
+// live in Redis so the limit holds across instances; requests are let through when Redis is unavailable
+func RateLimit(name string, limit int, window time.Duration) gin.HandlerFunc {
+	cache := repository.NewCacheRepository()
+
+	return func(c *gin.Context) {
+		windowStart := time.Now().Truncate(window)
+		key := repository.BuildCacheKey(repository.CacheKeyRateLimit, name, c.ClientIP(), strconv.FormatInt(windowStart.Unix(), 10))
+
+		count, err := cache.Increment(c, key, window)
+		if err != nil {
+			logger.Error(err, "Failed to count request for rate limit")
+			c.Next()
+			return
+		}
+		if count > int64(limit) {
+			retryAfter := time.Until(windowStart.Add(window))
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			logger.Error(errors.ErrTooManyRequests, "Rate limit exceeded for "+name)
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": errors.ErrTooManyRequests})
+			return
+		}
+		c.Next()
+	}
+}
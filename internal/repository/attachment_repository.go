@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AttachmentRepository interface {
+	CreateAttachmentWithinQuota(ctx context.Context, attachment *entity.ServiceVisitAttachment, quota int64) error
+	GetAttachment(ctx context.Context, id uint64, attachment *entity.ServiceVisitAttachment) error
+	ListServiceVisitAttachments(ctx context.Context, serviceVisitID uuid.UUID, attachments *[]entity.ServiceVisitAttachment) error
+	DeleteAttachment(ctx context.Context, attachment *entity.ServiceVisitAttachment) error
+	DeleteServiceVisitAttachments(ctx context.Context, serviceVisitID uuid.UUID) error
+	GetUserAttachmentUsage(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository() AttachmentRepository {
+	db := database.ConnectDatabase()
+	return &attachmentRepository{db: db}
+}
+
+// CreateAttachmentWithinQuota saves the attachment unless it would take the user past the quota, in which case
+// ErrAttachmentQuotaExceeded is returned
+func (r *attachmentRepository) CreateAttachmentWithinQuota(ctx context.Context, attachment *entity.ServiceVisitAttachment, quota int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		usage, err := lockUserAttachmentUsage(tx, attachment.UserID)
+		if err != nil {
+			return err
+		}
+		if usage+attachment.Size > quota {
+			return errors.ErrAttachmentQuotaExceeded
+		}
+		return tx.Create(attachment).Error
+	})
+}
+
+func (r *attachmentRepository) GetAttachment(ctx context.Context, id uint64, attachment *entity.ServiceVisitAttachment) error {
+	return r.db.WithContext(ctx).First(attachment, id).Error
+}
+
+func (r *attachmentRepository) ListServiceVisitAttachments(ctx context.Context, serviceVisitID uuid.UUID, attachments *[]entity.ServiceVisitAttachment) error {
+	return r.db.WithContext(ctx).Where("service_visit_id = ?", serviceVisitID).Order("id").Find(attachments).Error
+}
+
+// DeleteAttachment removes the row for good, as the stored file is deleted with it
+func (r *attachmentRepository) DeleteAttachment(ctx context.Context, attachment *entity.ServiceVisitAttachment) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(attachment).Error
+}
+
+func (r *attachmentRepository) DeleteServiceVisitAttachments(ctx context.Context, serviceVisitID uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Where("service_visit_id = ?", serviceVisitID).Delete(&entity.ServiceVisitAttachment{}).Error
+}
+
+// GetUserAttachmentUsage sums the size of every file the user has stored, service visit attachments
+// and vehicle document scans alike
+func (r *attachmentRepository) GetUserAttachmentUsage(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return userAttachmentUsage(r.db.WithContext(ctx), userID)
+}
+
+func userAttachmentUsage(db *gorm.DB, userID uuid.UUID) (int64, error) {
+	var usage int64
+	err := db.
+		Raw(`SELECT
+			(SELECT COALESCE(SUM(size), 0) FROM service_visit_attachments WHERE user_id = @user_id AND deleted_at IS NULL) +
+			(SELECT COALESCE(SUM(scan_size), 0) FROM vehicle_documents WHERE user_id = @user_id AND deleted_at IS NULL)`,
+			map[string]interface{}{"user_id": userID}).
+		Scan(&usage).Error
+	return usage, err
+}
+
+// lockUserAttachmentUsage locks the user's row until the transaction ends, so the uploads of one user are checked
+// against the quota one at a time, and returns the user's usage
+func lockUserAttachmentUsage(tx *gorm.DB, userID uuid.UUID) (int64, error) {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", userID).Take(&entity.User{}).Error
+	if err != nil {
+		return 0, err
+	}
+	return userAttachmentUsage(tx, userID)
+}
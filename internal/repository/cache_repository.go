@@ -20,6 +20,7 @@ type CacheRepository interface {
 	Exists(ctx context.Context, key string) bool
 	SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags []string) error
 	InvalidateByTag(ctx context.Context, tag string) error
+	Increment(ctx context.Context, key string, expiration time.Duration) (int64, error)
 }
 
 type cacheRepository struct {
@@ -117,6 +118,19 @@ func (r *cacheRepository) Exists(ctx context.Context, key string) bool {
 	return result > 0
 }
 
+// Increment adds one to a counter and returns its new value. The expiration is set when the counter is created
+func (r *cacheRepository) Increment(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, expiration)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to increment cache key: %s", key))
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
 // SetWithTags stores a value with associated tags for invalidation
 func (r *cacheRepository) SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags []string) error {
 	// First set the main cache entry
@@ -193,6 +207,7 @@ const (
 	CacheKeyBrands           = "vehicle:brands"
 	CacheKeyModels           = "vehicle:models"
 	CacheKeyGenerations      = "vehicle:generations"
+	CacheKeyRateLimit        = "ratelimit"
 )
 
 // Common cache tags
@@ -0,0 +1,418 @@
+package repository
+
+import (
+	"context"
+	"slices"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CatalogRowIDs are IDs of vehicle catalog rows at each level
+type CatalogRowIDs struct {
+	Types       []uint64
+	Brands      []uint64
+	Models      []uint64
+	Generations []uint64
+}
+
+type catalogLevelRowIDs struct {
+	level string
+	ids   []uint64
+}
+
+func (r CatalogRowIDs) levels() []catalogLevelRowIDs {
+	return []catalogLevelRowIDs{
+		{entity.CatalogLevelType, r.Types},
+		{entity.CatalogLevelBrand, r.Brands},
+		{entity.CatalogLevelModel, r.Models},
+		{entity.CatalogLevelGeneration, r.Generations},
+	}
+}
+
+func (r *CatalogRowIDs) add(rows CatalogRowIDs) {
+	r.Types = append(r.Types, rows.Types...)
+	r.Brands = append(r.Brands, rows.Brands...)
+	r.Models = append(r.Models, rows.Models...)
+	r.Generations = append(r.Generations, rows.Generations...)
+}
+
+// CatalogTrash are the soft-deleted rows at each level of the vehicle catalog
+type CatalogTrash struct {
+	Types       []entity.VehicleType
+	Brands      []entity.VehicleBrand
+	Models      []entity.VehicleModel
+	Generations []entity.VehicleGeneration
+}
+
+type CatalogRepository interface {
+	GetCatalog(ctx context.Context, vehicleTypes *[]entity.VehicleType) error
+	ImportCatalog(ctx context.Context, vehicleTypes []entity.VehicleType, changed, deleted CatalogRowIDs) error
+	GetCatalogVersion(ctx context.Context) (uint64, error)
+	ListCatalogChanges(ctx context.Context, since, until uint64, changes *[]entity.VehicleCatalogChange) error
+	CountCatalogUserVehicles(ctx context.Context, level string, id uint64) (int64, error)
+	CountUserVehiclesByGeneration(ctx context.Context, generationIDs []uint64) (map[uint64]int64, error)
+	ListCatalogTrash(ctx context.Context, trash *CatalogTrash) error
+	RestoreCatalogRow(ctx context.Context, level string, id uint64) error
+}
+
+type catalogRepository struct {
+	db *gorm.DB
+}
+
+func NewCatalogRepository() CatalogRepository {
+	db := database.ConnectDatabase()
+	return &catalogRepository{db: db}
+}
+
+// GetCatalog loads the whole catalog tree including soft-deleted rows, so an import can match and restore them
+// instead of running into their unique names
+func (r *catalogRepository) GetCatalog(ctx context.Context, vehicleTypes *[]entity.VehicleType) error {
+	unscoped := func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Order("id")
+	}
+	return r.db.WithContext(ctx).Unscoped().
+		Preload("VehicleBrands", unscoped).
+		Preload("VehicleBrands.VehicleModels", unscoped).
+		Preload("VehicleBrands.VehicleModels.VehicleGenerations", unscoped).
+		Order("id").
+		Find(vehicleTypes).Error
+}
+
+// ImportCatalog writes an imported catalog tree in one transaction. Rows in deleted are soft deleted first, then rows
+// without an ID are created and rows whose ID is in changed are saved, which restores them if they were deleted.
+// Other rows are left untouched, and children are pointed at the ID of their parent. The whole import is one
+// catalog version, which includes the rows that show up again under a restored row
+func (r *catalogRepository) ImportCatalog(ctx context.Context, vehicleTypes []entity.VehicleType, changed, deleted CatalogRowIDs) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		restored, err := deletedCatalogRowIDs(tx, changed)
+		if err != nil {
+			return err
+		}
+		if err := deleteCatalogRows(tx, deleted); err != nil {
+			return err
+		}
+
+		written := CatalogRowIDs{
+			Types:       slices.Clone(deleted.Types),
+			Brands:      slices.Clone(deleted.Brands),
+			Models:      slices.Clone(deleted.Models),
+			Generations: slices.Clone(deleted.Generations),
+		}
+		for i := range vehicleTypes {
+			vehicleType := &vehicleTypes[i]
+			if err := saveCatalogRow(tx, vehicleType, &vehicleType.ID, changed.Types, &written.Types); err != nil {
+				return err
+			}
+			for j := range vehicleType.VehicleBrands {
+				brand := &vehicleType.VehicleBrands[j]
+				brand.VehicleTypeID = vehicleType.ID
+				if err := saveCatalogRow(tx, brand, &brand.ID, changed.Brands, &written.Brands); err != nil {
+					return err
+				}
+				for k := range brand.VehicleModels {
+					model := &brand.VehicleModels[k]
+					model.BrandID = brand.ID
+					if err := saveCatalogRow(tx, model, &model.ID, changed.Models, &written.Models); err != nil {
+						return err
+					}
+					for l := range model.VehicleGenerations {
+						generation := &model.VehicleGenerations[l]
+						generation.ModelID = model.ID
+						if err := saveCatalogRow(tx, generation, &generation.ID, changed.Generations, &written.Generations); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+		for _, level := range restored.levels() {
+			for _, id := range level.ids {
+				descendants, err := catalogDescendantIDs(tx, level.level, id)
+				if err != nil {
+					return err
+				}
+				written.add(descendants)
+			}
+		}
+		return recordCatalogChanges(tx, written)
+	})
+}
+
+// deletedCatalogRowIDs picks the soft-deleted rows out of rows
+func deletedCatalogRowIDs(tx *gorm.DB, rows CatalogRowIDs) (CatalogRowIDs, error) {
+	deleted := CatalogRowIDs{}
+	levels := []struct {
+		model   any
+		ids     []uint64
+		deleted *[]uint64
+	}{
+		{&entity.VehicleType{}, rows.Types, &deleted.Types},
+		{&entity.VehicleBrand{}, rows.Brands, &deleted.Brands},
+		{&entity.VehicleModel{}, rows.Models, &deleted.Models},
+		{&entity.VehicleGeneration{}, rows.Generations, &deleted.Generations},
+	}
+	for _, level := range levels {
+		if len(level.ids) == 0 {
+			continue
+		}
+		err := tx.Unscoped().Model(level.model).
+			Where("id IN ? AND deleted_at IS NOT NULL", level.ids).
+			Pluck("id", level.deleted).Error
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// catalogDescendantIDs collects the rows under a catalog row that are neither deleted themselves nor under another
+// deleted row, i.e. the ones that show up again when the row is restored
+func catalogDescendantIDs(tx *gorm.DB, level string, id uint64) (CatalogRowIDs, error) {
+	descendants := CatalogRowIDs{}
+	// children[i] are the rows under the rows of parentLevels[i]
+	parentLevels := []string{entity.CatalogLevelType, entity.CatalogLevelBrand, entity.CatalogLevelModel}
+	children := []struct {
+		model        any
+		parentColumn string
+		ids          *[]uint64
+	}{
+		{&entity.VehicleBrand{}, "vehicle_type_id", &descendants.Brands},
+		{&entity.VehicleModel{}, "brand_id", &descendants.Models},
+		{&entity.VehicleGeneration{}, "model_id", &descendants.Generations},
+	}
+	parentIDs := []uint64{}
+	for i, child := range children {
+		if parentLevels[i] == level {
+			parentIDs = []uint64{id}
+		}
+		if len(parentIDs) == 0 {
+			continue
+		}
+		err := tx.Model(child.model).Where(child.parentColumn+" IN ?", parentIDs).Pluck("id", child.ids).Error
+		if err != nil {
+			return descendants, err
+		}
+		parentIDs = *child.ids
+	}
+	return descendants, nil
+}
+
+// saveCatalogRow creates or saves row as described on ImportCatalog and adds its ID to written when it does
+func saveCatalogRow(tx *gorm.DB, row any, id *uint64, changed []uint64, written *[]uint64) error {
+	switch {
+	case *id == 0:
+		if err := tx.Omit(clause.Associations).Create(row).Error; err != nil {
+			return err
+		}
+	case slices.Contains(changed, *id):
+		if err := tx.Unscoped().Omit(clause.Associations).Save(row).Error; err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	*written = append(*written, *id)
+	return nil
+}
+
+// deleteCatalogRows soft deletes rows from the bottom of the tree up
+func deleteCatalogRows(tx *gorm.DB, deleted CatalogRowIDs) error {
+	levels := []struct {
+		model any
+		ids   []uint64
+	}{
+		{&entity.VehicleGeneration{}, deleted.Generations},
+		{&entity.VehicleModel{}, deleted.Models},
+		{&entity.VehicleBrand{}, deleted.Brands},
+		{&entity.VehicleType{}, deleted.Types},
+	}
+	for _, level := range levels {
+		if len(level.ids) == 0 {
+			continue
+		}
+		if err := tx.Where("id IN ?", level.ids).Delete(level.model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCatalogVersion returns 0 while the catalog has never changed
+func (r *catalogRepository) GetCatalogVersion(ctx context.Context) (uint64, error) {
+	var catalogVersion entity.VehicleCatalogVersion
+	err := r.db.WithContext(ctx).Limit(1).Find(&catalogVersion).Error
+	return catalogVersion.Version, err
+}
+
+// ListCatalogChanges lists the rows whose last change is after version since and up to version until
+func (r *catalogRepository) ListCatalogChanges(ctx context.Context, since, until uint64, changes *[]entity.VehicleCatalogChange) error {
+	return r.db.WithContext(ctx).
+		Where("version > ? AND version <= ?", since, until).
+		Order("version, level, row_id").
+		Find(changes).Error
+}
+
+// recordCatalogChanges raises the catalog version and marks rows as changed in it. It runs in the transaction of the
+// change, so a client never sees a version without the rows changed in it
+func recordCatalogChanges(tx *gorm.DB, rows CatalogRowIDs) error {
+	var version uint64
+	err := tx.Raw(`INSERT INTO vehicle_catalog_versions (id, version) VALUES (1, 1)
+		ON CONFLICT (id) DO UPDATE SET version = vehicle_catalog_versions.version + 1
+		RETURNING version`).Scan(&version).Error
+	if err != nil {
+		return err
+	}
+
+	changes := []entity.VehicleCatalogChange{}
+	for _, level := range rows.levels() {
+		// One statement can not update the same row twice
+		ids := slices.Compact(slices.Sorted(slices.Values(level.ids)))
+		for _, id := range ids {
+			changes = append(changes, entity.VehicleCatalogChange{Level: level.level, RowID: id, Version: version})
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "level"}, {Name: "row_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"version"}),
+	}).Create(&changes).Error
+}
+
+// CountCatalogUserVehicles counts the active user vehicles on the generations under a catalog row
+func (r *catalogRepository) CountCatalogUserVehicles(ctx context.Context, level string, id uint64) (int64, error) {
+	var count int64
+	db := r.db.WithContext(ctx)
+	err := db.Model(&entity.UserVehicle{}).
+		Where("generation_id IN (?)", catalogGenerationIDs(db, level, id)).
+		Count(&count).Error
+	return count, err
+}
+
+// CountUserVehiclesByGeneration counts the active user vehicles of each generation, leaving out generations without any
+func (r *catalogRepository) CountUserVehiclesByGeneration(ctx context.Context, generationIDs []uint64) (map[uint64]int64, error) {
+	counts := map[uint64]int64{}
+	if len(generationIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		GenerationID uint64
+		Count        int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&entity.UserVehicle{}).
+		Select("generation_id, COUNT(*) AS count").
+		Where("generation_id IN ?", generationIDs).
+		Group("generation_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.GenerationID] = row.Count
+	}
+	return counts, nil
+}
+
+// ListCatalogTrash lists the soft-deleted rows of the catalog, most recently deleted first
+func (r *catalogRepository) ListCatalogTrash(ctx context.Context, trash *CatalogTrash) error {
+	deleted := func(rows any) error {
+		return r.db.WithContext(ctx).Unscoped().
+			Where("deleted_at IS NOT NULL").
+			Order("deleted_at DESC, id").
+			Find(rows).Error
+	}
+	if err := deleted(&trash.Types); err != nil {
+		return err
+	}
+	if err := deleted(&trash.Brands); err != nil {
+		return err
+	}
+	if err := deleted(&trash.Models); err != nil {
+		return err
+	}
+	return deleted(&trash.Generations)
+}
+
+// RestoreCatalogRow brings a soft-deleted row back into the catalog as a new catalog version. The rows under it
+// are changed in that version too, since clients dropped them along with the row
+func (r *catalogRepository) RestoreCatalogRow(ctx context.Context, level string, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().
+			Model(catalogLevelModel(level)).
+			Where("id = ?", id).
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		rows, err := catalogDescendantIDs(tx, level, id)
+		if err != nil {
+			return err
+		}
+		rows.add(catalogRowIDs(level, id))
+		return recordCatalogChanges(tx, rows)
+	})
+}
+
+// reassignUserVehicles moves the active user vehicles on the generations under a catalog row to generationID.
+// A zero generationID leaves them where they are
+func reassignUserVehicles(tx *gorm.DB, level string, id, generationID uint64) error {
+	if generationID == 0 {
+		return nil
+	}
+	return tx.Model(&entity.UserVehicle{}).
+		Where("generation_id IN (?)", catalogGenerationIDs(tx, level, id)).
+		Update("generation_id", generationID).Error
+}
+
+// catalogGenerationIDs selects the IDs of the generations under a catalog row, deleted ones included since deleting
+// the row hides them all the same
+func catalogGenerationIDs(db *gorm.DB, level string, id uint64) *gorm.DB {
+	ids := func(model any) *gorm.DB {
+		return db.Session(&gorm.Session{NewDB: true}).Unscoped().Model(model).Select("id")
+	}
+	generations := ids(&entity.VehicleGeneration{})
+	switch level {
+	case entity.CatalogLevelType:
+		brands := ids(&entity.VehicleBrand{}).Where("vehicle_type_id = ?", id)
+		models := ids(&entity.VehicleModel{}).Where("brand_id IN (?)", brands)
+		return generations.Where("model_id IN (?)", models)
+	case entity.CatalogLevelBrand:
+		models := ids(&entity.VehicleModel{}).Where("brand_id = ?", id)
+		return generations.Where("model_id IN (?)", models)
+	case entity.CatalogLevelModel:
+		return generations.Where("model_id = ?", id)
+	default:
+		return generations.Where("id = ?", id)
+	}
+}
+
+func catalogLevelModel(level string) any {
+	switch level {
+	case entity.CatalogLevelType:
+		return &entity.VehicleType{}
+	case entity.CatalogLevelBrand:
+		return &entity.VehicleBrand{}
+	case entity.CatalogLevelModel:
+		return &entity.VehicleModel{}
+	default:
+		return &entity.VehicleGeneration{}
+	}
+}
+
+func catalogRowIDs(level string, id uint64) CatalogRowIDs {
+	switch level {
+	case entity.CatalogLevelType:
+		return CatalogRowIDs{Types: []uint64{id}}
+	case entity.CatalogLevelBrand:
+		return CatalogRowIDs{Brands: []uint64{id}}
+	case entity.CatalogLevelModel:
+		return CatalogRowIDs{Models: []uint64{id}}
+	default:
+		return CatalogRowIDs{Generations: []uint64{id}}
+	}
+}
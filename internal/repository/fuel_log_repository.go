@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type FuelLogRepository interface {
+	CreateFuelLog(ctx context.Context, fuelLog *entity.FuelLog) error
+	GetFuelLog(ctx context.Context, id uint64, fuelLog *entity.FuelLog) error
+	ListFuelLogs(ctx context.Context, userVehicleID uint64, fuelLogs *[]entity.FuelLog) error
+	UpdateFuelLog(ctx context.Context, fuelLog *entity.FuelLog) error
+	DeleteFuelLog(ctx context.Context, fuelLog *entity.FuelLog) error
+}
+
+type fuelLogRepository struct {
+	db *gorm.DB
+}
+
+func NewFuelLogRepository() FuelLogRepository {
+	db := database.ConnectDatabase()
+	return &fuelLogRepository{db: db}
+}
+
+func (r *fuelLogRepository) CreateFuelLog(ctx context.Context, fuelLog *entity.FuelLog) error {
+	return r.db.WithContext(ctx).Create(fuelLog).Error
+}
+
+func (r *fuelLogRepository) GetFuelLog(ctx context.Context, id uint64, fuelLog *entity.FuelLog) error {
+	return r.db.WithContext(ctx).First(fuelLog, id).Error
+}
+
+func (r *fuelLogRepository) ListFuelLogs(ctx context.Context, userVehicleID uint64, fuelLogs *[]entity.FuelLog) error {
+	return r.db.WithContext(ctx).
+		Where("user_vehicle_id = ?", userVehicleID).
+		Order("fill_date DESC, mileage DESC, id DESC").
+		Find(fuelLogs).Error
+}
+
+func (r *fuelLogRepository) UpdateFuelLog(ctx context.Context, fuelLog *entity.FuelLog) error {
+	return r.db.WithContext(ctx).Save(fuelLog).Error
+}
+
+func (r *fuelLogRepository) DeleteFuelLog(ctx context.Context, fuelLog *entity.FuelLog) error {
+	return r.db.WithContext(ctx).Delete(fuelLog).Error
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type MaintenanceScheduleRepository interface {
+	// Manufacturer intervals
+	CreateMaintenanceInterval(ctx context.Context, interval *entity.MaintenanceInterval) error
+	GetMaintenanceInterval(ctx context.Context, id uint64, interval *entity.MaintenanceInterval) error
+	ListMaintenanceIntervals(ctx context.Context, generationID uint64, intervals *[]entity.MaintenanceInterval) error
+	UpdateMaintenanceInterval(ctx context.Context, interval *entity.MaintenanceInterval) error
+	DeleteMaintenanceInterval(ctx context.Context, interval *entity.MaintenanceInterval) error
+	GetMaintenanceIntervalByItemType(ctx context.Context, generationID uint64, itemType string, interval *entity.MaintenanceInterval) error
+
+	// User vehicle plans
+	ListMaintenancePlanItems(ctx context.Context, userVehicleID uint64, items *[]entity.MaintenancePlanItem) error
+	GetMaintenancePlanItem(ctx context.Context, userVehicleID uint64, itemType string, item *entity.MaintenancePlanItem) error
+	ReplaceMaintenancePlan(ctx context.Context, userVehicleID uint64, items *[]entity.MaintenancePlanItem) error
+}
+
+type maintenanceScheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceScheduleRepository() MaintenanceScheduleRepository {
+	db := database.ConnectDatabase()
+	return &maintenanceScheduleRepository{db: db}
+}
+
+func (r *maintenanceScheduleRepository) CreateMaintenanceInterval(ctx context.Context, interval *entity.MaintenanceInterval) error {
+	return r.db.WithContext(ctx).Create(interval).Error
+}
+
+func (r *maintenanceScheduleRepository) GetMaintenanceInterval(ctx context.Context, id uint64, interval *entity.MaintenanceInterval) error {
+	return r.db.WithContext(ctx).First(interval, id).Error
+}
+
+func (r *maintenanceScheduleRepository) ListMaintenanceIntervals(ctx context.Context, generationID uint64, intervals *[]entity.MaintenanceInterval) error {
+	return r.db.WithContext(ctx).Where("generation_id = ?", generationID).Order("item_type").Find(intervals).Error
+}
+
+func (r *maintenanceScheduleRepository) UpdateMaintenanceInterval(ctx context.Context, interval *entity.MaintenanceInterval) error {
+	return r.db.WithContext(ctx).Save(interval).Error
+}
+
+// DeleteMaintenanceInterval removes the row for good so the item type can be added again
+func (r *maintenanceScheduleRepository) DeleteMaintenanceInterval(ctx context.Context, interval *entity.MaintenanceInterval) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(interval).Error
+}
+
+// GetMaintenanceIntervalByItemType leaves interval untouched when the generation has none for the item type
+func (r *maintenanceScheduleRepository) GetMaintenanceIntervalByItemType(ctx context.Context, generationID uint64, itemType string, interval *entity.MaintenanceInterval) error {
+	return r.db.WithContext(ctx).
+		Where("generation_id = ? AND item_type = ?", generationID, itemType).
+		Limit(1).
+		Find(interval).Error
+}
+
+func (r *maintenanceScheduleRepository) ListMaintenancePlanItems(ctx context.Context, userVehicleID uint64, items *[]entity.MaintenancePlanItem) error {
+	return r.db.WithContext(ctx).Where("user_vehicle_id = ?", userVehicleID).Order("item_type").Find(items).Error
+}
+
+// GetMaintenancePlanItem leaves item untouched when the vehicle's plan does not cover the item type
+func (r *maintenanceScheduleRepository) GetMaintenancePlanItem(ctx context.Context, userVehicleID uint64, itemType string, item *entity.MaintenancePlanItem) error {
+	return r.db.WithContext(ctx).
+		Where("user_vehicle_id = ? AND item_type = ?", userVehicleID, itemType).
+		Limit(1).
+		Find(item).Error
+}
+
+// ReplaceMaintenancePlan swaps the vehicle's plan for the given items in one transaction
+func (r *maintenanceScheduleRepository) ReplaceMaintenancePlan(ctx context.Context, userVehicleID uint64, items *[]entity.MaintenancePlanItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_vehicle_id = ?", userVehicleID).Delete(&entity.MaintenancePlanItem{}).Error; err != nil {
+			return err
+		}
+		if len(*items) == 0 {
+			return nil
+		}
+		return tx.Create(items).Error
+	})
+}
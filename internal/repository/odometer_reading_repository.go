@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OdometerReadingRepository interface {
+	CreateOdometerReading(ctx context.Context, odometerReading *entity.OdometerReading) error
+	GetOdometerReading(ctx context.Context, id uint64, odometerReading *entity.OdometerReading) error
+	ListOdometerReadings(ctx context.Context, userVehicleID uint64, odometerReadings *[]entity.OdometerReading) error
+	UpdateOdometerReading(ctx context.Context, odometerReading *entity.OdometerReading) error
+	DeleteOdometerReading(ctx context.Context, odometerReading *entity.OdometerReading) error
+	GetLatestOdometerReading(ctx context.Context, userVehicleID uint64, odometerReading *entity.OdometerReading) error
+	GetPreviousOdometerReading(ctx context.Context, userVehicleID uint64, readingDate time.Time, excludeID uint64, odometerReading *entity.OdometerReading) error
+	GetNextOdometerReading(ctx context.Context, userVehicleID uint64, readingDate time.Time, excludeID uint64, odometerReading *entity.OdometerReading) error
+	GetServiceVisitOdometerReading(ctx context.Context, serviceVisitID uuid.UUID, odometerReading *entity.OdometerReading) error
+}
+
+type odometerReadingRepository struct {
+	db *gorm.DB
+}
+
+func NewOdometerReadingRepository() OdometerReadingRepository {
+	db := database.ConnectDatabase()
+	return &odometerReadingRepository{db: db}
+}
+
+func (r *odometerReadingRepository) CreateOdometerReading(ctx context.Context, odometerReading *entity.OdometerReading) error {
+	return r.db.WithContext(ctx).Create(odometerReading).Error
+}
+
+func (r *odometerReadingRepository) GetOdometerReading(ctx context.Context, id uint64, odometerReading *entity.OdometerReading) error {
+	return r.db.WithContext(ctx).First(odometerReading, id).Error
+}
+
+func (r *odometerReadingRepository) ListOdometerReadings(ctx context.Context, userVehicleID uint64, odometerReadings *[]entity.OdometerReading) error {
+	return r.db.WithContext(ctx).
+		Where("user_vehicle_id = ?", userVehicleID).
+		Order("reading_date DESC, id DESC").
+		Find(odometerReadings).Error
+}
+
+func (r *odometerReadingRepository) UpdateOdometerReading(ctx context.Context, odometerReading *entity.OdometerReading) error {
+	return r.db.WithContext(ctx).Save(odometerReading).Error
+}
+
+func (r *odometerReadingRepository) DeleteOdometerReading(ctx context.Context, odometerReading *entity.OdometerReading) error {
+	return r.db.WithContext(ctx).Delete(odometerReading).Error
+}
+
+// GetLatestOdometerReading leaves odometerReading untouched when the vehicle has no readings
+func (r *odometerReadingRepository) GetLatestOdometerReading(ctx context.Context, userVehicleID uint64, odometerReading *entity.OdometerReading) error {
+	return r.db.WithContext(ctx).
+		Where("user_vehicle_id = ?", userVehicleID).
+		Order("reading_date DESC, id DESC").
+		Limit(1).
+		Find(odometerReading).Error
+}
+
+// GetPreviousOdometerReading finds the latest reading on or before readingDate, ignoring excludeID.
+// odometerReading is left untouched when there is none
+func (r *odometerReadingRepository) GetPreviousOdometerReading(ctx context.Context, userVehicleID uint64, readingDate time.Time, excludeID uint64, odometerReading *entity.OdometerReading) error {
+	return r.db.WithContext(ctx).
+		Where("user_vehicle_id = ? AND reading_date <= ? AND id <> ?", userVehicleID, readingDate, excludeID).
+		Order("reading_date DESC, id DESC").
+		Limit(1).
+		Find(odometerReading).Error
+}
+
+// GetNextOdometerReading finds the earliest reading after readingDate, ignoring excludeID.
+// odometerReading is left untouched when there is none
+func (r *odometerReadingRepository) GetNextOdometerReading(ctx context.Context, userVehicleID uint64, readingDate time.Time, excludeID uint64, odometerReading *entity.OdometerReading) error {
+	return r.db.WithContext(ctx).
+		Where("user_vehicle_id = ? AND reading_date > ? AND id <> ?", userVehicleID, readingDate, excludeID).
+		Order("reading_date, id").
+		Limit(1).
+		Find(odometerReading).Error
+}
+
+// GetServiceVisitOdometerReading leaves odometerReading untouched when the visit recorded no reading
+func (r *odometerReadingRepository) GetServiceVisitOdometerReading(ctx context.Context, serviceVisitID uuid.UUID, odometerReading *entity.OdometerReading) error {
+	return r.db.WithContext(ctx).
+		Where("service_visit_id = ?", serviceVisitID).
+		Limit(1).
+		Find(odometerReading).Error
+}
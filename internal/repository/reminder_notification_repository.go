@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"github.com/redis/go-redis/v9"
+)
+
+const reminderNotifierLockKey = "reminder:notifier:lock"
+
+// releaseLockScript deletes the lock only if it is still held by the given token
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type ReminderNotificationRepository interface {
+	AcquireNotifierLock(ctx context.Context, token string, ttl time.Duration) (bool, error)
+	ReleaseNotifierLock(ctx context.Context, token string) error
+	IsReminderNotified(ctx context.Context, source string, sourceID uint64, dueTarget string) (bool, error)
+	MarkReminderNotified(ctx context.Context, source string, sourceID uint64, dueTarget string, ttl time.Duration) error
+}
+
+type reminderNotificationRepository struct {
+	client *redis.Client
+}
+
+func NewReminderNotificationRepository() ReminderNotificationRepository {
+	return &reminderNotificationRepository{
+		client: database.GetRedisClient(),
+	}
+}
+
+func makeReminderNotificationKey(source string, sourceID uint64, dueTarget string) string {
+	return fmt.Sprintf("reminder:notified:%s:%d:%s", source, sourceID, dueTarget)
+}
+
+// AcquireNotifierLock takes the notifier lock so that only one replica sends reminders
+func (r *reminderNotificationRepository) AcquireNotifierLock(ctx context.Context, token string, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, reminderNotifierLockKey, token, ttl).Result()
+}
+
+// ReleaseNotifierLock releases the notifier lock if it is still held by token
+func (r *reminderNotificationRepository) ReleaseNotifierLock(ctx context.Context, token string) error {
+	return releaseLockScript.Run(ctx, r.client, []string{reminderNotifierLockKey}, token).Err()
+}
+
+// IsReminderNotified reports whether the owner was already notified about a record falling due at dueTarget
+func (r *reminderNotificationRepository) IsReminderNotified(ctx context.Context, source string, sourceID uint64, dueTarget string) (bool, error) {
+	count, err := r.client.Exists(ctx, makeReminderNotificationKey(source, sourceID, dueTarget)).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// MarkReminderNotified records that the owner was notified about a record falling due at dueTarget
+func (r *reminderNotificationRepository) MarkReminderNotified(ctx context.Context, source string, sourceID uint64, dueTarget string, ttl time.Duration) error {
+	return r.client.Set(ctx, makeReminderNotificationKey(source, sourceID, dueTarget), time.Now().Unix(), ttl).Err()
+}
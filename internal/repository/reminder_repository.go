@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type ReminderRepository interface {
+	ListDueReminderRecipients(ctx context.Context, dueSoonMileage int, dueBefore, documentExpiresBefore time.Time, recipients *[]entity.ReminderRecipient) error
+}
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository() ReminderRepository {
+	db := database.ConnectDatabase()
+	return &reminderRepository{db: db}
+}
+
+// ListDueReminderRecipients lists vehicles of active users having at least one record whose next change
+// mileage or date falls within the given thresholds, or a document expiring before documentExpiresBefore.
+// Vehicles with a maintenance plan are always listed, since their plan-based due dates are only known
+// once the reminders are built
+func (r *reminderRepository) ListDueReminderRecipients(ctx context.Context, dueSoonMileage int, dueBefore, documentExpiresBefore time.Time, recipients *[]entity.ReminderRecipient) error {
+	dueRecord := func(table string) string {
+		return "EXISTS (SELECT 1 FROM " + table + " t WHERE t.user_vehicle_id = user_vehicles.id AND t.deleted_at IS NULL" +
+			" AND ((t.next_change_mileage > 0 AND t.next_change_mileage <= user_vehicles.current_mileage + @mileage)" +
+			" OR (t.next_change_date > @zero AND t.next_change_date <= @due_before)))"
+	}
+	return r.db.WithContext(ctx).
+		Table("user_vehicles").
+		Select("user_vehicles.*, users.phone_number").
+		Joins("JOIN users ON users.id = user_vehicles.user_id AND users.deleted_at IS NULL").
+		Where("user_vehicles.deleted_at IS NULL AND users.status = ?", entity.Active).
+		Where("("+dueRecord("oil_changes")+" OR "+dueRecord("oil_filters")+" OR "+dueRecord("service_items")+
+			" OR EXISTS (SELECT 1 FROM maintenance_plan_items p WHERE p.user_vehicle_id = user_vehicles.id AND p.deleted_at IS NULL)"+
+			" OR EXISTS (SELECT 1 FROM vehicle_documents d WHERE d.user_vehicle_id = user_vehicles.id AND d.deleted_at IS NULL AND d.expiry_date <= @document_expires_before))",
+			map[string]interface{}{
+				"mileage":                 dueSoonMileage,
+				"zero":                    time.Time{},
+				"due_before":              dueBefore,
+				"document_expires_before": documentExpiresBefore,
+			}).
+		Order("user_vehicles.id").
+		Scan(recipients).Error
+}
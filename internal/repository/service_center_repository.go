@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ServiceCenterRepository interface {
+	// Directory
+	CreateServiceCenter(ctx context.Context, serviceCenter *entity.ServiceCenter) error
+	GetServiceCenter(ctx context.Context, id uint64, serviceCenter *entity.ServiceCenter) error
+	ListServiceCenters(ctx context.Context, city, name string, serviceCenters *[]entity.ServiceCenter) error
+	ListServiceCentersInBoundingBox(ctx context.Context, minLat, maxLat, minLng, maxLng float64, serviceCenters *[]entity.ServiceCenter) error
+	UpdateServiceCenter(ctx context.Context, serviceCenter *entity.ServiceCenter) error
+	DeleteServiceCenter(ctx context.Context, serviceCenter *entity.ServiceCenter) error
+
+	// Reviews
+	HasUserVisitedServiceCenter(ctx context.Context, userID uuid.UUID, serviceCenterID uint64) (bool, error)
+	GetServiceCenterReview(ctx context.Context, serviceCenterID uint64, userID uuid.UUID, review *entity.ServiceCenterReview) error
+	ListServiceCenterReviews(ctx context.Context, serviceCenterID uint64, reviews *[]entity.ServiceCenterReview) error
+	SaveServiceCenterReview(ctx context.Context, review *entity.ServiceCenterReview) error
+	DeleteServiceCenterReview(ctx context.Context, review *entity.ServiceCenterReview) error
+}
+
+type serviceCenterRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceCenterRepository() ServiceCenterRepository {
+	db := database.ConnectDatabase()
+	return &serviceCenterRepository{db: db}
+}
+
+func (r *serviceCenterRepository) CreateServiceCenter(ctx context.Context, serviceCenter *entity.ServiceCenter) error {
+	return r.db.WithContext(ctx).Create(serviceCenter).Error
+}
+
+// GetServiceCenter leaves serviceCenter untouched when no center has the id
+func (r *serviceCenterRepository) GetServiceCenter(ctx context.Context, id uint64, serviceCenter *entity.ServiceCenter) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(serviceCenter).Error
+}
+
+// ListServiceCenters filters by exact city and partial name when they are not empty
+func (r *serviceCenterRepository) ListServiceCenters(ctx context.Context, city, name string, serviceCenters *[]entity.ServiceCenter) error {
+	query := r.db.WithContext(ctx)
+	if city != "" {
+		query = query.Where("city = ?", city)
+	}
+	if name != "" {
+		query = query.Where("name ILIKE ?", "%"+name+"%")
+	}
+	return query.Order("name").Find(serviceCenters).Error
+}
+
+func (r *serviceCenterRepository) ListServiceCentersInBoundingBox(ctx context.Context, minLat, maxLat, minLng, maxLng float64, serviceCenters *[]entity.ServiceCenter) error {
+	return r.db.WithContext(ctx).
+		Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", minLat, maxLat, minLng, maxLng).
+		Find(serviceCenters).Error
+}
+
+func (r *serviceCenterRepository) UpdateServiceCenter(ctx context.Context, serviceCenter *entity.ServiceCenter) error {
+	return r.db.WithContext(ctx).Save(serviceCenter).Error
+}
+
+// DeleteServiceCenter soft deletes the center so visits that reference it keep their link
+func (r *serviceCenterRepository) DeleteServiceCenter(ctx context.Context, serviceCenter *entity.ServiceCenter) error {
+	return r.db.WithContext(ctx).Delete(serviceCenter).Error
+}
+
+func (r *serviceCenterRepository) HasUserVisitedServiceCenter(ctx context.Context, userID uuid.UUID, serviceCenterID uint64) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.ServiceVisit{}).
+		Where("user_id = ? AND service_center_id = ?", userID, serviceCenterID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetServiceCenterReview leaves review untouched when the user has not reviewed the center
+func (r *serviceCenterRepository) GetServiceCenterReview(ctx context.Context, serviceCenterID uint64, userID uuid.UUID, review *entity.ServiceCenterReview) error {
+	return r.db.WithContext(ctx).
+		Where("service_center_id = ? AND user_id = ?", serviceCenterID, userID).
+		Limit(1).
+		Find(review).Error
+}
+
+func (r *serviceCenterRepository) ListServiceCenterReviews(ctx context.Context, serviceCenterID uint64, reviews *[]entity.ServiceCenterReview) error {
+	return r.db.WithContext(ctx).Where("service_center_id = ?", serviceCenterID).Order("updated_at DESC").Find(reviews).Error
+}
+
+// SaveServiceCenterReview creates or updates the review and refreshes the center's rating in one transaction
+func (r *serviceCenterRepository) SaveServiceCenterReview(ctx context.Context, review *entity.ServiceCenterReview) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+		return refreshServiceCenterRating(tx, review.ServiceCenterID)
+	})
+}
+
+// DeleteServiceCenterReview removes the review for good, so the user can review the center again, and refreshes its rating
+func (r *serviceCenterRepository) DeleteServiceCenterReview(ctx context.Context, review *entity.ServiceCenterReview) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(review).Error; err != nil {
+			return err
+		}
+		return refreshServiceCenterRating(tx, review.ServiceCenterID)
+	})
+}
+
+// refreshServiceCenterRating recomputes the center's average rating and review count from its reviews
+func refreshServiceCenterRating(tx *gorm.DB, serviceCenterID uint64) error {
+	reviews := func(column string) *gorm.DB {
+		return tx.Session(&gorm.Session{NewDB: true}).
+			Model(&entity.ServiceCenterReview{}).
+			Select(column).
+			Where("service_center_id = ?", serviceCenterID)
+	}
+	return tx.Model(&entity.ServiceCenter{}).
+		Where("id = ?", serviceCenterID).
+		Updates(map[string]interface{}{
+			"average_rating": gorm.Expr("(?)", reviews("COALESCE(AVG(rating), 0)")),
+			"review_count":   gorm.Expr("(?)", reviews("COUNT(*)")),
+		}).Error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ServiceItemRepository interface {
+	CreateServiceItem(ctx context.Context, serviceItem *entity.ServiceItem) error
+	GetServiceItem(ctx context.Context, id uint64, serviceItem *entity.ServiceItem) error
+	ListServiceItems(ctx context.Context, serviceVisitID uuid.UUID, serviceItems *[]entity.ServiceItem) error
+	UpdateServiceItem(ctx context.Context, serviceItem *entity.ServiceItem) error
+	DeleteServiceItem(ctx context.Context, serviceItem *entity.ServiceItem) error
+	ListLatestServiceItems(ctx context.Context, userVehicleID uint64, serviceItems *[]entity.ServiceItem) error
+}
+
+type serviceItemRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceItemRepository() ServiceItemRepository {
+	db := database.ConnectDatabase()
+	return &serviceItemRepository{db: db}
+}
+
+func (r *serviceItemRepository) CreateServiceItem(ctx context.Context, serviceItem *entity.ServiceItem) error {
+	return r.db.WithContext(ctx).Create(serviceItem).Error
+}
+
+func (r *serviceItemRepository) GetServiceItem(ctx context.Context, id uint64, serviceItem *entity.ServiceItem) error {
+	return r.db.WithContext(ctx).First(serviceItem, id).Error
+}
+
+func (r *serviceItemRepository) ListServiceItems(ctx context.Context, serviceVisitID uuid.UUID, serviceItems *[]entity.ServiceItem) error {
+	return r.db.WithContext(ctx).
+		Where("service_visit_id = ?", serviceVisitID).
+		Order("id ASC").
+		Find(serviceItems).Error
+}
+
+func (r *serviceItemRepository) UpdateServiceItem(ctx context.Context, serviceItem *entity.ServiceItem) error {
+	return r.db.WithContext(ctx).Save(serviceItem).Error
+}
+
+func (r *serviceItemRepository) DeleteServiceItem(ctx context.Context, serviceItem *entity.ServiceItem) error {
+	return r.db.WithContext(ctx).Delete(serviceItem).Error
+}
+
+// ListLatestServiceItems returns the most recent service item of each type for a vehicle
+func (r *serviceItemRepository) ListLatestServiceItems(ctx context.Context, userVehicleID uint64, serviceItems *[]entity.ServiceItem) error {
+	return r.db.WithContext(ctx).
+		Raw(`SELECT DISTINCT ON (item_type) * FROM service_items
+			WHERE user_vehicle_id = ? AND deleted_at IS NULL
+			ORDER BY item_type, change_date DESC, id DESC`, userVehicleID).
+		Scan(serviceItems).Error
+}
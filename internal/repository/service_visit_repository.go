@@ -9,12 +9,13 @@ import (
 )
 
 type ServiceVisitRepository interface {
-	CreateServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit) error
+	CreateServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit, odometerReading *entity.OdometerReading) error
 	GetServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit) error
 	ListServiceVisits(ctx context.Context, userVehicleID string, serviceVisits *[]entity.ServiceVisit) error
-	UpdateServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit) error
+	UpdateServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit, odometerReading *entity.OdometerReading) error
 	DeleteServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit) error
 	GetLastServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit) error
+	ImportServiceVisits(ctx context.Context, serviceVisits []entity.ServiceVisit, odometerReadings []entity.OdometerReading) error
 }
 
 type ServiceVisitRepositoryImpl struct {
@@ -26,20 +27,36 @@ func NewServiceVisitRepository() ServiceVisitRepository {
 	return &ServiceVisitRepositoryImpl{db: db}
 }
 
-func (r *ServiceVisitRepositoryImpl) CreateServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit) error {
-	return r.db.WithContext(ctx).Preload("OilChange").Preload("OilFilter").Create(serviceVisit).Error
+// CreateServiceVisit creates the service visit, with its oil change, filter and items, and the odometer reading it
+// records in one transaction
+func (r *ServiceVisitRepositoryImpl) CreateServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit, odometerReading *entity.OdometerReading) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(serviceVisit).Error; err != nil {
+			return err
+		}
+		return tx.Create(odometerReading).Error
+	})
 }
 
 func (r *ServiceVisitRepositoryImpl) GetServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit) error {
-	return r.db.WithContext(ctx).Preload("OilChange").Preload("OilFilter").First(&serviceVisit).Error
+	return r.db.WithContext(ctx).Preload("OilChange").Preload("OilFilter").Preload("ServiceItems").Preload("Attachments").First(&serviceVisit).Error
 }
 
 func (r *ServiceVisitRepositoryImpl) ListServiceVisits(ctx context.Context, userVehicleID string, serviceVisits *[]entity.ServiceVisit) error {
-	return r.db.WithContext(ctx).Preload("OilChange").Preload("OilFilter").Where("user_vehicle_id = ?", userVehicleID).Order("service_date DESC").Find(serviceVisits).Error
+	return r.db.WithContext(ctx).Preload("OilChange").Preload("OilFilter").Preload("ServiceItems").Preload("Attachments").Where("user_vehicle_id = ?", userVehicleID).Order("service_date DESC").Find(serviceVisits).Error
 }
 
-func (r *ServiceVisitRepositoryImpl) UpdateServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit) error {
-	return r.db.WithContext(ctx).Preload("OilChange").Preload("OilFilter").Save(serviceVisit).Error
+// UpdateServiceVisit saves the service visit and, when it is not nil, its odometer reading in one transaction
+func (r *ServiceVisitRepositoryImpl) UpdateServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit, odometerReading *entity.OdometerReading) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(serviceVisit).Error; err != nil {
+			return err
+		}
+		if odometerReading == nil {
+			return nil
+		}
+		return tx.Save(odometerReading).Error
+	})
 }
 
 func (r *ServiceVisitRepositoryImpl) DeleteServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit) error {
@@ -47,5 +64,21 @@ func (r *ServiceVisitRepositoryImpl) DeleteServiceVisit(ctx context.Context, ser
 }
 
 func (r *ServiceVisitRepositoryImpl) GetLastServiceVisit(ctx context.Context, serviceVisit *entity.ServiceVisit) error {
-	return r.db.WithContext(ctx).Preload("OilChange").Preload("OilFilter").Where("user_vehicle_id = ?", serviceVisit.UserVehicleID).Order("service_date DESC").First(serviceVisit).Error
+	return r.db.WithContext(ctx).Preload("OilChange").Preload("OilFilter").Preload("ServiceItems").Preload("Attachments").Where("user_vehicle_id = ?", serviceVisit.UserVehicleID).Order("service_date DESC").First(serviceVisit).Error
+}
+
+// ImportServiceVisits creates the service visits, with their oil changes, filters and items, and their odometer
+// readings in one transaction, so either all of them are saved or none are
+func (r *ServiceVisitRepositoryImpl) ImportServiceVisits(ctx context.Context, serviceVisits []entity.ServiceVisit, odometerReadings []entity.OdometerReading) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range serviceVisits {
+			if err := tx.Create(&serviceVisits[i]).Error; err != nil {
+				return err
+			}
+		}
+		if len(odometerReadings) == 0 {
+			return nil
+		}
+		return tx.Create(&odometerReadings).Error
+	})
 }
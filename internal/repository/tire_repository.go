@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TireRepository interface {
+	// Tire sets
+	CreateTireSet(ctx context.Context, tireSet *entity.TireSet, mount *entity.TireMount) error
+	GetTireSet(ctx context.Context, id uint64, tireSet *entity.TireSet) error
+	ListTireSets(ctx context.Context, userVehicleID uint64, tireSets *[]entity.TireSet) error
+	UpdateTireSet(ctx context.Context, tireSet *entity.TireSet) error
+	DeleteTireSet(ctx context.Context, tireSet *entity.TireSet) error
+
+	// Seasonal swaps
+	MountTireSet(ctx context.Context, tireSet *entity.TireSet, mount *entity.TireMount) error
+	UnmountTireSet(ctx context.Context, tireSet *entity.TireSet, mount *entity.TireMount) error
+
+	// Rotations
+	CreateTireRotation(ctx context.Context, rotation *entity.TireRotation) error
+	GetTireRotation(ctx context.Context, id uint64, rotation *entity.TireRotation) error
+	DeleteTireRotation(ctx context.Context, rotation *entity.TireRotation) error
+
+	// Tread depth measurements
+	CreateTreadDepth(ctx context.Context, measurement *entity.TreadDepthMeasurement) error
+	GetTreadDepth(ctx context.Context, id uint64, measurement *entity.TreadDepthMeasurement) error
+	DeleteTreadDepth(ctx context.Context, measurement *entity.TreadDepthMeasurement) error
+}
+
+type tireRepository struct {
+	db *gorm.DB
+}
+
+func NewTireRepository() TireRepository {
+	db := database.ConnectDatabase()
+	return &tireRepository{db: db}
+}
+
+// CreateTireSet saves the set and, when mount is given, mounts it in place of the set on the vehicle
+func (r *tireRepository) CreateTireSet(ctx context.Context, tireSet *entity.TireSet, mount *entity.TireMount) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(tireSet).Error; err != nil {
+			return err
+		}
+		if mount == nil {
+			return nil
+		}
+		return mountTireSet(tx, tireSet, mount)
+	})
+}
+
+func (r *tireRepository) GetTireSet(ctx context.Context, id uint64, tireSet *entity.TireSet) error {
+	return r.db.WithContext(ctx).
+		Preload("Mounts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("mounted_at DESC, id DESC")
+		}).
+		Preload("Rotations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("rotation_date DESC, id DESC")
+		}).
+		Preload("TreadDepths", func(db *gorm.DB) *gorm.DB {
+			return db.Order("measured_at DESC, id DESC")
+		}).
+		First(tireSet, id).Error
+}
+
+// ListTireSets lists the sets of a vehicle with their mounts and tread depths, mounted set first
+func (r *tireRepository) ListTireSets(ctx context.Context, userVehicleID uint64, tireSets *[]entity.TireSet) error {
+	return r.db.WithContext(ctx).
+		Preload("Mounts").
+		Preload("TreadDepths").
+		Where("user_vehicle_id = ?", userVehicleID).
+		Order("is_mounted DESC, purchase_date DESC, id DESC").
+		Find(tireSets).Error
+}
+
+func (r *tireRepository) UpdateTireSet(ctx context.Context, tireSet *entity.TireSet) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(tireSet).Error
+}
+
+// DeleteTireSet removes the set for good, its mounts, rotations and tread depths go with it
+func (r *tireRepository) DeleteTireSet(ctx context.Context, tireSet *entity.TireSet) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(tireSet).Error
+}
+
+func (r *tireRepository) MountTireSet(ctx context.Context, tireSet *entity.TireSet, mount *entity.TireMount) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return mountTireSet(tx, tireSet, mount)
+	})
+}
+
+// mountTireSet takes every set of the vehicle off at the mileage of the new mount and puts tireSet on
+func mountTireSet(tx *gorm.DB, tireSet *entity.TireSet, mount *entity.TireMount) error {
+	mountedSetIDs := tx.Model(&entity.TireSet{}).Select("id").Where("user_vehicle_id = ?", tireSet.UserVehicleID)
+	err := tx.Model(&entity.TireMount{}).
+		Where("tire_set_id IN (?) AND removed_mileage IS NULL", mountedSetIDs).
+		Updates(map[string]interface{}{
+			"removed_at":      mount.MountedAt,
+			"removed_mileage": mount.MountedMileage,
+		}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Model(&entity.TireSet{}).
+		Where("user_vehicle_id = ? AND is_mounted = ?", tireSet.UserVehicleID, true).
+		Update("is_mounted", false).Error
+	if err != nil {
+		return err
+	}
+
+	mount.TireSetID = tireSet.ID
+	if err := tx.Create(mount).Error; err != nil {
+		return err
+	}
+	tireSet.IsMounted = true
+	return tx.Model(tireSet).Update("is_mounted", true).Error
+}
+
+// UnmountTireSet closes the open mount of the set and puts it in storage
+func (r *tireRepository) UnmountTireSet(ctx context.Context, tireSet *entity.TireSet, mount *entity.TireMount) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(mount).Error; err != nil {
+			return err
+		}
+		tireSet.IsMounted = false
+		return tx.Model(tireSet).Update("is_mounted", false).Error
+	})
+}
+
+func (r *tireRepository) CreateTireRotation(ctx context.Context, rotation *entity.TireRotation) error {
+	return r.db.WithContext(ctx).Create(rotation).Error
+}
+
+// GetTireRotation leaves rotation untouched when no rotation has the id
+func (r *tireRepository) GetTireRotation(ctx context.Context, id uint64, rotation *entity.TireRotation) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(rotation).Error
+}
+
+func (r *tireRepository) DeleteTireRotation(ctx context.Context, rotation *entity.TireRotation) error {
+	return r.db.WithContext(ctx).Delete(rotation).Error
+}
+
+func (r *tireRepository) CreateTreadDepth(ctx context.Context, measurement *entity.TreadDepthMeasurement) error {
+	return r.db.WithContext(ctx).Create(measurement).Error
+}
+
+// GetTreadDepth leaves measurement untouched when no measurement has the id
+func (r *tireRepository) GetTreadDepth(ctx context.Context, id uint64, measurement *entity.TreadDepthMeasurement) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(measurement).Error
+}
+
+func (r *tireRepository) DeleteTreadDepth(ctx context.Context, measurement *entity.TreadDepthMeasurement) error {
+	return r.db.WithContext(ctx).Delete(measurement).Error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/amirdashtii/AutoBan/internal/domain/entity"
@@ -12,8 +13,8 @@ import (
 )
 
 type VehicleCacheRepository interface {
-	GetVehicleHierarchy(ctx context.Context, vehicleTypes *[]entity.VehicleType) error
-	SetVehicleHierarchy(ctx context.Context, vehicleTypes []entity.VehicleType) error
+	GetVehicleHierarchy(ctx context.Context, version uint64, vehicleTypes *[]entity.VehicleType) error
+	SetVehicleHierarchy(ctx context.Context, version uint64, vehicleTypes []entity.VehicleType) error
 	InvalidateVehicleHierarchy(ctx context.Context) error
 }
 
@@ -28,12 +29,13 @@ func NewVehicleCacheRepository() VehicleCacheRepository {
 }
 
 // ساخت کلید برای ذخیره hierarchy در Redis
-func makeVehicleHierarchyKey() string {
-	return "vehicle:hierarchy:complete"
+// The hierarchy is cached per catalog version, so a tree cached before a change is never served under a newer version
+func makeVehicleHierarchyKey(version uint64) string {
+	return fmt.Sprintf("vehicle:hierarchy:complete:%d", version)
 }
 
-func (r *vehicleCacheRepository) GetVehicleHierarchy(ctx context.Context, vehicleTypes *[]entity.VehicleType) error {
-	key := makeVehicleHierarchyKey()
+func (r *vehicleCacheRepository) GetVehicleHierarchy(ctx context.Context, version uint64, vehicleTypes *[]entity.VehicleType) error {
+	key := makeVehicleHierarchyKey(version)
 	data, err := r.client.Get(ctx, key).Result()
 	if err == redis.Nil {
 		return nil
@@ -49,13 +51,13 @@ func (r *vehicleCacheRepository) GetVehicleHierarchy(ctx context.Context, vehicl
 	return nil
 }
 
-func (r *vehicleCacheRepository) SetVehicleHierarchy(ctx context.Context, vehicleTypes []entity.VehicleType) error {
+func (r *vehicleCacheRepository) SetVehicleHierarchy(ctx context.Context, version uint64, vehicleTypes []entity.VehicleType) error {
 	vehicleTypesData, err := json.Marshal(vehicleTypes)
 	if err != nil {
 		return err
 	}
 
-	key := makeVehicleHierarchyKey()
+	key := makeVehicleHierarchyKey(version)
 	// Set cache with 24 hour expiration
 	err = r.client.Set(ctx, key, vehicleTypesData, 24*time.Hour).Err()
 	if err != nil {
@@ -64,9 +66,21 @@ func (r *vehicleCacheRepository) SetVehicleHierarchy(ctx context.Context, vehicl
 	return nil
 }
 
+// InvalidateVehicleHierarchy removes the trees cached for every catalog version. SCAN walks the keyspace in
+// batches, where KEYS would block Redis until it went through all of it
 func (r *vehicleCacheRepository) InvalidateVehicleHierarchy(ctx context.Context) error {
-	key := makeVehicleHierarchyKey()
-	err := r.client.Del(ctx, key).Err()
+	keys := []string{}
+	iter := r.client.Scan(ctx, 0, "vehicle:hierarchy:complete*", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	err := r.client.Del(ctx, keys...).Err()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type VehicleDocumentRepository interface {
+	CreateVehicleDocument(ctx context.Context, document *entity.VehicleDocument) error
+	GetVehicleDocument(ctx context.Context, id uint64, document *entity.VehicleDocument) error
+	ListVehicleDocuments(ctx context.Context, userVehicleID uint64, documents *[]entity.VehicleDocument) error
+	ListLatestVehicleDocuments(ctx context.Context, userVehicleID uint64, documents *[]entity.VehicleDocument) error
+	UpdateVehicleDocument(ctx context.Context, document *entity.VehicleDocument) error
+	UpdateVehicleDocumentScan(ctx context.Context, document *entity.VehicleDocument, quota int64) error
+	DeleteVehicleDocument(ctx context.Context, document *entity.VehicleDocument) error
+}
+
+type vehicleDocumentRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleDocumentRepository() VehicleDocumentRepository {
+	db := database.ConnectDatabase()
+	return &vehicleDocumentRepository{db: db}
+}
+
+func (r *vehicleDocumentRepository) CreateVehicleDocument(ctx context.Context, document *entity.VehicleDocument) error {
+	return r.db.WithContext(ctx).Create(document).Error
+}
+
+func (r *vehicleDocumentRepository) GetVehicleDocument(ctx context.Context, id uint64, document *entity.VehicleDocument) error {
+	return r.db.WithContext(ctx).First(document, id).Error
+}
+
+func (r *vehicleDocumentRepository) ListVehicleDocuments(ctx context.Context, userVehicleID uint64, documents *[]entity.VehicleDocument) error {
+	return r.db.WithContext(ctx).
+		Where("user_vehicle_id = ?", userVehicleID).
+		Order("expiry_date DESC, id DESC").
+		Find(documents).Error
+}
+
+// ListLatestVehicleDocuments returns the document of each type that expires last, so a renewed
+// policy replaces the one it renews
+func (r *vehicleDocumentRepository) ListLatestVehicleDocuments(ctx context.Context, userVehicleID uint64, documents *[]entity.VehicleDocument) error {
+	return r.db.WithContext(ctx).
+		Raw(`SELECT DISTINCT ON (document_type) * FROM vehicle_documents
+			WHERE user_vehicle_id = ? AND deleted_at IS NULL
+			ORDER BY document_type, expiry_date DESC, id DESC`, userVehicleID).
+		Scan(documents).Error
+}
+
+func (r *vehicleDocumentRepository) UpdateVehicleDocument(ctx context.Context, document *entity.VehicleDocument) error {
+	return r.db.WithContext(ctx).Save(document).Error
+}
+
+// UpdateVehicleDocumentScan saves the document with its new scan unless the scan would take the user past the
+// quota, in which case ErrAttachmentQuotaExceeded is returned. The scan it replaces frees its space
+func (r *vehicleDocumentRepository) UpdateVehicleDocumentScan(ctx context.Context, document *entity.VehicleDocument, quota int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		usage, err := lockUserAttachmentUsage(tx, document.UserID)
+		if err != nil {
+			return err
+		}
+		var replacedScanSize int64
+		err = tx.Model(&entity.VehicleDocument{}).Select("scan_size").Where("id = ?", document.ID).Scan(&replacedScanSize).Error
+		if err != nil {
+			return err
+		}
+		if usage-replacedScanSize+document.ScanSize > quota {
+			return errors.ErrAttachmentQuotaExceeded
+		}
+		return tx.Save(document).Error
+	})
+}
+
+// DeleteVehicleDocument removes the row for good, as its scan is deleted with it
+func (r *vehicleDocumentRepository) DeleteVehicleDocument(ctx context.Context, document *entity.VehicleDocument) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(document).Error
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VehicleExpenseRepository interface {
+	// Expenses
+	CreateVehicleExpense(ctx context.Context, expense *entity.VehicleExpense) error
+	GetVehicleExpense(ctx context.Context, id uint64, expense *entity.VehicleExpense) error
+	ListVehicleExpenses(ctx context.Context, userVehicleID uint64, expenses *[]entity.VehicleExpense) error
+	UpdateVehicleExpense(ctx context.Context, expense *entity.VehicleExpense) error
+	DeleteVehicleExpense(ctx context.Context, expense *entity.VehicleExpense) error
+
+	// Categories of a user
+	CreateUserExpenseCategory(ctx context.Context, category *entity.UserExpenseCategory) error
+	GetUserExpenseCategory(ctx context.Context, id uint64, category *entity.UserExpenseCategory) error
+	GetUserExpenseCategoryByName(ctx context.Context, userID uuid.UUID, name string, category *entity.UserExpenseCategory) error
+	ListUserExpenseCategories(ctx context.Context, userID uuid.UUID, categories *[]entity.UserExpenseCategory) error
+	UpdateUserExpenseCategory(ctx context.Context, category *entity.UserExpenseCategory) error
+	DeleteUserExpenseCategory(ctx context.Context, category *entity.UserExpenseCategory) error
+	CountUserExpenseCategoryExpenses(ctx context.Context, categoryID uint64) (int64, error)
+}
+
+type vehicleExpenseRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleExpenseRepository() VehicleExpenseRepository {
+	db := database.ConnectDatabase()
+	return &vehicleExpenseRepository{db: db}
+}
+
+func (r *vehicleExpenseRepository) CreateVehicleExpense(ctx context.Context, expense *entity.VehicleExpense) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(expense).Error
+}
+
+func (r *vehicleExpenseRepository) GetVehicleExpense(ctx context.Context, id uint64, expense *entity.VehicleExpense) error {
+	return r.db.WithContext(ctx).Preload("UserExpenseCategory").First(expense, id).Error
+}
+
+func (r *vehicleExpenseRepository) ListVehicleExpenses(ctx context.Context, userVehicleID uint64, expenses *[]entity.VehicleExpense) error {
+	return r.db.WithContext(ctx).
+		Preload("UserExpenseCategory").
+		Where("user_vehicle_id = ?", userVehicleID).
+		Order("expense_date DESC, id DESC").
+		Find(expenses).Error
+}
+
+// UpdateVehicleExpense saves the expense only, its category is changed through UserExpenseCategoryID
+func (r *vehicleExpenseRepository) UpdateVehicleExpense(ctx context.Context, expense *entity.VehicleExpense) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(expense).Error
+}
+
+func (r *vehicleExpenseRepository) DeleteVehicleExpense(ctx context.Context, expense *entity.VehicleExpense) error {
+	return r.db.WithContext(ctx).Delete(expense).Error
+}
+
+func (r *vehicleExpenseRepository) CreateUserExpenseCategory(ctx context.Context, category *entity.UserExpenseCategory) error {
+	return r.db.WithContext(ctx).Create(category).Error
+}
+
+// GetUserExpenseCategory leaves category untouched when no category has the id
+func (r *vehicleExpenseRepository) GetUserExpenseCategory(ctx context.Context, id uint64, category *entity.UserExpenseCategory) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(category).Error
+}
+
+// GetUserExpenseCategoryByName leaves category untouched when the user has no category of that name
+func (r *vehicleExpenseRepository) GetUserExpenseCategoryByName(ctx context.Context, userID uuid.UUID, name string, category *entity.UserExpenseCategory) error {
+	return r.db.WithContext(ctx).Where("user_id = ? AND name = ?", userID, name).Limit(1).Find(category).Error
+}
+
+func (r *vehicleExpenseRepository) ListUserExpenseCategories(ctx context.Context, userID uuid.UUID, categories *[]entity.UserExpenseCategory) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Order("name").Find(categories).Error
+}
+
+func (r *vehicleExpenseRepository) UpdateUserExpenseCategory(ctx context.Context, category *entity.UserExpenseCategory) error {
+	return r.db.WithContext(ctx).Save(category).Error
+}
+
+// DeleteUserExpenseCategory removes the row for good so its name can be used again
+func (r *vehicleExpenseRepository) DeleteUserExpenseCategory(ctx context.Context, category *entity.UserExpenseCategory) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(category).Error
+}
+
+func (r *vehicleExpenseRepository) CountUserExpenseCategoryExpenses(ctx context.Context, categoryID uint64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.VehicleExpense{}).
+		Where("user_expense_category_id = ?", categoryID).
+		Count(&count).Error
+	return count, err
+}
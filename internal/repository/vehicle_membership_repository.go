@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type VehicleMembershipRepository interface {
+	CreateVehicleMembership(ctx context.Context, membership *entity.VehicleMembership) error
+	GetVehicleMembership(ctx context.Context, id uint64, membership *entity.VehicleMembership) error
+	GetVehicleMembershipByPhoneNumber(ctx context.Context, userVehicleID uint64, phoneNumber string, membership *entity.VehicleMembership) error
+	GetAcceptedVehicleMembership(ctx context.Context, userVehicleID uint64, userID uuid.UUID, membership *entity.VehicleMembership) error
+	ListVehicleMemberships(ctx context.Context, userVehicleID uint64, memberships *[]entity.VehicleMembership) error
+	ListUserVehicleMemberships(ctx context.Context, userID uuid.UUID, phoneNumber string, memberships *[]entity.VehicleMembership) error
+	UpdateVehicleMembership(ctx context.Context, membership *entity.VehicleMembership) error
+	DeleteVehicleMembership(ctx context.Context, membership *entity.VehicleMembership) error
+
+	// GetSharedUserVehicle loads a user vehicle whoever owns it, for members of the vehicle and share links
+	GetSharedUserVehicle(ctx context.Context, userVehicleID uint64, userVehicle *entity.UserVehicle) error
+}
+
+type vehicleMembershipRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleMembershipRepository() VehicleMembershipRepository {
+	db := database.ConnectDatabase()
+	return &vehicleMembershipRepository{db: db}
+}
+
+func (r *vehicleMembershipRepository) CreateVehicleMembership(ctx context.Context, membership *entity.VehicleMembership) error {
+	return r.db.WithContext(ctx).Create(membership).Error
+}
+
+// GetVehicleMembership leaves membership untouched when no membership has the id
+func (r *vehicleMembershipRepository) GetVehicleMembership(ctx context.Context, id uint64, membership *entity.VehicleMembership) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(membership).Error
+}
+
+func (r *vehicleMembershipRepository) GetVehicleMembershipByPhoneNumber(ctx context.Context, userVehicleID uint64, phoneNumber string, membership *entity.VehicleMembership) error {
+	return r.db.WithContext(ctx).
+		Where("user_vehicle_id = ? AND phone_number = ?", userVehicleID, phoneNumber).
+		Limit(1).
+		Find(membership).Error
+}
+
+func (r *vehicleMembershipRepository) GetAcceptedVehicleMembership(ctx context.Context, userVehicleID uint64, userID uuid.UUID, membership *entity.VehicleMembership) error {
+	return r.db.WithContext(ctx).
+		Where("user_vehicle_id = ? AND user_id = ? AND status = ?", userVehicleID, userID, entity.AcceptedVehicleMembership).
+		Limit(1).
+		Find(membership).Error
+}
+
+func (r *vehicleMembershipRepository) ListVehicleMemberships(ctx context.Context, userVehicleID uint64, memberships *[]entity.VehicleMembership) error {
+	return r.db.WithContext(ctx).Where("user_vehicle_id = ?", userVehicleID).Order("created_at").Find(memberships).Error
+}
+
+// ListUserVehicleMemberships finds the memberships a user accepted and the invitations sent to their phone number
+func (r *vehicleMembershipRepository) ListUserVehicleMemberships(ctx context.Context, userID uuid.UUID, phoneNumber string, memberships *[]entity.VehicleMembership) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? OR (phone_number = ? AND status = ?)", userID, phoneNumber, entity.InvitedVehicleMembership).
+		Order("created_at DESC").
+		Find(memberships).Error
+}
+
+func (r *vehicleMembershipRepository) UpdateVehicleMembership(ctx context.Context, membership *entity.VehicleMembership) error {
+	return r.db.WithContext(ctx).Save(membership).Error
+}
+
+func (r *vehicleMembershipRepository) DeleteVehicleMembership(ctx context.Context, membership *entity.VehicleMembership) error {
+	return r.db.WithContext(ctx).Delete(membership).Error
+}
+
+func (r *vehicleMembershipRepository) GetSharedUserVehicle(ctx context.Context, userVehicleID uint64, userVehicle *entity.UserVehicle) error {
+	return r.db.WithContext(ctx).Where("id = ?", userVehicleID).Limit(1).Find(userVehicle).Error
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/errors"
 	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
 	"github.com/amirdashtii/AutoBan/pkg/logger"
 	"github.com/google/uuid"
@@ -17,34 +18,35 @@ type VehicleRepository interface {
 	GetVehicleType(ctx context.Context, vehicleType *entity.VehicleType) error
 	CreateVehicleType(ctx context.Context, vehicleType *entity.VehicleType) error
 	UpdateVehicleType(ctx context.Context, vehicleType *entity.VehicleType) error
-	DeleteVehicleType(ctx context.Context, vehicleType *entity.VehicleType) error
+	DeleteVehicleType(ctx context.Context, vehicleType *entity.VehicleType, reassignToGenerationID uint64) error
 
 	ListBrands(ctx context.Context, brands *[]entity.VehicleBrand) error
 	GetBrand(ctx context.Context, brand *entity.VehicleBrand) error
 	ListBrandsByType(ctx context.Context, brands *[]entity.VehicleBrand, typeID uint64) error
 	CreateBrand(ctx context.Context, brand *entity.VehicleBrand) error
 	UpdateBrand(ctx context.Context, brand *entity.VehicleBrand) error
-	DeleteBrand(ctx context.Context, brand *entity.VehicleBrand) error
+	DeleteBrand(ctx context.Context, brand *entity.VehicleBrand, reassignToGenerationID uint64) error
 
 	ListModels(ctx context.Context, models *[]entity.VehicleModel) error
 	GetModel(ctx context.Context, model *entity.VehicleModel) error
 	ListModelsByBrand(ctx context.Context, models *[]entity.VehicleModel, brandID uint64) error
 	CreateModel(ctx context.Context, model *entity.VehicleModel) error
 	UpdateModel(ctx context.Context, model *entity.VehicleModel) error
-	DeleteModel(ctx context.Context, model *entity.VehicleModel) error
+	DeleteModel(ctx context.Context, model *entity.VehicleModel, reassignToGenerationID uint64) error
 
 	ListGenerations(ctx context.Context, generations *[]entity.VehicleGeneration) error
 	GetGeneration(ctx context.Context, generation *entity.VehicleGeneration) error
 	ListGenerationsByModel(ctx context.Context, generations *[]entity.VehicleGeneration, modelID uint64) error
 	CreateGeneration(ctx context.Context, generation *entity.VehicleGeneration) error
 	UpdateGeneration(ctx context.Context, generation *entity.VehicleGeneration) error
-	DeleteGeneration(ctx context.Context, generation *entity.VehicleGeneration) error
+	DeleteGeneration(ctx context.Context, generation *entity.VehicleGeneration, reassignToGenerationID uint64) error
 
 	CreateUserVehicle(ctx context.Context, userVehicle *entity.UserVehicle) error
 	ListUserVehicles(ctx context.Context, userID uuid.UUID, userVehicles *[]entity.UserVehicle) error
 	GetUserVehicle(ctx context.Context, userID uuid.UUID, vehicleId uint64, userVehicle *entity.UserVehicle) error
 	UpdateUserVehicle(ctx context.Context, userVehicle *entity.UserVehicle) error
 	DeleteUserVehicle(ctx context.Context, userVehicle *entity.UserVehicle) error
+	IsLicensePlateRegistered(ctx context.Context, licensePlate string, excludeVehicleID uint64) (bool, error)
 
 	// Complete hierarchy methods
 	GetCompleteVehicleHierarchy(ctx context.Context, vehicleTypes *[]entity.VehicleType) error
@@ -74,15 +76,25 @@ func (r *vehicleRepository) GetVehicleType(ctx context.Context, vehicleType *ent
 }
 
 func (r *vehicleRepository) CreateVehicleType(ctx context.Context, vehicleType *entity.VehicleType) error {
-	return r.db.WithContext(ctx).Create(vehicleType).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(vehicleType).Error; err != nil {
+			return err
+		}
+		return recordCatalogChanges(tx, CatalogRowIDs{Types: []uint64{vehicleType.ID}})
+	})
 }
 
 func (r *vehicleRepository) UpdateVehicleType(ctx context.Context, vehicleType *entity.VehicleType) error {
-	return r.db.WithContext(ctx).Model(vehicleType).Updates(vehicleType).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(vehicleType).Updates(vehicleType).Error; err != nil {
+			return err
+		}
+		return recordCatalogChanges(tx, CatalogRowIDs{Types: []uint64{vehicleType.ID}})
+	})
 }
 
-func (r *vehicleRepository) DeleteVehicleType(ctx context.Context, vehicleType *entity.VehicleType) error {
-	return r.db.WithContext(ctx).Delete(vehicleType).Error
+func (r *vehicleRepository) DeleteVehicleType(ctx context.Context, vehicleType *entity.VehicleType, reassignToGenerationID uint64) error {
+	return r.deleteCatalogRow(ctx, entity.CatalogLevelType, vehicleType.ID, vehicleType, reassignToGenerationID)
 }
 
 // Brands
@@ -99,15 +111,25 @@ func (r *vehicleRepository) GetBrand(ctx context.Context, brand *entity.VehicleB
 }
 
 func (r *vehicleRepository) CreateBrand(ctx context.Context, brand *entity.VehicleBrand) error {
-	return r.db.WithContext(ctx).Create(brand).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(brand).Error; err != nil {
+			return err
+		}
+		return recordCatalogChanges(tx, CatalogRowIDs{Brands: []uint64{brand.ID}})
+	})
 }
 
 func (r *vehicleRepository) UpdateBrand(ctx context.Context, brand *entity.VehicleBrand) error {
-	return r.db.WithContext(ctx).Model(brand).Updates(brand).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(brand).Updates(brand).Error; err != nil {
+			return err
+		}
+		return recordCatalogChanges(tx, CatalogRowIDs{Brands: []uint64{brand.ID}})
+	})
 }
 
-func (r *vehicleRepository) DeleteBrand(ctx context.Context, brand *entity.VehicleBrand) error {
-	return r.db.WithContext(ctx).Delete(brand).Error
+func (r *vehicleRepository) DeleteBrand(ctx context.Context, brand *entity.VehicleBrand, reassignToGenerationID uint64) error {
+	return r.deleteCatalogRow(ctx, entity.CatalogLevelBrand, brand.ID, brand, reassignToGenerationID)
 }
 
 // Models
@@ -124,15 +146,25 @@ func (r *vehicleRepository) GetModel(ctx context.Context, model *entity.VehicleM
 }
 
 func (r *vehicleRepository) CreateModel(ctx context.Context, model *entity.VehicleModel) error {
-	return r.db.WithContext(ctx).Create(model).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		return recordCatalogChanges(tx, CatalogRowIDs{Models: []uint64{model.ID}})
+	})
 }
 
 func (r *vehicleRepository) UpdateModel(ctx context.Context, model *entity.VehicleModel) error {
-	return r.db.WithContext(ctx).Model(model).Updates(model).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(model).Updates(model).Error; err != nil {
+			return err
+		}
+		return recordCatalogChanges(tx, CatalogRowIDs{Models: []uint64{model.ID}})
+	})
 }
 
-func (r *vehicleRepository) DeleteModel(ctx context.Context, model *entity.VehicleModel) error {
-	return r.db.WithContext(ctx).Delete(model).Error
+func (r *vehicleRepository) DeleteModel(ctx context.Context, model *entity.VehicleModel, reassignToGenerationID uint64) error {
+	return r.deleteCatalogRow(ctx, entity.CatalogLevelModel, model.ID, model, reassignToGenerationID)
 }
 
 // Generations
@@ -148,21 +180,56 @@ func (r *vehicleRepository) ListGenerationsByModel(ctx context.Context, generati
 	return r.db.WithContext(ctx).Where("model_id = ?", modelID).Find(generations).Error
 }
 func (r *vehicleRepository) CreateGeneration(ctx context.Context, generation *entity.VehicleGeneration) error {
-	return r.db.WithContext(ctx).Create(generation).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(generation).Error; err != nil {
+			return err
+		}
+		return recordCatalogChanges(tx, CatalogRowIDs{Generations: []uint64{generation.ID}})
+	})
 }
 
 func (r *vehicleRepository) UpdateGeneration(ctx context.Context, generation *entity.VehicleGeneration) error {
-	return r.db.WithContext(ctx).Model(generation).Updates(generation).Error
-}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(generation).Updates(generation).Error; err != nil {
+			return err
+		}
+		return recordCatalogChanges(tx, CatalogRowIDs{Generations: []uint64{generation.ID}})
+	})
+}
+
+func (r *vehicleRepository) DeleteGeneration(ctx context.Context, generation *entity.VehicleGeneration, reassignToGenerationID uint64) error {
+	return r.deleteCatalogRow(ctx, entity.CatalogLevelGeneration, generation.ID, generation, reassignToGenerationID)
+}
+
+// deleteCatalogRow soft deletes a type, brand, model or generation. The user vehicles on the generations under it
+// are moved to reassignToGenerationID first when it is set
+func (r *vehicleRepository) deleteCatalogRow(ctx context.Context, level string, id uint64, row any, reassignToGenerationID uint64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := reassignUserVehicles(tx, level, id, reassignToGenerationID); err != nil {
+			return err
+		}
+		if err := tx.Delete(row).Error; err != nil {
+			return err
+		}
+		return recordCatalogChanges(tx, catalogRowIDs(level, id))
+	})
+	if err != nil || reassignToGenerationID == 0 {
+		return err
+	}
 
-func (r *vehicleRepository) DeleteGeneration(ctx context.Context, generation *entity.VehicleGeneration) error {
-	return r.db.WithContext(ctx).Delete(generation).Error
+	// Reassigned vehicles may belong to any user
+	r.cache.DeleteByPattern(ctx, BuildCacheKey(CacheKeyUserVehicles)+"*")
+	return nil
 }
 
 // User Vehicles with caching
 func (r *vehicleRepository) CreateUserVehicle(ctx context.Context, userVehicle *entity.UserVehicle) error {
 	err := r.db.WithContext(ctx).Create(userVehicle).Error
 	if err != nil {
+		// The only unique index on user vehicles is the one on active license plates
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.ErrLicensePlateAlreadyRegistered
+		}
 		return err
 	}
 
@@ -226,6 +293,9 @@ func (r *vehicleRepository) GetUserVehicle(ctx context.Context, userID uuid.UUID
 func (r *vehicleRepository) UpdateUserVehicle(ctx context.Context, userVehicle *entity.UserVehicle) error {
 	err := r.db.WithContext(ctx).Model(userVehicle).Updates(userVehicle).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.ErrLicensePlateAlreadyRegistered
+		}
 		return err
 	}
 
@@ -255,6 +325,16 @@ func (r *vehicleRepository) DeleteUserVehicle(ctx context.Context, userVehicle *
 	return nil
 }
 
+// IsLicensePlateRegistered reports whether an active vehicle other than excludeVehicleID has the plate
+func (r *vehicleRepository) IsLicensePlateRegistered(ctx context.Context, licensePlate string, excludeVehicleID uint64) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.UserVehicle{}).
+		Where("license_plate = ? AND id <> ?", licensePlate, excludeVehicleID).
+		Count(&count).Error
+	return count > 0, err
+}
+
 // Complete hierarchy methods with caching
 func (r *vehicleRepository) GetCompleteVehicleHierarchy(ctx context.Context, vehicleTypes *[]entity.VehicleType) error {
 
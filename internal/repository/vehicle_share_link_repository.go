@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type VehicleShareLinkRepository interface {
+	CreateVehicleShareLink(ctx context.Context, link *entity.VehicleShareLink) error
+	GetVehicleShareLink(ctx context.Context, id uint64, link *entity.VehicleShareLink) error
+	GetVehicleShareLinkByNonce(ctx context.Context, nonce string, link *entity.VehicleShareLink) error
+	ListVehicleShareLinks(ctx context.Context, userVehicleID uint64, links *[]entity.VehicleShareLink) error
+	UpdateVehicleShareLink(ctx context.Context, link *entity.VehicleShareLink) error
+	RecordVehicleShareLinkView(ctx context.Context, id uint64, viewedAt time.Time) error
+}
+
+type vehicleShareLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleShareLinkRepository() VehicleShareLinkRepository {
+	db := database.ConnectDatabase()
+	return &vehicleShareLinkRepository{db: db}
+}
+
+func (r *vehicleShareLinkRepository) CreateVehicleShareLink(ctx context.Context, link *entity.VehicleShareLink) error {
+	return r.db.WithContext(ctx).Create(link).Error
+}
+
+// GetVehicleShareLink leaves link untouched when no link has the id
+func (r *vehicleShareLinkRepository) GetVehicleShareLink(ctx context.Context, id uint64, link *entity.VehicleShareLink) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(link).Error
+}
+
+// GetVehicleShareLinkByNonce leaves link untouched when no link has the nonce
+func (r *vehicleShareLinkRepository) GetVehicleShareLinkByNonce(ctx context.Context, nonce string, link *entity.VehicleShareLink) error {
+	return r.db.WithContext(ctx).Where("nonce = ?", nonce).Limit(1).Find(link).Error
+}
+
+func (r *vehicleShareLinkRepository) ListVehicleShareLinks(ctx context.Context, userVehicleID uint64, links *[]entity.VehicleShareLink) error {
+	return r.db.WithContext(ctx).Where("user_vehicle_id = ?", userVehicleID).Order("created_at DESC").Find(links).Error
+}
+
+func (r *vehicleShareLinkRepository) UpdateVehicleShareLink(ctx context.Context, link *entity.VehicleShareLink) error {
+	return r.db.WithContext(ctx).Save(link).Error
+}
+
+// RecordVehicleShareLinkView counts a view in the database so concurrent views are not lost
+func (r *vehicleShareLinkRepository) RecordVehicleShareLinkView(ctx context.Context, id uint64, viewedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&entity.VehicleShareLink{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"view_count":     gorm.Expr("view_count + 1"),
+		"last_viewed_at": viewedAt,
+	}).Error
+}
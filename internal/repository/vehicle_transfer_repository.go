@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// vehicleOwnedRecords are the records of a vehicle that carry its owner's id and move with it on a transfer
+var vehicleOwnedRecords = []interface{}{
+	&entity.ServiceVisit{},
+	&entity.OilChange{},
+	&entity.OilFilter{},
+	&entity.ServiceItem{},
+	&entity.ServiceVisitAttachment{},
+	&entity.OdometerReading{},
+	&entity.FuelLog{},
+	&entity.VehicleDocument{},
+	&entity.VehicleExpense{},
+	&entity.TireSet{},
+}
+
+type VehicleTransferRepository interface {
+	// Transfers
+	CreateVehicleTransfer(ctx context.Context, transfer *entity.VehicleTransfer) error
+	GetVehicleTransfer(ctx context.Context, id uint64, transfer *entity.VehicleTransfer) error
+	GetOpenVehicleTransfer(ctx context.Context, userVehicleID uint64, now time.Time, transfer *entity.VehicleTransfer) error
+	ListIncomingVehicleTransfers(ctx context.Context, toUserID uuid.UUID, now time.Time, transfers *[]entity.VehicleTransfer) error
+	UpdateVehicleTransfer(ctx context.Context, transfer *entity.VehicleTransfer) error
+	CompleteVehicleTransfer(ctx context.Context, transfer *entity.VehicleTransfer, previousOwnerSince time.Time) error
+
+	// Ownership history
+	CreateVehicleOwnership(ctx context.Context, ownership *entity.VehicleOwnership) error
+	ListVehicleOwnerships(ctx context.Context, userVehicleID uint64, ownerships *[]entity.VehicleOwnership) error
+}
+
+type vehicleTransferRepository struct {
+	db    *gorm.DB
+	cache CacheRepository
+}
+
+func NewVehicleTransferRepository() VehicleTransferRepository {
+	db := database.ConnectDatabase()
+	return &vehicleTransferRepository{db: db, cache: NewCacheRepository()}
+}
+
+func (r *vehicleTransferRepository) CreateVehicleTransfer(ctx context.Context, transfer *entity.VehicleTransfer) error {
+	return r.db.WithContext(ctx).Create(transfer).Error
+}
+
+// GetVehicleTransfer leaves transfer untouched when no transfer has the id
+func (r *vehicleTransferRepository) GetVehicleTransfer(ctx context.Context, id uint64, transfer *entity.VehicleTransfer) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(transfer).Error
+}
+
+// GetOpenVehicleTransfer finds the pending, unexpired transfer of the vehicle. transfer is left untouched when there is none
+func (r *vehicleTransferRepository) GetOpenVehicleTransfer(ctx context.Context, userVehicleID uint64, now time.Time, transfer *entity.VehicleTransfer) error {
+	return r.db.WithContext(ctx).
+		Where("user_vehicle_id = ? AND status = ? AND expires_at > ?", userVehicleID, entity.PendingVehicleTransfer, now).
+		Limit(1).
+		Find(transfer).Error
+}
+
+func (r *vehicleTransferRepository) ListIncomingVehicleTransfers(ctx context.Context, toUserID uuid.UUID, now time.Time, transfers *[]entity.VehicleTransfer) error {
+	return r.db.WithContext(ctx).
+		Where("to_user_id = ? AND status = ? AND expires_at > ?", toUserID, entity.PendingVehicleTransfer, now).
+		Order("created_at DESC").
+		Find(transfers).Error
+}
+
+func (r *vehicleTransferRepository) UpdateVehicleTransfer(ctx context.Context, transfer *entity.VehicleTransfer) error {
+	return r.db.WithContext(ctx).Save(transfer).Error
+}
+
+// CompleteVehicleTransfer moves the vehicle and every record of it to the recipient, with its custom expenses
+// refiled under the recipient's own categories, closes the previous owner's ownership and opens the recipient's,
+// all in one transaction. When the previous owner has no ownership record yet, one starting at
+// previousOwnerSince is added so the history stays complete
+func (r *vehicleTransferRepository) CompleteVehicleTransfer(ctx context.Context, transfer *entity.VehicleTransfer, previousOwnerSince time.Time) error {
+	completedAt := *transfer.CompletedAt
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The owner check guards against the vehicle changing hands while the transfer was pending
+		result := tx.Model(&entity.UserVehicle{}).
+			Where("id = ? AND user_id = ?", transfer.UserVehicleID, transfer.FromUserID).
+			Update("user_id", transfer.ToUserID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrVehicleTransferNotOpen
+		}
+
+		if err := moveUserExpenseCategories(tx, transfer); err != nil {
+			return err
+		}
+		for _, record := range vehicleOwnedRecords {
+			err := tx.Unscoped().Model(record).
+				Where("user_vehicle_id = ? AND user_id = ?", transfer.UserVehicleID, transfer.FromUserID).
+				Update("user_id", transfer.ToUserID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		result = tx.Model(&entity.VehicleOwnership{}).
+			Where("user_vehicle_id = ? AND ended_at IS NULL", transfer.UserVehicleID).
+			Update("ended_at", completedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			previous := entity.VehicleOwnership{
+				UserVehicleID: transfer.UserVehicleID,
+				UserID:        transfer.FromUserID,
+				StartedAt:     previousOwnerSince,
+				EndedAt:       &completedAt,
+			}
+			if err := tx.Create(&previous).Error; err != nil {
+				return err
+			}
+		}
+		// The people the previous owner shared the vehicle with lose access, the recipient shares it anew
+		err := tx.Where("user_vehicle_id = ?", transfer.UserVehicleID).Delete(&entity.VehicleMembership{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&entity.VehicleShareLink{}).
+			Where("user_vehicle_id = ? AND revoked_at IS NULL", transfer.UserVehicleID).
+			Update("revoked_at", completedAt).Error
+		if err != nil {
+			return err
+		}
+
+		current := entity.VehicleOwnership{
+			UserVehicleID: transfer.UserVehicleID,
+			UserID:        transfer.ToUserID,
+			StartedAt:     completedAt,
+			TransferID:    &transfer.ID,
+		}
+		if err := tx.Create(&current).Error; err != nil {
+			return err
+		}
+
+		return tx.Save(transfer).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	// Invalidate the vehicle caches of both owners
+	for _, userID := range []uuid.UUID{transfer.FromUserID, transfer.ToUserID} {
+		r.cache.Delete(ctx, BuildCacheKey(CacheKeyUserVehicles, userID.String()))
+		r.cache.Delete(ctx, BuildCacheKey(CacheKeyUserVehicles, userID.String(), fmt.Sprintf("%d", transfer.UserVehicleID)))
+	}
+	return nil
+}
+
+// moveUserExpenseCategories files the custom expenses of the vehicle under the recipient's categories of the same
+// name, adding the ones the recipient doesn't have yet. The previous owner's categories stay theirs
+func moveUserExpenseCategories(tx *gorm.DB, transfer *entity.VehicleTransfer) error {
+	var categoryIDs []uint64
+	err := tx.Unscoped().Model(&entity.VehicleExpense{}).
+		Where("user_vehicle_id = ? AND user_id = ? AND user_expense_category_id IS NOT NULL", transfer.UserVehicleID, transfer.FromUserID).
+		Distinct().
+		Pluck("user_expense_category_id", &categoryIDs).Error
+	if err != nil || len(categoryIDs) == 0 {
+		return err
+	}
+	categories := []entity.UserExpenseCategory{}
+	if err := tx.Where("id IN ?", categoryIDs).Find(&categories).Error; err != nil {
+		return err
+	}
+
+	for _, category := range categories {
+		recipientCategory := entity.UserExpenseCategory{}
+		err := tx.Where("user_id = ? AND name = ?", transfer.ToUserID, category.Name).Limit(1).Find(&recipientCategory).Error
+		if err != nil {
+			return err
+		}
+		if recipientCategory.ID == 0 {
+			recipientCategory = entity.UserExpenseCategory{UserID: transfer.ToUserID, Name: category.Name}
+			if err := tx.Create(&recipientCategory).Error; err != nil {
+				return err
+			}
+		}
+		err = tx.Unscoped().Model(&entity.VehicleExpense{}).
+			Where("user_vehicle_id = ? AND user_id = ? AND user_expense_category_id = ?", transfer.UserVehicleID, transfer.FromUserID, category.ID).
+			Update("user_expense_category_id", recipientCategory.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *vehicleTransferRepository) CreateVehicleOwnership(ctx context.Context, ownership *entity.VehicleOwnership) error {
+	return r.db.WithContext(ctx).Create(ownership).Error
+}
+
+func (r *vehicleTransferRepository) ListVehicleOwnerships(ctx context.Context, userVehicleID uint64, ownerships *[]entity.VehicleOwnership) error {
+	return r.db.WithContext(ctx).Where("user_vehicle_id = ?", userVehicleID).Order("started_at, id").Find(ownerships).Error
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type VehicleWMIRepository interface {
+	ListVehicleWMIs(ctx context.Context, wmis *[]entity.VehicleWMI) error
+	ListVehicleWMIsByCode(ctx context.Context, wmi string, wmis *[]entity.VehicleWMI) error
+	GetVehicleWMI(ctx context.Context, id uint64, wmi *entity.VehicleWMI) error
+	CreateVehicleWMI(ctx context.Context, wmi *entity.VehicleWMI) error
+	UpdateVehicleWMI(ctx context.Context, wmi *entity.VehicleWMI) error
+	DeleteVehicleWMI(ctx context.Context, wmi *entity.VehicleWMI) error
+}
+
+type vehicleWMIRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleWMIRepository() VehicleWMIRepository {
+	db := database.ConnectDatabase()
+	return &vehicleWMIRepository{db: db}
+}
+
+func (r *vehicleWMIRepository) ListVehicleWMIs(ctx context.Context, wmis *[]entity.VehicleWMI) error {
+	return r.db.WithContext(ctx).Order("wmi, vds_prefix").Find(wmis).Error
+}
+
+// ListVehicleWMIsByCode returns the rows of one WMI, longest VDS prefix first so the most specific match comes first
+func (r *vehicleWMIRepository) ListVehicleWMIsByCode(ctx context.Context, wmi string, wmis *[]entity.VehicleWMI) error {
+	return r.db.WithContext(ctx).
+		Where("wmi = ?", wmi).
+		Order("LENGTH(vds_prefix) DESC, vds_prefix").
+		Find(wmis).Error
+}
+
+// GetVehicleWMI leaves wmi untouched when no row has the id
+func (r *vehicleWMIRepository) GetVehicleWMI(ctx context.Context, id uint64, wmi *entity.VehicleWMI) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(wmi).Error
+}
+
+func (r *vehicleWMIRepository) CreateVehicleWMI(ctx context.Context, wmi *entity.VehicleWMI) error {
+	return r.db.WithContext(ctx).Create(wmi).Error
+}
+
+func (r *vehicleWMIRepository) UpdateVehicleWMI(ctx context.Context, wmi *entity.VehicleWMI) error {
+	return r.db.WithContext(ctx).Save(wmi).Error
+}
+
+// DeleteVehicleWMI removes the row for good so the WMI and VDS prefix can be added again
+func (r *vehicleWMIRepository) DeleteVehicleWMI(ctx context.Context, wmi *entity.VehicleWMI) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(wmi).Error
+}
@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/amirdashtii/AutoBan/config"
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/storage"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// allowedAttachmentTypes maps the sniffed content types accepted for invoices to the file extension they are stored with
+var allowedAttachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+type AttachmentUseCase interface {
+	UploadAttachment(ctx context.Context, userID, vehicleID, visitID, fileName string, size int64, content io.Reader) (*dto.AttachmentResponse, error)
+	ListAttachments(ctx context.Context, userID, vehicleID, visitID string) (*dto.ListAttachmentsResponse, error)
+	DownloadAttachment(ctx context.Context, userID, vehicleID, visitID, attachmentID string) (*dto.AttachmentResponse, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, userID, vehicleID, visitID, attachmentID string) error
+}
+
+type attachmentUseCase struct {
+	attachmentRepository   repository.AttachmentRepository
+	serviceVisitRepository repository.ServiceVisitRepository
+	storage                storage.Storage
+	maxFileSize            int64
+	userQuota              int64
+	vehicleAccess          *vehicleAccess
+}
+
+func NewAttachmentUseCase() AttachmentUseCase {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		logger.Error(err, "Failed to get config")
+		return nil
+	}
+	attachmentRepository := repository.NewAttachmentRepository()
+	serviceVisitRepository := repository.NewServiceVisitRepository()
+	return &attachmentUseCase{
+		attachmentRepository:   attachmentRepository,
+		serviceVisitRepository: serviceVisitRepository,
+		storage:                storage.GetStorage(),
+		maxFileSize:            int64(cfg.Attachment.MaxFileSizeMB) << 20,
+		userQuota:              int64(cfg.Attachment.UserQuotaMB) << 20,
+		vehicleAccess:          newVehicleAccess(repository.NewVehicleRepository(), repository.NewVehicleMembershipRepository()),
+	}
+}
+
+func (uc *attachmentUseCase) UploadAttachment(ctx context.Context, userID, vehicleID, visitID, fileName string, size int64, content io.Reader) (*dto.AttachmentResponse, error) {
+	serviceVisit, err := uc.getServiceVisitOfVehicle(ctx, userID, vehicleID, visitID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		return nil, errors.ErrAttachmentFileRequired
+	}
+	if size > uc.maxFileSize {
+		logger.Error(errors.ErrAttachmentTooLarge, "Attachment exceeds max file size")
+		return nil, errors.ErrAttachmentTooLarge
+	}
+
+	// Trust the file's bytes rather than the client supplied header
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		logger.Error(err, "Failed to read attachment")
+		return nil, errors.ErrFailedToUploadAttachment
+	}
+	head = head[:n]
+	contentType, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	extension, ok := allowedAttachmentTypes[contentType]
+	if !ok {
+		logger.Error(errors.ErrAttachmentTypeNotAllowed, "Attachment content type not allowed: "+contentType)
+		return nil, errors.ErrAttachmentTypeNotAllowed
+	}
+
+	// The quota is checked again when the attachment is saved; this spares storing a file that would not fit
+	usage, err := uc.attachmentRepository.GetUserAttachmentUsage(ctx, serviceVisit.UserID)
+	if err != nil {
+		logger.Error(err, "Failed to get user attachment usage")
+		return nil, errors.ErrFailedToUploadAttachment
+	}
+	if usage+size > uc.userQuota {
+		logger.Error(errors.ErrAttachmentQuotaExceeded, "User attachment quota exceeded")
+		return nil, errors.ErrAttachmentQuotaExceeded
+	}
+
+	storageKey, err := newAttachmentStorageKey(serviceVisit, extension)
+	if err != nil {
+		logger.Error(err, "Failed to generate attachment storage key")
+		return nil, errors.ErrFailedToUploadAttachment
+	}
+
+	body := io.LimitReader(io.MultiReader(bytes.NewReader(head), content), size)
+	err = uc.storage.Put(ctx, storageKey, body, size, contentType)
+	if err != nil {
+		logger.Error(err, "Failed to store attachment")
+		return nil, errors.ErrFailedToUploadAttachment
+	}
+
+	attachment := entity.ServiceVisitAttachment{
+		UserID:         serviceVisit.UserID,
+		UserVehicleID:  serviceVisit.UserVehicleID,
+		ServiceVisitID: serviceVisit.ID,
+		FileName:       fileName,
+		ContentType:    contentType,
+		Size:           size,
+		StorageKey:     storageKey,
+	}
+	err = uc.attachmentRepository.CreateAttachmentWithinQuota(ctx, &attachment, uc.userQuota)
+	if err != nil {
+		logger.Error(err, "Failed to create attachment")
+		if err := uc.storage.Delete(ctx, storageKey); err != nil {
+			logger.Error(err, "Failed to delete orphaned attachment file")
+		}
+		if err == errors.ErrAttachmentQuotaExceeded {
+			return nil, err
+		}
+		return nil, errors.ErrFailedToUploadAttachment
+	}
+
+	return mapAttachmentToResponse(&attachment), nil
+}
+
+func (uc *attachmentUseCase) ListAttachments(ctx context.Context, userID, vehicleID, visitID string) (*dto.ListAttachmentsResponse, error) {
+	serviceVisit, err := uc.getServiceVisitOfVehicle(ctx, userID, vehicleID, visitID, entity.ViewerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := []entity.ServiceVisitAttachment{}
+	err = uc.attachmentRepository.ListServiceVisitAttachments(ctx, serviceVisit.ID, &attachments)
+	if err != nil {
+		logger.Error(err, "Failed to list attachments")
+		return nil, errors.ErrFailedToListAttachments
+	}
+
+	usage, err := uc.attachmentRepository.GetUserAttachmentUsage(ctx, serviceVisit.UserID)
+	if err != nil {
+		logger.Error(err, "Failed to get user attachment usage")
+		return nil, errors.ErrFailedToListAttachments
+	}
+
+	attachmentsResponse := []dto.AttachmentResponse{}
+	for _, attachment := range attachments {
+		attachmentsResponse = append(attachmentsResponse, *mapAttachmentToResponse(&attachment))
+	}
+
+	return &dto.ListAttachmentsResponse{
+		Attachments: attachmentsResponse,
+		UsedBytes:   usage,
+		QuotaBytes:  uc.userQuota,
+	}, nil
+}
+
+func (uc *attachmentUseCase) DownloadAttachment(ctx context.Context, userID, vehicleID, visitID, attachmentID string) (*dto.AttachmentResponse, io.ReadCloser, error) {
+	serviceVisit, err := uc.getServiceVisitOfVehicle(ctx, userID, vehicleID, visitID, entity.ViewerVehicleRole)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := uc.getAttachmentOfVisit(ctx, serviceVisit, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := uc.storage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		logger.Error(err, "Failed to open attachment file")
+		return nil, nil, errors.ErrFailedToGetAttachment
+	}
+
+	return mapAttachmentToResponse(attachment), content, nil
+}
+
+func (uc *attachmentUseCase) DeleteAttachment(ctx context.Context, userID, vehicleID, visitID, attachmentID string) error {
+	serviceVisit, err := uc.getServiceVisitOfVehicle(ctx, userID, vehicleID, visitID, entity.EditorVehicleRole)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := uc.getAttachmentOfVisit(ctx, serviceVisit, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.storage.Delete(ctx, attachment.StorageKey)
+	if err != nil {
+		logger.Error(err, "Failed to delete attachment file")
+		return errors.ErrFailedToDeleteAttachment
+	}
+
+	err = uc.attachmentRepository.DeleteAttachment(ctx, attachment)
+	if err != nil {
+		logger.Error(err, "Failed to delete attachment")
+		return errors.ErrFailedToDeleteAttachment
+	}
+
+	return nil
+}
+
+// getServiceVisitOfVehicle loads a service visit of the vehicle and checks the user has the required role on it
+func (uc *attachmentUseCase) getServiceVisitOfVehicle(ctx context.Context, userID, vehicleID, visitID string, required entity.VehicleRole) (*entity.ServiceVisit, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+	uuidServiceVisitID, err := uuid.Parse(visitID)
+	if err != nil {
+		logger.Error(err, "Failed to parse service visit id")
+		return nil, errors.ErrInvalidServiceVisitID
+	}
+
+	serviceVisit := entity.ServiceVisit{}
+	serviceVisit.ID = uuidServiceVisitID
+
+	err = uc.serviceVisitRepository.GetServiceVisit(ctx, &serviceVisit)
+	if err != nil {
+		logger.Error(err, "Failed to get service visit")
+		return nil, errors.ErrFailedToGetServiceVisit
+	}
+	if serviceVisit.UserVehicleID != uintVehicleID {
+		logger.Error(errors.ErrUserVehicleNotOwned, "Service visit does not belong to vehicle")
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, serviceVisit.UserVehicleID, required)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceVisit, nil
+}
+
+// getAttachmentOfVisit loads an attachment and checks it belongs to the service visit
+func (uc *attachmentUseCase) getAttachmentOfVisit(ctx context.Context, serviceVisit *entity.ServiceVisit, attachmentID string) (*entity.ServiceVisitAttachment, error) {
+	uintAttachmentID, err := strconv.ParseUint(attachmentID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse attachment id")
+		return nil, errors.ErrInvalidAttachmentID
+	}
+
+	attachment := entity.ServiceVisitAttachment{}
+	err = uc.attachmentRepository.GetAttachment(ctx, uintAttachmentID, &attachment)
+	if err != nil {
+		logger.Error(err, "Failed to get attachment")
+		return nil, errors.ErrFailedToGetAttachment
+	}
+	if attachment.ServiceVisitID != serviceVisit.ID {
+		logger.Error(errors.ErrAttachmentNotOwned, "Attachment does not belong to service visit")
+		return nil, errors.ErrAttachmentNotOwned
+	}
+
+	return &attachment, nil
+}
+
+// newAttachmentStorageKey builds an unguessable key grouped by user and service visit
+func newAttachmentStorageKey(serviceVisit *entity.ServiceVisit, extension string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("users/%s/service-visits/%s/%s%s", serviceVisit.UserID, serviceVisit.ID, hex.EncodeToString(random), extension), nil
+}
+
+func mapAttachmentToResponse(attachment *entity.ServiceVisitAttachment) *dto.AttachmentResponse {
+	return &dto.AttachmentResponse{
+		ID:             attachment.ID,
+		ServiceVisitID: attachment.ServiceVisitID,
+		FileName:       attachment.FileName,
+		ContentType:    attachment.ContentType,
+		Size:           attachment.Size,
+		URL:            fmt.Sprintf("/api/v1/user/vehicles/%d/service-visits/%s/attachments/%d", attachment.UserVehicleID, attachment.ServiceVisitID, attachment.ID),
+		UploadedAt:     attachment.CreatedAt.Format("2006-01-02"),
+	}
+}
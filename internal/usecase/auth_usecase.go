@@ -56,7 +56,7 @@ func NewAuthUseCase() AuthUseCase {
 	authRepository := repository.NewAuthRepository()
 	sessionRepository := repository.NewSessionRepository()
 	verificationRepository := repository.NewVerificationRepository()
-	smsService := http.NewSMSService(cfg.SMS.BaseURL, cfg.SMS.XAPIKey)
+	smsService := http.NewSMSService(cfg.SMS.BaseURL, cfg.SMS.XAPIKey, cfg.SMS.ReminderTemplateID, cfg.SMS.TransferTemplateID)
 	return &authUseCase{
 		authRepository:         authRepository,
 		sessionRepository:      sessionRepository,
@@ -0,0 +1,1102 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/spreadsheet"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+)
+
+const maxCatalogImportFileSize = 20 << 20
+
+const catalogPathSeparator = " > "
+
+type CatalogUseCase interface {
+	ExportCatalog(ctx context.Context, request dto.ExportCatalogRequest) (*dto.ExportFile, error)
+	ImportCatalog(ctx context.Context, fileName string, size int64, content io.Reader, request dto.ImportCatalogRequest) (*dto.ImportCatalogResponse, error)
+	ListCatalogTrash(ctx context.Context) (*dto.ListCatalogTrashResponse, error)
+	RestoreCatalogItem(ctx context.Context, level, rowID string) error
+}
+
+type catalogUseCase struct {
+	catalogRepository      repository.CatalogRepository
+	vehicleCacheRepository repository.VehicleCacheRepository
+}
+
+func NewCatalogUseCase() CatalogUseCase {
+	return &catalogUseCase{
+		catalogRepository:      repository.NewCatalogRepository(),
+		vehicleCacheRepository: repository.NewVehicleCacheRepository(),
+	}
+}
+
+// catalogRow holds one entry of each level, a line of a CSV catalog
+type catalogRow struct {
+	Type       *dto.CatalogType
+	Brand      *dto.CatalogBrand
+	Model      *dto.CatalogModel
+	Generation *dto.CatalogGeneration
+}
+
+func newCatalogRow() catalogRow {
+	return catalogRow{
+		Type:       &dto.CatalogType{},
+		Brand:      &dto.CatalogBrand{},
+		Model:      &dto.CatalogModel{},
+		Generation: &dto.CatalogGeneration{},
+	}
+}
+
+// catalogColumn is a CSV column of the catalog. The values of its columns are also what an import compares to
+// tell whether a row changed
+type catalogColumn struct {
+	name string
+	get  func(row *catalogRow) string
+	set  func(row *catalogRow, value string) error
+}
+
+func textCatalogColumn(name string, field func(row *catalogRow) *string) catalogColumn {
+	return catalogColumn{
+		name: name,
+		get:  func(row *catalogRow) string { return strings.TrimSpace(*field(row)) },
+		set: func(row *catalogRow, value string) error {
+			*field(row) = value
+			return nil
+		},
+	}
+}
+
+func intCatalogColumn(name string, field func(row *catalogRow) *int) catalogColumn {
+	return catalogColumn{
+		name: name,
+		get: func(row *catalogRow) string {
+			if *field(row) == 0 {
+				return ""
+			}
+			return strconv.Itoa(*field(row))
+		},
+		set: func(row *catalogRow, value string) error {
+			number, err := strconv.Atoi(normalizeImportNumber(value))
+			if err != nil {
+				return fmt.Errorf("invalid number %q", value)
+			}
+			*field(row) = number
+			return nil
+		},
+	}
+}
+
+func floatCatalogColumn(name string, field func(row *catalogRow) *float64) catalogColumn {
+	return catalogColumn{
+		name: name,
+		get: func(row *catalogRow) string {
+			if *field(row) == 0 {
+				return ""
+			}
+			return strconv.FormatFloat(*field(row), 'f', -1, 64)
+		},
+		set: func(row *catalogRow, value string) error {
+			number, err := strconv.ParseFloat(normalizeImportNumber(value), 64)
+			if err != nil {
+				return fmt.Errorf("invalid number %q", value)
+			}
+			*field(row) = number
+			return nil
+		},
+	}
+}
+
+// listCatalogColumn holds a list as comma separated values in one cell
+func listCatalogColumn(name string, field func(row *catalogRow) *[]string) catalogColumn {
+	return catalogColumn{
+		name: name,
+		get:  func(row *catalogRow) string { return joinList(*field(row)) },
+		set: func(row *catalogRow, value string) error {
+			*field(row) = strings.Split(value, ",")
+			return nil
+		},
+	}
+}
+
+var catalogTypeColumns = []catalogColumn{
+	textCatalogColumn("type_name_en", func(r *catalogRow) *string { return &r.Type.NameEn }),
+	textCatalogColumn("type_name_fa", func(r *catalogRow) *string { return &r.Type.NameFa }),
+	textCatalogColumn("type_description_en", func(r *catalogRow) *string { return &r.Type.DescriptionEn }),
+	textCatalogColumn("type_description_fa", func(r *catalogRow) *string { return &r.Type.DescriptionFa }),
+}
+
+var catalogBrandColumns = []catalogColumn{
+	textCatalogColumn("brand_name_en", func(r *catalogRow) *string { return &r.Brand.NameEn }),
+	textCatalogColumn("brand_name_fa", func(r *catalogRow) *string { return &r.Brand.NameFa }),
+	textCatalogColumn("brand_description_en", func(r *catalogRow) *string { return &r.Brand.DescriptionEn }),
+	textCatalogColumn("brand_description_fa", func(r *catalogRow) *string { return &r.Brand.DescriptionFa }),
+}
+
+var catalogModelColumns = []catalogColumn{
+	textCatalogColumn("model_name_en", func(r *catalogRow) *string { return &r.Model.NameEn }),
+	textCatalogColumn("model_name_fa", func(r *catalogRow) *string { return &r.Model.NameFa }),
+	textCatalogColumn("model_description_en", func(r *catalogRow) *string { return &r.Model.DescriptionEn }),
+	textCatalogColumn("model_description_fa", func(r *catalogRow) *string { return &r.Model.DescriptionFa }),
+}
+
+var catalogGenerationColumns = []catalogColumn{
+	textCatalogColumn("generation_name_en", func(r *catalogRow) *string { return &r.Generation.NameEn }),
+	textCatalogColumn("generation_name_fa", func(r *catalogRow) *string { return &r.Generation.NameFa }),
+	textCatalogColumn("generation_description_en", func(r *catalogRow) *string { return &r.Generation.DescriptionEn }),
+	textCatalogColumn("generation_description_fa", func(r *catalogRow) *string { return &r.Generation.DescriptionFa }),
+	intCatalogColumn("start_year", func(r *catalogRow) *int { return &r.Generation.StartYear }),
+	intCatalogColumn("end_year", func(r *catalogRow) *int { return &r.Generation.EndYear }),
+	textCatalogColumn("body_style_fa", func(r *catalogRow) *string { return &r.Generation.BodyStyleFa }),
+	textCatalogColumn("body_style_en", func(r *catalogRow) *string { return &r.Generation.BodyStyleEn }),
+	textCatalogColumn("engine", func(r *catalogRow) *string { return &r.Generation.Engine }),
+	intCatalogColumn("engine_volume", func(r *catalogRow) *int { return &r.Generation.EngineVolume }),
+	intCatalogColumn("cylinders", func(r *catalogRow) *int { return &r.Generation.Cylinders }),
+	textCatalogColumn("drivetrain_fa", func(r *catalogRow) *string { return &r.Generation.DrivetrainFa }),
+	textCatalogColumn("drivetrain_en", func(r *catalogRow) *string { return &r.Generation.DrivetrainEn }),
+	textCatalogColumn("gearbox", func(r *catalogRow) *string { return &r.Generation.Gearbox }),
+	textCatalogColumn("fuel_type", func(r *catalogRow) *string { return &r.Generation.FuelType }),
+	textCatalogColumn("battery", func(r *catalogRow) *string { return &r.Generation.Battery }),
+	textCatalogColumn("seller", func(r *catalogRow) *string { return &r.Generation.Seller }),
+	textCatalogColumn("assembly_type", func(r *catalogRow) *string { return &r.Generation.AssemblyType }),
+	textCatalogColumn("assembler", func(r *catalogRow) *string { return &r.Generation.Assembler }),
+	listCatalogColumn("tire_sizes", func(r *catalogRow) *[]string { return &r.Generation.TireSizes }),
+	listCatalogColumn("oil_viscosities", func(r *catalogRow) *[]string { return &r.Generation.OilViscosities }),
+	floatCatalogColumn("oil_capacity", func(r *catalogRow) *float64 { return &r.Generation.OilCapacity }),
+	listCatalogColumn("oil_filter_part_numbers", func(r *catalogRow) *[]string { return &r.Generation.OilFilterPartNumbers }),
+}
+
+func catalogColumns() []catalogColumn {
+	return slices.Concat(catalogTypeColumns, catalogBrandColumns, catalogModelColumns, catalogGenerationColumns)
+}
+
+func (uc *catalogUseCase) ExportCatalog(ctx context.Context, request dto.ExportCatalogRequest) (*dto.ExportFile, error) {
+	err := validation.ValidateExportCatalogRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate catalog export request")
+		return nil, errors.ErrInvalidCatalogExportRequest
+	}
+
+	vehicleTypes := []entity.VehicleType{}
+	err = uc.catalogRepository.GetCatalog(ctx, &vehicleTypes)
+	if err != nil {
+		logger.Error(err, "Failed to get catalog")
+		return nil, errors.ErrFailedToExportCatalog
+	}
+	catalog := mapEntitiesToCatalog(vehicleTypes)
+
+	if request.Format == "csv" {
+		content, err := writeCatalogCSV(catalog)
+		if err != nil {
+			logger.Error(err, "Failed to write catalog csv")
+			return nil, errors.ErrFailedToExportCatalog
+		}
+		return &dto.ExportFile{FileName: "vehicle-catalog.csv", ContentType: "text/csv; charset=utf-8", Content: content}, nil
+	}
+
+	content, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		logger.Error(err, "Failed to write catalog json")
+		return nil, errors.ErrFailedToExportCatalog
+	}
+	return &dto.ExportFile{FileName: "vehicle-catalog.json", ContentType: "application/json; charset=utf-8", Content: content}, nil
+}
+
+// writeCatalogCSV writes one row per generation. Types, brands and models without children get a row of their own
+func writeCatalogCSV(catalog *dto.Catalog) ([]byte, error) {
+	var buffer bytes.Buffer
+	// The byte order mark makes spreadsheet applications read Persian text as UTF-8
+	buffer.WriteString("\ufeff")
+
+	columns := catalogColumns()
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	records := [][]string{header}
+	write := func(row catalogRow) {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = column.get(&row)
+		}
+		records = append(records, record)
+	}
+
+	for i := range catalog.Types {
+		row := newCatalogRow()
+		row.Type = &catalog.Types[i]
+		if len(row.Type.Brands) == 0 {
+			write(row)
+		}
+		for j := range row.Type.Brands {
+			row.Brand = &row.Type.Brands[j]
+			row.Model = &dto.CatalogModel{}
+			row.Generation = &dto.CatalogGeneration{}
+			if len(row.Brand.Models) == 0 {
+				write(row)
+			}
+			for k := range row.Brand.Models {
+				row.Model = &row.Brand.Models[k]
+				row.Generation = &dto.CatalogGeneration{}
+				if len(row.Model.Generations) == 0 {
+					write(row)
+				}
+				for l := range row.Model.Generations {
+					row.Generation = &row.Model.Generations[l]
+					write(row)
+				}
+			}
+		}
+	}
+
+	writer := csv.NewWriter(&buffer)
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (uc *catalogUseCase) ImportCatalog(ctx context.Context, fileName string, size int64, content io.Reader, request dto.ImportCatalogRequest) (*dto.ImportCatalogResponse, error) {
+	if size <= 0 {
+		return nil, errors.ErrCatalogImportFileRequired
+	}
+	if size > maxCatalogImportFileSize {
+		logger.Error(errors.ErrCatalogImportFileTooLarge, "Catalog import file exceeds max file size")
+		return nil, errors.ErrCatalogImportFileTooLarge
+	}
+	data, err := io.ReadAll(io.LimitReader(content, maxCatalogImportFileSize))
+	if err != nil {
+		logger.Error(err, "Failed to read catalog import file")
+		return nil, errors.ErrInvalidCatalogImportFile
+	}
+
+	response := &dto.ImportCatalogResponse{
+		DryRun:  request.DryRun,
+		Errors:  []dto.CatalogImportError{},
+		Changes: []dto.CatalogChange{},
+	}
+
+	catalog := &dto.Catalog{}
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\ufeff")))
+	if strings.EqualFold(filepath.Ext(fileName), ".json") || bytes.HasPrefix(trimmed, []byte("{")) {
+		if err := json.Unmarshal(trimmed, catalog); err != nil {
+			logger.Error(err, "Failed to parse catalog import json")
+			return nil, errors.ErrInvalidCatalogImportFile
+		}
+	} else {
+		rows, err := spreadsheet.ReadRows(fileName, bytes.NewReader(data))
+		if err != nil {
+			logger.Error(err, "Failed to read catalog import file")
+			return nil, errors.ErrInvalidCatalogImportFile
+		}
+		catalog, err = parseCatalogRows(rows, response)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(catalog.Types) == 0 && len(response.Errors) == 0 {
+		// An empty file would otherwise prune the whole catalog
+		return nil, errors.ErrInvalidCatalogImportFile
+	}
+
+	validateCatalog(catalog, response)
+	if len(response.Errors) > 0 {
+		return response, nil
+	}
+
+	existing := []entity.VehicleType{}
+	err = uc.catalogRepository.GetCatalog(ctx, &existing)
+	if err != nil {
+		logger.Error(err, "Failed to get catalog")
+		return nil, errors.ErrFailedToImportCatalog
+	}
+
+	plan := catalogImportPlan{response: response, prune: request.Prune, deletedGenerationPaths: map[uint64]string{}}
+	vehicleTypes := plan.types(catalog.Types, existing)
+	err = uc.checkPrunedGenerations(ctx, &plan)
+	if err != nil {
+		return nil, err
+	}
+	if request.DryRun || len(response.Changes) == 0 || len(response.Errors) > 0 {
+		return response, nil
+	}
+
+	err = uc.catalogRepository.ImportCatalog(ctx, vehicleTypes, plan.changed, plan.deleted)
+	if err != nil {
+		logger.Error(err, "Failed to import catalog")
+		return nil, errors.ErrFailedToImportCatalog
+	}
+	response.Imported = true
+
+	// Invalidate cache once for the whole import
+	err = uc.vehicleCacheRepository.InvalidateVehicleHierarchy(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to invalidate vehicle hierarchy cache")
+		// Don't return error, just log it
+	}
+
+	return response, nil
+}
+
+// checkPrunedGenerations reports the generations a pruning import would delete while user vehicles are on them, which
+// keeps the import from being saved
+func (uc *catalogUseCase) checkPrunedGenerations(ctx context.Context, plan *catalogImportPlan) error {
+	counts, err := uc.catalogRepository.CountUserVehiclesByGeneration(ctx, plan.deleted.Generations)
+	if err != nil {
+		logger.Error(err, "Failed to count user vehicles of pruned generations")
+		return errors.ErrFailedToImportCatalog
+	}
+	for _, generationID := range plan.deleted.Generations {
+		if counts[generationID] == 0 {
+			continue
+		}
+		plan.response.Errors = append(plan.response.Errors, dto.CatalogImportError{
+			Path:  plan.deletedGenerationPaths[generationID],
+			Error: fmt.Sprintf("generation is used by %d user vehicles, reassign them before deleting it", counts[generationID]),
+		})
+	}
+	return nil
+}
+
+// parseCatalogRows builds the catalog tree from the rows of a CSV or XLSX file. Each row names a type and
+// optionally a brand, model and generation under it. Details of a parent may be repeated on every row of its
+// children or given once; rows that disagree on them are reported
+func parseCatalogRows(rows [][]string, response *dto.ImportCatalogResponse) (*dto.Catalog, error) {
+	catalog := &dto.Catalog{}
+	if len(rows) == 0 {
+		return catalog, nil
+	}
+
+	columnsByName := map[string]catalogColumn{}
+	for _, column := range catalogColumns() {
+		columnsByName[column.name] = column
+	}
+	header := make([]string, len(rows[0]))
+	for i, cell := range rows[0] {
+		header[i] = strings.ReplaceAll(strings.ReplaceAll(strings.ToLower(cell), " ", "_"), "-", "_")
+		if _, ok := columnsByName[header[i]]; !ok && header[i] != "" {
+			response.IgnoredColumns = append(response.IgnoredColumns, cell)
+		}
+	}
+	if !slices.Contains(header, "type_name_en") {
+		return nil, errors.ErrCatalogImportMissingColumns
+	}
+
+	// firstRows remembers the row each entry was first given in, by its path
+	firstRows := map[string]int{}
+	for i, cells := range rows[1:] {
+		if spreadsheet.IsBlank(cells) {
+			continue
+		}
+		number := i + 2
+
+		row := newCatalogRow()
+		var rowError *dto.CatalogImportError
+		for j, value := range cells {
+			if j >= len(header) || value == "" {
+				continue
+			}
+			column, ok := columnsByName[header[j]]
+			if !ok {
+				continue
+			}
+			if err := column.set(&row, value); err != nil {
+				rowError = &dto.CatalogImportError{Row: number, Error: column.name + ": " + err.Error()}
+				break
+			}
+		}
+		if rowError == nil {
+			rowError = mergeCatalogRow(catalog, row, number, firstRows)
+		}
+		if rowError != nil {
+			response.Errors = append(response.Errors, *rowError)
+		}
+	}
+	return catalog, nil
+}
+
+// mergeCatalogRow adds the entries of a row to the catalog, reusing the ones earlier rows gave
+func mergeCatalogRow(catalog *dto.Catalog, row catalogRow, number int, firstRows map[string]int) *dto.CatalogImportError {
+	levels := []struct {
+		level   string
+		columns []catalogColumn
+		name    string
+	}{
+		{"type", catalogTypeColumns, row.Type.NameEn},
+		{"brand", catalogBrandColumns, row.Brand.NameEn},
+		{"model", catalogModelColumns, row.Model.NameEn},
+		{"generation", catalogGenerationColumns, row.Generation.NameEn},
+	}
+	// A level may only be left out when the levels below it are left out too
+	last := -1
+	for i, level := range levels {
+		if hasCatalogValues(level.columns, &row) {
+			last = i
+		}
+	}
+	for i := 0; i <= last; i++ {
+		if strings.TrimSpace(levels[i].name) == "" {
+			return &dto.CatalogImportError{Row: number, Error: levels[i].columns[0].name + " is required"}
+		}
+	}
+
+	stored := catalogRow{}
+	path := []string{}
+	for i := 0; i <= last; i++ {
+		name := strings.TrimSpace(levels[i].name)
+		path = append(path, name)
+		key := strings.ToLower(strings.Join(path, catalogPathSeparator))
+
+		switch i {
+		case 0:
+			stored.Type = findCatalogType(catalog.Types, name)
+			if stored.Type == nil {
+				catalog.Types = append(catalog.Types, *row.Type)
+				stored.Type = &catalog.Types[len(catalog.Types)-1]
+			}
+		case 1:
+			stored.Brand = findCatalogBrand(stored.Type.Brands, name)
+			if stored.Brand == nil {
+				stored.Type.Brands = append(stored.Type.Brands, *row.Brand)
+				stored.Brand = &stored.Type.Brands[len(stored.Type.Brands)-1]
+			}
+		case 2:
+			stored.Model = findCatalogModel(stored.Brand.Models, name)
+			if stored.Model == nil {
+				stored.Brand.Models = append(stored.Brand.Models, *row.Model)
+				stored.Model = &stored.Brand.Models[len(stored.Brand.Models)-1]
+			}
+		case 3:
+			stored.Generation = findCatalogGeneration(stored.Model.Generations, name)
+			if stored.Generation == nil {
+				stored.Model.Generations = append(stored.Model.Generations, *row.Generation)
+				stored.Generation = &stored.Model.Generations[len(stored.Model.Generations)-1]
+			}
+		}
+
+		first, seen := firstRows[key]
+		if !seen {
+			firstRows[key] = number
+			continue
+		}
+		// Fill in details the earlier rows left empty and report the ones they disagree on
+		for _, column := range levels[i].columns {
+			value := column.get(&row)
+			if value == "" {
+				continue
+			}
+			if current := column.get(&stored); current == "" {
+				column.set(&stored, value)
+			} else if current != value {
+				return &dto.CatalogImportError{
+					Row:   number,
+					Path:  strings.Join(path, catalogPathSeparator),
+					Error: fmt.Sprintf("%s differs from row %d", column.name, first),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func hasCatalogValues(columns []catalogColumn, row *catalogRow) bool {
+	for _, column := range columns {
+		if column.get(row) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func findCatalogType(types []dto.CatalogType, name string) *dto.CatalogType {
+	for i := range types {
+		if strings.EqualFold(strings.TrimSpace(types[i].NameEn), name) {
+			return &types[i]
+		}
+	}
+	return nil
+}
+
+func findCatalogBrand(brands []dto.CatalogBrand, name string) *dto.CatalogBrand {
+	for i := range brands {
+		if strings.EqualFold(strings.TrimSpace(brands[i].NameEn), name) {
+			return &brands[i]
+		}
+	}
+	return nil
+}
+
+func findCatalogModel(models []dto.CatalogModel, name string) *dto.CatalogModel {
+	for i := range models {
+		if strings.EqualFold(strings.TrimSpace(models[i].NameEn), name) {
+			return &models[i]
+		}
+	}
+	return nil
+}
+
+func findCatalogGeneration(generations []dto.CatalogGeneration, name string) *dto.CatalogGeneration {
+	for i := range generations {
+		if strings.EqualFold(strings.TrimSpace(generations[i].NameEn), name) {
+			return &generations[i]
+		}
+	}
+	return nil
+}
+
+// validateCatalog reports invalid entries and English names used twice within the same parent
+func validateCatalog(catalog *dto.Catalog, response *dto.ImportCatalogResponse) {
+	check := func(path []string, entry any) {
+		if err := validation.ValidateCatalogEntry(entry); err != nil {
+			response.Errors = append(response.Errors, dto.CatalogImportError{Path: strings.Join(path, catalogPathSeparator), Error: err.Error()})
+		}
+	}
+	duplicate := func(seen map[string]bool, path []string) {
+		key := strings.ToLower(strings.TrimSpace(path[len(path)-1]))
+		if seen[key] {
+			response.Errors = append(response.Errors, dto.CatalogImportError{Path: strings.Join(path, catalogPathSeparator), Error: "english name is used more than once"})
+		}
+		seen[key] = true
+	}
+
+	types := map[string]bool{}
+	for _, vehicleType := range catalog.Types {
+		typePath := []string{vehicleType.NameEn}
+		check(typePath, vehicleType)
+		duplicate(types, typePath)
+
+		brands := map[string]bool{}
+		for _, brand := range vehicleType.Brands {
+			brandPath := append(slices.Clone(typePath), brand.NameEn)
+			check(brandPath, brand)
+			duplicate(brands, brandPath)
+
+			models := map[string]bool{}
+			for _, model := range brand.Models {
+				modelPath := append(slices.Clone(brandPath), model.NameEn)
+				check(modelPath, model)
+				duplicate(models, modelPath)
+
+				generations := map[string]bool{}
+				for _, generation := range model.Generations {
+					generationPath := append(slices.Clone(modelPath), generation.NameEn)
+					check(generationPath, generation)
+					duplicate(generations, generationPath)
+				}
+			}
+		}
+	}
+}
+
+// catalogImportPlan matches an imported catalog with the stored one by English name within each parent and
+// records what the import creates, updates and deletes
+type catalogImportPlan struct {
+	response *dto.ImportCatalogResponse
+	prune    bool
+	changed  repository.CatalogRowIDs
+	deleted  repository.CatalogRowIDs
+	// deletedGenerationPaths are the paths of the generations in deleted, to report the ones still in use
+	deletedGenerationPaths map[uint64]string
+}
+
+// compare records the change of a row. matched is false for new rows, deleted rows that are matched are restored
+func (p *catalogImportPlan) compare(level string, path []string, columns []catalogColumn, stored, imported catalogRow, matched, deleted bool) (changed bool) {
+	change := dto.CatalogChange{Level: level, Path: strings.Join(path, catalogPathSeparator)}
+	switch {
+	case !matched || deleted:
+		change.Action = "create"
+		p.response.Created++
+	default:
+		for _, column := range columns {
+			if column.get(&stored) != column.get(&imported) {
+				change.Fields = append(change.Fields, column.name)
+			}
+		}
+		if len(change.Fields) == 0 {
+			p.response.Unchanged++
+			return false
+		}
+		change.Action = "update"
+		p.response.Updated++
+	}
+	p.response.Changes = append(p.response.Changes, change)
+	return true
+}
+
+// remove records the deletion of a stored row missing from the file, when pruning
+func (p *catalogImportPlan) remove(level string, path []string, deleted bool) bool {
+	if !p.prune || deleted {
+		return false
+	}
+	p.response.Deleted++
+	p.response.Changes = append(p.response.Changes, dto.CatalogChange{Action: "delete", Level: level, Path: strings.Join(path, catalogPathSeparator)})
+	return true
+}
+
+func (p *catalogImportPlan) types(imported []dto.CatalogType, stored []entity.VehicleType) []entity.VehicleType {
+	vehicleTypes := []entity.VehicleType{}
+	matched := map[uint64]bool{}
+	for i := range imported {
+		path := []string{strings.TrimSpace(imported[i].NameEn)}
+		vehicleType := entity.VehicleType{}
+		var existing *entity.VehicleType
+		for j := range stored {
+			if strings.EqualFold(strings.TrimSpace(stored[j].NameEn), path[0]) {
+				existing = &stored[j]
+				vehicleType = stored[j]
+				matched[existing.ID] = true
+				break
+			}
+		}
+
+		vehicleType.NameEn = path[0]
+		vehicleType.NameFa = strings.TrimSpace(imported[i].NameFa)
+		vehicleType.DescriptionEn = strings.TrimSpace(imported[i].DescriptionEn)
+		vehicleType.DescriptionFa = strings.TrimSpace(imported[i].DescriptionFa)
+		vehicleType.DeletedAt.Valid = false
+		vehicleType.VehicleBrands = nil
+
+		storedRow, importedRow := newCatalogRow(), newCatalogRow()
+		var storedBrands []entity.VehicleBrand
+		if existing != nil {
+			*storedRow.Type = mapVehicleTypeToCatalog(existing)
+			storedBrands = existing.VehicleBrands
+		}
+		*importedRow.Type = imported[i]
+		if p.compare("type", path, catalogTypeColumns, storedRow, importedRow, existing != nil, existing != nil && existing.DeletedAt.Valid) && existing != nil {
+			p.changed.Types = append(p.changed.Types, existing.ID)
+		}
+
+		vehicleType.VehicleBrands = p.brands(path, imported[i].Brands, storedBrands)
+		vehicleTypes = append(vehicleTypes, vehicleType)
+	}
+
+	for i := range stored {
+		if !matched[stored[i].ID] {
+			p.removeType(&stored[i])
+		}
+	}
+	return vehicleTypes
+}
+
+func (p *catalogImportPlan) brands(parentPath []string, imported []dto.CatalogBrand, stored []entity.VehicleBrand) []entity.VehicleBrand {
+	brands := []entity.VehicleBrand{}
+	matched := map[uint64]bool{}
+	for i := range imported {
+		path := append(slices.Clone(parentPath), strings.TrimSpace(imported[i].NameEn))
+		brand := entity.VehicleBrand{}
+		var existing *entity.VehicleBrand
+		for j := range stored {
+			if strings.EqualFold(strings.TrimSpace(stored[j].NameEn), path[len(path)-1]) {
+				existing = &stored[j]
+				brand = stored[j]
+				matched[existing.ID] = true
+				break
+			}
+		}
+
+		brand.NameEn = path[len(path)-1]
+		brand.NameFa = strings.TrimSpace(imported[i].NameFa)
+		brand.DescriptionEn = strings.TrimSpace(imported[i].DescriptionEn)
+		brand.DescriptionFa = strings.TrimSpace(imported[i].DescriptionFa)
+		brand.DeletedAt.Valid = false
+		brand.VehicleModels = nil
+
+		storedRow, importedRow := newCatalogRow(), newCatalogRow()
+		var storedModels []entity.VehicleModel
+		if existing != nil {
+			*storedRow.Brand = mapVehicleBrandToCatalog(existing)
+			storedModels = existing.VehicleModels
+		}
+		*importedRow.Brand = imported[i]
+		if p.compare("brand", path, catalogBrandColumns, storedRow, importedRow, existing != nil, existing != nil && existing.DeletedAt.Valid) && existing != nil {
+			p.changed.Brands = append(p.changed.Brands, existing.ID)
+		}
+
+		brand.VehicleModels = p.models(path, imported[i].Models, storedModels)
+		brands = append(brands, brand)
+	}
+
+	for i := range stored {
+		if !matched[stored[i].ID] {
+			p.removeBrand(parentPath, &stored[i])
+		}
+	}
+	return brands
+}
+
+func (p *catalogImportPlan) models(parentPath []string, imported []dto.CatalogModel, stored []entity.VehicleModel) []entity.VehicleModel {
+	models := []entity.VehicleModel{}
+	matched := map[uint64]bool{}
+	for i := range imported {
+		path := append(slices.Clone(parentPath), strings.TrimSpace(imported[i].NameEn))
+		model := entity.VehicleModel{}
+		var existing *entity.VehicleModel
+		for j := range stored {
+			if strings.EqualFold(strings.TrimSpace(stored[j].NameEn), path[len(path)-1]) {
+				existing = &stored[j]
+				model = stored[j]
+				matched[existing.ID] = true
+				break
+			}
+		}
+
+		model.NameEn = path[len(path)-1]
+		model.NameFa = strings.TrimSpace(imported[i].NameFa)
+		model.DescriptionEn = strings.TrimSpace(imported[i].DescriptionEn)
+		model.DescriptionFa = strings.TrimSpace(imported[i].DescriptionFa)
+		model.DeletedAt.Valid = false
+		model.VehicleGenerations = nil
+
+		storedRow, importedRow := newCatalogRow(), newCatalogRow()
+		var storedGenerations []entity.VehicleGeneration
+		if existing != nil {
+			*storedRow.Model = mapVehicleModelToCatalog(existing)
+			storedGenerations = existing.VehicleGenerations
+		}
+		*importedRow.Model = imported[i]
+		if p.compare("model", path, catalogModelColumns, storedRow, importedRow, existing != nil, existing != nil && existing.DeletedAt.Valid) && existing != nil {
+			p.changed.Models = append(p.changed.Models, existing.ID)
+		}
+
+		model.VehicleGenerations = p.generations(path, imported[i].Generations, storedGenerations)
+		models = append(models, model)
+	}
+
+	for i := range stored {
+		if !matched[stored[i].ID] {
+			p.removeModel(parentPath, &stored[i])
+		}
+	}
+	return models
+}
+
+func (p *catalogImportPlan) generations(parentPath []string, imported []dto.CatalogGeneration, stored []entity.VehicleGeneration) []entity.VehicleGeneration {
+	generations := []entity.VehicleGeneration{}
+	matched := map[uint64]bool{}
+	for i := range imported {
+		path := append(slices.Clone(parentPath), strings.TrimSpace(imported[i].NameEn))
+		generation := entity.VehicleGeneration{}
+		var existing *entity.VehicleGeneration
+		for j := range stored {
+			if strings.EqualFold(strings.TrimSpace(stored[j].NameEn), path[len(path)-1]) {
+				existing = &stored[j]
+				generation = stored[j]
+				matched[existing.ID] = true
+				break
+			}
+		}
+
+		applyCatalogGeneration(&generation, imported[i])
+		generation.NameEn = path[len(path)-1]
+		generation.DeletedAt.Valid = false
+
+		storedRow, importedRow := newCatalogRow(), newCatalogRow()
+		if existing != nil {
+			*storedRow.Generation = mapVehicleGenerationToCatalog(existing)
+		}
+		*importedRow.Generation = imported[i]
+		if p.compare("generation", path, catalogGenerationColumns, storedRow, importedRow, existing != nil, existing != nil && existing.DeletedAt.Valid) && existing != nil {
+			p.changed.Generations = append(p.changed.Generations, existing.ID)
+		}
+
+		generations = append(generations, generation)
+	}
+
+	for i := range stored {
+		if !matched[stored[i].ID] {
+			p.removeGeneration(parentPath, &stored[i])
+		}
+	}
+	return generations
+}
+
+func (p *catalogImportPlan) removeType(vehicleType *entity.VehicleType) {
+	path := []string{vehicleType.NameEn}
+	if p.remove("type", path, vehicleType.DeletedAt.Valid) {
+		p.deleted.Types = append(p.deleted.Types, vehicleType.ID)
+	}
+	for i := range vehicleType.VehicleBrands {
+		p.removeBrand(path, &vehicleType.VehicleBrands[i])
+	}
+}
+
+func (p *catalogImportPlan) removeBrand(parentPath []string, brand *entity.VehicleBrand) {
+	path := append(slices.Clone(parentPath), brand.NameEn)
+	if p.remove("brand", path, brand.DeletedAt.Valid) {
+		p.deleted.Brands = append(p.deleted.Brands, brand.ID)
+	}
+	for i := range brand.VehicleModels {
+		p.removeModel(path, &brand.VehicleModels[i])
+	}
+}
+
+func (p *catalogImportPlan) removeModel(parentPath []string, model *entity.VehicleModel) {
+	path := append(slices.Clone(parentPath), model.NameEn)
+	if p.remove("model", path, model.DeletedAt.Valid) {
+		p.deleted.Models = append(p.deleted.Models, model.ID)
+	}
+	for i := range model.VehicleGenerations {
+		p.removeGeneration(path, &model.VehicleGenerations[i])
+	}
+}
+
+func (p *catalogImportPlan) removeGeneration(parentPath []string, generation *entity.VehicleGeneration) {
+	path := append(slices.Clone(parentPath), generation.NameEn)
+	if p.remove("generation", path, generation.DeletedAt.Valid) {
+		p.deleted.Generations = append(p.deleted.Generations, generation.ID)
+		p.deletedGenerationPaths[generation.ID] = strings.Join(path, catalogPathSeparator)
+	}
+}
+
+// applyCatalogGeneration copies the details of an imported generation, the file being the source of truth
+func applyCatalogGeneration(generation *entity.VehicleGeneration, imported dto.CatalogGeneration) {
+	generation.NameFa = strings.TrimSpace(imported.NameFa)
+	generation.DescriptionEn = strings.TrimSpace(imported.DescriptionEn)
+	generation.DescriptionFa = strings.TrimSpace(imported.DescriptionFa)
+	generation.StartYear = imported.StartYear
+	generation.EndYear = imported.EndYear
+	generation.BodyStyleFa = strings.TrimSpace(imported.BodyStyleFa)
+	generation.BodyStyleEn = strings.TrimSpace(imported.BodyStyleEn)
+	generation.Engine = strings.TrimSpace(imported.Engine)
+	generation.EngineVolume = imported.EngineVolume
+	generation.Cylinders = imported.Cylinders
+	generation.DrivetrainFa = strings.TrimSpace(imported.DrivetrainFa)
+	generation.DrivetrainEn = strings.TrimSpace(imported.DrivetrainEn)
+	generation.Gearbox = strings.TrimSpace(imported.Gearbox)
+	generation.FuelType = strings.TrimSpace(imported.FuelType)
+	generation.Battery = strings.TrimSpace(imported.Battery)
+	generation.Seller = strings.TrimSpace(imported.Seller)
+	generation.AssemblyType = strings.TrimSpace(imported.AssemblyType)
+	generation.Assembler = strings.TrimSpace(imported.Assembler)
+	generation.TireSizes = joinList(imported.TireSizes)
+	generation.OilViscosities = joinList(imported.OilViscosities)
+	generation.OilCapacity = imported.OilCapacity
+	generation.OilFilterPartNumbers = joinList(imported.OilFilterPartNumbers)
+}
+
+// mapEntitiesToCatalog converts the stored catalog for export, leaving deleted rows out
+func mapEntitiesToCatalog(vehicleTypes []entity.VehicleType) *dto.Catalog {
+	catalog := &dto.Catalog{Types: []dto.CatalogType{}}
+	for i := range vehicleTypes {
+		if vehicleTypes[i].DeletedAt.Valid {
+			continue
+		}
+		catalogType := mapVehicleTypeToCatalog(&vehicleTypes[i])
+		catalogType.Brands = []dto.CatalogBrand{}
+		for j := range vehicleTypes[i].VehicleBrands {
+			brand := &vehicleTypes[i].VehicleBrands[j]
+			if brand.DeletedAt.Valid {
+				continue
+			}
+			catalogBrand := mapVehicleBrandToCatalog(brand)
+			catalogBrand.Models = []dto.CatalogModel{}
+			for k := range brand.VehicleModels {
+				model := &brand.VehicleModels[k]
+				if model.DeletedAt.Valid {
+					continue
+				}
+				catalogModel := mapVehicleModelToCatalog(model)
+				catalogModel.Generations = []dto.CatalogGeneration{}
+				for l := range model.VehicleGenerations {
+					if !model.VehicleGenerations[l].DeletedAt.Valid {
+						catalogModel.Generations = append(catalogModel.Generations, mapVehicleGenerationToCatalog(&model.VehicleGenerations[l]))
+					}
+				}
+				catalogBrand.Models = append(catalogBrand.Models, catalogModel)
+			}
+			catalogType.Brands = append(catalogType.Brands, catalogBrand)
+		}
+		catalog.Types = append(catalog.Types, catalogType)
+	}
+	return catalog
+}
+
+func mapVehicleTypeToCatalog(vehicleType *entity.VehicleType) dto.CatalogType {
+	return dto.CatalogType{
+		NameEn:        vehicleType.NameEn,
+		NameFa:        vehicleType.NameFa,
+		DescriptionEn: vehicleType.DescriptionEn,
+		DescriptionFa: vehicleType.DescriptionFa,
+	}
+}
+
+func mapVehicleBrandToCatalog(brand *entity.VehicleBrand) dto.CatalogBrand {
+	return dto.CatalogBrand{
+		NameEn:        brand.NameEn,
+		NameFa:        brand.NameFa,
+		DescriptionEn: brand.DescriptionEn,
+		DescriptionFa: brand.DescriptionFa,
+	}
+}
+
+func mapVehicleModelToCatalog(model *entity.VehicleModel) dto.CatalogModel {
+	return dto.CatalogModel{
+		NameEn:        model.NameEn,
+		NameFa:        model.NameFa,
+		DescriptionEn: model.DescriptionEn,
+		DescriptionFa: model.DescriptionFa,
+	}
+}
+
+func mapVehicleGenerationToCatalog(generation *entity.VehicleGeneration) dto.CatalogGeneration {
+	return dto.CatalogGeneration{
+		NameEn:               generation.NameEn,
+		NameFa:               generation.NameFa,
+		DescriptionEn:        generation.DescriptionEn,
+		DescriptionFa:        generation.DescriptionFa,
+		StartYear:            generation.StartYear,
+		EndYear:              generation.EndYear,
+		BodyStyleFa:          generation.BodyStyleFa,
+		BodyStyleEn:          generation.BodyStyleEn,
+		Engine:               generation.Engine,
+		EngineVolume:         generation.EngineVolume,
+		Cylinders:            generation.Cylinders,
+		DrivetrainFa:         generation.DrivetrainFa,
+		DrivetrainEn:         generation.DrivetrainEn,
+		Gearbox:              generation.Gearbox,
+		FuelType:             generation.FuelType,
+		Battery:              generation.Battery,
+		Seller:               generation.Seller,
+		AssemblyType:         generation.AssemblyType,
+		Assembler:            generation.Assembler,
+		TireSizes:            generation.TireSizeList(),
+		OilViscosities:       generation.OilViscosityList(),
+		OilCapacity:          generation.OilCapacity,
+		OilFilterPartNumbers: generation.OilFilterPartNumberList(),
+	}
+}
+
+func (uc *catalogUseCase) ListCatalogTrash(ctx context.Context) (*dto.ListCatalogTrashResponse, error) {
+	trash := repository.CatalogTrash{}
+	err := uc.catalogRepository.ListCatalogTrash(ctx, &trash)
+	if err != nil {
+		logger.Error(err, "Failed to list catalog trash")
+		return nil, errors.ErrFailedToListCatalogTrash
+	}
+	return &dto.ListCatalogTrashResponse{Items: mapCatalogTrash(trash)}, nil
+}
+
+// RestoreCatalogItem brings a deleted type, brand, model or generation back with everything under it that was not
+// deleted on its own. A row under a deleted parent can only be restored after the parent
+func (uc *catalogUseCase) RestoreCatalogItem(ctx context.Context, level, rowID string) error {
+	if !slices.Contains([]string{entity.CatalogLevelType, entity.CatalogLevelBrand, entity.CatalogLevelModel, entity.CatalogLevelGeneration}, level) {
+		return errors.ErrInvalidCatalogTrashLevel
+	}
+	uintRowID, err := strconv.ParseUint(rowID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse catalog row id")
+		return errors.ErrInvalidCatalogTrashRowID
+	}
+
+	trash := repository.CatalogTrash{}
+	err = uc.catalogRepository.ListCatalogTrash(ctx, &trash)
+	if err != nil {
+		logger.Error(err, "Failed to list catalog trash")
+		return errors.ErrFailedToRestoreCatalogItem
+	}
+	items := mapCatalogTrash(trash)
+	i := slices.IndexFunc(items, func(item dto.CatalogTrashItem) bool {
+		return item.Level == level && item.ID == uintRowID
+	})
+	if i < 0 {
+		return errors.ErrCatalogTrashItemNotFound
+	}
+	if items[i].ParentDeleted {
+		return errors.ErrCatalogTrashParentDeleted
+	}
+
+	err = uc.catalogRepository.RestoreCatalogRow(ctx, level, uintRowID)
+	if err != nil {
+		logger.Error(err, "Failed to restore catalog row")
+		return errors.ErrFailedToRestoreCatalogItem
+	}
+
+	// Invalidate cache after restoring the row
+	err = uc.vehicleCacheRepository.InvalidateVehicleHierarchy(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to invalidate vehicle hierarchy cache")
+		// Don't return error, just log it
+	}
+
+	return nil
+}
+
+// mapCatalogTrash lists the deleted rows most recently deleted first, marking the ones whose parent is deleted too
+func mapCatalogTrash(trash repository.CatalogTrash) []dto.CatalogTrashItem {
+	deleted := map[string]map[uint64]bool{
+		entity.CatalogLevelType:  {},
+		entity.CatalogLevelBrand: {},
+		entity.CatalogLevelModel: {},
+	}
+	for _, vehicleType := range trash.Types {
+		deleted[entity.CatalogLevelType][vehicleType.ID] = true
+	}
+	for _, brand := range trash.Brands {
+		deleted[entity.CatalogLevelBrand][brand.ID] = true
+	}
+	for _, model := range trash.Models {
+		deleted[entity.CatalogLevelModel][model.ID] = true
+	}
+
+	items := []dto.CatalogTrashItem{}
+	for _, vehicleType := range trash.Types {
+		items = append(items, dto.CatalogTrashItem{
+			Level:     entity.CatalogLevelType,
+			ID:        vehicleType.ID,
+			NameFa:    vehicleType.NameFa,
+			NameEn:    vehicleType.NameEn,
+			DeletedAt: vehicleType.DeletedAt.Time,
+		})
+	}
+	for _, brand := range trash.Brands {
+		items = append(items, dto.CatalogTrashItem{
+			Level:         entity.CatalogLevelBrand,
+			ID:            brand.ID,
+			ParentID:      brand.VehicleTypeID,
+			ParentDeleted: deleted[entity.CatalogLevelType][brand.VehicleTypeID],
+			NameFa:        brand.NameFa,
+			NameEn:        brand.NameEn,
+			DeletedAt:     brand.DeletedAt.Time,
+		})
+	}
+	for _, model := range trash.Models {
+		items = append(items, dto.CatalogTrashItem{
+			Level:         entity.CatalogLevelModel,
+			ID:            model.ID,
+			ParentID:      model.BrandID,
+			ParentDeleted: deleted[entity.CatalogLevelBrand][model.BrandID],
+			NameFa:        model.NameFa,
+			NameEn:        model.NameEn,
+			DeletedAt:     model.DeletedAt.Time,
+		})
+	}
+	for _, generation := range trash.Generations {
+		items = append(items, dto.CatalogTrashItem{
+			Level:         entity.CatalogLevelGeneration,
+			ID:            generation.ID,
+			ParentID:      generation.ModelID,
+			ParentDeleted: deleted[entity.CatalogLevelModel][generation.ModelID],
+			NameFa:        generation.NameFa,
+			NameEn:        generation.NameEn,
+			DeletedAt:     generation.DeletedAt.Time,
+		})
+	}
+	slices.SortStableFunc(items, func(a, b dto.CatalogTrashItem) int {
+		return b.DeletedAt.Compare(a.DeletedAt)
+	})
+	return items
+}
@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// generalCostCategory holds visit costs not attributed to a specific item
+const generalCostCategory = "general"
+
+type CostReportUseCase interface {
+	GetVehicleCostReport(ctx context.Context, userID, vehicleID string) (*dto.VehicleCostReportResponse, error)
+	GetVehicleOwnershipCost(ctx context.Context, userID, vehicleID string) (*dto.VehicleOwnershipCostResponse, error)
+}
+
+type costReportUseCase struct {
+	serviceVisitRepository   repository.ServiceVisitRepository
+	vehicleRepository        repository.VehicleRepository
+	fuelLogRepository        repository.FuelLogRepository
+	vehicleExpenseRepository repository.VehicleExpenseRepository
+	vehicleAccess            *vehicleAccess
+}
+
+func NewCostReportUseCase() CostReportUseCase {
+	serviceVisitRepository := repository.NewServiceVisitRepository()
+	vehicleRepository := repository.NewVehicleRepository()
+	fuelLogRepository := repository.NewFuelLogRepository()
+	vehicleExpenseRepository := repository.NewVehicleExpenseRepository()
+	return &costReportUseCase{
+		serviceVisitRepository:   serviceVisitRepository,
+		vehicleRepository:        vehicleRepository,
+		fuelLogRepository:        fuelLogRepository,
+		vehicleExpenseRepository: vehicleExpenseRepository,
+		vehicleAccess:            newVehicleAccess(vehicleRepository, repository.NewVehicleMembershipRepository()),
+	}
+}
+
+// costTotals accumulates labour and parts costs of a report bucket
+type costTotals struct {
+	labourCost entity.Rial
+	partsCost  entity.Rial
+	visitCount int
+}
+
+func (t *costTotals) add(labourCost, partsCost entity.Rial) {
+	t.labourCost += labourCost
+	t.partsCost += partsCost
+}
+
+func (uc *costReportUseCase) GetVehicleCostReport(ctx context.Context, userID, vehicleID string) (*dto.VehicleCostReportResponse, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintUserVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse user vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "User vehicle not owned by user")
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+
+	serviceVisits := []entity.ServiceVisit{}
+	err = uc.serviceVisitRepository.ListServiceVisits(ctx, vehicleID, &serviceVisits)
+	if err != nil {
+		logger.Error(err, "Failed to list service visits")
+		return nil, errors.ErrFailedToGetCostReport
+	}
+
+	total := costTotals{}
+	byMonth := map[string]*costTotals{}
+	byYear := map[string]*costTotals{}
+	byCategory := map[string]*costTotals{}
+	bucket := func(buckets map[string]*costTotals, key string) *costTotals {
+		if buckets[key] == nil {
+			buckets[key] = &costTotals{}
+		}
+		return buckets[key]
+	}
+
+	var firstMileage, lastMileage uint
+	for i, serviceVisit := range serviceVisits {
+		if i == 0 || serviceVisit.ServiceMileage < firstMileage {
+			firstMileage = serviceVisit.ServiceMileage
+		}
+		if serviceVisit.ServiceMileage > lastMileage {
+			lastMileage = serviceVisit.ServiceMileage
+		}
+
+		labourCost, partsCost := serviceVisit.TotalLabourCost(), serviceVisit.TotalPartsCost()
+		total.add(labourCost, partsCost)
+		total.visitCount++
+
+		month := bucket(byMonth, serviceVisit.ServiceDate.Format("2006-01"))
+		month.add(labourCost, partsCost)
+		month.visitCount++
+		year := bucket(byYear, serviceVisit.ServiceDate.Format("2006"))
+		year.add(labourCost, partsCost)
+		year.visitCount++
+
+		bucket(byCategory, generalCostCategory).add(serviceVisit.LabourCost, serviceVisit.PartsCost)
+		if serviceVisit.OilChange.ID != 0 {
+			bucket(byCategory, "oil_change").add(serviceVisit.OilChange.LabourCost, serviceVisit.OilChange.PartsCost)
+		}
+		if serviceVisit.OilFilter.ID != 0 {
+			bucket(byCategory, "oil_filter").add(serviceVisit.OilFilter.LabourCost, serviceVisit.OilFilter.PartsCost)
+		}
+		for _, serviceItem := range serviceVisit.ServiceItems {
+			bucket(byCategory, serviceItem.ItemType.String()).add(serviceItem.LabourCost, serviceItem.PartsCost)
+		}
+	}
+
+	response := &dto.VehicleCostReportResponse{
+		UserVehicleID: userVehicle.ID,
+		VisitCount:    total.visitCount,
+		Total:         *mapCostToResponse(total.labourCost, total.partsCost),
+		FirstMileage:  firstMileage,
+		LastMileage:   lastMileage,
+		Distance:      lastMileage - firstMileage,
+		ByMonth:       mapPeriodCostsToResponse(byMonth),
+		ByYear:        mapPeriodCostsToResponse(byYear),
+		ByCategory:    []dto.CategoryCostResponse{},
+	}
+
+	if response.Distance > 0 {
+		costPerKm := float64(total.labourCost+total.partsCost) / float64(response.Distance)
+		response.CostPerKm = math.Round(costPerKm*10) / 10
+		response.CostPerKmDisplay = entity.Rial(math.Round(costPerKm)).FormatToman()
+	}
+
+	for category, totals := range byCategory {
+		if totals.labourCost+totals.partsCost == 0 {
+			continue
+		}
+		response.ByCategory = append(response.ByCategory, dto.CategoryCostResponse{
+			Category: category,
+			Cost:     *mapCostToResponse(totals.labourCost, totals.partsCost),
+		})
+	}
+	sort.Slice(response.ByCategory, func(i, j int) bool {
+		if response.ByCategory[i].Cost.TotalCost == response.ByCategory[j].Cost.TotalCost {
+			return response.ByCategory[i].Category < response.ByCategory[j].Category
+		}
+		return response.ByCategory[i].Cost.TotalCost > response.ByCategory[j].Cost.TotalCost
+	})
+
+	return response, nil
+}
+
+// ownershipTotals accumulates everything spent on a vehicle in a report bucket
+type ownershipTotals struct {
+	maintenance entity.Rial
+	fuel        entity.Rial
+	expenses    entity.Rial
+}
+
+// expenseCategoryTotal is the spending on a built-in or custom expense category
+type expenseCategoryTotal struct {
+	category         entity.ExpenseCategory
+	customCategoryID *uint64
+	categoryName     string
+	total            entity.Rial
+}
+
+// GetVehicleOwnershipCost is open to the members of the vehicle like its expense ledger
+func (uc *costReportUseCase) GetVehicleOwnershipCost(ctx context.Context, userID, vehicleID string) (*dto.VehicleOwnershipCostResponse, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.ViewerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceVisits := []entity.ServiceVisit{}
+	err = uc.serviceVisitRepository.ListServiceVisits(ctx, vehicleID, &serviceVisits)
+	if err != nil {
+		logger.Error(err, "Failed to list service visits")
+		return nil, errors.ErrFailedToGetOwnershipCost
+	}
+	fuelLogs := []entity.FuelLog{}
+	err = uc.fuelLogRepository.ListFuelLogs(ctx, userVehicle.ID, &fuelLogs)
+	if err != nil {
+		logger.Error(err, "Failed to list fuel logs")
+		return nil, errors.ErrFailedToGetOwnershipCost
+	}
+	expenses := []entity.VehicleExpense{}
+	err = uc.vehicleExpenseRepository.ListVehicleExpenses(ctx, userVehicle.ID, &expenses)
+	if err != nil {
+		logger.Error(err, "Failed to list vehicle expenses")
+		return nil, errors.ErrFailedToGetOwnershipCost
+	}
+
+	total := ownershipTotals{}
+	byMonth := map[string]*ownershipTotals{}
+	month := func(date time.Time) *ownershipTotals {
+		key := date.Format("2006-01")
+		if byMonth[key] == nil {
+			byMonth[key] = &ownershipTotals{}
+		}
+		return byMonth[key]
+	}
+
+	for _, serviceVisit := range serviceVisits {
+		cost := serviceVisit.TotalLabourCost() + serviceVisit.TotalPartsCost()
+		total.maintenance += cost
+		month(serviceVisit.ServiceDate).maintenance += cost
+	}
+	for _, fuelLog := range fuelLogs {
+		total.fuel += fuelLog.TotalCost
+		month(fuelLog.FillDate).fuel += fuelLog.TotalCost
+	}
+
+	byCategory := map[string]*expenseCategoryTotal{}
+	for _, expense := range expenses {
+		total.expenses += expense.Amount
+		month(expense.ExpenseDate).expenses += expense.Amount
+
+		key := expense.Category.String()
+		if expense.UserExpenseCategoryID != nil {
+			key += ":" + strconv.FormatUint(*expense.UserExpenseCategoryID, 10)
+		}
+		if byCategory[key] == nil {
+			byCategory[key] = &expenseCategoryTotal{
+				category:         expense.Category,
+				customCategoryID: expense.UserExpenseCategoryID,
+				categoryName:     expense.CategoryName(),
+			}
+		}
+		byCategory[key].total += expense.Amount
+	}
+
+	response := &dto.VehicleOwnershipCostResponse{
+		UserVehicleID:      userVehicle.ID,
+		Total:              *mapOwnershipCostToResponse(total),
+		ByMonth:            []dto.MonthlyOwnershipCostResponse{},
+		ExpensesByCategory: []dto.ExpenseCategoryCostResponse{},
+	}
+	for key, totals := range byMonth {
+		response.ByMonth = append(response.ByMonth, dto.MonthlyOwnershipCostResponse{
+			Month: key,
+			Cost:  *mapOwnershipCostToResponse(*totals),
+		})
+	}
+	sort.Slice(response.ByMonth, func(i, j int) bool {
+		return response.ByMonth[i].Month < response.ByMonth[j].Month
+	})
+
+	for _, totals := range byCategory {
+		response.ExpensesByCategory = append(response.ExpensesByCategory, dto.ExpenseCategoryCostResponse{
+			Category:         totals.category.String(),
+			CustomCategoryID: totals.customCategoryID,
+			CategoryName:     totals.categoryName,
+			Total:            int64(totals.total),
+			TotalDisplay:     totals.total.FormatToman(),
+		})
+	}
+	sort.Slice(response.ExpensesByCategory, func(i, j int) bool {
+		if response.ExpensesByCategory[i].Total == response.ExpensesByCategory[j].Total {
+			return response.ExpensesByCategory[i].CategoryName < response.ExpensesByCategory[j].CategoryName
+		}
+		return response.ExpensesByCategory[i].Total > response.ExpensesByCategory[j].Total
+	})
+
+	return response, nil
+}
+
+// mapPeriodCostsToResponse lists the periods in chronological order
+func mapPeriodCostsToResponse(periods map[string]*costTotals) []dto.PeriodCostResponse {
+	response := []dto.PeriodCostResponse{}
+	for period, totals := range periods {
+		response = append(response, dto.PeriodCostResponse{
+			Period:     period,
+			VisitCount: totals.visitCount,
+			Cost:       *mapCostToResponse(totals.labourCost, totals.partsCost),
+		})
+	}
+	sort.Slice(response, func(i, j int) bool {
+		return response[i].Period < response[j].Period
+	})
+	return response
+}
+
+func mapCostToResponse(labourCost, partsCost entity.Rial) *dto.CostResponse {
+	totalCost := labourCost + partsCost
+	return &dto.CostResponse{
+		LabourCost:       int64(labourCost),
+		PartsCost:        int64(partsCost),
+		TotalCost:        int64(totalCost),
+		TotalCostToman:   totalCost.Toman(),
+		TotalCostDisplay: totalCost.FormatToman(),
+	}
+}
+
+func mapOwnershipCostToResponse(totals ownershipTotals) *dto.OwnershipCostResponse {
+	total := totals.maintenance + totals.fuel + totals.expenses
+	return &dto.OwnershipCostResponse{
+		Maintenance:  int64(totals.maintenance),
+		Fuel:         int64(totals.fuel),
+		Expenses:     int64(totals.expenses),
+		Total:        int64(total),
+		TotalToman:   total.Toman(),
+		TotalDisplay: total.FormatToman(),
+	}
+}
@@ -0,0 +1,429 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// fuelEconomyRollingWindow is the number of recent full-to-full intervals the rolling average covers
+const fuelEconomyRollingWindow = 3
+
+type FuelLogUseCase interface {
+	CreateFuelLog(ctx context.Context, userID, vehicleID string, request dto.CreateFuelLogRequest) (*dto.FuelLogResponse, error)
+	GetFuelLog(ctx context.Context, userID, vehicleID, fuelLogID string) (*dto.FuelLogResponse, error)
+	ListFuelLogs(ctx context.Context, userID, vehicleID string) (*dto.ListFuelLogsResponse, error)
+	UpdateFuelLog(ctx context.Context, userID, vehicleID, fuelLogID string, request dto.UpdateFuelLogRequest) (*dto.FuelLogResponse, error)
+	DeleteFuelLog(ctx context.Context, userID, vehicleID, fuelLogID string) error
+	GetFuelLogSummary(ctx context.Context, userID, vehicleID string) (*dto.FuelLogSummaryResponse, error)
+}
+
+type fuelLogUseCase struct {
+	fuelLogRepository repository.FuelLogRepository
+	vehicleRepository repository.VehicleRepository
+}
+
+func NewFuelLogUseCase() FuelLogUseCase {
+	fuelLogRepository := repository.NewFuelLogRepository()
+	vehicleRepository := repository.NewVehicleRepository()
+	return &fuelLogUseCase{
+		fuelLogRepository: fuelLogRepository,
+		vehicleRepository: vehicleRepository,
+	}
+}
+
+func (uc *fuelLogUseCase) CreateFuelLog(ctx context.Context, userID, vehicleID string, request dto.CreateFuelLogRequest) (*dto.FuelLogResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateFuelLogCreateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate fuel log create request")
+		return nil, errors.ErrInvalidFuelLogCreateRequest
+	}
+
+	fillDate := time.Now().Truncate(24 * time.Hour)
+	if request.FillDate != "" {
+		fillDate, err = time.Parse("2006-01-02", request.FillDate)
+		if err != nil {
+			logger.Error(err, "Failed to parse fill date")
+			return nil, errors.ErrInvalidDate
+		}
+	}
+
+	allowedFuelTypes := uc.allowedFuelTypes(ctx, userVehicle)
+	fuelType := allowedFuelTypes[0]
+	if request.FuelType != "" {
+		fuelType = entity.ParseFuelType(request.FuelType)
+		if !fuelTypeAllowed(allowedFuelTypes, fuelType) {
+			logger.Error(errors.ErrFuelTypeNotSupported, "Fuel type not supported by vehicle generation")
+			return nil, errors.ErrFuelTypeNotSupported
+		}
+	}
+
+	fuelLog := entity.FuelLog{
+		UserID:        userVehicle.UserID,
+		UserVehicleID: userVehicle.ID,
+		FillDate:      fillDate,
+		Mileage:       request.Mileage,
+		FuelType:      fuelType,
+		Litres:        request.Litres,
+		PricePerLitre: entity.Rial(request.PricePerLitre),
+		TotalCost:     entity.Rial(request.TotalCost),
+		IsFullTank:    request.IsFullTank,
+		Station:       request.Station,
+		Notes:         request.Notes,
+	}
+	fillFuelPrice(&fuelLog, request.TotalCost > 0, request.PricePerLitre > 0)
+
+	err = uc.fuelLogRepository.CreateFuelLog(ctx, &fuelLog)
+	if err != nil {
+		logger.Error(err, "Failed to create fuel log")
+		return nil, errors.ErrFailedToCreateFuelLog
+	}
+
+	return uc.mapFuelLogWithConsumption(ctx, &fuelLog), nil
+}
+
+func (uc *fuelLogUseCase) GetFuelLog(ctx context.Context, userID, vehicleID, fuelLogID string) (*dto.FuelLogResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	fuelLog, err := uc.getFuelLogOfVehicle(ctx, userVehicle, fuelLogID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.mapFuelLogWithConsumption(ctx, fuelLog), nil
+}
+
+func (uc *fuelLogUseCase) ListFuelLogs(ctx context.Context, userID, vehicleID string) (*dto.ListFuelLogsResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	fuelLogs := []entity.FuelLog{}
+	err = uc.fuelLogRepository.ListFuelLogs(ctx, userVehicle.ID, &fuelLogs)
+	if err != nil {
+		logger.Error(err, "Failed to list fuel logs")
+		return nil, errors.ErrFailedToListFuelLogs
+	}
+
+	consumptions := consumptionByFuelLog(entity.CalculateFuelEconomy(fuelLogs))
+	fuelLogsResponse := []dto.FuelLogResponse{}
+	for _, fuelLog := range fuelLogs {
+		fuelLogsResponse = append(fuelLogsResponse, *mapFuelLogToResponse(&fuelLog, consumptions))
+	}
+
+	return &dto.ListFuelLogsResponse{
+		FuelLogs: fuelLogsResponse,
+	}, nil
+}
+
+func (uc *fuelLogUseCase) UpdateFuelLog(ctx context.Context, userID, vehicleID, fuelLogID string, request dto.UpdateFuelLogRequest) (*dto.FuelLogResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateFuelLogUpdateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate fuel log update request")
+		return nil, errors.ErrInvalidFuelLogUpdateRequest
+	}
+
+	fuelLog, err := uc.getFuelLogOfVehicle(ctx, userVehicle, fuelLogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.FillDate != nil {
+		fillDate, err := time.Parse("2006-01-02", *request.FillDate)
+		if err != nil {
+			logger.Error(err, "Failed to parse fill date")
+			return nil, errors.ErrInvalidDate
+		}
+		fuelLog.FillDate = fillDate
+	}
+	if request.Mileage != nil {
+		fuelLog.Mileage = *request.Mileage
+	}
+	if request.FuelType != nil {
+		fuelType := entity.ParseFuelType(*request.FuelType)
+		if !fuelTypeAllowed(uc.allowedFuelTypes(ctx, userVehicle), fuelType) {
+			logger.Error(errors.ErrFuelTypeNotSupported, "Fuel type not supported by vehicle generation")
+			return nil, errors.ErrFuelTypeNotSupported
+		}
+		fuelLog.FuelType = fuelType
+	}
+	if request.Litres != nil {
+		fuelLog.Litres = *request.Litres
+	}
+	if request.PricePerLitre != nil {
+		fuelLog.PricePerLitre = entity.Rial(*request.PricePerLitre)
+	}
+	if request.TotalCost != nil {
+		fuelLog.TotalCost = entity.Rial(*request.TotalCost)
+	}
+	if request.IsFullTank != nil {
+		fuelLog.IsFullTank = *request.IsFullTank
+	}
+	if request.Station != nil {
+		fuelLog.Station = *request.Station
+	}
+	if request.Notes != nil {
+		fuelLog.Notes = *request.Notes
+	}
+	fillFuelPrice(fuelLog, request.TotalCost != nil, request.PricePerLitre != nil)
+
+	err = uc.fuelLogRepository.UpdateFuelLog(ctx, fuelLog)
+	if err != nil {
+		logger.Error(err, "Failed to update fuel log")
+		return nil, errors.ErrFailedToUpdateFuelLog
+	}
+
+	return uc.mapFuelLogWithConsumption(ctx, fuelLog), nil
+}
+
+func (uc *fuelLogUseCase) DeleteFuelLog(ctx context.Context, userID, vehicleID, fuelLogID string) error {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return err
+	}
+
+	fuelLog, err := uc.getFuelLogOfVehicle(ctx, userVehicle, fuelLogID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.fuelLogRepository.DeleteFuelLog(ctx, fuelLog)
+	if err != nil {
+		logger.Error(err, "Failed to delete fuel log")
+		return errors.ErrFailedToDeleteFuelLog
+	}
+	return nil
+}
+
+func (uc *fuelLogUseCase) GetFuelLogSummary(ctx context.Context, userID, vehicleID string) (*dto.FuelLogSummaryResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	fuelLogs := []entity.FuelLog{}
+	err = uc.fuelLogRepository.ListFuelLogs(ctx, userVehicle.ID, &fuelLogs)
+	if err != nil {
+		logger.Error(err, "Failed to list fuel logs")
+		return nil, errors.ErrFailedToGetFuelSummary
+	}
+
+	response := &dto.FuelLogSummaryResponse{
+		UserVehicleID: userVehicle.ID,
+		FuelTypes:     []string{},
+		RollingWindow: fuelEconomyRollingWindow,
+		Economy:       []dto.FuelEconomyResponse{},
+		Monthly:       []dto.MonthlyFuelSpendResponse{},
+	}
+	allowedFuelTypes := uc.allowedFuelTypes(ctx, userVehicle)
+	for _, fuelType := range allowedFuelTypes {
+		response.FuelTypes = append(response.FuelTypes, fuelType.String())
+	}
+
+	// Economy per fuel, in the order of the vehicle's fuels followed by any other fuel logged
+	economies := entity.CalculateFuelEconomy(fuelLogs)
+	fuelTypes := append([]entity.FuelType{}, allowedFuelTypes...)
+	for _, fuelLog := range fuelLogs {
+		if !fuelTypeAllowed(fuelTypes, fuelLog.FuelType) {
+			fuelTypes = append(fuelTypes, fuelLog.FuelType)
+		}
+	}
+	for _, fuelType := range fuelTypes {
+		economy := dto.FuelEconomyResponse{
+			FuelType: fuelType.String(),
+			Unit:     fuelType.Unit(),
+		}
+		for _, fuelLog := range fuelLogs {
+			if fuelLog.FuelType == fuelType {
+				economy.FillUpCount++
+				economy.TotalLitres += fuelLog.Litres
+				economy.TotalCost += int64(fuelLog.TotalCost)
+			}
+		}
+		if economy.FillUpCount == 0 {
+			continue
+		}
+
+		// CalculateFuelEconomy returns intervals in mileage order, oldest first
+		intervals := []entity.FuelEconomy{}
+		for _, interval := range economies {
+			if interval.FuelType == fuelType {
+				intervals = append(intervals, interval)
+			}
+		}
+		economy.TotalLitres = roundToOneDecimal(economy.TotalLitres)
+		economy.IntervalCount = len(intervals)
+		if len(intervals) > 0 {
+			recent := intervals[max(0, len(intervals)-fuelEconomyRollingWindow):]
+			economy.AverageConsumption = roundToOneDecimal(entity.AverageConsumption(intervals))
+			economy.RollingAverageConsumption = roundToOneDecimal(entity.AverageConsumption(recent))
+			economy.LastConsumption = roundToOneDecimal(intervals[len(intervals)-1].Consumption)
+		}
+		response.Economy = append(response.Economy, economy)
+	}
+
+	months := map[string]*dto.MonthlyFuelSpendResponse{}
+	for _, fuelLog := range fuelLogs {
+		month := fuelLog.FillDate.Format("2006-01")
+		if months[month] == nil {
+			months[month] = &dto.MonthlyFuelSpendResponse{Month: month}
+		}
+		months[month].FillUpCount++
+		months[month].TotalCost += int64(fuelLog.TotalCost)
+	}
+	for _, month := range months {
+		month.TotalCostToman = entity.Rial(month.TotalCost).Toman()
+		month.TotalCostDisplay = entity.Rial(month.TotalCost).FormatToman()
+		response.Monthly = append(response.Monthly, *month)
+	}
+	sort.Slice(response.Monthly, func(i, j int) bool {
+		return response.Monthly[i].Month < response.Monthly[j].Month
+	})
+
+	return response, nil
+}
+
+// getOwnedUserVehicle parses the ids and ensures the vehicle belongs to the user
+func (uc *fuelLogUseCase) getOwnedUserVehicle(ctx context.Context, userID, vehicleID string) (*entity.UserVehicle, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintUserVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse user vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "User vehicle not owned by user")
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+	return &userVehicle, nil
+}
+
+// getFuelLogOfVehicle loads a fuel log and ensures it belongs to the vehicle
+func (uc *fuelLogUseCase) getFuelLogOfVehicle(ctx context.Context, userVehicle *entity.UserVehicle, fuelLogID string) (*entity.FuelLog, error) {
+	uintFuelLogID, err := strconv.ParseUint(fuelLogID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse fuel log id")
+		return nil, errors.ErrInvalidFuelLogID
+	}
+
+	fuelLog := entity.FuelLog{}
+	err = uc.fuelLogRepository.GetFuelLog(ctx, uintFuelLogID, &fuelLog)
+	if err != nil {
+		logger.Error(err, "Failed to get fuel log")
+		return nil, errors.ErrFailedToGetFuelLog
+	}
+	if fuelLog.UserVehicleID != userVehicle.ID {
+		logger.Error(errors.ErrFuelLogNotOwned, "Fuel log does not belong to user vehicle")
+		return nil, errors.ErrFuelLogNotOwned
+	}
+	return &fuelLog, nil
+}
+
+// allowedFuelTypes reads the fuels of the vehicle's generation, accepting every fuel if the generation can't be loaded
+func (uc *fuelLogUseCase) allowedFuelTypes(ctx context.Context, userVehicle *entity.UserVehicle) []entity.FuelType {
+	generation := entity.VehicleGeneration{}
+	generation.ID = userVehicle.GenerationID
+	err := uc.vehicleRepository.GetGeneration(ctx, &generation)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle generation")
+		return entity.AllowedFuelTypes("")
+	}
+	return entity.AllowedFuelTypes(generation.FuelType)
+}
+
+// mapFuelLogWithConsumption maps a single fuel log, looking up its consumption among the vehicle's fill-ups
+func (uc *fuelLogUseCase) mapFuelLogWithConsumption(ctx context.Context, fuelLog *entity.FuelLog) *dto.FuelLogResponse {
+	fuelLogs := []entity.FuelLog{}
+	err := uc.fuelLogRepository.ListFuelLogs(ctx, fuelLog.UserVehicleID, &fuelLogs)
+	if err != nil {
+		logger.Error(err, "Failed to list fuel logs")
+		return mapFuelLogToResponse(fuelLog, nil)
+	}
+	return mapFuelLogToResponse(fuelLog, consumptionByFuelLog(entity.CalculateFuelEconomy(fuelLogs)))
+}
+
+func fuelTypeAllowed(allowedFuelTypes []entity.FuelType, fuelType entity.FuelType) bool {
+	for _, allowed := range allowedFuelTypes {
+		if allowed == fuelType {
+			return true
+		}
+	}
+	return false
+}
+
+// fillFuelPrice derives whichever of total cost and price per litre was not given from the other.
+// When neither was given, e.g. only the litres changed, the total follows the price per litre
+func fillFuelPrice(fuelLog *entity.FuelLog, totalGiven, priceGiven bool) {
+	switch {
+	case totalGiven && priceGiven:
+	case totalGiven:
+		fuelLog.PricePerLitre = entity.Rial(math.Round(float64(fuelLog.TotalCost) / fuelLog.Litres))
+	case fuelLog.PricePerLitre > 0:
+		fuelLog.TotalCost = entity.Rial(math.Round(float64(fuelLog.PricePerLitre) * fuelLog.Litres))
+	}
+}
+
+func consumptionByFuelLog(economies []entity.FuelEconomy) map[uint64]float64 {
+	consumptions := map[uint64]float64{}
+	for _, economy := range economies {
+		consumptions[economy.FuelLogID] = roundToOneDecimal(economy.Consumption)
+	}
+	return consumptions
+}
+
+func roundToOneDecimal(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+func mapFuelLogToResponse(fuelLog *entity.FuelLog, consumptions map[uint64]float64) *dto.FuelLogResponse {
+	response := &dto.FuelLogResponse{
+		ID:               fuelLog.ID,
+		UserVehicleID:    fuelLog.UserVehicleID,
+		FillDate:         fuelLog.FillDate.Format("2006-01-02"),
+		Mileage:          fuelLog.Mileage,
+		FuelType:         fuelLog.FuelType.String(),
+		Unit:             fuelLog.FuelType.Unit(),
+		Litres:           fuelLog.Litres,
+		PricePerLitre:    int64(fuelLog.PricePerLitre),
+		TotalCost:        int64(fuelLog.TotalCost),
+		TotalCostDisplay: fuelLog.TotalCost.FormatToman(),
+		IsFullTank:       fuelLog.IsFullTank,
+		Station:          fuelLog.Station,
+		Notes:            fuelLog.Notes,
+	}
+	if consumption, ok := consumptions[fuelLog.ID]; ok {
+		response.ConsumptionPer100Km = &consumption
+	}
+	return response
+}
@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+type MaintenanceScheduleUseCase interface {
+	// Manufacturer intervals
+	ListMaintenanceIntervals(ctx context.Context, generationID string) (*dto.ListMaintenanceIntervalsResponse, error)
+	CreateMaintenanceInterval(ctx context.Context, generationID string, request dto.CreateMaintenanceIntervalRequest) (*dto.MaintenanceIntervalResponse, error)
+	UpdateMaintenanceInterval(ctx context.Context, generationID, intervalID string, request dto.UpdateMaintenanceIntervalRequest) (*dto.MaintenanceIntervalResponse, error)
+	DeleteMaintenanceInterval(ctx context.Context, generationID, intervalID string) error
+
+	// User vehicle plans
+	GetMaintenancePlan(ctx context.Context, userID, vehicleID string) (*dto.MaintenancePlanResponse, error)
+	RegenerateMaintenancePlan(ctx context.Context, userID, vehicleID string) (*dto.MaintenancePlanResponse, error)
+}
+
+type maintenanceScheduleUseCase struct {
+	maintenanceScheduleRepository repository.MaintenanceScheduleRepository
+	vehicleRepository             repository.VehicleRepository
+	maintenancePlanner            *maintenancePlanner
+}
+
+func NewMaintenanceScheduleUseCase() MaintenanceScheduleUseCase {
+	maintenanceScheduleRepository := repository.NewMaintenanceScheduleRepository()
+	vehicleRepository := repository.NewVehicleRepository()
+	return &maintenanceScheduleUseCase{
+		maintenanceScheduleRepository: maintenanceScheduleRepository,
+		vehicleRepository:             vehicleRepository,
+		maintenancePlanner:            newMaintenancePlanner(maintenanceScheduleRepository),
+	}
+}
+
+func (uc *maintenanceScheduleUseCase) ListMaintenanceIntervals(ctx context.Context, generationID string) (*dto.ListMaintenanceIntervalsResponse, error) {
+	uintGenerationID, err := strconv.ParseUint(generationID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle generation id")
+		return nil, errors.ErrInvalidVehicleGenerationID
+	}
+
+	intervals := []entity.MaintenanceInterval{}
+	err = uc.maintenanceScheduleRepository.ListMaintenanceIntervals(ctx, uintGenerationID, &intervals)
+	if err != nil {
+		logger.Error(err, "Failed to list maintenance intervals")
+		return nil, errors.ErrFailedToListMaintenanceIntervals
+	}
+
+	intervalsResponse := []dto.MaintenanceIntervalResponse{}
+	for _, interval := range intervals {
+		intervalsResponse = append(intervalsResponse, *mapMaintenanceIntervalToResponse(&interval))
+	}
+	return &dto.ListMaintenanceIntervalsResponse{Intervals: intervalsResponse}, nil
+}
+
+func (uc *maintenanceScheduleUseCase) CreateMaintenanceInterval(ctx context.Context, generationID string, request dto.CreateMaintenanceIntervalRequest) (*dto.MaintenanceIntervalResponse, error) {
+	err := validation.ValidateMaintenanceIntervalCreateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate maintenance interval create request")
+		return nil, errors.ErrInvalidMaintenanceIntervalCreateRequest
+	}
+
+	uintGenerationID, err := strconv.ParseUint(generationID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle generation id")
+		return nil, errors.ErrInvalidVehicleGenerationID
+	}
+
+	generation := entity.VehicleGeneration{}
+	generation.ID = uintGenerationID
+	err = uc.vehicleRepository.GetGeneration(ctx, &generation)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle generation")
+		return nil, errors.ErrFailedToGetVehicleGeneration
+	}
+
+	existing := entity.MaintenanceInterval{}
+	err = uc.maintenanceScheduleRepository.GetMaintenanceIntervalByItemType(ctx, uintGenerationID, request.ItemType, &existing)
+	if err != nil {
+		logger.Error(err, "Failed to get maintenance interval")
+		return nil, errors.ErrFailedToCreateMaintenanceInterval
+	}
+	if existing.ID != 0 {
+		logger.Error(errors.ErrMaintenanceIntervalExists, "Generation already has an interval for the item type")
+		return nil, errors.ErrMaintenanceIntervalExists
+	}
+
+	interval := entity.MaintenanceInterval{
+		GenerationID:    uintGenerationID,
+		ItemType:        request.ItemType,
+		NameFa:          request.NameFa,
+		NameEn:          request.NameEn,
+		IntervalMileage: request.IntervalMileage,
+		IntervalMonths:  request.IntervalMonths,
+	}
+	err = uc.maintenanceScheduleRepository.CreateMaintenanceInterval(ctx, &interval)
+	if err != nil {
+		logger.Error(err, "Failed to create maintenance interval")
+		return nil, errors.ErrFailedToCreateMaintenanceInterval
+	}
+
+	return mapMaintenanceIntervalToResponse(&interval), nil
+}
+
+func (uc *maintenanceScheduleUseCase) UpdateMaintenanceInterval(ctx context.Context, generationID, intervalID string, request dto.UpdateMaintenanceIntervalRequest) (*dto.MaintenanceIntervalResponse, error) {
+	err := validation.ValidateMaintenanceIntervalUpdateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate maintenance interval update request")
+		return nil, errors.ErrInvalidMaintenanceIntervalUpdateRequest
+	}
+
+	interval, err := uc.getMaintenanceIntervalOfGeneration(ctx, generationID, intervalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.NameFa != nil {
+		interval.NameFa = *request.NameFa
+	}
+	if request.NameEn != nil {
+		interval.NameEn = *request.NameEn
+	}
+	if request.IntervalMileage != nil {
+		interval.IntervalMileage = *request.IntervalMileage
+	}
+	if request.IntervalMonths != nil {
+		interval.IntervalMonths = *request.IntervalMonths
+	}
+	if interval.IntervalMileage == 0 && interval.IntervalMonths == 0 {
+		logger.Error(errors.ErrInvalidMaintenanceIntervalUpdateRequest, "Maintenance interval has neither mileage nor months")
+		return nil, errors.ErrInvalidMaintenanceIntervalUpdateRequest
+	}
+
+	err = uc.maintenanceScheduleRepository.UpdateMaintenanceInterval(ctx, interval)
+	if err != nil {
+		logger.Error(err, "Failed to update maintenance interval")
+		return nil, errors.ErrFailedToUpdateMaintenanceInterval
+	}
+
+	return mapMaintenanceIntervalToResponse(interval), nil
+}
+
+func (uc *maintenanceScheduleUseCase) DeleteMaintenanceInterval(ctx context.Context, generationID, intervalID string) error {
+	interval, err := uc.getMaintenanceIntervalOfGeneration(ctx, generationID, intervalID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.maintenanceScheduleRepository.DeleteMaintenanceInterval(ctx, interval)
+	if err != nil {
+		logger.Error(err, "Failed to delete maintenance interval")
+		return errors.ErrFailedToDeleteMaintenanceInterval
+	}
+	return nil
+}
+
+func (uc *maintenanceScheduleUseCase) GetMaintenancePlan(ctx context.Context, userID, vehicleID string) (*dto.MaintenancePlanResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []entity.MaintenancePlanItem{}
+	err = uc.maintenanceScheduleRepository.ListMaintenancePlanItems(ctx, userVehicle.ID, &items)
+	if err != nil {
+		logger.Error(err, "Failed to list maintenance plan items")
+		return nil, errors.ErrFailedToGetMaintenancePlan
+	}
+
+	return mapMaintenancePlanToResponse(userVehicle, items), nil
+}
+
+func (uc *maintenanceScheduleUseCase) RegenerateMaintenancePlan(ctx context.Context, userID, vehicleID string) (*dto.MaintenancePlanResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := uc.maintenancePlanner.generatePlan(ctx, userVehicle)
+	if err != nil {
+		logger.Error(err, "Failed to generate maintenance plan")
+		return nil, errors.ErrFailedToGenerateMaintenancePlan
+	}
+
+	return mapMaintenancePlanToResponse(userVehicle, items), nil
+}
+
+// getMaintenanceIntervalOfGeneration loads an interval and ensures it belongs to the generation
+func (uc *maintenanceScheduleUseCase) getMaintenanceIntervalOfGeneration(ctx context.Context, generationID, intervalID string) (*entity.MaintenanceInterval, error) {
+	uintGenerationID, err := strconv.ParseUint(generationID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle generation id")
+		return nil, errors.ErrInvalidVehicleGenerationID
+	}
+	uintIntervalID, err := strconv.ParseUint(intervalID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse maintenance interval id")
+		return nil, errors.ErrInvalidMaintenanceIntervalID
+	}
+
+	interval := entity.MaintenanceInterval{}
+	err = uc.maintenanceScheduleRepository.GetMaintenanceInterval(ctx, uintIntervalID, &interval)
+	if err != nil || interval.GenerationID != uintGenerationID {
+		logger.Error(err, "Maintenance interval not found in generation")
+		return nil, errors.ErrMaintenanceIntervalNotFound
+	}
+	return &interval, nil
+}
+
+// getOwnedUserVehicle parses the ids and ensures the vehicle belongs to the user
+func (uc *maintenanceScheduleUseCase) getOwnedUserVehicle(ctx context.Context, userID, vehicleID string) (*entity.UserVehicle, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintUserVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse user vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "User vehicle not owned by user")
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+	return &userVehicle, nil
+}
+
+// maintenancePlanner turns a generation's manufacturer intervals into a vehicle's maintenance plan
+// and supplies the plan's next-due values wherever the user left them out
+type maintenancePlanner struct {
+	maintenanceScheduleRepository repository.MaintenanceScheduleRepository
+}
+
+func newMaintenancePlanner(maintenanceScheduleRepository repository.MaintenanceScheduleRepository) *maintenancePlanner {
+	return &maintenancePlanner{maintenanceScheduleRepository: maintenanceScheduleRepository}
+}
+
+// generatePlan replaces the vehicle's plan with its generation's current intervals, starting from
+// the vehicle's current mileage and today
+func (p *maintenancePlanner) generatePlan(ctx context.Context, userVehicle *entity.UserVehicle) ([]entity.MaintenancePlanItem, error) {
+	intervals := []entity.MaintenanceInterval{}
+	err := p.maintenanceScheduleRepository.ListMaintenanceIntervals(ctx, userVehicle.GenerationID, &intervals)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := time.Now().Truncate(24 * time.Hour)
+	items := []entity.MaintenancePlanItem{}
+	for _, interval := range intervals {
+		items = append(items, entity.MaintenancePlanItem{
+			UserVehicleID:         userVehicle.ID,
+			MaintenanceIntervalID: interval.ID,
+			ItemType:              interval.ItemType,
+			NameFa:                interval.NameFa,
+			NameEn:                interval.NameEn,
+			IntervalMileage:       interval.IntervalMileage,
+			IntervalMonths:        interval.IntervalMonths,
+			StartMileage:          uint(userVehicle.CurrentMileage),
+			StartDate:             startDate,
+		})
+	}
+
+	err = p.maintenanceScheduleRepository.ReplaceMaintenancePlan(ctx, userVehicle.ID, &items)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// applyDefaultSchedule fills a next change mileage or date the user left empty from the vehicle's plan
+func (p *maintenancePlanner) applyDefaultSchedule(ctx context.Context, userVehicleID uint64, itemType string, changeMileage uint, changeDate time.Time, nextChangeMileage *uint, nextChangeDate *time.Time) {
+	if *nextChangeMileage > 0 && !nextChangeDate.IsZero() {
+		return
+	}
+
+	item := entity.MaintenancePlanItem{}
+	err := p.maintenanceScheduleRepository.GetMaintenancePlanItem(ctx, userVehicleID, itemType, &item)
+	if err != nil {
+		logger.Error(err, "Failed to get maintenance plan item")
+		return
+	}
+	if item.ID == 0 {
+		return
+	}
+
+	planMileage, planDate := item.NextDue(changeMileage, changeDate)
+	if *nextChangeMileage == 0 {
+		*nextChangeMileage = planMileage
+	}
+	if nextChangeDate.IsZero() {
+		*nextChangeDate = planDate
+	}
+}
+
+func mapMaintenanceIntervalToResponse(interval *entity.MaintenanceInterval) *dto.MaintenanceIntervalResponse {
+	return &dto.MaintenanceIntervalResponse{
+		ID:              interval.ID,
+		GenerationID:    interval.GenerationID,
+		ItemType:        interval.ItemType,
+		NameFa:          interval.NameFa,
+		NameEn:          interval.NameEn,
+		IntervalMileage: interval.IntervalMileage,
+		IntervalMonths:  interval.IntervalMonths,
+	}
+}
+
+func mapMaintenancePlanToResponse(userVehicle *entity.UserVehicle, items []entity.MaintenancePlanItem) *dto.MaintenancePlanResponse {
+	response := &dto.MaintenancePlanResponse{
+		UserVehicleID: userVehicle.ID,
+		GenerationID:  userVehicle.GenerationID,
+		Items:         []dto.MaintenancePlanItemResponse{},
+	}
+	for _, item := range items {
+		response.Items = append(response.Items, dto.MaintenancePlanItemResponse{
+			ID:              item.ID,
+			ItemType:        item.ItemType,
+			NameFa:          item.NameFa,
+			NameEn:          item.NameEn,
+			IntervalMileage: item.IntervalMileage,
+			IntervalMonths:  item.IntervalMonths,
+			StartMileage:    item.StartMileage,
+			StartDate:       item.StartDate.Format("2006-01-02"),
+		})
+	}
+	return response
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+)
+
+// mileageEstimator estimates how far a vehicle is driven per day from its odometer readings and service visits
+type mileageEstimator struct {
+	odometerReadingRepository repository.OdometerReadingRepository
+	serviceVisitRepository    repository.ServiceVisitRepository
+}
+
+func newMileageEstimator(odometerReadingRepository repository.OdometerReadingRepository, serviceVisitRepository repository.ServiceVisitRepository) *mileageEstimator {
+	return &mileageEstimator{
+		odometerReadingRepository: odometerReadingRepository,
+		serviceVisitRepository:    serviceVisitRepository,
+	}
+}
+
+// estimate returns nil when the vehicle's history is too short to estimate from
+func (e *mileageEstimator) estimate(ctx context.Context, userVehicleID uint64) (*entity.MileageEstimate, error) {
+	points := []entity.MileagePoint{}
+
+	odometerReadings := []entity.OdometerReading{}
+	if err := e.odometerReadingRepository.ListOdometerReadings(ctx, userVehicleID, &odometerReadings); err != nil {
+		return nil, err
+	}
+	for _, odometerReading := range odometerReadings {
+		points = append(points, entity.MileagePoint{
+			Date:          odometerReading.ReadingDate,
+			Mileage:       odometerReading.Mileage,
+			IsReplacement: odometerReading.IsReplacement,
+		})
+	}
+
+	// visits logged before odometer readings existed still carry a mileage
+	serviceVisits := []entity.ServiceVisit{}
+	if err := e.serviceVisitRepository.ListServiceVisits(ctx, strconv.FormatUint(userVehicleID, 10), &serviceVisits); err != nil {
+		return nil, err
+	}
+	for _, serviceVisit := range serviceVisits {
+		points = append(points, entity.MileagePoint{
+			Date:    serviceVisit.ServiceDate,
+			Mileage: serviceVisit.ServiceMileage,
+		})
+	}
+
+	return entity.EstimateDailyMileage(points), nil
+}
+
+// formatDueAround formats the projected date of nextMileage and the resulting due-around date,
+// leaving either empty when it is unknown
+func formatDueAround(estimate *entity.MileageEstimate, nextMileage uint, nextDate time.Time) (string, string) {
+	projected, dueAround := entity.DueAround(estimate, nextMileage, nextDate)
+	projectedDate, dueAroundDate := "", ""
+	if !projected.IsZero() {
+		projectedDate = projected.Format("2006-01-02")
+	}
+	if !dueAround.IsZero() {
+		dueAroundDate = dueAround.Format("2006-01-02")
+	}
+	return projectedDate, dueAroundDate
+}
@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+type OdometerReadingUseCase interface {
+	CreateOdometerReading(ctx context.Context, userID, vehicleID string, request dto.CreateOdometerReadingRequest) (*dto.OdometerReadingResponse, error)
+	GetOdometerReading(ctx context.Context, userID, vehicleID, readingID string) (*dto.OdometerReadingResponse, error)
+	ListOdometerReadings(ctx context.Context, userID, vehicleID string) (*dto.ListOdometerReadingsResponse, error)
+	DeleteOdometerReading(ctx context.Context, userID, vehicleID, readingID string) error
+}
+
+type odometerReadingUseCase struct {
+	odometerReadingRepository repository.OdometerReadingRepository
+	vehicleRepository         repository.VehicleRepository
+	odometerTracker           *odometerTracker
+}
+
+func NewOdometerReadingUseCase() OdometerReadingUseCase {
+	odometerReadingRepository := repository.NewOdometerReadingRepository()
+	vehicleRepository := repository.NewVehicleRepository()
+	return &odometerReadingUseCase{
+		odometerReadingRepository: odometerReadingRepository,
+		vehicleRepository:         vehicleRepository,
+		odometerTracker:           newOdometerTracker(odometerReadingRepository, vehicleRepository),
+	}
+}
+
+func (uc *odometerReadingUseCase) CreateOdometerReading(ctx context.Context, userID, vehicleID string, request dto.CreateOdometerReadingRequest) (*dto.OdometerReadingResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateOdometerReadingCreateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate odometer reading create request")
+		return nil, errors.ErrInvalidOdometerReadingCreateRequest
+	}
+
+	readingDate := time.Now().Truncate(24 * time.Hour)
+	if request.ReadingDate != "" {
+		readingDate, err = time.Parse("2006-01-02", request.ReadingDate)
+		if err != nil {
+			logger.Error(err, "Failed to parse reading date")
+			return nil, errors.ErrInvalidDate
+		}
+	}
+
+	odometerReading := entity.OdometerReading{
+		UserID:        userVehicle.UserID,
+		UserVehicleID: userVehicle.ID,
+		Mileage:       request.Mileage,
+		ReadingDate:   readingDate,
+		Source:        entity.ManualOdometerReading,
+		IsReplacement: request.IsReplacement,
+		Notes:         request.Notes,
+	}
+
+	err = uc.odometerTracker.recordReading(ctx, userVehicle, &odometerReading)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapOdometerReadingToResponse(&odometerReading), nil
+}
+
+func (uc *odometerReadingUseCase) GetOdometerReading(ctx context.Context, userID, vehicleID, readingID string) (*dto.OdometerReadingResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	odometerReading, err := uc.getOdometerReadingOfVehicle(ctx, userVehicle, readingID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapOdometerReadingToResponse(odometerReading), nil
+}
+
+func (uc *odometerReadingUseCase) ListOdometerReadings(ctx context.Context, userID, vehicleID string) (*dto.ListOdometerReadingsResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	odometerReadings := []entity.OdometerReading{}
+	err = uc.odometerReadingRepository.ListOdometerReadings(ctx, userVehicle.ID, &odometerReadings)
+	if err != nil {
+		logger.Error(err, "Failed to list odometer readings")
+		return nil, errors.ErrFailedToListOdometerReadings
+	}
+
+	odometerReadingsResponse := []dto.OdometerReadingResponse{}
+	for _, odometerReading := range odometerReadings {
+		odometerReadingsResponse = append(odometerReadingsResponse, *mapOdometerReadingToResponse(&odometerReading))
+	}
+
+	return &dto.ListOdometerReadingsResponse{
+		OdometerReadings: odometerReadingsResponse,
+	}, nil
+}
+
+func (uc *odometerReadingUseCase) DeleteOdometerReading(ctx context.Context, userID, vehicleID, readingID string) error {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return err
+	}
+
+	odometerReading, err := uc.getOdometerReadingOfVehicle(ctx, userVehicle, readingID)
+	if err != nil {
+		return err
+	}
+	if odometerReading.ServiceVisitID != nil {
+		logger.Error(errors.ErrOdometerReadingManagedByServiceVisit, "Odometer reading belongs to a service visit")
+		return errors.ErrOdometerReadingManagedByServiceVisit
+	}
+
+	err = uc.odometerReadingRepository.DeleteOdometerReading(ctx, odometerReading)
+	if err != nil {
+		logger.Error(err, "Failed to delete odometer reading")
+		return errors.ErrFailedToDeleteOdometerReading
+	}
+
+	if err := uc.odometerTracker.syncCurrentMileage(ctx, userVehicle); err != nil {
+		logger.Error(err, "Failed to sync current mileage")
+	}
+
+	return nil
+}
+
+// getOwnedUserVehicle parses the ids and ensures the vehicle belongs to the user
+func (uc *odometerReadingUseCase) getOwnedUserVehicle(ctx context.Context, userID, vehicleID string) (*entity.UserVehicle, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintUserVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse user vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "User vehicle not owned by user")
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+	return &userVehicle, nil
+}
+
+// getOdometerReadingOfVehicle loads a reading and ensures it belongs to the vehicle
+func (uc *odometerReadingUseCase) getOdometerReadingOfVehicle(ctx context.Context, userVehicle *entity.UserVehicle, readingID string) (*entity.OdometerReading, error) {
+	uintReadingID, err := strconv.ParseUint(readingID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse odometer reading id")
+		return nil, errors.ErrInvalidOdometerReadingID
+	}
+
+	odometerReading := entity.OdometerReading{}
+	err = uc.odometerReadingRepository.GetOdometerReading(ctx, uintReadingID, &odometerReading)
+	if err != nil {
+		logger.Error(err, "Failed to get odometer reading")
+		return nil, errors.ErrFailedToGetOdometerReading
+	}
+	if odometerReading.UserVehicleID != userVehicle.ID {
+		logger.Error(errors.ErrOdometerReadingNotOwned, "Odometer reading does not belong to user vehicle")
+		return nil, errors.ErrOdometerReadingNotOwned
+	}
+	return &odometerReading, nil
+}
+
+// odometerTracker keeps odometer readings and UserVehicle.CurrentMileage consistent.
+// It is shared by every use case that learns a new mileage
+type odometerTracker struct {
+	odometerReadingRepository repository.OdometerReadingRepository
+	vehicleRepository         repository.VehicleRepository
+}
+
+func newOdometerTracker(odometerReadingRepository repository.OdometerReadingRepository, vehicleRepository repository.VehicleRepository) *odometerTracker {
+	return &odometerTracker{
+		odometerReadingRepository: odometerReadingRepository,
+		vehicleRepository:         vehicleRepository,
+	}
+}
+
+// validateReading rejects a reading lower than the one before it or higher than the one after it, so the log
+// stays monotonic when readings are backdated. An odometer replacement may be lower than the reading before it,
+// and a reading is not compared with a replacement after it
+func (t *odometerTracker) validateReading(ctx context.Context, odometerReading *entity.OdometerReading) error {
+	if odometerReading.IsReplacement {
+		return nil
+	}
+
+	previous := entity.OdometerReading{}
+	err := t.odometerReadingRepository.GetPreviousOdometerReading(ctx, odometerReading.UserVehicleID, odometerReading.ReadingDate, odometerReading.ID, &previous)
+	if err != nil {
+		logger.Error(err, "Failed to get previous odometer reading")
+		return errors.ErrFailedToGetOdometerReading
+	}
+	if previous.ID != 0 && odometerReading.Mileage < previous.Mileage {
+		logger.Error(errors.ErrOdometerReadingTooLow, "Odometer reading is lower than the previous reading")
+		return errors.ErrOdometerReadingTooLow
+	}
+
+	next := entity.OdometerReading{}
+	err = t.odometerReadingRepository.GetNextOdometerReading(ctx, odometerReading.UserVehicleID, odometerReading.ReadingDate, odometerReading.ID, &next)
+	if err != nil {
+		logger.Error(err, "Failed to get next odometer reading")
+		return errors.ErrFailedToGetOdometerReading
+	}
+	if next.ID != 0 && !next.IsReplacement && odometerReading.Mileage > next.Mileage {
+		logger.Error(errors.ErrOdometerReadingTooHigh, "Odometer reading is higher than the next reading")
+		return errors.ErrOdometerReadingTooHigh
+	}
+	return nil
+}
+
+// recordReading validates and saves a reading, then syncs the vehicle's current mileage
+func (t *odometerTracker) recordReading(ctx context.Context, userVehicle *entity.UserVehicle, odometerReading *entity.OdometerReading) error {
+	if err := t.validateReading(ctx, odometerReading); err != nil {
+		return err
+	}
+
+	var err error
+	if odometerReading.ID == 0 {
+		err = t.odometerReadingRepository.CreateOdometerReading(ctx, odometerReading)
+	} else {
+		err = t.odometerReadingRepository.UpdateOdometerReading(ctx, odometerReading)
+	}
+	if err != nil {
+		logger.Error(err, "Failed to save odometer reading")
+		return errors.ErrFailedToCreateOdometerReading
+	}
+
+	if err := t.syncCurrentMileage(ctx, userVehicle); err != nil {
+		logger.Error(err, "Failed to sync current mileage")
+	}
+	return nil
+}
+
+// syncCurrentMileage sets the vehicle's current mileage to its latest reading
+func (t *odometerTracker) syncCurrentMileage(ctx context.Context, userVehicle *entity.UserVehicle) error {
+	latest := entity.OdometerReading{}
+	err := t.odometerReadingRepository.GetLatestOdometerReading(ctx, userVehicle.ID, &latest)
+	if err != nil {
+		return err
+	}
+	if latest.ID == 0 || int(latest.Mileage) == userVehicle.CurrentMileage {
+		return nil
+	}
+
+	userVehicle.CurrentMileage = int(latest.Mileage)
+	return t.vehicleRepository.UpdateUserVehicle(ctx, &entity.UserVehicle{
+		BaseModel:      entity.BaseModel{ID: userVehicle.ID},
+		UserID:         userVehicle.UserID,
+		CurrentMileage: userVehicle.CurrentMileage,
+	})
+}
+
+func mapOdometerReadingToResponse(odometerReading *entity.OdometerReading) *dto.OdometerReadingResponse {
+	response := &dto.OdometerReadingResponse{
+		ID:            odometerReading.ID,
+		UserVehicleID: odometerReading.UserVehicleID,
+		Mileage:       odometerReading.Mileage,
+		ReadingDate:   odometerReading.ReadingDate.Format("2006-01-02"),
+		Source:        odometerReading.Source.String(),
+		IsReplacement: odometerReading.IsReplacement,
+		Notes:         odometerReading.Notes,
+	}
+	if odometerReading.ServiceVisitID != nil {
+		response.ServiceVisitID = odometerReading.ServiceVisitID.String()
+	}
+	return response
+}
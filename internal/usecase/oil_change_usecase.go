@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"math"
 	"strconv"
 
 	"github.com/amirdashtii/AutoBan/internal/domain/entity"
@@ -20,13 +21,15 @@ type OilChangeUseCase interface {
 
 type oilChangeUseCase struct {
 	oilChangeRepository repository.OilChangeRepository
-	vehicleRepository   repository.VehicleRepository
+	vehicleAccess       *vehicleAccess
+	mileageEstimator    *mileageEstimator
 }
 
 func NewOilChangeUseCase() OilChangeUseCase {
 	oilChangeRepository := repository.NewOilChangeRepository()
-	vehicleRepository := repository.NewVehicleRepository()
-	return &oilChangeUseCase{oilChangeRepository: oilChangeRepository, vehicleRepository: vehicleRepository}
+	vehicleAccess := newVehicleAccess(repository.NewVehicleRepository(), repository.NewVehicleMembershipRepository())
+	mileageEstimator := newMileageEstimator(repository.NewOdometerReadingRepository(), repository.NewServiceVisitRepository())
+	return &oilChangeUseCase{oilChangeRepository: oilChangeRepository, vehicleAccess: vehicleAccess, mileageEstimator: mileageEstimator}
 }
 
 func (uc *oilChangeUseCase) GetOilChange(ctx context.Context, userID string, vehicleID string, oilChangeID string) (*dto.OilChangeResponse, error) {
@@ -48,11 +51,9 @@ func (uc *oilChangeUseCase) GetOilChange(ctx context.Context, userID string, veh
 		return nil, errors.ErrInvalidOilChangeID
 	}
 
-	userVehicle := entity.UserVehicle{}
-	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintVehicleID, &userVehicle)
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, uintVehicleID, entity.ViewerVehicleRole)
 	if err != nil {
-		logger.Error(err, "User vehicle not owned by user")
-		return nil, errors.ErrUserVehicleNotOwned
+		return nil, err
 	}
 
 	oilChange := entity.OilChange{}
@@ -63,6 +64,10 @@ func (uc *oilChangeUseCase) GetOilChange(ctx context.Context, userID string, veh
 		logger.Error(err, "Failed to get oil change")
 		return nil, errors.ErrFailedToGetOilChange
 	}
+	if oilChange.UserVehicleID != uintVehicleID {
+		logger.Error(errors.ErrOilChangeNotOwned, "Oil change not owned by vehicle")
+		return nil, errors.ErrOilChangeNotOwned
+	}
 
 	return uc.mapOilChangeToResponse(&oilChange), nil
 }
@@ -79,11 +84,9 @@ func (uc *oilChangeUseCase) ListOilChanges(ctx context.Context, userID string, v
 		return nil, errors.ErrInvalidUserVehicleID
 	}
 
-	userVehicle := entity.UserVehicle{}
-	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, uintUserVehicleID, entity.ViewerVehicleRole)
 	if err != nil {
-		logger.Error(err, "User vehicle not owned by user")
-		return nil, errors.ErrUserVehicleNotOwned
+		return nil, err
 	}
 
 	oilChanges := []entity.OilChange{}
@@ -114,11 +117,9 @@ func (uc *oilChangeUseCase) GetLastOilChange(ctx context.Context, userID string,
 		logger.Error(err, "Failed to parse user vehicle id")
 		return nil, errors.ErrInvalidUserVehicleID
 	}
-	userVehicle := entity.UserVehicle{}
-	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, uintUserVehicleID, entity.ViewerVehicleRole)
 	if err != nil {
-		logger.Error(err, "User vehicle not owned by user")
-		return nil, errors.ErrUserVehicleNotOwned
+		return nil, err
 	}
 	oilChange := entity.OilChange{}
 	err = uc.oilChangeRepository.GetLastOilChange(ctx, uintUserVehicleID, &oilChange)
@@ -127,7 +128,19 @@ func (uc *oilChangeUseCase) GetLastOilChange(ctx context.Context, userID string,
 		return nil, errors.ErrFailedToGetOilChange
 	}
 
-	return uc.mapOilChangeToResponse(&oilChange), nil
+	response := uc.mapOilChangeToResponse(&oilChange)
+
+	// Project when the next change mileage will be reached; the response is still useful without it
+	estimate, err := uc.mileageEstimator.estimate(ctx, uintUserVehicleID)
+	if err != nil {
+		logger.Error(err, "Failed to estimate daily mileage")
+	}
+	if estimate != nil {
+		response.EstimatedDailyMileage = math.Round(estimate.DailyMileage*10) / 10
+	}
+	response.ProjectedNextChangeDate, response.DueAround = formatDueAround(estimate, oilChange.NextChangeMileage, oilChange.NextChangeDate)
+
+	return response, nil
 }
 
 func (uc *oilChangeUseCase) mapOilChangeToResponse(oilChange *entity.OilChange) *dto.OilChangeResponse {
@@ -145,5 +158,6 @@ func (uc *oilChangeUseCase) mapOilChangeToResponse(oilChange *entity.OilChange)
 		NextChangeDate:    oilChange.NextChangeDate.Format("2006-01-02"),
 		ServiceCenter:     oilChange.ServiceCenter,
 		Notes:             oilChange.Notes,
+		Cost:              mapCostToResponse(oilChange.LabourCost, oilChange.PartsCost),
 	}
 }
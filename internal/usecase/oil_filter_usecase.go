@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"math"
 	"strconv"
 
 	"github.com/amirdashtii/AutoBan/internal/domain/entity"
@@ -20,13 +21,15 @@ type OilFilterUseCase interface {
 
 type oilFilterUseCase struct {
 	oilFilterRepository repository.OilFilterRepository
-	vehicleRepository   repository.VehicleRepository
+	vehicleAccess       *vehicleAccess
+	mileageEstimator    *mileageEstimator
 }
 
 func NewOilFilterUseCase() OilFilterUseCase {
 	oilFilterRepository := repository.NewOilFilterRepository()
-	vehicleRepository := repository.NewVehicleRepository()
-	return &oilFilterUseCase{oilFilterRepository: oilFilterRepository, vehicleRepository: vehicleRepository}
+	vehicleAccess := newVehicleAccess(repository.NewVehicleRepository(), repository.NewVehicleMembershipRepository())
+	mileageEstimator := newMileageEstimator(repository.NewOdometerReadingRepository(), repository.NewServiceVisitRepository())
+	return &oilFilterUseCase{oilFilterRepository: oilFilterRepository, vehicleAccess: vehicleAccess, mileageEstimator: mileageEstimator}
 }
 
 func (uc *oilFilterUseCase) ListOilFilters(ctx context.Context, userID, userVehicleID string) (*dto.ListOilFiltersResponse, error) {
@@ -41,11 +44,9 @@ func (uc *oilFilterUseCase) ListOilFilters(ctx context.Context, userID, userVehi
 		return nil, errors.ErrInvalidUserVehicleID
 	}
 
-	userVehicle := entity.UserVehicle{}
-	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, uintUserVehicleID, entity.ViewerVehicleRole)
 	if err != nil {
-		logger.Error(err, "User vehicle not owned by user")
-		return nil, errors.ErrUserVehicleNotOwned
+		return nil, err
 	}
 
 	oilFilters := []entity.OilFilter{}
@@ -77,11 +78,9 @@ func (uc *oilFilterUseCase) GetLastOilFilter(ctx context.Context, userID, userVe
 		return nil, errors.ErrInvalidUserVehicleID
 	}
 
-	userVehicle := entity.UserVehicle{}
-	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, uintUserVehicleID, entity.ViewerVehicleRole)
 	if err != nil {
-		logger.Error(err, "User vehicle not owned by user")
-		return nil, errors.ErrUserVehicleNotOwned
+		return nil, err
 	}
 
 	oilFilter := entity.OilFilter{}
@@ -91,7 +90,19 @@ func (uc *oilFilterUseCase) GetLastOilFilter(ctx context.Context, userID, userVe
 		return nil, errors.ErrFailedToGetOilFilter
 	}
 
-	return uc.mapOilFilterToResponse(&oilFilter), nil
+	response := uc.mapOilFilterToResponse(&oilFilter)
+
+	// Project when the next change mileage will be reached; the response is still useful without it
+	estimate, err := uc.mileageEstimator.estimate(ctx, uintUserVehicleID)
+	if err != nil {
+		logger.Error(err, "Failed to estimate daily mileage")
+	}
+	if estimate != nil {
+		response.EstimatedDailyMileage = math.Round(estimate.DailyMileage*10) / 10
+	}
+	response.ProjectedNextChangeDate, response.DueAround = formatDueAround(estimate, oilFilter.NextChangeMileage, oilFilter.NextChangeDate)
+
+	return response, nil
 }
 
 func (uc *oilFilterUseCase) GetOilFilter(ctx context.Context, userID, userVehicleID, oilFilterID string) (*dto.OilFilterResponse, error) {
@@ -113,9 +124,12 @@ func (uc *oilFilterUseCase) GetOilFilter(ctx context.Context, userID, userVehicl
 		return nil, errors.ErrFailedToGetOilFilter
 	}
 
-	if oilFilter.UserID != uuidUserID {
-		logger.Error(err, "Oil filter not owned by user")
-		return nil, errors.ErrOilFilterNotOwned
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, oilFilter.UserVehicleID, entity.ViewerVehicleRole)
+	if err != nil {
+		if errors.Is(err, errors.ErrUserVehicleNotOwned) {
+			return nil, errors.ErrOilFilterNotOwned
+		}
+		return nil, err
 	}
 
 	return uc.mapOilFilterToResponse(&oilFilter), nil
@@ -135,5 +149,6 @@ func (uc *oilFilterUseCase) mapOilFilterToResponse(oilFilter *entity.OilFilter)
 		NextChangeDate:    oilFilter.NextChangeDate.Format("2006-01-02"),
 		ServiceCenter:     oilFilter.ServiceCenter,
 		Notes:             oilFilter.Notes,
+		Cost:              mapCostToResponse(oilFilter.LabourCost, oilFilter.PartsCost),
 	}
 }
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/config"
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/http"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// reminderNotificationTTL is how long a sent reminder is remembered; servicing the item resets it anyway
+const reminderNotificationTTL = 180 * 24 * time.Hour
+
+// ReminderNotifier periodically texts owners about maintenance that is due soon or overdue
+type ReminderNotifier interface {
+	Start(ctx context.Context)
+}
+
+type reminderNotifier struct {
+	reminderUseCase                *reminderUseCase
+	reminderRepository             repository.ReminderRepository
+	reminderNotificationRepository repository.ReminderNotificationRepository
+	smsService                     http.SMSService
+	interval                       time.Duration
+	quietHoursStart                int
+	quietHoursEnd                  int
+	location                       *time.Location
+	instanceID                     string
+}
+
+// NewReminderNotifier returns nil when the config can not be loaded or no reminder SMS template is set
+func NewReminderNotifier() ReminderNotifier {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		logger.Error(err, "Failed to get config")
+		return nil
+	}
+	if cfg.SMS.ReminderTemplateID == "" {
+		logger.Warn("Reminder notifier not started, sms.reminder_template_id is not set")
+		return nil
+	}
+
+	interval := time.Duration(cfg.Reminder.ScanIntervalMinutes) * time.Minute
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	location, err := time.LoadLocation(cfg.Reminder.Timezone)
+	if err != nil {
+		logger.Error(err, "Failed to load reminder timezone, falling back to Iran Standard Time")
+		location = time.FixedZone("IRST", 3*60*60+30*60)
+	}
+
+	return &reminderNotifier{
+		reminderUseCase:                newReminderUseCase(cfg),
+		reminderRepository:             repository.NewReminderRepository(),
+		reminderNotificationRepository: repository.NewReminderNotificationRepository(),
+		smsService:                     http.NewSMSService(cfg.SMS.BaseURL, cfg.SMS.XAPIKey, cfg.SMS.ReminderTemplateID, cfg.SMS.TransferTemplateID),
+		interval:                       interval,
+		quietHoursStart:                cfg.Reminder.QuietHoursStart,
+		quietHoursEnd:                  cfg.Reminder.QuietHoursEnd,
+		location:                       location,
+		instanceID:                     uuid.New().String(),
+	}
+}
+
+// Start scans immediately and then once every interval until ctx is cancelled
+func (n *reminderNotifier) Start(ctx context.Context) {
+	logger.Info(fmt.Sprintf("Reminder notifier started, scanning every %s", n.interval))
+
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+	for {
+		n.scan(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (n *reminderNotifier) scan(ctx context.Context) {
+	now := time.Now().In(n.location)
+	if n.inQuietHours(now) {
+		return
+	}
+
+	// only one replica scans at a time, the others skip this round
+	acquired, err := n.reminderNotificationRepository.AcquireNotifierLock(ctx, n.instanceID, n.interval)
+	if err != nil {
+		logger.Error(err, "Failed to acquire reminder notifier lock")
+		return
+	}
+	if !acquired {
+		return
+	}
+	defer func() {
+		if err := n.reminderNotificationRepository.ReleaseNotifierLock(context.Background(), n.instanceID); err != nil {
+			logger.Error(err, "Failed to release reminder notifier lock")
+		}
+	}()
+
+	thresholds := n.reminderUseCase.thresholds
+	recipients := []entity.ReminderRecipient{}
+	documentExpiresBefore := now.AddDate(0, 0, n.reminderUseCase.documentThresholds.DueSoonDays)
+	err = n.reminderRepository.ListDueReminderRecipients(ctx, thresholds.DueSoonMileage, now.AddDate(0, 0, thresholds.DueSoonDays), documentExpiresBefore, &recipients)
+	if err != nil {
+		logger.Error(err, "Failed to list reminder recipients")
+		return
+	}
+
+	sent := 0
+	for _, recipient := range recipients {
+		if ctx.Err() != nil {
+			return
+		}
+		userVehicle := recipient.UserVehicle
+		reminders, err := n.reminderUseCase.buildVehicleReminders(ctx, &userVehicle, now)
+		if err != nil {
+			logger.Error(err, "Failed to build vehicle reminders")
+			continue
+		}
+		for _, reminder := range reminders {
+			if reminder.Status == entity.ReminderUpcoming {
+				continue
+			}
+			if n.notify(ctx, recipient.PhoneNumber, &userVehicle, &reminder) {
+				sent++
+			}
+		}
+	}
+	logger.Info(fmt.Sprintf("Reminder scan finished: %d vehicles checked, %d SMS sent", len(recipients), sent))
+}
+
+// notify texts the owner unless they were already notified about this record. A record is texted once, when it
+// becomes due soon or overdue, whichever comes first, and again only after it is serviced and falls due anew
+func (n *reminderNotifier) notify(ctx context.Context, phoneNumber string, userVehicle *entity.UserVehicle, reminder *entity.MaintenanceReminder) bool {
+	dueTarget := reminderDueTarget(reminder)
+	notified, err := n.reminderNotificationRepository.IsReminderNotified(ctx, reminder.Source, reminder.SourceID, dueTarget)
+	if err != nil {
+		logger.Error(err, "Failed to check reminder notification")
+		return false
+	}
+	if notified {
+		return false
+	}
+
+	err = n.smsService.SendMaintenanceReminder(ctx, phoneNumber, userVehicle.Name, reminderItemLabel(reminder), reminderStatusLabel(reminder.Status))
+	if err != nil {
+		logger.Error(err, "Failed to send maintenance reminder via SMS")
+		return false
+	}
+
+	err = n.reminderNotificationRepository.MarkReminderNotified(ctx, reminder.Source, reminder.SourceID, dueTarget, reminderNotificationTTL)
+	if err != nil {
+		logger.Error(err, "Failed to mark reminder as notified")
+	}
+	return true
+}
+
+// reminderDueTarget identifies the next change mileage and date a record falls due at, which only change when
+// the item is serviced
+func reminderDueTarget(reminder *entity.MaintenanceReminder) string {
+	return fmt.Sprintf("%d:%s", reminder.NextChangeMileage, reminder.NextChangeDate.Format("2006-01-02"))
+}
+
+// inQuietHours reports whether now falls in the quiet window, which may span midnight
+func (n *reminderNotifier) inQuietHours(now time.Time) bool {
+	hour := now.Hour()
+	if n.quietHoursStart == n.quietHoursEnd {
+		return false
+	}
+	if n.quietHoursStart < n.quietHoursEnd {
+		return hour >= n.quietHoursStart && hour < n.quietHoursEnd
+	}
+	return hour >= n.quietHoursStart || hour < n.quietHoursEnd
+}
+
+func reminderItemLabel(reminder *entity.MaintenanceReminder) string {
+	if reminder.Source == vehicleDocumentReminderSource {
+		return entity.ParseVehicleDocumentType(reminder.ItemType).Label()
+	}
+	switch reminder.ItemType {
+	case "oil_change":
+		return "روغن موتور"
+	case "oil_filter":
+		return "فیلتر روغن"
+	default:
+		return reminder.Name
+	}
+}
+
+func reminderStatusLabel(status entity.ReminderStatus) string {
+	if status == entity.ReminderOverdue {
+		return "موعد گذشته"
+	}
+	return "نزدیک به موعد"
+}
@@ -0,0 +1,331 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/config"
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// vehicleDocumentReminderSource is the reminder source of insurance and inspection documents, which fall due on their expiry date
+const vehicleDocumentReminderSource = "vehicle_document"
+
+type ReminderUseCase interface {
+	GetVehicleReminders(ctx context.Context, userID, vehicleID string) (*dto.VehicleRemindersResponse, error)
+	GetVehicleForecast(ctx context.Context, userID, vehicleID string) (*dto.VehicleForecastResponse, error)
+}
+
+type reminderUseCase struct {
+	oilChangeRepository           repository.OilChangeRepository
+	oilFilterRepository           repository.OilFilterRepository
+	serviceItemRepository         repository.ServiceItemRepository
+	vehicleRepository             repository.VehicleRepository
+	maintenanceScheduleRepository repository.MaintenanceScheduleRepository
+	vehicleDocumentRepository     repository.VehicleDocumentRepository
+	mileageEstimator              *mileageEstimator
+	thresholds                    entity.ReminderThresholds
+	documentThresholds            entity.ReminderThresholds
+}
+
+func NewReminderUseCase() ReminderUseCase {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		logger.Error(err, "Failed to get config")
+		return nil
+	}
+	return newReminderUseCase(cfg)
+}
+
+func newReminderUseCase(cfg *config.Config) *reminderUseCase {
+	oilChangeRepository := repository.NewOilChangeRepository()
+	oilFilterRepository := repository.NewOilFilterRepository()
+	serviceItemRepository := repository.NewServiceItemRepository()
+	vehicleRepository := repository.NewVehicleRepository()
+	mileageEstimator := newMileageEstimator(repository.NewOdometerReadingRepository(), repository.NewServiceVisitRepository())
+	return &reminderUseCase{
+		oilChangeRepository:           oilChangeRepository,
+		oilFilterRepository:           oilFilterRepository,
+		serviceItemRepository:         serviceItemRepository,
+		vehicleRepository:             vehicleRepository,
+		maintenanceScheduleRepository: repository.NewMaintenanceScheduleRepository(),
+		vehicleDocumentRepository:     repository.NewVehicleDocumentRepository(),
+		mileageEstimator:              mileageEstimator,
+		thresholds: entity.ReminderThresholds{
+			DueSoonMileage: cfg.Reminder.DueSoonMileage,
+			DueSoonDays:    cfg.Reminder.DueSoonDays,
+		},
+		documentThresholds: entity.ReminderThresholds{
+			DueSoonDays: cfg.Reminder.DocumentDueSoonDays,
+		},
+	}
+}
+
+func (uc *reminderUseCase) GetVehicleReminders(ctx context.Context, userID, vehicleID string) (*dto.VehicleRemindersResponse, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintUserVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse user vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "User vehicle not owned by user")
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+
+	reminders, err := uc.buildVehicleReminders(ctx, &userVehicle, time.Now())
+	if err != nil {
+		logger.Error(err, "Failed to build vehicle reminders")
+		return nil, errors.ErrFailedToGetReminders
+	}
+
+	remindersResponse := []dto.MaintenanceReminderResponse{}
+	for _, reminder := range reminders {
+		remindersResponse = append(remindersResponse, *mapReminderToResponse(&reminder))
+	}
+
+	return &dto.VehicleRemindersResponse{
+		UserVehicleID:  userVehicle.ID,
+		CurrentMileage: userVehicle.CurrentMileage,
+		Reminders:      remindersResponse,
+	}, nil
+}
+
+func (uc *reminderUseCase) GetVehicleForecast(ctx context.Context, userID, vehicleID string) (*dto.VehicleForecastResponse, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintUserVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse user vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "User vehicle not owned by user")
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+
+	reminders, err := uc.buildVehicleReminders(ctx, &userVehicle, time.Now())
+	if err != nil {
+		logger.Error(err, "Failed to build vehicle reminders")
+		return nil, errors.ErrFailedToGetForecast
+	}
+
+	estimate, err := uc.mileageEstimator.estimate(ctx, userVehicle.ID)
+	if err != nil {
+		logger.Error(err, "Failed to estimate daily mileage")
+		return nil, errors.ErrFailedToGetForecast
+	}
+
+	response := &dto.VehicleForecastResponse{
+		UserVehicleID:  userVehicle.ID,
+		CurrentMileage: userVehicle.CurrentMileage,
+		Items:          []dto.MaintenanceForecastResponse{},
+	}
+	if estimate != nil {
+		response.EstimatedDailyMileage = math.Round(estimate.DailyMileage*10) / 10
+	}
+
+	for _, reminder := range reminders {
+		item := dto.MaintenanceForecastResponse{
+			ItemType:          reminder.ItemType,
+			Name:              reminder.Name,
+			Source:            reminder.Source,
+			SourceID:          reminder.SourceID,
+			NextChangeMileage: reminder.NextChangeMileage,
+		}
+		if !reminder.NextChangeDate.IsZero() {
+			item.NextChangeDate = reminder.NextChangeDate.Format("2006-01-02")
+		}
+		item.ProjectedNextChangeDate, item.DueAround = formatDueAround(estimate, reminder.NextChangeMileage, reminder.NextChangeDate)
+		response.Items = append(response.Items, item)
+	}
+
+	// dates are formatted as yyyy-mm-dd, so they sort as strings; unknown dates go last
+	sort.SliceStable(response.Items, func(i, j int) bool {
+		a, b := response.Items[i].DueAround, response.Items[j].DueAround
+		if a == "" || b == "" {
+			return b == "" && a != ""
+		}
+		return a < b
+	})
+
+	return response, nil
+}
+
+// buildVehicleReminders collects the latest record of every tracked item and evaluates it
+// against the vehicle's current mileage, most urgent first. The vehicle's maintenance plan
+// fills in next changes the user left out and covers plan items never recorded, counting
+// from the plan's start. The latest document of each type is due on its expiry date
+func (uc *reminderUseCase) buildVehicleReminders(ctx context.Context, userVehicle *entity.UserVehicle, now time.Time) ([]entity.MaintenanceReminder, error) {
+	reminders := []entity.MaintenanceReminder{}
+
+	oilChanges := []entity.OilChange{}
+	if err := uc.oilChangeRepository.ListOilChanges(ctx, userVehicle.ID, &oilChanges); err != nil {
+		return nil, err
+	}
+	if len(oilChanges) > 0 {
+		oilChange := oilChanges[0]
+		reminders = append(reminders, entity.MaintenanceReminder{
+			ItemType:          "oil_change",
+			Name:              oilChange.OilName,
+			Source:            "oil_change",
+			SourceID:          oilChange.ID,
+			LastChangeMileage: oilChange.ChangeMileage,
+			LastChangeDate:    oilChange.ChangeDate,
+			NextChangeMileage: oilChange.NextChangeMileage,
+			NextChangeDate:    oilChange.NextChangeDate,
+		})
+	}
+
+	oilFilters := []entity.OilFilter{}
+	if err := uc.oilFilterRepository.ListOilFilters(ctx, userVehicle.ID, &oilFilters); err != nil {
+		return nil, err
+	}
+	if len(oilFilters) > 0 {
+		oilFilter := oilFilters[0]
+		reminders = append(reminders, entity.MaintenanceReminder{
+			ItemType:          "oil_filter",
+			Name:              oilFilter.FilterName,
+			Source:            "oil_filter",
+			SourceID:          oilFilter.ID,
+			LastChangeMileage: oilFilter.ChangeMileage,
+			LastChangeDate:    oilFilter.ChangeDate,
+			NextChangeMileage: oilFilter.NextChangeMileage,
+			NextChangeDate:    oilFilter.NextChangeDate,
+		})
+	}
+
+	serviceItems := []entity.ServiceItem{}
+	if err := uc.serviceItemRepository.ListLatestServiceItems(ctx, userVehicle.ID, &serviceItems); err != nil {
+		return nil, err
+	}
+	for _, serviceItem := range serviceItems {
+		reminders = append(reminders, entity.MaintenanceReminder{
+			ItemType:          serviceItem.ItemType.String(),
+			Name:              serviceItem.Name,
+			Source:            "service_item",
+			SourceID:          serviceItem.ID,
+			LastChangeMileage: serviceItem.ChangeMileage,
+			LastChangeDate:    serviceItem.ChangeDate,
+			NextChangeMileage: serviceItem.NextChangeMileage,
+			NextChangeDate:    serviceItem.NextChangeDate,
+		})
+	}
+
+	planItems := []entity.MaintenancePlanItem{}
+	if err := uc.maintenanceScheduleRepository.ListMaintenancePlanItems(ctx, userVehicle.ID, &planItems); err != nil {
+		return nil, err
+	}
+	for _, planItem := range planItems {
+		recorded := false
+		for i := range reminders {
+			reminder := &reminders[i]
+			if reminder.ItemType != planItem.ItemType {
+				continue
+			}
+			recorded = true
+			planMileage, planDate := planItem.NextDue(reminder.LastChangeMileage, reminder.LastChangeDate)
+			if reminder.NextChangeMileage == 0 {
+				reminder.NextChangeMileage = planMileage
+			}
+			if reminder.NextChangeDate.IsZero() {
+				reminder.NextChangeDate = planDate
+			}
+		}
+		if recorded {
+			continue
+		}
+
+		nextChangeMileage, nextChangeDate := planItem.NextDue(planItem.StartMileage, planItem.StartDate)
+		reminders = append(reminders, entity.MaintenanceReminder{
+			ItemType:          planItem.ItemType,
+			Name:              planItem.NameFa,
+			Source:            "maintenance_plan",
+			SourceID:          planItem.ID,
+			LastChangeMileage: planItem.StartMileage,
+			LastChangeDate:    planItem.StartDate,
+			NextChangeMileage: nextChangeMileage,
+			NextChangeDate:    nextChangeDate,
+		})
+	}
+
+	documents := []entity.VehicleDocument{}
+	if err := uc.vehicleDocumentRepository.ListLatestVehicleDocuments(ctx, userVehicle.ID, &documents); err != nil {
+		return nil, err
+	}
+	for _, document := range documents {
+		reminders = append(reminders, vehicleDocumentReminder(&document))
+	}
+
+	evaluated := []entity.MaintenanceReminder{}
+	for _, reminder := range reminders {
+		if !reminder.HasSchedule() {
+			continue
+		}
+		thresholds := uc.thresholds
+		if reminder.Source == vehicleDocumentReminderSource {
+			thresholds = uc.documentThresholds
+		}
+		reminder.Evaluate(userVehicle.CurrentMileage, now, thresholds)
+		evaluated = append(evaluated, reminder)
+	}
+
+	sort.SliceStable(evaluated, func(i, j int) bool {
+		return evaluated[i].Status > evaluated[j].Status
+	})
+
+	return evaluated, nil
+}
+
+// vehicleDocumentReminder tracks a document like a maintenance item that was last done on its start date
+// and is next due on its expiry date
+func vehicleDocumentReminder(document *entity.VehicleDocument) entity.MaintenanceReminder {
+	return entity.MaintenanceReminder{
+		ItemType:       document.DocumentType.String(),
+		Name:           document.Issuer,
+		Source:         vehicleDocumentReminderSource,
+		SourceID:       document.ID,
+		LastChangeDate: document.StartDate,
+		NextChangeDate: document.ExpiryDate,
+	}
+}
+
+func mapReminderToResponse(reminder *entity.MaintenanceReminder) *dto.MaintenanceReminderResponse {
+	response := &dto.MaintenanceReminderResponse{
+		ItemType:          reminder.ItemType,
+		Name:              reminder.Name,
+		Source:            reminder.Source,
+		SourceID:          reminder.SourceID,
+		LastChangeMileage: reminder.LastChangeMileage,
+		LastChangeDate:    reminder.LastChangeDate.Format("2006-01-02"),
+		NextChangeMileage: reminder.NextChangeMileage,
+		RemainingMileage:  reminder.RemainingMileage,
+		RemainingDays:     reminder.RemainingDays,
+		Status:            reminder.Status.String(),
+	}
+	if !reminder.NextChangeDate.IsZero() {
+		response.NextChangeDate = reminder.NextChangeDate.Format("2006-01-02")
+	}
+	return response
+}
@@ -0,0 +1,406 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/pdf"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/jalali"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// serviceBookLabels holds the text of a service book in one language
+type serviceBookLabels struct {
+	Title           string
+	Vehicle         string
+	Name            string
+	Type            string
+	Brand           string
+	Model           string
+	Generation      string
+	ProductionYear  string
+	Color           string
+	LicensePlate    string
+	VIN             string
+	CurrentMileage  string
+	PurchaseDate    string
+	ServiceHistory  string
+	NoServiceVisits string
+	Date            string
+	Mileage         string
+	ServiceCenter   string
+	OilChange       string
+	OilFilter       string
+	OtherItems      string
+	LabourCost      string
+	PartsCost       string
+	Cost            string
+	Notes           string
+	TotalSpent      string
+	GeneratedOn     string
+	Kilometres      string
+	Litres          string
+}
+
+var serviceBookLabelsFa = serviceBookLabels{
+	Title:           "دفترچه سرویس",
+	Vehicle:         "مشخصات خودرو",
+	Name:            "نام",
+	Type:            "نوع",
+	Brand:           "برند",
+	Model:           "مدل",
+	Generation:      "نسل",
+	ProductionYear:  "سال تولید",
+	Color:           "رنگ",
+	LicensePlate:    "پلاک",
+	VIN:             "شماره شاسی",
+	CurrentMileage:  "کارکرد فعلی",
+	PurchaseDate:    "تاریخ خرید",
+	ServiceHistory:  "سوابق سرویس",
+	NoServiceVisits: "هنوز سرویسی برای این خودرو ثبت نشده است.",
+	Date:            "تاریخ",
+	Mileage:         "کارکرد",
+	ServiceCenter:   "مرکز سرویس",
+	OilChange:       "تعویض روغن",
+	OilFilter:       "فیلتر روغن",
+	OtherItems:      "سایر موارد",
+	LabourCost:      "اجرت (ریال)",
+	PartsCost:       "قطعات (ریال)",
+	Cost:            "هزینه",
+	Notes:           "توضیحات",
+	TotalSpent:      "مجموع هزینه‌ها",
+	GeneratedOn:     "تاریخ تهیه",
+	Kilometres:      "کیلومتر",
+	Litres:          "لیتر",
+}
+
+var serviceBookLabelsEn = serviceBookLabels{
+	Title:           "Service Book",
+	Vehicle:         "Vehicle",
+	Name:            "Name",
+	Type:            "Type",
+	Brand:           "Brand",
+	Model:           "Model",
+	Generation:      "Generation",
+	ProductionYear:  "Production year",
+	Color:           "Color",
+	LicensePlate:    "License plate",
+	VIN:             "VIN",
+	CurrentMileage:  "Current mileage",
+	PurchaseDate:    "Purchase date",
+	ServiceHistory:  "Service history",
+	NoServiceVisits: "No service visits have been recorded for this vehicle yet.",
+	Date:            "Date",
+	Mileage:         "Mileage",
+	ServiceCenter:   "Service center",
+	OilChange:       "Oil change",
+	OilFilter:       "Oil filter",
+	OtherItems:      "Other items",
+	LabourCost:      "Labour (Rial)",
+	PartsCost:       "Parts (Rial)",
+	Cost:            "Cost",
+	Notes:           "Notes",
+	TotalSpent:      "Total spent",
+	GeneratedOn:     "Generated on",
+	Kilometres:      "km",
+	Litres:          "L",
+}
+
+// serviceBook is everything a service book export shows, with the language and calendar already chosen
+type serviceBook struct {
+	labels  serviceBookLabels
+	persian bool
+	jalali  bool
+
+	vehicle    entity.UserVehicle
+	typeName   string
+	brandName  string
+	modelName  string
+	genName    string
+	visits     []entity.ServiceVisit
+	totalSpent entity.Rial
+}
+
+func (uc *vehicleUseCase) ExportServiceBook(ctx context.Context, userID, vehicleID string, request dto.ExportServiceBookRequest) (*dto.ExportFile, error) {
+	err := validation.ValidateExportServiceBookRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate service book export request")
+		return nil, errors.ErrInvalidServiceBookExportRequest
+	}
+
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle id")
+		return nil, errors.ErrInvalidVehicleID
+	}
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "User vehicle not owned by user")
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+
+	book := serviceBook{
+		labels:  serviceBookLabelsFa,
+		persian: request.Language != "en",
+		jalali:  request.Calendar == "jalali",
+		vehicle: userVehicle,
+	}
+	if !book.persian {
+		book.labels = serviceBookLabelsEn
+	}
+
+	vehicleType, brand, model, generation, err := uc.resolvePathForGeneration(ctx, userVehicle.GenerationID)
+	if err != nil {
+		logger.Error(err, "Failed to resolve vehicle path for generation")
+		return nil, errors.ErrFailedToExportServiceBook
+	}
+	book.typeName = book.localized(vehicleType.NameFa, vehicleType.NameEn)
+	book.brandName = book.localized(brand.NameFa, brand.NameEn)
+	book.modelName = book.localized(model.NameFa, model.NameEn)
+	book.genName = book.localized(generation.NameFa, generation.NameEn)
+
+	err = uc.serviceVisitRepository.ListServiceVisits(ctx, strconv.FormatUint(userVehicle.ID, 10), &book.visits)
+	if err != nil {
+		logger.Error(err, "Failed to list service visits")
+		return nil, errors.ErrFailedToExportServiceBook
+	}
+	sort.SliceStable(book.visits, func(i, j int) bool {
+		if !book.visits[i].ServiceDate.Equal(book.visits[j].ServiceDate) {
+			return book.visits[i].ServiceDate.Before(book.visits[j].ServiceDate)
+		}
+		return book.visits[i].ServiceMileage < book.visits[j].ServiceMileage
+	})
+	for i := range book.visits {
+		book.totalSpent += book.visits[i].TotalLabourCost() + book.visits[i].TotalPartsCost()
+	}
+
+	fileName := fmt.Sprintf("service-book-%d", userVehicle.ID)
+	if request.Format == "csv" {
+		content, err := book.csv()
+		if err != nil {
+			logger.Error(err, "Failed to write service book csv")
+			return nil, errors.ErrFailedToExportServiceBook
+		}
+		return &dto.ExportFile{FileName: fileName + ".csv", ContentType: "text/csv; charset=utf-8", Content: content}, nil
+	}
+
+	content, err := book.pdf(uc.pdfFontPath)
+	if err != nil {
+		logger.Error(err, "Failed to write service book pdf")
+		return nil, errors.ErrFailedToExportServiceBook
+	}
+	return &dto.ExportFile{FileName: fileName + ".pdf", ContentType: "application/pdf", Content: content}, nil
+}
+
+// pdf lays the service book out as a printable document, right to left when it is in Persian
+func (b *serviceBook) pdf(fontPath string) ([]byte, error) {
+	document := pdf.NewDocument(fontPath, b.persian)
+	labels := b.labels
+
+	document.Title(labels.Title + " - " + b.vehicle.Name)
+	document.Field(labels.GeneratedOn, b.date(time.Now()))
+
+	document.Heading(labels.Vehicle)
+	document.Field(labels.Name, b.vehicle.Name)
+	document.Field(labels.Type, b.typeName)
+	document.Field(labels.Brand, b.brandName)
+	document.Field(labels.Model, b.modelName)
+	document.Field(labels.Generation, b.genName)
+	if b.vehicle.ProductionYear != 0 {
+		document.Field(labels.ProductionYear, strconv.Itoa(b.vehicle.ProductionYear))
+	}
+	document.Field(labels.Color, b.vehicle.Color)
+	document.Field(labels.LicensePlate, entity.NormalizeLicensePlate(b.vehicle.LicensePlate))
+	document.Field(labels.VIN, b.vehicle.VIN)
+	document.Field(labels.CurrentMileage, b.mileage(uint(max(b.vehicle.CurrentMileage, 0))))
+	document.Field(labels.PurchaseDate, b.date(b.vehicle.PurchaseDate))
+
+	document.Heading(labels.ServiceHistory)
+	if len(b.visits) == 0 {
+		document.Paragraph(labels.NoServiceVisits)
+		return b.render(document)
+	}
+
+	columns := []pdf.Column{
+		{Header: labels.Date, Width: 2.2},
+		{Header: labels.Mileage, Width: 2.2},
+		{Header: labels.ServiceCenter, Width: 2.8},
+		{Header: labels.OilChange, Width: 3.4},
+		{Header: labels.OilFilter, Width: 3},
+		{Header: labels.OtherItems, Width: 3.4},
+		{Header: labels.Cost, Width: 2.6},
+	}
+	rows := make([][]string, 0, len(b.visits))
+	for i := range b.visits {
+		visit := &b.visits[i]
+		rows = append(rows, []string{
+			b.date(visit.ServiceDate),
+			b.mileage(visit.ServiceMileage),
+			visit.ServiceCenter,
+			b.oilChange(visit),
+			b.oilFilter(visit),
+			strings.Join(b.serviceItems(visit), "\n"),
+			b.cost(visit.TotalLabourCost() + visit.TotalPartsCost()),
+		})
+	}
+	document.Table(columns, rows)
+
+	document.Heading(labels.TotalSpent)
+	document.Paragraph(b.cost(b.totalSpent))
+	return b.render(document)
+}
+
+func (b *serviceBook) render(document *pdf.Document) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := document.Write(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// csv writes one row per service visit. Costs are plain Rial amounts so spreadsheets can sum them
+func (b *serviceBook) csv() ([]byte, error) {
+	var buffer bytes.Buffer
+	// The byte order mark makes spreadsheet applications read Persian text as UTF-8
+	buffer.WriteString("\ufeff")
+
+	writer := csv.NewWriter(&buffer)
+	labels := b.labels
+	records := [][]string{{
+		labels.Date, labels.Mileage, labels.ServiceCenter, labels.OilChange, labels.OilFilter,
+		labels.OtherItems, labels.LabourCost, labels.PartsCost, labels.Notes,
+	}}
+	for i := range b.visits {
+		visit := &b.visits[i]
+		records = append(records, []string{
+			b.date(visit.ServiceDate),
+			strconv.FormatUint(uint64(visit.ServiceMileage), 10),
+			csvText(visit.ServiceCenter),
+			csvText(b.oilChange(visit)),
+			csvText(b.oilFilter(visit)),
+			csvText(strings.Join(b.serviceItems(visit), "; ")),
+			strconv.FormatInt(int64(visit.TotalLabourCost()), 10),
+			strconv.FormatInt(int64(visit.TotalPartsCost()), 10),
+			csvText(visit.Notes),
+		})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// csvText keeps text the user typed from being run as a formula when the CSV is opened in a spreadsheet,
+// by prefixing the cells that start like one with an apostrophe
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (b *serviceBook) oilChange(visit *entity.ServiceVisit) string {
+	oilChange := visit.OilChange
+	if oilChange.ID == 0 {
+		return ""
+	}
+	capacity := ""
+	if oilChange.OilCapacity > 0 {
+		capacity = strconv.FormatFloat(oilChange.OilCapacity, 'f', -1, 64) + " " + b.labels.Litres
+	}
+	return joinNonEmpty(", ", oilChange.OilName, oilChange.OilBrand, oilChange.OilViscosity, capacity)
+}
+
+func (b *serviceBook) oilFilter(visit *entity.ServiceVisit) string {
+	oilFilter := visit.OilFilter
+	if oilFilter.ID == 0 {
+		return ""
+	}
+	return joinNonEmpty(", ", oilFilter.FilterName, oilFilter.FilterBrand, oilFilter.FilterPartNumber)
+}
+
+func (b *serviceBook) serviceItems(visit *entity.ServiceVisit) []string {
+	items := make([]string, 0, len(visit.ServiceItems))
+	for _, serviceItem := range visit.ServiceItems {
+		items = append(items, joinNonEmpty(", ", serviceItem.Name, serviceItem.Brand, serviceItem.PartNumber))
+	}
+	return items
+}
+
+// date formats the calendar day in the requested calendar. Zero times are left empty
+func (b *serviceBook) date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	if b.jalali {
+		return jalali.Format(t)
+	}
+	return t.Format("2006-01-02")
+}
+
+func (b *serviceBook) mileage(kilometres uint) string {
+	if kilometres == 0 {
+		return ""
+	}
+	return groupThousands(int64(kilometres)) + " " + b.labels.Kilometres
+}
+
+func (b *serviceBook) cost(amount entity.Rial) string {
+	if b.persian {
+		return amount.FormatToman()
+	}
+	return groupThousands(amount.Toman()) + " Toman"
+}
+
+// localized picks the name in the book's language, falling back to the other one
+func (b *serviceBook) localized(nameFa, nameEn string) string {
+	if b.persian && nameFa != "" || nameEn == "" {
+		return nameFa
+	}
+	return nameEn
+}
+
+// groupThousands formats n with comma separated thousands, e.g. 125,000
+func groupThousands(n int64) string {
+	sign := ""
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+	digits := strconv.FormatInt(n, 10)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteRune(',')
+		}
+		b.WriteRune(digit)
+	}
+	return sign + b.String()
+}
+
+func joinNonEmpty(separator string, parts ...string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, separator)
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import "testing"
+
+func TestCSVText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"تعمیرگاه مرکزی", "تعمیرگاه مرکزی"},
+		{"Castrol 10W-40", "Castrol 10W-40"},
+		{`=HYPERLINK("http://example.com","click")`, `'=HYPERLINK("http://example.com","click")`},
+		{"+98 21 1234", "'+98 21 1234"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+		{"a=1", "a=1"},
+	}
+	for _, tt := range tests {
+		if got := csvText(tt.value); got != tt.want {
+			t.Errorf("csvText(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultNearbyRadiusKm is the search radius used when the request does not set one
+	defaultNearbyRadiusKm = 10.0
+	// defaultNearbyLimit is the number of centers returned when the request does not set a limit
+	defaultNearbyLimit = 20
+)
+
+type ServiceCenterUseCase interface {
+	// Directory
+	ListServiceCenters(ctx context.Context, city, name string) (*dto.ListServiceCentersResponse, error)
+	FindNearbyServiceCenters(ctx context.Context, request dto.NearbyServiceCentersRequest) (*dto.ListServiceCentersResponse, error)
+	GetServiceCenter(ctx context.Context, centerID string) (*dto.ServiceCenterResponse, error)
+	CreateServiceCenter(ctx context.Context, request dto.CreateServiceCenterRequest) (*dto.ServiceCenterResponse, error)
+	UpdateServiceCenter(ctx context.Context, centerID string, request dto.UpdateServiceCenterRequest) (*dto.ServiceCenterResponse, error)
+	DeleteServiceCenter(ctx context.Context, centerID string) error
+
+	// Reviews
+	ListServiceCenterReviews(ctx context.Context, centerID string) (*dto.ListServiceCenterReviewsResponse, error)
+	ReviewServiceCenter(ctx context.Context, userID, centerID string, request dto.ReviewServiceCenterRequest) (*dto.ServiceCenterReviewResponse, error)
+	DeleteServiceCenterReview(ctx context.Context, userID, centerID string) error
+}
+
+type serviceCenterUseCase struct {
+	serviceCenterRepository repository.ServiceCenterRepository
+}
+
+func NewServiceCenterUseCase() ServiceCenterUseCase {
+	serviceCenterRepository := repository.NewServiceCenterRepository()
+	return &serviceCenterUseCase{
+		serviceCenterRepository: serviceCenterRepository,
+	}
+}
+
+func (uc *serviceCenterUseCase) ListServiceCenters(ctx context.Context, city, name string) (*dto.ListServiceCentersResponse, error) {
+	serviceCenters := []entity.ServiceCenter{}
+	err := uc.serviceCenterRepository.ListServiceCenters(ctx, city, name, &serviceCenters)
+	if err != nil {
+		logger.Error(err, "Failed to list service centers")
+		return nil, errors.ErrFailedToListServiceCenters
+	}
+
+	serviceCentersResponse := []dto.ServiceCenterResponse{}
+	for _, serviceCenter := range serviceCenters {
+		serviceCentersResponse = append(serviceCentersResponse, *mapServiceCenterToResponse(&serviceCenter))
+	}
+	return &dto.ListServiceCentersResponse{ServiceCenters: serviceCentersResponse}, nil
+}
+
+func (uc *serviceCenterUseCase) FindNearbyServiceCenters(ctx context.Context, request dto.NearbyServiceCentersRequest) (*dto.ListServiceCentersResponse, error) {
+	err := validation.ValidateNearbyServiceCentersRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate nearby service centers request")
+		return nil, errors.ErrInvalidNearbyServiceCentersRequest
+	}
+
+	radiusKm := request.RadiusKm
+	if radiusKm == 0 {
+		radiusKm = defaultNearbyRadiusKm
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultNearbyLimit
+	}
+	latitude, longitude := *request.Latitude, *request.Longitude
+
+	// Narrow the candidates down with the bounding box, then keep those within the exact radius
+	minLat, maxLat, minLng, maxLng := entity.BoundingBox(latitude, longitude, radiusKm)
+	candidates := []entity.ServiceCenter{}
+	err = uc.serviceCenterRepository.ListServiceCentersInBoundingBox(ctx, minLat, maxLat, minLng, maxLng, &candidates)
+	if err != nil {
+		logger.Error(err, "Failed to list service centers in bounding box")
+		return nil, errors.ErrFailedToListServiceCenters
+	}
+
+	serviceCentersResponse := []dto.ServiceCenterResponse{}
+	for _, serviceCenter := range candidates {
+		distanceKm := serviceCenter.DistanceKm(latitude, longitude)
+		if distanceKm > radiusKm {
+			continue
+		}
+		response := mapServiceCenterToResponse(&serviceCenter)
+		distanceKm = roundToOneDecimal(distanceKm)
+		response.DistanceKm = &distanceKm
+		serviceCentersResponse = append(serviceCentersResponse, *response)
+	}
+	sort.SliceStable(serviceCentersResponse, func(i, j int) bool {
+		return *serviceCentersResponse[i].DistanceKm < *serviceCentersResponse[j].DistanceKm
+	})
+	if len(serviceCentersResponse) > limit {
+		serviceCentersResponse = serviceCentersResponse[:limit]
+	}
+
+	return &dto.ListServiceCentersResponse{ServiceCenters: serviceCentersResponse}, nil
+}
+
+func (uc *serviceCenterUseCase) GetServiceCenter(ctx context.Context, centerID string) (*dto.ServiceCenterResponse, error) {
+	serviceCenter, err := uc.getServiceCenter(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+	return mapServiceCenterToResponse(serviceCenter), nil
+}
+
+func (uc *serviceCenterUseCase) CreateServiceCenter(ctx context.Context, request dto.CreateServiceCenterRequest) (*dto.ServiceCenterResponse, error) {
+	err := validation.ValidateServiceCenterCreateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate service center create request")
+		return nil, errors.ErrInvalidServiceCenterCreateRequest
+	}
+
+	serviceCenter := entity.ServiceCenter{
+		Name:      request.Name,
+		City:      request.City,
+		Address:   request.Address,
+		Phone:     request.Phone,
+		Latitude:  *request.Latitude,
+		Longitude: *request.Longitude,
+	}
+	err = uc.serviceCenterRepository.CreateServiceCenter(ctx, &serviceCenter)
+	if err != nil {
+		logger.Error(err, "Failed to create service center")
+		return nil, errors.ErrFailedToCreateServiceCenter
+	}
+
+	return mapServiceCenterToResponse(&serviceCenter), nil
+}
+
+func (uc *serviceCenterUseCase) UpdateServiceCenter(ctx context.Context, centerID string, request dto.UpdateServiceCenterRequest) (*dto.ServiceCenterResponse, error) {
+	err := validation.ValidateServiceCenterUpdateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate service center update request")
+		return nil, errors.ErrInvalidServiceCenterUpdateRequest
+	}
+
+	serviceCenter, err := uc.getServiceCenter(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name != nil {
+		serviceCenter.Name = *request.Name
+	}
+	if request.City != nil {
+		serviceCenter.City = *request.City
+	}
+	if request.Address != nil {
+		serviceCenter.Address = *request.Address
+	}
+	if request.Phone != nil {
+		serviceCenter.Phone = *request.Phone
+	}
+	if request.Latitude != nil {
+		serviceCenter.Latitude = *request.Latitude
+	}
+	if request.Longitude != nil {
+		serviceCenter.Longitude = *request.Longitude
+	}
+
+	err = uc.serviceCenterRepository.UpdateServiceCenter(ctx, serviceCenter)
+	if err != nil {
+		logger.Error(err, "Failed to update service center")
+		return nil, errors.ErrFailedToUpdateServiceCenter
+	}
+
+	return mapServiceCenterToResponse(serviceCenter), nil
+}
+
+func (uc *serviceCenterUseCase) DeleteServiceCenter(ctx context.Context, centerID string) error {
+	serviceCenter, err := uc.getServiceCenter(ctx, centerID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.serviceCenterRepository.DeleteServiceCenter(ctx, serviceCenter)
+	if err != nil {
+		logger.Error(err, "Failed to delete service center")
+		return errors.ErrFailedToDeleteServiceCenter
+	}
+	return nil
+}
+
+func (uc *serviceCenterUseCase) ListServiceCenterReviews(ctx context.Context, centerID string) (*dto.ListServiceCenterReviewsResponse, error) {
+	serviceCenter, err := uc.getServiceCenter(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := []entity.ServiceCenterReview{}
+	err = uc.serviceCenterRepository.ListServiceCenterReviews(ctx, serviceCenter.ID, &reviews)
+	if err != nil {
+		logger.Error(err, "Failed to list service center reviews")
+		return nil, errors.ErrFailedToListServiceCenterReviews
+	}
+
+	reviewsResponse := []dto.ServiceCenterReviewResponse{}
+	for _, review := range reviews {
+		reviewsResponse = append(reviewsResponse, *mapServiceCenterReviewToResponse(&review))
+	}
+	return &dto.ListServiceCenterReviewsResponse{
+		Reviews:       reviewsResponse,
+		AverageRating: roundToOneDecimal(serviceCenter.AverageRating),
+		ReviewCount:   serviceCenter.ReviewCount,
+	}, nil
+}
+
+func (uc *serviceCenterUseCase) ReviewServiceCenter(ctx context.Context, userID, centerID string, request dto.ReviewServiceCenterRequest) (*dto.ServiceCenterReviewResponse, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+
+	err = validation.ValidateServiceCenterReviewRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate service center review request")
+		return nil, errors.ErrInvalidServiceCenterReviewRequest
+	}
+
+	serviceCenter, err := uc.getServiceCenter(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+
+	visited, err := uc.serviceCenterRepository.HasUserVisitedServiceCenter(ctx, uuidUserID, serviceCenter.ID)
+	if err != nil {
+		logger.Error(err, "Failed to check service center visits")
+		return nil, errors.ErrFailedToReviewServiceCenter
+	}
+	if !visited {
+		logger.Error(errors.ErrServiceCenterNotVisited, "User has no recorded visit at the service center")
+		return nil, errors.ErrServiceCenterNotVisited
+	}
+
+	// Reviewing again replaces the user's previous review
+	review := entity.ServiceCenterReview{}
+	err = uc.serviceCenterRepository.GetServiceCenterReview(ctx, serviceCenter.ID, uuidUserID, &review)
+	if err != nil {
+		logger.Error(err, "Failed to get service center review")
+		return nil, errors.ErrFailedToReviewServiceCenter
+	}
+	review.ServiceCenterID = serviceCenter.ID
+	review.UserID = uuidUserID
+	review.Rating = request.Rating
+	review.Comment = request.Comment
+
+	err = uc.serviceCenterRepository.SaveServiceCenterReview(ctx, &review)
+	if err != nil {
+		logger.Error(err, "Failed to save service center review")
+		return nil, errors.ErrFailedToReviewServiceCenter
+	}
+
+	return mapServiceCenterReviewToResponse(&review), nil
+}
+
+func (uc *serviceCenterUseCase) DeleteServiceCenterReview(ctx context.Context, userID, centerID string) error {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return errors.ErrInvalidUserID
+	}
+
+	serviceCenter, err := uc.getServiceCenter(ctx, centerID)
+	if err != nil {
+		return err
+	}
+
+	review := entity.ServiceCenterReview{}
+	err = uc.serviceCenterRepository.GetServiceCenterReview(ctx, serviceCenter.ID, uuidUserID, &review)
+	if err != nil {
+		logger.Error(err, "Failed to get service center review")
+		return errors.ErrFailedToDeleteServiceCenterReview
+	}
+	if review.ID == 0 {
+		return errors.ErrServiceCenterReviewNotFound
+	}
+
+	err = uc.serviceCenterRepository.DeleteServiceCenterReview(ctx, &review)
+	if err != nil {
+		logger.Error(err, "Failed to delete service center review")
+		return errors.ErrFailedToDeleteServiceCenterReview
+	}
+	return nil
+}
+
+// getServiceCenter parses the id and loads the service center
+func (uc *serviceCenterUseCase) getServiceCenter(ctx context.Context, centerID string) (*entity.ServiceCenter, error) {
+	uintServiceCenterID, err := strconv.ParseUint(centerID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse service center id")
+		return nil, errors.ErrInvalidServiceCenterID
+	}
+
+	serviceCenter := entity.ServiceCenter{}
+	err = uc.serviceCenterRepository.GetServiceCenter(ctx, uintServiceCenterID, &serviceCenter)
+	if err != nil {
+		logger.Error(err, "Failed to get service center")
+		return nil, errors.ErrFailedToGetServiceCenter
+	}
+	if serviceCenter.ID == 0 {
+		return nil, errors.ErrServiceCenterNotFound
+	}
+
+	return &serviceCenter, nil
+}
+
+func mapServiceCenterToResponse(serviceCenter *entity.ServiceCenter) *dto.ServiceCenterResponse {
+	return &dto.ServiceCenterResponse{
+		ID:            serviceCenter.ID,
+		Name:          serviceCenter.Name,
+		City:          serviceCenter.City,
+		Address:       serviceCenter.Address,
+		Phone:         serviceCenter.Phone,
+		Latitude:      serviceCenter.Latitude,
+		Longitude:     serviceCenter.Longitude,
+		AverageRating: roundToOneDecimal(serviceCenter.AverageRating),
+		ReviewCount:   serviceCenter.ReviewCount,
+	}
+}
+
+func mapServiceCenterReviewToResponse(review *entity.ServiceCenterReview) *dto.ServiceCenterReviewResponse {
+	return &dto.ServiceCenterReviewResponse{
+		ID:              review.ID,
+		ServiceCenterID: review.ServiceCenterID,
+		Rating:          review.Rating,
+		Comment:         review.Comment,
+		ReviewedAt:      review.UpdatedAt.Format("2006-01-02"),
+	}
+}
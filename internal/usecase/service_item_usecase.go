@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+type ServiceItemUseCase interface {
+	CreateServiceItem(ctx context.Context, userID, vehicleID, visitID string, request dto.CreateServiceItemRequest) (*dto.ServiceItemResponse, error)
+	GetServiceItem(ctx context.Context, userID, vehicleID, visitID, itemID string) (*dto.ServiceItemResponse, error)
+	ListServiceItems(ctx context.Context, userID, vehicleID, visitID string) (*dto.ListServiceItemsResponse, error)
+	UpdateServiceItem(ctx context.Context, userID, vehicleID, visitID, itemID string, request dto.UpdateServiceItemRequest) (*dto.ServiceItemResponse, error)
+	DeleteServiceItem(ctx context.Context, userID, vehicleID, visitID, itemID string) error
+}
+
+type serviceItemUseCase struct {
+	serviceItemRepository  repository.ServiceItemRepository
+	serviceVisitRepository repository.ServiceVisitRepository
+	maintenancePlanner     *maintenancePlanner
+	vehicleAccess          *vehicleAccess
+}
+
+func NewServiceItemUseCase() ServiceItemUseCase {
+	serviceItemRepository := repository.NewServiceItemRepository()
+	serviceVisitRepository := repository.NewServiceVisitRepository()
+	return &serviceItemUseCase{
+		serviceItemRepository:  serviceItemRepository,
+		serviceVisitRepository: serviceVisitRepository,
+		maintenancePlanner:     newMaintenancePlanner(repository.NewMaintenanceScheduleRepository()),
+		vehicleAccess:          newVehicleAccess(repository.NewVehicleRepository(), repository.NewVehicleMembershipRepository()),
+	}
+}
+
+func (uc *serviceItemUseCase) CreateServiceItem(ctx context.Context, userID, vehicleID, visitID string, request dto.CreateServiceItemRequest) (*dto.ServiceItemResponse, error) {
+	serviceVisit, err := uc.getServiceVisitOfVehicle(ctx, userID, vehicleID, visitID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateServiceItemCreateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate service item create request")
+		return nil, errors.ErrInvalidServiceItemCreateRequest
+	}
+
+	serviceItem := entity.ServiceItem{
+		UserID:            serviceVisit.UserID,
+		UserVehicleID:     serviceVisit.UserVehicleID,
+		ServiceVisitID:    serviceVisit.ID,
+		ItemType:          entity.ParseServiceItemType(request.ItemType),
+		Name:              request.Name,
+		Brand:             request.Brand,
+		PartNumber:        request.PartNumber,
+		ChangeMileage:     serviceVisit.ServiceMileage,
+		ChangeDate:        serviceVisit.ServiceDate,
+		NextChangeMileage: request.NextChangeMileage,
+		Notes:             request.Notes,
+		LabourCost:        entity.Rial(request.LabourCost),
+		PartsCost:         entity.Rial(request.PartsCost),
+	}
+
+	if request.NextChangeDate != "" {
+		nextChangeDate, err := time.Parse("2006-01-02", request.NextChangeDate)
+		if err != nil {
+			logger.Error(err, "Failed to parse service item next change date")
+			return nil, errors.ErrInvalidDate
+		}
+		serviceItem.NextChangeDate = nextChangeDate
+	}
+	uc.maintenancePlanner.applyDefaultSchedule(ctx, serviceItem.UserVehicleID, serviceItem.ItemType.String(), serviceItem.ChangeMileage, serviceItem.ChangeDate,
+		&serviceItem.NextChangeMileage, &serviceItem.NextChangeDate)
+
+	err = uc.serviceItemRepository.CreateServiceItem(ctx, &serviceItem)
+	if err != nil {
+		logger.Error(err, "Failed to create service item")
+		return nil, errors.ErrFailedToCreateServiceItem
+	}
+
+	return mapServiceItemToResponse(&serviceItem), nil
+}
+
+func (uc *serviceItemUseCase) GetServiceItem(ctx context.Context, userID, vehicleID, visitID, itemID string) (*dto.ServiceItemResponse, error) {
+	serviceVisit, err := uc.getServiceVisitOfVehicle(ctx, userID, vehicleID, visitID, entity.ViewerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceItem, err := uc.getServiceItemOfVisit(ctx, serviceVisit, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapServiceItemToResponse(serviceItem), nil
+}
+
+func (uc *serviceItemUseCase) ListServiceItems(ctx context.Context, userID, vehicleID, visitID string) (*dto.ListServiceItemsResponse, error) {
+	serviceVisit, err := uc.getServiceVisitOfVehicle(ctx, userID, vehicleID, visitID, entity.ViewerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceItems := []entity.ServiceItem{}
+	err = uc.serviceItemRepository.ListServiceItems(ctx, serviceVisit.ID, &serviceItems)
+	if err != nil {
+		logger.Error(err, "Failed to list service items")
+		return nil, errors.ErrFailedToListServiceItems
+	}
+
+	serviceItemsResponse := []dto.ServiceItemResponse{}
+	for _, serviceItem := range serviceItems {
+		serviceItemsResponse = append(serviceItemsResponse, *mapServiceItemToResponse(&serviceItem))
+	}
+
+	return &dto.ListServiceItemsResponse{
+		ServiceItems: serviceItemsResponse,
+	}, nil
+}
+
+func (uc *serviceItemUseCase) UpdateServiceItem(ctx context.Context, userID, vehicleID, visitID, itemID string, request dto.UpdateServiceItemRequest) (*dto.ServiceItemResponse, error) {
+	serviceVisit, err := uc.getServiceVisitOfVehicle(ctx, userID, vehicleID, visitID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceItem, err := uc.getServiceItemOfVisit(ctx, serviceVisit, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateServiceItemUpdateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate service item update request")
+		return nil, errors.ErrInvalidServiceItemUpdateRequest
+	}
+
+	// Update fields if provided
+	if request.ItemType != nil {
+		serviceItem.ItemType = entity.ParseServiceItemType(*request.ItemType)
+	}
+	if request.Name != nil {
+		serviceItem.Name = *request.Name
+	}
+	if request.Brand != nil {
+		serviceItem.Brand = *request.Brand
+	}
+	if request.PartNumber != nil {
+		serviceItem.PartNumber = *request.PartNumber
+	}
+	if request.NextChangeMileage != nil {
+		serviceItem.NextChangeMileage = *request.NextChangeMileage
+	}
+	if request.NextChangeDate != nil {
+		nextChangeDate, err := time.Parse("2006-01-02", *request.NextChangeDate)
+		if err != nil {
+			logger.Error(err, "Failed to parse service item next change date")
+			return nil, errors.ErrInvalidDate
+		}
+		serviceItem.NextChangeDate = nextChangeDate
+	}
+	if request.Notes != nil {
+		serviceItem.Notes = *request.Notes
+	}
+	if request.LabourCost != nil {
+		serviceItem.LabourCost = entity.Rial(*request.LabourCost)
+	}
+	if request.PartsCost != nil {
+		serviceItem.PartsCost = entity.Rial(*request.PartsCost)
+	}
+
+	err = uc.serviceItemRepository.UpdateServiceItem(ctx, serviceItem)
+	if err != nil {
+		logger.Error(err, "Failed to update service item")
+		return nil, errors.ErrFailedToUpdateServiceItem
+	}
+
+	return mapServiceItemToResponse(serviceItem), nil
+}
+
+func (uc *serviceItemUseCase) DeleteServiceItem(ctx context.Context, userID, vehicleID, visitID, itemID string) error {
+	serviceVisit, err := uc.getServiceVisitOfVehicle(ctx, userID, vehicleID, visitID, entity.EditorVehicleRole)
+	if err != nil {
+		return err
+	}
+
+	serviceItem, err := uc.getServiceItemOfVisit(ctx, serviceVisit, itemID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.serviceItemRepository.DeleteServiceItem(ctx, serviceItem)
+	if err != nil {
+		logger.Error(err, "Failed to delete service item")
+		return errors.ErrFailedToDeleteServiceItem
+	}
+
+	return nil
+}
+
+// getServiceVisitOfVehicle loads a service visit of the vehicle and checks the user has the required role on it
+func (uc *serviceItemUseCase) getServiceVisitOfVehicle(ctx context.Context, userID, vehicleID, visitID string, required entity.VehicleRole) (*entity.ServiceVisit, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+	uuidServiceVisitID, err := uuid.Parse(visitID)
+	if err != nil {
+		logger.Error(err, "Failed to parse service visit id")
+		return nil, errors.ErrInvalidServiceVisitID
+	}
+
+	serviceVisit := entity.ServiceVisit{}
+	serviceVisit.ID = uuidServiceVisitID
+
+	err = uc.serviceVisitRepository.GetServiceVisit(ctx, &serviceVisit)
+	if err != nil {
+		logger.Error(err, "Failed to get service visit")
+		return nil, errors.ErrFailedToGetServiceVisit
+	}
+	if serviceVisit.UserVehicleID != uintVehicleID {
+		logger.Error(errors.ErrUserVehicleNotOwned, "Service visit does not belong to vehicle")
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, serviceVisit.UserVehicleID, required)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceVisit, nil
+}
+
+// getServiceItemOfVisit loads a service item and checks it belongs to the service visit
+func (uc *serviceItemUseCase) getServiceItemOfVisit(ctx context.Context, serviceVisit *entity.ServiceVisit, itemID string) (*entity.ServiceItem, error) {
+	uintServiceItemID, err := strconv.ParseUint(itemID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse service item id")
+		return nil, errors.ErrInvalidServiceItemID
+	}
+
+	serviceItem := entity.ServiceItem{}
+	err = uc.serviceItemRepository.GetServiceItem(ctx, uintServiceItemID, &serviceItem)
+	if err != nil {
+		logger.Error(err, "Failed to get service item")
+		return nil, errors.ErrFailedToGetServiceItem
+	}
+	if serviceItem.ServiceVisitID != serviceVisit.ID {
+		logger.Error(errors.ErrServiceItemNotOwned, "Service item does not belong to service visit")
+		return nil, errors.ErrServiceItemNotOwned
+	}
+
+	return &serviceItem, nil
+}
+
+func mapServiceItemToResponse(serviceItem *entity.ServiceItem) *dto.ServiceItemResponse {
+	return &dto.ServiceItemResponse{
+		ID:                serviceItem.ID,
+		ServiceVisitID:    serviceItem.ServiceVisitID.String(),
+		ItemType:          serviceItem.ItemType.String(),
+		Name:              serviceItem.Name,
+		Brand:             serviceItem.Brand,
+		PartNumber:        serviceItem.PartNumber,
+		ChangeMileage:     serviceItem.ChangeMileage,
+		ChangeDate:        serviceItem.ChangeDate.Format("2006-01-02"),
+		NextChangeMileage: serviceItem.NextChangeMileage,
+		NextChangeDate:    serviceItem.NextChangeDate.Format("2006-01-02"),
+		Notes:             serviceItem.Notes,
+		Cost:              mapCostToResponse(serviceItem.LabourCost, serviceItem.PartsCost),
+	}
+}
@@ -0,0 +1,411 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/spreadsheet"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/jalali"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/amirdashtii/AutoBan/pkg/persian"
+	"github.com/google/uuid"
+)
+
+const (
+	maxServiceVisitImportFileSize = 5 << 20
+	maxServiceVisitImportRows     = 5000
+)
+
+// serviceVisitImportColumn copies a cell into the create request. Empty cells are skipped
+type serviceVisitImportColumn func(request *dto.CreateServiceVisitRequest, value string) error
+
+// serviceVisitImportColumns maps the header of an import file to the create request fields. Nested fields
+// are prefixed with oil_ and filter_, and service_items holds "item_type:name" entries separated by ";".
+// Dates may be Gregorian or Jalali and numbers may use Persian digits and thousands separators
+var serviceVisitImportColumns = map[string]serviceVisitImportColumn{
+	"service_date": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		r.ServiceDate, err = parseImportDate(v)
+		return err
+	},
+	"service_mileage": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		r.ServiceMileage, err = parseImportUint(v)
+		return err
+	},
+	"service_center": func(r *dto.CreateServiceVisitRequest, v string) error {
+		r.ServiceCenter = v
+		return nil
+	},
+	"service_center_id": func(r *dto.CreateServiceVisitRequest, v string) error {
+		id, err := strconv.ParseUint(normalizeImportNumber(v), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid service center id %q", v)
+		}
+		r.ServiceCenterID = &id
+		return nil
+	},
+	"notes": func(r *dto.CreateServiceVisitRequest, v string) error {
+		r.Notes = v
+		return nil
+	},
+	"labour_cost": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		r.LabourCost, err = parseImportInt(v)
+		return err
+	},
+	"parts_cost": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		r.PartsCost, err = parseImportInt(v)
+		return err
+	},
+
+	"oil_name": func(r *dto.CreateServiceVisitRequest, v string) error {
+		importOilChange(r).OilName = v
+		return nil
+	},
+	"oil_brand": func(r *dto.CreateServiceVisitRequest, v string) error {
+		importOilChange(r).OilBrand = v
+		return nil
+	},
+	"oil_type": func(r *dto.CreateServiceVisitRequest, v string) error {
+		importOilChange(r).OilType = v
+		return nil
+	},
+	"oil_viscosity": func(r *dto.CreateServiceVisitRequest, v string) error {
+		importOilChange(r).OilViscosity = v
+		return nil
+	},
+	"oil_capacity": func(r *dto.CreateServiceVisitRequest, v string) error {
+		capacity, err := strconv.ParseFloat(normalizeImportNumber(v), 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", v)
+		}
+		importOilChange(r).OilCapacity = capacity
+		return nil
+	},
+	"oil_next_change_mileage": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		importOilChange(r).NextChangeMileage, err = parseImportUint(v)
+		return err
+	},
+	"oil_next_change_date": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		importOilChange(r).NextChangeDate, err = parseImportDate(v)
+		return err
+	},
+	"oil_notes": func(r *dto.CreateServiceVisitRequest, v string) error {
+		importOilChange(r).Notes = v
+		return nil
+	},
+	"oil_labour_cost": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		importOilChange(r).LabourCost, err = parseImportInt(v)
+		return err
+	},
+	"oil_parts_cost": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		importOilChange(r).PartsCost, err = parseImportInt(v)
+		return err
+	},
+
+	"filter_name": func(r *dto.CreateServiceVisitRequest, v string) error {
+		importOilFilter(r).FilterName = v
+		return nil
+	},
+	"filter_brand": func(r *dto.CreateServiceVisitRequest, v string) error {
+		importOilFilter(r).FilterBrand = v
+		return nil
+	},
+	"filter_type": func(r *dto.CreateServiceVisitRequest, v string) error {
+		importOilFilter(r).FilterType = v
+		return nil
+	},
+	"filter_part_number": func(r *dto.CreateServiceVisitRequest, v string) error {
+		importOilFilter(r).FilterPartNumber = v
+		return nil
+	},
+	"filter_next_change_mileage": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		importOilFilter(r).NextChangeMileage, err = parseImportUint(v)
+		return err
+	},
+	"filter_next_change_date": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		importOilFilter(r).NextChangeDate, err = parseImportDate(v)
+		return err
+	},
+	"filter_notes": func(r *dto.CreateServiceVisitRequest, v string) error {
+		importOilFilter(r).Notes = v
+		return nil
+	},
+	"filter_labour_cost": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		importOilFilter(r).LabourCost, err = parseImportInt(v)
+		return err
+	},
+	"filter_parts_cost": func(r *dto.CreateServiceVisitRequest, v string) (err error) {
+		importOilFilter(r).PartsCost, err = parseImportInt(v)
+		return err
+	},
+
+	"service_items": func(r *dto.CreateServiceVisitRequest, v string) error {
+		for _, entry := range strings.Split(v, ";") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			item := dto.CreateServiceItemRequest{ItemType: entity.OtherServiceItem.String(), Name: entry}
+			if itemType, name, ok := strings.Cut(entry, ":"); ok {
+				itemType = strings.ToLower(strings.TrimSpace(itemType))
+				if entity.ParseServiceItemType(itemType).String() == itemType {
+					item.ItemType = itemType
+					item.Name = strings.TrimSpace(name)
+				}
+			}
+			r.ServiceItems = append(r.ServiceItems, item)
+		}
+		return nil
+	},
+}
+
+// serviceVisitImportRow is a row of an import file that passed validation
+type serviceVisitImportRow struct {
+	row             int
+	serviceVisit    *entity.ServiceVisit
+	odometerReading *entity.OdometerReading
+}
+
+func (uc *serviceVisitUseCase) ImportServiceVisits(ctx context.Context, userID, vehicleID, fileName string, size int64, content io.Reader, request dto.ImportServiceVisitsRequest) (*dto.ImportServiceVisitsResponse, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+	userVehicle, _, err := uc.vehicleAccess.authorize(ctx, uuidUserID, uintVehicleID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		return nil, errors.ErrServiceVisitImportFileRequired
+	}
+	if size > maxServiceVisitImportFileSize {
+		logger.Error(errors.ErrServiceVisitImportFileTooLarge, "Service visit import file exceeds max file size")
+		return nil, errors.ErrServiceVisitImportFileTooLarge
+	}
+	rows, err := spreadsheet.ReadRows(fileName, io.LimitReader(content, maxServiceVisitImportFileSize))
+	if err != nil {
+		logger.Error(err, "Failed to read service visit import file")
+		return nil, errors.ErrInvalidServiceVisitImportFile
+	}
+	if len(rows) < 2 {
+		return nil, errors.ErrServiceVisitImportFileEmpty
+	}
+	if len(rows)-1 > maxServiceVisitImportRows {
+		logger.Error(errors.ErrServiceVisitImportTooManyRows, "Service visit import file has too many rows")
+		return nil, errors.ErrServiceVisitImportTooManyRows
+	}
+
+	response := &dto.ImportServiceVisitsResponse{
+		DryRun:        request.DryRun,
+		Errors:        []dto.ServiceVisitImportRowError{},
+		ServiceVisits: []dto.ServiceVisitResponse{},
+	}
+
+	header := make([]string, len(rows[0]))
+	for i, cell := range rows[0] {
+		header[i] = strings.ReplaceAll(strings.ReplaceAll(strings.ToLower(cell), " ", "_"), "-", "_")
+		if _, ok := serviceVisitImportColumns[header[i]]; !ok && header[i] != "" {
+			response.IgnoredColumns = append(response.IgnoredColumns, cell)
+		}
+	}
+	if !slices.Contains(header, "service_date") || !slices.Contains(header, "service_mileage") {
+		return nil, errors.ErrServiceVisitImportMissingColumns
+	}
+
+	imported := []serviceVisitImportRow{}
+	for i, cells := range rows[1:] {
+		if spreadsheet.IsBlank(cells) {
+			continue
+		}
+		row := i + 2
+		response.TotalRows++
+
+		createRequest, rowError := parseServiceVisitImportRow(header, cells)
+		if rowError != nil {
+			rowError.Row = row
+			response.Errors = append(response.Errors, *rowError)
+			continue
+		}
+		createRequest.UserVehicleID = uintVehicleID
+		if err := validation.ValidateServiceVisitCreateRequest(createRequest); err != nil {
+			response.Errors = append(response.Errors, dto.ServiceVisitImportRowError{Row: row, Error: err.Error()})
+			continue
+		}
+		serviceVisit, odometerReading, err := uc.buildServiceVisit(ctx, userVehicle.UserID, uintVehicleID, createRequest)
+		if err != nil {
+			response.Errors = append(response.Errors, dto.ServiceVisitImportRowError{Row: row, Error: err.Error()})
+			continue
+		}
+		imported = append(imported, serviceVisitImportRow{row: row, serviceVisit: serviceVisit, odometerReading: odometerReading})
+	}
+
+	// The mileage must not go backwards, neither between the imported visits nor against the readings already recorded
+	sort.SliceStable(imported, func(i, j int) bool {
+		return imported[i].serviceVisit.ServiceDate.Before(imported[j].serviceVisit.ServiceDate)
+	})
+	valid := []serviceVisitImportRow{}
+	var highest *serviceVisitImportRow
+	for i := range imported {
+		current := &imported[i]
+		if highest != nil && current.serviceVisit.ServiceMileage < highest.serviceVisit.ServiceMileage {
+			response.Errors = append(response.Errors, dto.ServiceVisitImportRowError{
+				Row:    current.row,
+				Column: "service_mileage",
+				Error:  fmt.Sprintf("service mileage is lower than the mileage of the earlier service visit in row %d", highest.row),
+			})
+			continue
+		}
+		err := uc.odometerTracker.validateReading(ctx, current.odometerReading)
+		if errors.Is(err, errors.ErrOdometerReadingTooLow) || errors.Is(err, errors.ErrOdometerReadingTooHigh) {
+			response.Errors = append(response.Errors, dto.ServiceVisitImportRowError{Row: current.row, Column: "service_mileage", Error: err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		highest = current
+		valid = append(valid, *current)
+	}
+
+	sort.Slice(response.Errors, func(i, j int) bool {
+		return response.Errors[i].Row < response.Errors[j].Row
+	})
+	response.ValidRows = len(valid)
+	for _, row := range valid {
+		response.ServiceVisits = append(response.ServiceVisits, *mapServiceVisitToResponse(row.serviceVisit))
+	}
+	if len(response.Errors) > 0 || request.DryRun {
+		return response, nil
+	}
+
+	serviceVisits := make([]entity.ServiceVisit, 0, len(valid))
+	odometerReadings := make([]entity.OdometerReading, 0, len(valid))
+	for _, row := range valid {
+		serviceVisits = append(serviceVisits, *row.serviceVisit)
+		odometerReadings = append(odometerReadings, *row.odometerReading)
+	}
+	err = uc.serviceVisitRepository.ImportServiceVisits(ctx, serviceVisits, odometerReadings)
+	if err != nil {
+		logger.Error(err, "Failed to import service visits")
+		return nil, errors.ErrFailedToImportServiceVisits
+	}
+	response.Imported = true
+
+	if err := uc.odometerTracker.syncCurrentMileage(ctx, userVehicle); err != nil {
+		logger.Error(err, "Failed to sync current mileage")
+	}
+	return response, nil
+}
+
+// parseServiceVisitImportRow builds the create request of a row from its cells
+func parseServiceVisitImportRow(header, cells []string) (dto.CreateServiceVisitRequest, *dto.ServiceVisitImportRowError) {
+	request := dto.CreateServiceVisitRequest{}
+	for i, value := range cells {
+		if i >= len(header) || value == "" {
+			continue
+		}
+		column, ok := serviceVisitImportColumns[header[i]]
+		if !ok {
+			continue
+		}
+		if err := column(&request, value); err != nil {
+			return request, &dto.ServiceVisitImportRowError{Column: header[i], Error: err.Error()}
+		}
+	}
+	return request, nil
+}
+
+func importOilChange(request *dto.CreateServiceVisitRequest) *dto.ServiceVisitOilChange {
+	if request.OilChange == nil {
+		request.OilChange = &dto.ServiceVisitOilChange{}
+	}
+	return request.OilChange
+}
+
+func importOilFilter(request *dto.CreateServiceVisitRequest) *dto.ServiceVisitOilFilter {
+	if request.OilFilter == nil {
+		request.OilFilter = &dto.ServiceVisitOilFilter{}
+	}
+	return request.OilFilter
+}
+
+// normalizeImportNumber converts Persian and Arabic digits to ASCII and drops thousands separators
+func normalizeImportNumber(value string) string {
+	var b strings.Builder
+	for _, r := range persian.ToLatinDigits(value) {
+		switch {
+		case r == ',' || r == '٬' || r == ' ':
+		case r == '٫':
+			b.WriteRune('.')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func parseImportUint(value string) (uint, error) {
+	n, err := strconv.ParseUint(normalizeImportNumber(value), 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("invalid whole number %q", value)
+	}
+	return uint(n), nil
+}
+
+func parseImportInt(value string) (int64, error) {
+	n, err := strconv.ParseInt(normalizeImportNumber(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid whole number %q", value)
+	}
+	return n, nil
+}
+
+// excelEpoch is day zero of Excel serial dates
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Numbers outside the serials of 1970 to 2099 are mistyped dates such as 1402 or 14020512 rather than serials
+var (
+	minImportDateSerial = float64(time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC).Sub(excelEpoch) / (24 * time.Hour))
+	maxImportDateSerial = float64(time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC).Sub(excelEpoch) / (24 * time.Hour))
+)
+
+// parseImportDate accepts YYYY-MM-DD and YYYY/MM/DD dates, read as Jalali when the year is before 1700, and
+// the serial numbers XLSX files store dates of 1970 to 2099 as. It returns the Gregorian date as YYYY-MM-DD
+func parseImportDate(value string) (string, error) {
+	value = normalizeImportNumber(value)
+	parts := strings.FieldsFunc(value, func(r rune) bool { return r == '-' || r == '/' })
+	if len(parts) == 3 {
+		year, yearErr := strconv.Atoi(parts[0])
+		month, monthErr := strconv.Atoi(parts[1])
+		day, dayErr := strconv.Atoi(parts[2])
+		if yearErr == nil && monthErr == nil && dayErr == nil {
+			if year < 1700 {
+				date := jalali.Date{Year: year, Month: month, Day: day}
+				if date.Valid() {
+					return date.Time(time.UTC).Format("2006-01-02"), nil
+				}
+			} else if date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC); date.Month() == time.Month(month) && date.Day() == day {
+				return date.Format("2006-01-02"), nil
+			}
+		}
+	} else if serial, err := strconv.ParseFloat(value, 64); err == nil && serial >= minImportDateSerial && serial < maxImportDateSerial {
+		return excelEpoch.AddDate(0, 0, int(serial)).Format("2006-01-02"), nil
+	}
+	return "", fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+}
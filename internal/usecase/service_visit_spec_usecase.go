@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	oilViscosityMismatchWarning = "oil_viscosity_mismatch"
+	oilCapacityMismatchWarning  = "oil_capacity_mismatch"
+	oilFilterMismatchWarning    = "oil_filter_mismatch"
+)
+
+// GetServiceVisitDefaults returns a create request pre-filled with today's date, the current mileage
+// and the oil and filter recommended for the vehicle's generation
+func (uc *serviceVisitUseCase) GetServiceVisitDefaults(ctx context.Context, userID, vehicleID string) (*dto.CreateServiceVisitRequest, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+	userVehicle, _, err := uc.vehicleAccess.authorize(ctx, uuidUserID, uintVehicleID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &dto.CreateServiceVisitRequest{
+		UserVehicleID: uintVehicleID,
+		ServiceDate:   time.Now().Format("2006-01-02"),
+		OilChange:     &dto.ServiceVisitOilChange{},
+		OilFilter:     &dto.ServiceVisitOilFilter{},
+	}
+	if userVehicle.CurrentMileage > 0 {
+		request.ServiceMileage = uint(userVehicle.CurrentMileage)
+	}
+	applyGenerationDefaults(uc.getVehicleGeneration(ctx, userVehicle), request)
+
+	return request, nil
+}
+
+// getVehicleGeneration loads the generation of the vehicle for its recommended specs. Recommendations
+// never block a visit, so a failed lookup is only logged and returns nil
+func (uc *serviceVisitUseCase) getVehicleGeneration(ctx context.Context, userVehicle *entity.UserVehicle) *entity.VehicleGeneration {
+	generation := entity.VehicleGeneration{}
+	generation.ID = userVehicle.GenerationID
+	err := uc.vehicleRepository.GetGeneration(ctx, &generation)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle generation")
+		return nil
+	}
+	return &generation
+}
+
+// applyGenerationDefaults fills the oil viscosity, oil capacity and filter part number the request leaves
+// empty with the first recommendation of the generation
+func applyGenerationDefaults(generation *entity.VehicleGeneration, request *dto.CreateServiceVisitRequest) {
+	if generation == nil {
+		return
+	}
+	if request.OilChange != nil {
+		if viscosities := generation.OilViscosityList(); request.OilChange.OilViscosity == "" && len(viscosities) > 0 {
+			request.OilChange.OilViscosity = viscosities[0]
+		}
+		if request.OilChange.OilCapacity == 0 {
+			request.OilChange.OilCapacity = generation.OilCapacity
+		}
+	}
+	if request.OilFilter != nil {
+		if partNumbers := generation.OilFilterPartNumberList(); request.OilFilter.FilterPartNumber == "" && len(partNumbers) > 0 {
+			request.OilFilter.FilterPartNumber = partNumbers[0]
+		}
+	}
+}
+
+// oilSpecWarnings lists the oil change and filter specs of the visit that differ from the recommendation
+// of the generation. Empty specs and specs without a recommendation are not compared
+func oilSpecWarnings(generation *entity.VehicleGeneration, serviceVisit *entity.ServiceVisit) []dto.ServiceVisitWarning {
+	warnings := []dto.ServiceVisitWarning{}
+	if generation == nil {
+		return warnings
+	}
+
+	oilChange := serviceVisit.OilChange
+	if oilChange.OilViscosity != "" && !generation.MatchesOilViscosity(oilChange.OilViscosity) {
+		warnings = append(warnings, dto.ServiceVisitWarning{
+			Code:        oilViscosityMismatchWarning,
+			Field:       "oil_change.oil_viscosity",
+			Value:       oilChange.OilViscosity,
+			Recommended: generation.OilViscosityList(),
+			MessageEn:   "Oil viscosity is not one of the recommended viscosities",
+			MessageFa:   "گرانروی روغن جزو گرانروی‌های توصیه‌شده نیست",
+		})
+	}
+	if oilChange.OilCapacity > 0 && !generation.MatchesOilCapacity(oilChange.OilCapacity) {
+		warnings = append(warnings, dto.ServiceVisitWarning{
+			Code:        oilCapacityMismatchWarning,
+			Field:       "oil_change.oil_capacity",
+			Value:       strconv.FormatFloat(oilChange.OilCapacity, 'f', -1, 64),
+			Recommended: []string{strconv.FormatFloat(generation.OilCapacity, 'f', -1, 64)},
+			MessageEn:   "Oil capacity differs from the recommended capacity",
+			MessageFa:   "مقدار روغن با مقدار توصیه‌شده متفاوت است",
+		})
+	}
+
+	partNumber := serviceVisit.OilFilter.FilterPartNumber
+	if partNumber != "" && !generation.MatchesOilFilterPartNumber(partNumber) {
+		warnings = append(warnings, dto.ServiceVisitWarning{
+			Code:        oilFilterMismatchWarning,
+			Field:       "oil_filter.filter_part_number",
+			Value:       partNumber,
+			Recommended: generation.OilFilterPartNumberList(),
+			MessageEn:   "Oil filter is not one of the OEM filters",
+			MessageFa:   "فیلتر روغن جزو فیلترهای اصلی خودرو نیست",
+		})
+	}
+
+	return warnings
+}
@@ -2,12 +2,14 @@ package usecase
 
 import (
 	"context"
+	"io"
 	"strconv"
 	"time"
 
 	"github.com/amirdashtii/AutoBan/internal/domain/entity"
 	"github.com/amirdashtii/AutoBan/internal/dto"
 	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/storage"
 	"github.com/amirdashtii/AutoBan/internal/repository"
 	"github.com/amirdashtii/AutoBan/internal/validation"
 	"github.com/amirdashtii/AutoBan/pkg/logger"
@@ -21,13 +23,22 @@ type ServiceVisitUseCase interface {
 	UpdateServiceVisit(ctx context.Context, userID, vehicleID, visitID string, request dto.UpdateServiceVisitRequest) (*dto.ServiceVisitResponse, error)
 	DeleteServiceVisit(ctx context.Context, userID, vehicleID, visitID string) error
 	GetLastServiceVisit(ctx context.Context, userID, vehicleID string) (*dto.ServiceVisitResponse, error)
+	ImportServiceVisits(ctx context.Context, userID, vehicleID, fileName string, size int64, content io.Reader, request dto.ImportServiceVisitsRequest) (*dto.ImportServiceVisitsResponse, error)
+	GetServiceVisitDefaults(ctx context.Context, userID, vehicleID string) (*dto.CreateServiceVisitRequest, error)
 }
 
 type serviceVisitUseCase struct {
-	serviceVisitRepository repository.ServiceVisitRepository
-	oilChangeRepository    repository.OilChangeRepository
-	oilFilterRepository    repository.OilFilterRepository
-	vehicleRepository      repository.VehicleRepository
+	serviceVisitRepository    repository.ServiceVisitRepository
+	oilChangeRepository       repository.OilChangeRepository
+	oilFilterRepository       repository.OilFilterRepository
+	vehicleRepository         repository.VehicleRepository
+	odometerReadingRepository repository.OdometerReadingRepository
+	attachmentRepository      repository.AttachmentRepository
+	serviceCenterRepository   repository.ServiceCenterRepository
+	storage                   storage.Storage
+	odometerTracker           *odometerTracker
+	maintenancePlanner        *maintenancePlanner
+	vehicleAccess             *vehicleAccess
 }
 
 func NewServiceVisitUseCase() ServiceVisitUseCase {
@@ -35,11 +46,19 @@ func NewServiceVisitUseCase() ServiceVisitUseCase {
 	oilChangeRepository := repository.NewOilChangeRepository()
 	oilFilterRepository := repository.NewOilFilterRepository()
 	vehicleRepository := repository.NewVehicleRepository()
+	odometerReadingRepository := repository.NewOdometerReadingRepository()
 	return &serviceVisitUseCase{
-		serviceVisitRepository: serviceVisitRepository,
-		oilChangeRepository:    oilChangeRepository,
-		oilFilterRepository:    oilFilterRepository,
-		vehicleRepository:      vehicleRepository,
+		serviceVisitRepository:    serviceVisitRepository,
+		oilChangeRepository:       oilChangeRepository,
+		oilFilterRepository:       oilFilterRepository,
+		vehicleRepository:         vehicleRepository,
+		odometerReadingRepository: odometerReadingRepository,
+		attachmentRepository:      repository.NewAttachmentRepository(),
+		serviceCenterRepository:   repository.NewServiceCenterRepository(),
+		storage:                   storage.GetStorage(),
+		odometerTracker:           newOdometerTracker(odometerReadingRepository, vehicleRepository),
+		maintenancePlanner:        newMaintenancePlanner(repository.NewMaintenanceScheduleRepository()),
+		vehicleAccess:             newVehicleAccess(vehicleRepository, repository.NewVehicleMembershipRepository()),
 	}
 }
 
@@ -54,11 +73,9 @@ func (uc *serviceVisitUseCase) CreateServiceVisit(ctx context.Context, userID, v
 		logger.Error(err, "Failed to parse vehicle id")
 		return nil, errors.ErrInvalidUserVehicleID
 	}
-	userVehicle := entity.UserVehicle{}
-	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintVehicleID, &userVehicle)
+	userVehicle, _, err := uc.vehicleAccess.authorize(ctx, uuidUserID, uintVehicleID, entity.EditorVehicleRole)
 	if err != nil {
-		logger.Error(err, "User vehicle not owned by user")
-		return nil, errors.ErrUserVehicleNotOwned
+		return nil, err
 	}
 
 	err = validation.ValidateServiceVisitCreateRequest(request)
@@ -67,10 +84,39 @@ func (uc *serviceVisitUseCase) CreateServiceVisit(ctx context.Context, userID, v
 		return nil, errors.ErrInvalidServiceVisitCreateRequest
 	}
 
+	// Visits logged by members belong to the vehicle's owner like the rest of its history
+	serviceVisit, odometerReading, err := uc.buildServiceVisit(ctx, userVehicle.UserID, uintVehicleID, request)
+	if err != nil {
+		return nil, err
+	}
+	err = uc.odometerTracker.validateReading(ctx, odometerReading)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.serviceVisitRepository.CreateServiceVisit(ctx, serviceVisit, odometerReading)
+	if err != nil {
+		logger.Error(err, "Failed to create service visit")
+		return nil, errors.ErrFailedToCreateServiceVisit
+	}
+
+	if err := uc.odometerTracker.syncCurrentMileage(ctx, userVehicle); err != nil {
+		logger.Error(err, "Failed to sync current mileage")
+	}
+
+	// Specs are saved as sent, the generation's recommendation only prefills the defaults
+	response := mapServiceVisitToResponse(serviceVisit)
+	response.Warnings = oilSpecWarnings(uc.getVehicleGeneration(ctx, userVehicle), serviceVisit)
+	return response, nil
+}
+
+// buildServiceVisit turns a validated create request into a service visit, with its oil change, filter and items,
+// and the odometer reading the visit records. Nothing is saved
+func (uc *serviceVisitUseCase) buildServiceVisit(ctx context.Context, uuidUserID uuid.UUID, uintVehicleID uint64, request dto.CreateServiceVisitRequest) (*entity.ServiceVisit, *entity.OdometerReading, error) {
 	serviceDate, err := time.Parse("2006-01-02", request.ServiceDate)
 	if err != nil {
 		logger.Error(err, "Failed to parse service date")
-		return nil, errors.ErrInvalidDate
+		return nil, nil, errors.ErrInvalidDate
 	}
 
 	serviceVisit := entity.ServiceVisit{
@@ -80,9 +126,28 @@ func (uc *serviceVisitUseCase) CreateServiceVisit(ctx context.Context, userID, v
 		ServiceDate:    serviceDate,
 		ServiceCenter:  request.ServiceCenter,
 		Notes:          request.Notes,
+		LabourCost:     entity.Rial(request.LabourCost),
+		PartsCost:      entity.Rial(request.PartsCost),
 	}
 	serviceVisit.ID = uuid.New()
 
+	if request.ServiceCenterID != nil && *request.ServiceCenterID != 0 {
+		err = uc.linkServiceCenter(ctx, &serviceVisit, *request.ServiceCenterID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// the visit's mileage is recorded as an odometer reading and must not go backwards
+	odometerReading := entity.OdometerReading{
+		UserID:         uuidUserID,
+		UserVehicleID:  uintVehicleID,
+		Mileage:        request.ServiceMileage,
+		ReadingDate:    serviceDate,
+		Source:         entity.ServiceVisitOdometerReading,
+		ServiceVisitID: &serviceVisit.ID,
+	}
+
 	if request.OilChange != nil {
 		serviceVisit.OilChange = entity.OilChange{
 			UserID:            uuidUserID,
@@ -96,18 +161,22 @@ func (uc *serviceVisitUseCase) CreateServiceVisit(ctx context.Context, userID, v
 			ChangeDate:        serviceDate,
 			OilCapacity:       request.OilChange.OilCapacity,
 			NextChangeMileage: request.OilChange.NextChangeMileage,
-			ServiceCenter:     request.ServiceCenter,
+			ServiceCenter:     serviceVisit.ServiceCenter,
 			Notes:             request.OilChange.Notes,
+			LabourCost:        entity.Rial(request.OilChange.LabourCost),
+			PartsCost:         entity.Rial(request.OilChange.PartsCost),
 		}
 
 		if request.OilChange.NextChangeDate != "" {
 			nextChangeDate, err := time.Parse("2006-01-02", request.OilChange.NextChangeDate)
 			if err != nil {
 				logger.Error(err, "Failed to parse oil change next change date")
-				return nil, errors.ErrInvalidDate
+				return nil, nil, errors.ErrInvalidDate
 			}
 			serviceVisit.OilChange.NextChangeDate = nextChangeDate
 		}
+		uc.maintenancePlanner.applyDefaultSchedule(ctx, uintVehicleID, "oil_change", serviceVisit.OilChange.ChangeMileage, serviceVisit.OilChange.ChangeDate,
+			&serviceVisit.OilChange.NextChangeMileage, &serviceVisit.OilChange.NextChangeDate)
 	}
 
 	if request.OilFilter != nil {
@@ -122,27 +191,56 @@ func (uc *serviceVisitUseCase) CreateServiceVisit(ctx context.Context, userID, v
 			ChangeMileage:     request.ServiceMileage,
 			ChangeDate:        serviceDate,
 			NextChangeMileage: request.OilFilter.NextChangeMileage,
-			ServiceCenter:     request.ServiceCenter,
+			ServiceCenter:     serviceVisit.ServiceCenter,
 			Notes:             request.OilFilter.Notes,
+			LabourCost:        entity.Rial(request.OilFilter.LabourCost),
+			PartsCost:         entity.Rial(request.OilFilter.PartsCost),
 		}
 
 		if request.OilFilter.NextChangeDate != "" {
 			nextChangeDate, err := time.Parse("2006-01-02", request.OilFilter.NextChangeDate)
 			if err != nil {
 				logger.Error(err, "Failed to parse oil filter next change date")
-				return nil, errors.ErrInvalidDate
+				return nil, nil, errors.ErrInvalidDate
 			}
 			serviceVisit.OilFilter.NextChangeDate = nextChangeDate
 		}
+		uc.maintenancePlanner.applyDefaultSchedule(ctx, uintVehicleID, "oil_filter", serviceVisit.OilFilter.ChangeMileage, serviceVisit.OilFilter.ChangeDate,
+			&serviceVisit.OilFilter.NextChangeMileage, &serviceVisit.OilFilter.NextChangeDate)
+	}
 
-		err = uc.serviceVisitRepository.CreateServiceVisit(ctx, &serviceVisit)
-		if err != nil {
-			logger.Error(err, "Failed to create service visit")
-			return nil, errors.ErrFailedToCreateServiceVisit
+	for _, item := range request.ServiceItems {
+		serviceItem := entity.ServiceItem{
+			UserID:            uuidUserID,
+			UserVehicleID:     uintVehicleID,
+			ServiceVisitID:    serviceVisit.ID,
+			ItemType:          entity.ParseServiceItemType(item.ItemType),
+			Name:              item.Name,
+			Brand:             item.Brand,
+			PartNumber:        item.PartNumber,
+			ChangeMileage:     request.ServiceMileage,
+			ChangeDate:        serviceDate,
+			NextChangeMileage: item.NextChangeMileage,
+			Notes:             item.Notes,
+			LabourCost:        entity.Rial(item.LabourCost),
+			PartsCost:         entity.Rial(item.PartsCost),
+		}
+
+		if item.NextChangeDate != "" {
+			nextChangeDate, err := time.Parse("2006-01-02", item.NextChangeDate)
+			if err != nil {
+				logger.Error(err, "Failed to parse service item next change date")
+				return nil, nil, errors.ErrInvalidDate
+			}
+			serviceItem.NextChangeDate = nextChangeDate
 		}
+		uc.maintenancePlanner.applyDefaultSchedule(ctx, uintVehicleID, serviceItem.ItemType.String(), serviceItem.ChangeMileage, serviceItem.ChangeDate,
+			&serviceItem.NextChangeMileage, &serviceItem.NextChangeDate)
+
+		serviceVisit.ServiceItems = append(serviceVisit.ServiceItems, serviceItem)
 	}
 
-	return uc.mapServiceVisitToResponse(&serviceVisit), nil
+	return &serviceVisit, &odometerReading, nil
 }
 
 func (uc *serviceVisitUseCase) GetServiceVisit(ctx context.Context, userID, vehicleID, visitID string) (*dto.ServiceVisitResponse, error) {
@@ -165,12 +263,12 @@ func (uc *serviceVisitUseCase) GetServiceVisit(ctx context.Context, userID, vehi
 		logger.Error(err, "Failed to get service visit")
 		return nil, errors.ErrFailedToGetServiceVisit
 	}
-	if serviceVisit.UserID != uuidUserID {
-		logger.Error(errors.ErrUserVehicleNotOwned, "User vehicle not owned by user")
-		return nil, errors.ErrUserVehicleNotOwned
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, serviceVisit.UserVehicleID, entity.ViewerVehicleRole)
+	if err != nil {
+		return nil, err
 	}
 
-	return uc.mapServiceVisitToResponse(&serviceVisit), nil
+	return mapServiceVisitToResponse(&serviceVisit), nil
 }
 
 func (uc *serviceVisitUseCase) ListServiceVisits(ctx context.Context, userID, vehicleID string) (*dto.ListServiceVisitsResponse, error) {
@@ -186,11 +284,9 @@ func (uc *serviceVisitUseCase) ListServiceVisits(ctx context.Context, userID, ve
 		return nil, errors.ErrInvalidUserVehicleID
 	}
 
-	userVehicle := entity.UserVehicle{}
-	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, uintUserVehicleID, entity.ViewerVehicleRole)
 	if err != nil {
-		logger.Error(err, "User vehicle not owned by user")
-		return nil, errors.ErrUserVehicleNotOwned
+		return nil, err
 	}
 
 	serviceVisits := []entity.ServiceVisit{}
@@ -202,7 +298,7 @@ func (uc *serviceVisitUseCase) ListServiceVisits(ctx context.Context, userID, ve
 
 	serviceVisitsResponse := []dto.ServiceVisitResponse{}
 	for _, serviceVisit := range serviceVisits {
-		serviceVisitsResponse = append(serviceVisitsResponse, *uc.mapServiceVisitToResponse(&serviceVisit))
+		serviceVisitsResponse = append(serviceVisitsResponse, *mapServiceVisitToResponse(&serviceVisit))
 	}
 
 	return &dto.ListServiceVisitsResponse{
@@ -231,9 +327,9 @@ func (uc *serviceVisitUseCase) UpdateServiceVisit(ctx context.Context, userID, v
 		logger.Error(err, "Failed to get service visit")
 		return nil, errors.ErrFailedToGetServiceVisit
 	}
-	if serviceVisit.UserID != uuidUserID {
-		logger.Error(errors.ErrUserVehicleNotOwned, "User vehicle not owned by user")
-		return nil, errors.ErrUserVehicleNotOwned
+	userVehicle, _, err := uc.vehicleAccess.authorize(ctx, uuidUserID, serviceVisit.UserVehicleID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
 	}
 
 	err = validation.ValidateServiceVisitUpdateRequest(request)
@@ -254,16 +350,58 @@ func (uc *serviceVisitUseCase) UpdateServiceVisit(ctx context.Context, userID, v
 		}
 		serviceVisit.ServiceDate = serviceDate
 	}
+
+	// Keep the visit's odometer reading in step with its mileage and date
+	var odometerReading *entity.OdometerReading
+	if request.ServiceMileage != nil || request.ServiceDate != nil {
+		odometerReading = &entity.OdometerReading{}
+		err = uc.odometerReadingRepository.GetServiceVisitOdometerReading(ctx, serviceVisit.ID, odometerReading)
+		if err != nil {
+			logger.Error(err, "Failed to get service visit odometer reading")
+			return nil, errors.ErrFailedToGetOdometerReading
+		}
+		if odometerReading.ID == 0 {
+			odometerReading.UserID = serviceVisit.UserID
+			odometerReading.UserVehicleID = serviceVisit.UserVehicleID
+			odometerReading.Source = entity.ServiceVisitOdometerReading
+			odometerReading.ServiceVisitID = &serviceVisit.ID
+		}
+		odometerReading.Mileage = serviceVisit.ServiceMileage
+		odometerReading.ReadingDate = serviceVisit.ServiceDate
+		err = uc.odometerTracker.validateReading(ctx, odometerReading)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if request.ServiceCenter != nil {
 		serviceVisit.ServiceCenter = *request.ServiceCenter
 	}
+	if request.ServiceCenterID != nil {
+		if *request.ServiceCenterID == 0 {
+			serviceVisit.ServiceCenterID = nil
+		} else {
+			if request.ServiceCenter == nil {
+				serviceVisit.ServiceCenter = ""
+			}
+			err = uc.linkServiceCenter(ctx, &serviceVisit, *request.ServiceCenterID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 	if request.Notes != nil {
 		serviceVisit.Notes = *request.Notes
 	}
+	if request.LabourCost != nil {
+		serviceVisit.LabourCost = entity.Rial(*request.LabourCost)
+	}
+	if request.PartsCost != nil {
+		serviceVisit.PartsCost = entity.Rial(*request.PartsCost)
+	}
 
 	// Update oil change if provided
 	if request.OilChange != nil {
-		oilChange := entity.OilChange{}
+		oilChange := serviceVisit.OilChange
 
 		// Update oil change fields if provided
 		if request.OilChange.OilName != nil {
@@ -295,13 +433,19 @@ func (uc *serviceVisitUseCase) UpdateServiceVisit(ctx context.Context, userID, v
 		if request.OilChange.Notes != nil {
 			oilChange.Notes = *request.OilChange.Notes
 		}
+		if request.OilChange.LabourCost != nil {
+			oilChange.LabourCost = entity.Rial(*request.OilChange.LabourCost)
+		}
+		if request.OilChange.PartsCost != nil {
+			oilChange.PartsCost = entity.Rial(*request.OilChange.PartsCost)
+		}
 
 		serviceVisit.OilChange = oilChange
 	}
 
 	// Update oil filter if provided
 	if request.OilFilter != nil {
-		oilFilter := entity.OilFilter{}
+		oilFilter := serviceVisit.OilFilter
 
 		// Update oil filter fields if provided
 		if request.OilFilter.FilterName != nil {
@@ -330,17 +474,31 @@ func (uc *serviceVisitUseCase) UpdateServiceVisit(ctx context.Context, userID, v
 		if request.OilFilter.Notes != nil {
 			oilFilter.Notes = *request.OilFilter.Notes
 		}
+		if request.OilFilter.LabourCost != nil {
+			oilFilter.LabourCost = entity.Rial(*request.OilFilter.LabourCost)
+		}
+		if request.OilFilter.PartsCost != nil {
+			oilFilter.PartsCost = entity.Rial(*request.OilFilter.PartsCost)
+		}
 
 		serviceVisit.OilFilter = oilFilter
 	}
 
-	err = uc.serviceVisitRepository.UpdateServiceVisit(ctx, &serviceVisit)
+	err = uc.serviceVisitRepository.UpdateServiceVisit(ctx, &serviceVisit, odometerReading)
 	if err != nil {
 		logger.Error(err, "Failed to update service visit")
 		return nil, errors.ErrFailedToUpdateServiceVisit
 	}
 
-	return uc.mapServiceVisitToResponse(&serviceVisit), nil
+	if odometerReading != nil {
+		if err := uc.odometerTracker.syncCurrentMileage(ctx, userVehicle); err != nil {
+			logger.Error(err, "Failed to sync current mileage")
+		}
+	}
+
+	response := mapServiceVisitToResponse(&serviceVisit)
+	response.Warnings = oilSpecWarnings(uc.getVehicleGeneration(ctx, userVehicle), &serviceVisit)
+	return response, nil
 }
 
 func (uc *serviceVisitUseCase) DeleteServiceVisit(ctx context.Context, userID, vehicleID, visitID string) error {
@@ -363,9 +521,9 @@ func (uc *serviceVisitUseCase) DeleteServiceVisit(ctx context.Context, userID, v
 		logger.Error(err, "Failed to get service visit")
 		return errors.ErrFailedToGetServiceVisit
 	}
-	if serviceVisit.UserID != uuidUserID {
-		logger.Error(errors.ErrUserVehicleNotOwned, "User vehicle not owned by user")
-		return errors.ErrUserVehicleNotOwned
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, serviceVisit.UserVehicleID, entity.EditorVehicleRole)
+	if err != nil {
+		return err
 	}
 
 	err = uc.serviceVisitRepository.DeleteServiceVisit(ctx, &serviceVisit)
@@ -374,6 +532,9 @@ func (uc *serviceVisitUseCase) DeleteServiceVisit(ctx context.Context, userID, v
 		return errors.ErrFailedToDeleteServiceVisit
 	}
 
+	uc.removeServiceVisitOdometerReading(ctx, &serviceVisit)
+	uc.removeServiceVisitAttachments(ctx, &serviceVisit)
+
 	return nil
 }
 
@@ -389,11 +550,9 @@ func (uc *serviceVisitUseCase) GetLastServiceVisit(ctx context.Context, userID,
 		return nil, errors.ErrInvalidUserVehicleID
 	}
 
-	userVehicle := entity.UserVehicle{}
-	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	_, _, err = uc.vehicleAccess.authorize(ctx, uuidUserID, uintUserVehicleID, entity.ViewerVehicleRole)
 	if err != nil {
-		logger.Error(err, "User vehicle not owned by user")
-		return nil, errors.ErrUserVehicleNotOwned
+		return nil, err
 	}
 
 	serviceVisit := entity.ServiceVisit{}
@@ -406,17 +565,78 @@ func (uc *serviceVisitUseCase) GetLastServiceVisit(ctx context.Context, userID,
 		return nil, errors.ErrFailedToGetServiceVisit
 	}
 
-	return uc.mapServiceVisitToResponse(&serviceVisit), nil
+	return mapServiceVisitToResponse(&serviceVisit), nil
 }
 
-func (uc *serviceVisitUseCase) mapServiceVisitToResponse(serviceVisit *entity.ServiceVisit) *dto.ServiceVisitResponse {
+// removeServiceVisitOdometerReading deletes the reading recorded by a deleted visit and resyncs the current mileage
+func (uc *serviceVisitUseCase) removeServiceVisitOdometerReading(ctx context.Context, serviceVisit *entity.ServiceVisit) {
+	odometerReading := entity.OdometerReading{}
+	err := uc.odometerReadingRepository.GetServiceVisitOdometerReading(ctx, serviceVisit.ID, &odometerReading)
+	if err != nil {
+		logger.Error(err, "Failed to get service visit odometer reading")
+		return
+	}
+	if odometerReading.ID == 0 {
+		return
+	}
+	err = uc.odometerReadingRepository.DeleteOdometerReading(ctx, &odometerReading)
+	if err != nil {
+		logger.Error(err, "Failed to delete service visit odometer reading")
+		return
+	}
+
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleRepository.GetUserVehicle(ctx, serviceVisit.UserID, serviceVisit.UserVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "Failed to get user vehicle")
+		return
+	}
+	if err := uc.odometerTracker.syncCurrentMileage(ctx, &userVehicle); err != nil {
+		logger.Error(err, "Failed to sync current mileage")
+	}
+}
+
+// linkServiceCenter points the visit at a directory entry, using its name when the visit has no free-text center
+func (uc *serviceVisitUseCase) linkServiceCenter(ctx context.Context, serviceVisit *entity.ServiceVisit, serviceCenterID uint64) error {
+	serviceCenter := entity.ServiceCenter{}
+	err := uc.serviceCenterRepository.GetServiceCenter(ctx, serviceCenterID, &serviceCenter)
+	if err != nil {
+		logger.Error(err, "Failed to get service center")
+		return errors.ErrFailedToGetServiceCenter
+	}
+	if serviceCenter.ID == 0 {
+		return errors.ErrServiceCenterNotFound
+	}
+
+	serviceVisit.ServiceCenterID = &serviceCenter.ID
+	if serviceVisit.ServiceCenter == "" {
+		serviceVisit.ServiceCenter = serviceCenter.Name
+	}
+	return nil
+}
+
+// removeServiceVisitAttachments deletes the stored files of a deleted visit along with their rows
+func (uc *serviceVisitUseCase) removeServiceVisitAttachments(ctx context.Context, serviceVisit *entity.ServiceVisit) {
+	for _, attachment := range serviceVisit.Attachments {
+		if err := uc.storage.Delete(ctx, attachment.StorageKey); err != nil {
+			logger.Error(err, "Failed to delete attachment file "+attachment.StorageKey)
+		}
+	}
+	if err := uc.attachmentRepository.DeleteServiceVisitAttachments(ctx, serviceVisit.ID); err != nil {
+		logger.Error(err, "Failed to delete service visit attachments")
+	}
+}
+
+func mapServiceVisitToResponse(serviceVisit *entity.ServiceVisit) *dto.ServiceVisitResponse {
 	response := &dto.ServiceVisitResponse{
-		ID:             serviceVisit.ID,
-		UserVehicleID:  serviceVisit.UserVehicleID,
-		ServiceMileage: serviceVisit.ServiceMileage,
-		ServiceDate:    serviceVisit.ServiceDate.Format("2006-01-02"),
-		ServiceCenter:  serviceVisit.ServiceCenter,
-		Notes:          serviceVisit.Notes,
+		ID:              serviceVisit.ID,
+		UserVehicleID:   serviceVisit.UserVehicleID,
+		ServiceMileage:  serviceVisit.ServiceMileage,
+		ServiceDate:     serviceVisit.ServiceDate.Format("2006-01-02"),
+		ServiceCenter:   serviceVisit.ServiceCenter,
+		ServiceCenterID: serviceVisit.ServiceCenterID,
+		Notes:           serviceVisit.Notes,
+		Cost:            mapCostToResponse(serviceVisit.TotalLabourCost(), serviceVisit.TotalPartsCost()),
 	}
 
 	// Map oil change if exists
@@ -431,6 +651,7 @@ func (uc *serviceVisitUseCase) mapServiceVisitToResponse(serviceVisit *entity.Se
 			NextChangeMileage: serviceVisit.OilChange.NextChangeMileage,
 			NextChangeDate:    serviceVisit.OilChange.NextChangeDate.Format("2006-01-02"),
 			Notes:             serviceVisit.OilChange.Notes,
+			Cost:              mapCostToResponse(serviceVisit.OilChange.LabourCost, serviceVisit.OilChange.PartsCost),
 		}
 	}
 
@@ -445,8 +666,19 @@ func (uc *serviceVisitUseCase) mapServiceVisitToResponse(serviceVisit *entity.Se
 			NextChangeMileage: serviceVisit.OilFilter.NextChangeMileage,
 			NextChangeDate:    serviceVisit.OilFilter.NextChangeDate.Format("2006-01-02"),
 			Notes:             serviceVisit.OilFilter.Notes,
+			Cost:              mapCostToResponse(serviceVisit.OilFilter.LabourCost, serviceVisit.OilFilter.PartsCost),
 		}
 	}
 
+	// Map other service items
+	for _, serviceItem := range serviceVisit.ServiceItems {
+		response.ServiceItems = append(response.ServiceItems, *mapServiceItemToResponse(&serviceItem))
+	}
+
+	// Map uploaded invoices and receipts
+	for _, attachment := range serviceVisit.Attachments {
+		response.Attachments = append(response.Attachments, *mapAttachmentToResponse(&attachment))
+	}
+
 	return response
 }
@@ -0,0 +1,572 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/config"
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+)
+
+type TireUseCase interface {
+	// Tire sets of a vehicle
+	CreateTireSet(ctx context.Context, userID, vehicleID string, request dto.CreateTireSetRequest) (*dto.TireSetDetailResponse, error)
+	GetTireSet(ctx context.Context, userID, vehicleID, tireSetID string) (*dto.TireSetDetailResponse, error)
+	ListTireSets(ctx context.Context, userID, vehicleID string) (*dto.ListTireSetsResponse, error)
+	UpdateTireSet(ctx context.Context, userID, vehicleID, tireSetID string, request dto.UpdateTireSetRequest) (*dto.TireSetDetailResponse, error)
+	DeleteTireSet(ctx context.Context, userID, vehicleID, tireSetID string) error
+
+	// Seasonal swaps
+	MountTireSet(ctx context.Context, userID, vehicleID, tireSetID string, request dto.TireMountRequest) (*dto.TireSetDetailResponse, error)
+	UnmountTireSet(ctx context.Context, userID, vehicleID, tireSetID string, request dto.TireMountRequest) (*dto.TireSetDetailResponse, error)
+
+	// Rotations and tread depths of a set
+	CreateTireRotation(ctx context.Context, userID, vehicleID, tireSetID string, request dto.CreateTireRotationRequest) (*dto.TireRotationResponse, error)
+	DeleteTireRotation(ctx context.Context, userID, vehicleID, tireSetID, rotationID string) error
+	CreateTreadDepth(ctx context.Context, userID, vehicleID, tireSetID string, request dto.CreateTreadDepthRequest) (*dto.TreadDepthResponse, error)
+	DeleteTreadDepth(ctx context.Context, userID, vehicleID, tireSetID, measurementID string) error
+}
+
+type tireUseCase struct {
+	tireRepository    repository.TireRepository
+	vehicleRepository repository.VehicleRepository
+	vehicleAccess     *vehicleAccess
+	thresholds        entity.TireThresholds
+}
+
+func NewTireUseCase() TireUseCase {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		logger.Error(err, "Failed to get config")
+		return nil
+	}
+	vehicleRepository := repository.NewVehicleRepository()
+	return &tireUseCase{
+		tireRepository:    repository.NewTireRepository(),
+		vehicleRepository: vehicleRepository,
+		vehicleAccess:     newVehicleAccess(vehicleRepository, repository.NewVehicleMembershipRepository()),
+		thresholds: entity.TireThresholds{
+			MinTreadDepthMM: cfg.Tire.MinTreadDepthMM,
+			MaxAgeYears:     cfg.Tire.MaxAgeYears,
+		},
+	}
+}
+
+func (uc *tireUseCase) CreateTireSet(ctx context.Context, userID, vehicleID string, request dto.CreateTireSetRequest) (*dto.TireSetDetailResponse, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateTireSetCreateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate tire set create request")
+		return nil, errors.ErrInvalidTireSetCreateRequest
+	}
+
+	purchaseDate, err := parseDateOrToday(request.PurchaseDate)
+	if err != nil {
+		logger.Error(err, "Failed to parse purchase date")
+		return nil, errors.ErrInvalidDate
+	}
+
+	tireSet := entity.TireSet{
+		UserID:          userVehicle.UserID,
+		UserVehicleID:   userVehicle.ID,
+		Brand:           request.Brand,
+		Model:           request.Model,
+		Size:            request.Size,
+		Season:          entity.ParseTireSeason(request.Season),
+		DOTCode:         request.DOTCode,
+		PurchaseDate:    purchaseDate,
+		PurchaseMileage: request.PurchaseMileage,
+		Notes:           request.Notes,
+	}
+
+	var mount *entity.TireMount
+	if request.Mounted {
+		mount = &entity.TireMount{
+			MountedAt:      purchaseDate,
+			MountedMileage: request.PurchaseMileage,
+		}
+		err = uc.checkSwapMileage(ctx, userVehicle.ID, request.PurchaseMileage)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = uc.tireRepository.CreateTireSet(ctx, &tireSet, mount)
+	if err != nil {
+		logger.Error(err, "Failed to create tire set")
+		return nil, errors.ErrFailedToCreateTireSet
+	}
+
+	return uc.getTireSetDetail(ctx, userVehicle, tireSet.ID)
+}
+
+func (uc *tireUseCase) GetTireSet(ctx context.Context, userID, vehicleID, tireSetID string) (*dto.TireSetDetailResponse, error) {
+	userVehicle, tireSet, err := uc.getTireSetOfVehicle(ctx, userID, vehicleID, tireSetID, entity.ViewerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+	return uc.mapTireSetToDetailResponse(userVehicle, tireSet), nil
+}
+
+func (uc *tireUseCase) ListTireSets(ctx context.Context, userID, vehicleID string) (*dto.ListTireSetsResponse, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.ViewerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	tireSets := []entity.TireSet{}
+	err = uc.tireRepository.ListTireSets(ctx, userVehicle.ID, &tireSets)
+	if err != nil {
+		logger.Error(err, "Failed to list tire sets")
+		return nil, errors.ErrFailedToListTireSets
+	}
+
+	response := &dto.ListTireSetsResponse{
+		TireSets:       []dto.TireSetResponse{},
+		SuggestedSizes: []string{},
+	}
+	for _, tireSet := range tireSets {
+		response.TireSets = append(response.TireSets, *uc.mapTireSetToResponse(userVehicle, &tireSet))
+	}
+
+	// Sizes listed for the generation are only suggestions, a missing generation leaves them empty
+	generation := entity.VehicleGeneration{}
+	generation.ID = userVehicle.GenerationID
+	err = uc.vehicleRepository.GetGeneration(ctx, &generation)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle generation")
+	} else {
+		response.SuggestedSizes = generation.TireSizeList()
+	}
+
+	return response, nil
+}
+
+func (uc *tireUseCase) UpdateTireSet(ctx context.Context, userID, vehicleID, tireSetID string, request dto.UpdateTireSetRequest) (*dto.TireSetDetailResponse, error) {
+	err := validation.ValidateTireSetUpdateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate tire set update request")
+		return nil, errors.ErrInvalidTireSetUpdateRequest
+	}
+
+	userVehicle, tireSet, err := uc.getTireSetOfVehicle(ctx, userID, vehicleID, tireSetID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Brand != nil {
+		tireSet.Brand = *request.Brand
+	}
+	if request.Model != nil {
+		tireSet.Model = *request.Model
+	}
+	if request.Size != nil {
+		tireSet.Size = *request.Size
+	}
+	if request.Season != nil {
+		tireSet.Season = entity.ParseTireSeason(*request.Season)
+	}
+	if request.DOTCode != nil {
+		tireSet.DOTCode = *request.DOTCode
+	}
+	if request.PurchaseDate != nil {
+		purchaseDate, err := time.Parse("2006-01-02", *request.PurchaseDate)
+		if err != nil {
+			logger.Error(err, "Failed to parse purchase date")
+			return nil, errors.ErrInvalidDate
+		}
+		tireSet.PurchaseDate = purchaseDate
+	}
+	if request.PurchaseMileage != nil {
+		tireSet.PurchaseMileage = *request.PurchaseMileage
+	}
+	if request.Notes != nil {
+		tireSet.Notes = *request.Notes
+	}
+
+	err = uc.tireRepository.UpdateTireSet(ctx, tireSet)
+	if err != nil {
+		logger.Error(err, "Failed to update tire set")
+		return nil, errors.ErrFailedToUpdateTireSet
+	}
+
+	return uc.mapTireSetToDetailResponse(userVehicle, tireSet), nil
+}
+
+func (uc *tireUseCase) DeleteTireSet(ctx context.Context, userID, vehicleID, tireSetID string) error {
+	_, tireSet, err := uc.getTireSetOfVehicle(ctx, userID, vehicleID, tireSetID, entity.EditorVehicleRole)
+	if err != nil {
+		return err
+	}
+
+	err = uc.tireRepository.DeleteTireSet(ctx, tireSet)
+	if err != nil {
+		logger.Error(err, "Failed to delete tire set")
+		return errors.ErrFailedToDeleteTireSet
+	}
+	return nil
+}
+
+func (uc *tireUseCase) MountTireSet(ctx context.Context, userID, vehicleID, tireSetID string, request dto.TireMountRequest) (*dto.TireSetDetailResponse, error) {
+	err := validation.ValidateTireMountRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate tire mount request")
+		return nil, errors.ErrInvalidTireMountRequest
+	}
+
+	userVehicle, tireSet, err := uc.getTireSetOfVehicle(ctx, userID, vehicleID, tireSetID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+	if tireSet.IsMounted {
+		return nil, errors.ErrTireSetAlreadyMounted
+	}
+
+	mountedAt, err := parseDateOrToday(request.Date)
+	if err != nil {
+		logger.Error(err, "Failed to parse mount date")
+		return nil, errors.ErrInvalidDate
+	}
+	// The set can't go back on before it last came off
+	for _, mount := range tireSet.Mounts {
+		if mount.RemovedMileage != nil && *mount.RemovedMileage > request.Mileage {
+			return nil, errors.ErrTireMileageBeforeMount
+		}
+	}
+	err = uc.checkSwapMileage(ctx, userVehicle.ID, request.Mileage)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.tireRepository.MountTireSet(ctx, tireSet, &entity.TireMount{
+		MountedAt:      mountedAt,
+		MountedMileage: request.Mileage,
+	})
+	if err != nil {
+		logger.Error(err, "Failed to mount tire set")
+		return nil, errors.ErrFailedToMountTireSet
+	}
+
+	return uc.getTireSetDetail(ctx, userVehicle, tireSet.ID)
+}
+
+func (uc *tireUseCase) UnmountTireSet(ctx context.Context, userID, vehicleID, tireSetID string, request dto.TireMountRequest) (*dto.TireSetDetailResponse, error) {
+	err := validation.ValidateTireMountRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate tire mount request")
+		return nil, errors.ErrInvalidTireMountRequest
+	}
+
+	userVehicle, tireSet, err := uc.getTireSetOfVehicle(ctx, userID, vehicleID, tireSetID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+	mount := tireSet.OpenMount()
+	if !tireSet.IsMounted || mount == nil {
+		return nil, errors.ErrTireSetNotMounted
+	}
+	if request.Mileage < mount.MountedMileage {
+		return nil, errors.ErrTireMileageBeforeMount
+	}
+
+	removedAt, err := parseDateOrToday(request.Date)
+	if err != nil {
+		logger.Error(err, "Failed to parse unmount date")
+		return nil, errors.ErrInvalidDate
+	}
+	mount.RemovedAt = &removedAt
+	mount.RemovedMileage = &request.Mileage
+
+	err = uc.tireRepository.UnmountTireSet(ctx, tireSet, mount)
+	if err != nil {
+		logger.Error(err, "Failed to unmount tire set")
+		return nil, errors.ErrFailedToUnmountTireSet
+	}
+
+	return uc.getTireSetDetail(ctx, userVehicle, tireSet.ID)
+}
+
+func (uc *tireUseCase) CreateTireRotation(ctx context.Context, userID, vehicleID, tireSetID string, request dto.CreateTireRotationRequest) (*dto.TireRotationResponse, error) {
+	err := validation.ValidateTireRotationRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate tire rotation request")
+		return nil, errors.ErrInvalidTireRotationRequest
+	}
+
+	_, tireSet, err := uc.getTireSetOfVehicle(ctx, userID, vehicleID, tireSetID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	rotationDate, err := parseDateOrToday(request.RotationDate)
+	if err != nil {
+		logger.Error(err, "Failed to parse rotation date")
+		return nil, errors.ErrInvalidDate
+	}
+
+	rotation := entity.TireRotation{
+		TireSetID:    tireSet.ID,
+		RotationDate: rotationDate,
+		Mileage:      request.Mileage,
+		Pattern:      request.Pattern,
+		Notes:        request.Notes,
+	}
+	err = uc.tireRepository.CreateTireRotation(ctx, &rotation)
+	if err != nil {
+		logger.Error(err, "Failed to create tire rotation")
+		return nil, errors.ErrFailedToCreateTireRotation
+	}
+
+	return mapTireRotationToResponse(&rotation), nil
+}
+
+func (uc *tireUseCase) DeleteTireRotation(ctx context.Context, userID, vehicleID, tireSetID, rotationID string) error {
+	_, tireSet, err := uc.getTireSetOfVehicle(ctx, userID, vehicleID, tireSetID, entity.EditorVehicleRole)
+	if err != nil {
+		return err
+	}
+	uintRotationID, err := strconv.ParseUint(rotationID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse tire rotation id")
+		return errors.ErrInvalidTireRotationID
+	}
+
+	rotation := entity.TireRotation{}
+	err = uc.tireRepository.GetTireRotation(ctx, uintRotationID, &rotation)
+	if err != nil {
+		logger.Error(err, "Failed to get tire rotation")
+		return errors.ErrFailedToDeleteTireRotation
+	}
+	if rotation.ID == 0 || rotation.TireSetID != tireSet.ID {
+		return errors.ErrTireRotationNotFound
+	}
+
+	err = uc.tireRepository.DeleteTireRotation(ctx, &rotation)
+	if err != nil {
+		logger.Error(err, "Failed to delete tire rotation")
+		return errors.ErrFailedToDeleteTireRotation
+	}
+	return nil
+}
+
+func (uc *tireUseCase) CreateTreadDepth(ctx context.Context, userID, vehicleID, tireSetID string, request dto.CreateTreadDepthRequest) (*dto.TreadDepthResponse, error) {
+	err := validation.ValidateTreadDepthRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate tread depth request")
+		return nil, errors.ErrInvalidTreadDepthRequest
+	}
+
+	_, tireSet, err := uc.getTireSetOfVehicle(ctx, userID, vehicleID, tireSetID, entity.EditorVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	measuredAt, err := parseDateOrToday(request.MeasuredAt)
+	if err != nil {
+		logger.Error(err, "Failed to parse measurement date")
+		return nil, errors.ErrInvalidDate
+	}
+
+	measurement := entity.TreadDepthMeasurement{
+		TireSetID:  tireSet.ID,
+		MeasuredAt: measuredAt,
+		Mileage:    request.Mileage,
+		FrontLeft:  request.FrontLeft,
+		FrontRight: request.FrontRight,
+		RearLeft:   request.RearLeft,
+		RearRight:  request.RearRight,
+	}
+	err = uc.tireRepository.CreateTreadDepth(ctx, &measurement)
+	if err != nil {
+		logger.Error(err, "Failed to create tread depth measurement")
+		return nil, errors.ErrFailedToCreateTreadDepth
+	}
+
+	return mapTreadDepthToResponse(&measurement), nil
+}
+
+func (uc *tireUseCase) DeleteTreadDepth(ctx context.Context, userID, vehicleID, tireSetID, measurementID string) error {
+	_, tireSet, err := uc.getTireSetOfVehicle(ctx, userID, vehicleID, tireSetID, entity.EditorVehicleRole)
+	if err != nil {
+		return err
+	}
+	uintMeasurementID, err := strconv.ParseUint(measurementID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse tread depth id")
+		return errors.ErrInvalidTreadDepthID
+	}
+
+	measurement := entity.TreadDepthMeasurement{}
+	err = uc.tireRepository.GetTreadDepth(ctx, uintMeasurementID, &measurement)
+	if err != nil {
+		logger.Error(err, "Failed to get tread depth measurement")
+		return errors.ErrFailedToDeleteTreadDepth
+	}
+	if measurement.ID == 0 || measurement.TireSetID != tireSet.ID {
+		return errors.ErrTreadDepthNotFound
+	}
+
+	err = uc.tireRepository.DeleteTreadDepth(ctx, &measurement)
+	if err != nil {
+		logger.Error(err, "Failed to delete tread depth measurement")
+		return errors.ErrFailedToDeleteTreadDepth
+	}
+	return nil
+}
+
+// getTireSetOfVehicle checks the user's role on the vehicle and loads a tire set of it with its history
+func (uc *tireUseCase) getTireSetOfVehicle(ctx context.Context, userID, vehicleID, tireSetID string, required entity.VehicleRole) (*entity.UserVehicle, *entity.TireSet, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, required)
+	if err != nil {
+		return nil, nil, err
+	}
+	uintTireSetID, err := strconv.ParseUint(tireSetID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse tire set id")
+		return nil, nil, errors.ErrInvalidTireSetID
+	}
+
+	tireSet := entity.TireSet{}
+	err = uc.tireRepository.GetTireSet(ctx, uintTireSetID, &tireSet)
+	if err != nil {
+		logger.Error(err, "Failed to get tire set")
+		return nil, nil, errors.ErrFailedToGetTireSet
+	}
+	if tireSet.UserVehicleID != userVehicle.ID {
+		logger.Error(errors.ErrTireSetNotOwned, "Tire set does not belong to user vehicle")
+		return nil, nil, errors.ErrTireSetNotOwned
+	}
+	return userVehicle, &tireSet, nil
+}
+
+// getTireSetDetail reloads a tire set after a change so the response carries its whole history
+func (uc *tireUseCase) getTireSetDetail(ctx context.Context, userVehicle *entity.UserVehicle, tireSetID uint64) (*dto.TireSetDetailResponse, error) {
+	tireSet := entity.TireSet{}
+	err := uc.tireRepository.GetTireSet(ctx, tireSetID, &tireSet)
+	if err != nil {
+		logger.Error(err, "Failed to get tire set")
+		return nil, errors.ErrFailedToGetTireSet
+	}
+	return uc.mapTireSetToDetailResponse(userVehicle, &tireSet), nil
+}
+
+// checkSwapMileage rejects a swap below the mileage the set currently on the vehicle was mounted at
+func (uc *tireUseCase) checkSwapMileage(ctx context.Context, userVehicleID uint64, mileage uint) error {
+	tireSets := []entity.TireSet{}
+	err := uc.tireRepository.ListTireSets(ctx, userVehicleID, &tireSets)
+	if err != nil {
+		logger.Error(err, "Failed to list tire sets")
+		return errors.ErrFailedToMountTireSet
+	}
+	for _, tireSet := range tireSets {
+		if mount := tireSet.OpenMount(); mount != nil && mileage < mount.MountedMileage {
+			return errors.ErrTireMileageBeforeMount
+		}
+	}
+	return nil
+}
+
+// parseDateOrToday parses a yyyy-mm-dd date, an empty one is today
+func parseDateOrToday(date string) (time.Time, error) {
+	if date == "" {
+		return time.Now().Truncate(24 * time.Hour), nil
+	}
+	return time.Parse("2006-01-02", date)
+}
+
+// currentMileage is the odometer reading kilometres driven on a mounted set are counted up to
+func currentMileage(userVehicle *entity.UserVehicle) uint {
+	if userVehicle.CurrentMileage < 0 {
+		return 0
+	}
+	return uint(userVehicle.CurrentMileage)
+}
+
+func (uc *tireUseCase) mapTireSetToResponse(userVehicle *entity.UserVehicle, tireSet *entity.TireSet) *dto.TireSetResponse {
+	replaceReasons := tireSet.ReplaceReasons(time.Now(), uc.thresholds)
+	response := &dto.TireSetResponse{
+		ID:              tireSet.ID,
+		UserVehicleID:   tireSet.UserVehicleID,
+		Brand:           tireSet.Brand,
+		Model:           tireSet.Model,
+		Size:            tireSet.Size,
+		Season:          tireSet.Season.String(),
+		DOTCode:         tireSet.DOTCode,
+		PurchaseDate:    tireSet.PurchaseDate.Format("2006-01-02"),
+		PurchaseMileage: tireSet.PurchaseMileage,
+		IsMounted:       tireSet.IsMounted,
+		DistanceDriven:  tireSet.DistanceDriven(currentMileage(userVehicle)),
+		ReplaceSoon:     len(replaceReasons) > 0,
+		ReplaceReasons:  replaceReasons,
+		Notes:           tireSet.Notes,
+	}
+	if manufactured, ok := tireSet.ManufactureDate(); ok {
+		response.ManufactureDate = manufactured.Format("2006-01-02")
+	}
+	if latest := tireSet.LatestTreadDepth(); latest != nil {
+		response.LatestTreadDepth = mapTreadDepthToResponse(latest)
+	}
+	return response
+}
+
+func (uc *tireUseCase) mapTireSetToDetailResponse(userVehicle *entity.UserVehicle, tireSet *entity.TireSet) *dto.TireSetDetailResponse {
+	response := &dto.TireSetDetailResponse{
+		TireSetResponse: *uc.mapTireSetToResponse(userVehicle, tireSet),
+		Mounts:          []dto.TireMountResponse{},
+		Rotations:       []dto.TireRotationResponse{},
+		TreadDepths:     []dto.TreadDepthResponse{},
+	}
+	for _, mount := range tireSet.Mounts {
+		mountResponse := dto.TireMountResponse{
+			ID:             mount.ID,
+			MountedAt:      mount.MountedAt.Format("2006-01-02"),
+			MountedMileage: mount.MountedMileage,
+			RemovedMileage: mount.RemovedMileage,
+			Distance:       mount.Distance(currentMileage(userVehicle)),
+		}
+		if mount.RemovedAt != nil {
+			mountResponse.RemovedAt = mount.RemovedAt.Format("2006-01-02")
+		}
+		response.Mounts = append(response.Mounts, mountResponse)
+	}
+	for _, rotation := range tireSet.Rotations {
+		response.Rotations = append(response.Rotations, *mapTireRotationToResponse(&rotation))
+	}
+	for _, measurement := range tireSet.TreadDepths {
+		response.TreadDepths = append(response.TreadDepths, *mapTreadDepthToResponse(&measurement))
+	}
+	return response
+}
+
+func mapTireRotationToResponse(rotation *entity.TireRotation) *dto.TireRotationResponse {
+	return &dto.TireRotationResponse{
+		ID:           rotation.ID,
+		RotationDate: rotation.RotationDate.Format("2006-01-02"),
+		Mileage:      rotation.Mileage,
+		Pattern:      rotation.Pattern,
+		Notes:        rotation.Notes,
+	}
+}
+
+func mapTreadDepthToResponse(measurement *entity.TreadDepthMeasurement) *dto.TreadDepthResponse {
+	return &dto.TreadDepthResponse{
+		ID:         measurement.ID,
+		MeasuredAt: measurement.MeasuredAt.Format("2006-01-02"),
+		Mileage:    measurement.Mileage,
+		FrontLeft:  measurement.FrontLeft,
+		FrontRight: measurement.FrontRight,
+		RearLeft:   measurement.RearLeft,
+		RearRight:  measurement.RearRight,
+		Minimum:    measurement.Minimum(),
+	}
+}
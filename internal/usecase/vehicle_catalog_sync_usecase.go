@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+)
+
+// vehicleCatalogRows are the rows of the catalog tree by ID. Rows under a deleted parent are not in the tree,
+// so they are missing here too
+type vehicleCatalogRows struct {
+	types       map[uint64]entity.VehicleType
+	brands      map[uint64]entity.VehicleBrand
+	models      map[uint64]entity.VehicleModel
+	generations map[uint64]entity.VehicleGeneration
+}
+
+func newVehicleCatalogRows(vehicleTypes []entity.VehicleType) vehicleCatalogRows {
+	rows := vehicleCatalogRows{
+		types:       map[uint64]entity.VehicleType{},
+		brands:      map[uint64]entity.VehicleBrand{},
+		models:      map[uint64]entity.VehicleModel{},
+		generations: map[uint64]entity.VehicleGeneration{},
+	}
+	for _, vehicleType := range vehicleTypes {
+		rows.types[vehicleType.ID] = vehicleType
+		for _, brand := range vehicleType.VehicleBrands {
+			rows.brands[brand.ID] = brand
+			for _, model := range brand.VehicleModels {
+				rows.models[model.ID] = model
+				for _, generation := range model.VehicleGenerations {
+					rows.generations[generation.ID] = generation
+				}
+			}
+		}
+	}
+	return rows
+}
+
+func (uc *vehicleUseCase) GetVehicleCatalogVersion(ctx context.Context) (uint64, error) {
+	version, err := uc.catalogRepository.GetCatalogVersion(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle catalog version")
+		return 0, errors.ErrFailedToGetVehicleCatalogVersion
+	}
+	return version, nil
+}
+
+// ListVehicleCatalogChanges returns the types, brands, models and generations changed after the client's version.
+// Rows still in the catalog are sent whole and the others as tombstones, whether they were deleted themselves or
+// with a parent. A client that has never synced, or is ahead of the catalog, gets the whole catalog instead
+func (uc *vehicleUseCase) ListVehicleCatalogChanges(ctx context.Context, request dto.ListVehicleCatalogChangesRequest) (*dto.ListVehicleCatalogChangesResponse, error) {
+	vehicleTypes, version, err := uc.getVehicleHierarchy(ctx)
+	if err != nil {
+		return nil, errors.ErrFailedToListVehicleCatalogChanges
+	}
+
+	response := &dto.ListVehicleCatalogChangesResponse{
+		Since:       request.Since,
+		Version:     version,
+		Types:       []dto.VehicleTypeResponse{},
+		Brands:      []dto.VehicleBrandResponse{},
+		Models:      []dto.VehicleModelResponse{},
+		Generations: []dto.VehicleGenerationResponse{},
+		Deleted:     []dto.VehicleCatalogTombstone{},
+	}
+
+	if request.Since == 0 || request.Since > version {
+		response.Full = true
+		for _, vehicleType := range vehicleTypes {
+			response.Types = append(response.Types, *uc.convertToVehicleTypeResponse(vehicleType))
+			for _, brand := range vehicleType.VehicleBrands {
+				response.Brands = append(response.Brands, *uc.convertToVehicleBrandResponse(brand))
+				for _, model := range brand.VehicleModels {
+					response.Models = append(response.Models, *uc.convertToVehicleModelResponse(model))
+					for _, generation := range model.VehicleGenerations {
+						response.Generations = append(response.Generations, *uc.convertToVehicleGenerationResponse(generation))
+					}
+				}
+			}
+		}
+		return response, nil
+	}
+
+	// Changes committed after the version was read are left for the next sync
+	changes := []entity.VehicleCatalogChange{}
+	err = uc.catalogRepository.ListCatalogChanges(ctx, request.Since, version, &changes)
+	if err != nil {
+		logger.Error(err, "Failed to list vehicle catalog changes")
+		return nil, errors.ErrFailedToListVehicleCatalogChanges
+	}
+
+	rows := newVehicleCatalogRows(vehicleTypes)
+	for _, change := range changes {
+		found := false
+		switch change.Level {
+		case entity.CatalogLevelType:
+			var vehicleType entity.VehicleType
+			if vehicleType, found = rows.types[change.RowID]; found {
+				response.Types = append(response.Types, *uc.convertToVehicleTypeResponse(vehicleType))
+			}
+		case entity.CatalogLevelBrand:
+			var brand entity.VehicleBrand
+			if brand, found = rows.brands[change.RowID]; found {
+				response.Brands = append(response.Brands, *uc.convertToVehicleBrandResponse(brand))
+			}
+		case entity.CatalogLevelModel:
+			var model entity.VehicleModel
+			if model, found = rows.models[change.RowID]; found {
+				response.Models = append(response.Models, *uc.convertToVehicleModelResponse(model))
+			}
+		case entity.CatalogLevelGeneration:
+			var generation entity.VehicleGeneration
+			if generation, found = rows.generations[change.RowID]; found {
+				response.Generations = append(response.Generations, *uc.convertToVehicleGenerationResponse(generation))
+			}
+		}
+		if !found {
+			response.Deleted = append(response.Deleted, dto.VehicleCatalogTombstone{Level: change.Level, ID: change.RowID})
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,409 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/config"
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/storage"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+)
+
+type VehicleDocumentUseCase interface {
+	CreateVehicleDocument(ctx context.Context, userID, vehicleID string, request dto.CreateVehicleDocumentRequest) (*dto.VehicleDocumentResponse, error)
+	GetVehicleDocument(ctx context.Context, userID, vehicleID, documentID string) (*dto.VehicleDocumentResponse, error)
+	ListVehicleDocuments(ctx context.Context, userID, vehicleID string) (*dto.ListVehicleDocumentsResponse, error)
+	UpdateVehicleDocument(ctx context.Context, userID, vehicleID, documentID string, request dto.UpdateVehicleDocumentRequest) (*dto.VehicleDocumentResponse, error)
+	DeleteVehicleDocument(ctx context.Context, userID, vehicleID, documentID string) error
+
+	// Scan of a document
+	UploadVehicleDocumentScan(ctx context.Context, userID, vehicleID, documentID, fileName string, size int64, content io.Reader) (*dto.VehicleDocumentResponse, error)
+	DownloadVehicleDocumentScan(ctx context.Context, userID, vehicleID, documentID string) (*dto.VehicleDocumentScanResponse, io.ReadCloser, error)
+	DeleteVehicleDocumentScan(ctx context.Context, userID, vehicleID, documentID string) error
+}
+
+type vehicleDocumentUseCase struct {
+	vehicleDocumentRepository repository.VehicleDocumentRepository
+	attachmentRepository      repository.AttachmentRepository
+	vehicleAccess             *vehicleAccess
+	storage                   storage.Storage
+	maxFileSize               int64
+	userQuota                 int64
+	thresholds                entity.ReminderThresholds
+}
+
+func NewVehicleDocumentUseCase() VehicleDocumentUseCase {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		logger.Error(err, "Failed to get config")
+		return nil
+	}
+	return &vehicleDocumentUseCase{
+		vehicleDocumentRepository: repository.NewVehicleDocumentRepository(),
+		attachmentRepository:      repository.NewAttachmentRepository(),
+		vehicleAccess:             newVehicleAccess(repository.NewVehicleRepository(), repository.NewVehicleMembershipRepository()),
+		storage:                   storage.GetStorage(),
+		maxFileSize:               int64(cfg.Attachment.MaxFileSizeMB) << 20,
+		userQuota:                 int64(cfg.Attachment.UserQuotaMB) << 20,
+		thresholds: entity.ReminderThresholds{
+			DueSoonDays: cfg.Reminder.DocumentDueSoonDays,
+		},
+	}
+}
+
+func (uc *vehicleDocumentUseCase) CreateVehicleDocument(ctx context.Context, userID, vehicleID string, request dto.CreateVehicleDocumentRequest) (*dto.VehicleDocumentResponse, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateVehicleDocumentCreateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle document create request")
+		return nil, errors.ErrInvalidVehicleDocumentCreateRequest
+	}
+
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		logger.Error(err, "Failed to parse start date")
+		return nil, errors.ErrInvalidDate
+	}
+	expiryDate, err := time.Parse("2006-01-02", request.ExpiryDate)
+	if err != nil {
+		logger.Error(err, "Failed to parse expiry date")
+		return nil, errors.ErrInvalidDate
+	}
+	if !expiryDate.After(startDate) {
+		return nil, errors.ErrVehicleDocumentExpiryBeforeStart
+	}
+
+	document := entity.VehicleDocument{
+		UserID:        userVehicle.UserID,
+		UserVehicleID: userVehicle.ID,
+		DocumentType:  entity.ParseVehicleDocumentType(request.DocumentType),
+		Issuer:        request.Issuer,
+		PolicyNumber:  request.PolicyNumber,
+		StartDate:     startDate,
+		ExpiryDate:    expiryDate,
+		Notes:         request.Notes,
+	}
+	err = uc.vehicleDocumentRepository.CreateVehicleDocument(ctx, &document)
+	if err != nil {
+		logger.Error(err, "Failed to create vehicle document")
+		return nil, errors.ErrFailedToCreateVehicleDocument
+	}
+
+	return uc.mapVehicleDocumentToResponse(&document, time.Now()), nil
+}
+
+func (uc *vehicleDocumentUseCase) GetVehicleDocument(ctx context.Context, userID, vehicleID, documentID string) (*dto.VehicleDocumentResponse, error) {
+	document, err := uc.getOwnedVehicleDocument(ctx, userID, vehicleID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.mapVehicleDocumentToResponse(document, time.Now()), nil
+}
+
+func (uc *vehicleDocumentUseCase) ListVehicleDocuments(ctx context.Context, userID, vehicleID string) (*dto.ListVehicleDocumentsResponse, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := []entity.VehicleDocument{}
+	err = uc.vehicleDocumentRepository.ListVehicleDocuments(ctx, userVehicle.ID, &documents)
+	if err != nil {
+		logger.Error(err, "Failed to list vehicle documents")
+		return nil, errors.ErrFailedToListVehicleDocuments
+	}
+
+	now := time.Now()
+	documentsResponse := []dto.VehicleDocumentResponse{}
+	for _, document := range documents {
+		documentsResponse = append(documentsResponse, *uc.mapVehicleDocumentToResponse(&document, now))
+	}
+
+	return &dto.ListVehicleDocumentsResponse{
+		Documents: documentsResponse,
+	}, nil
+}
+
+func (uc *vehicleDocumentUseCase) UpdateVehicleDocument(ctx context.Context, userID, vehicleID, documentID string, request dto.UpdateVehicleDocumentRequest) (*dto.VehicleDocumentResponse, error) {
+	err := validation.ValidateVehicleDocumentUpdateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle document update request")
+		return nil, errors.ErrInvalidVehicleDocumentUpdateRequest
+	}
+
+	document, err := uc.getOwnedVehicleDocument(ctx, userID, vehicleID, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.DocumentType != nil {
+		document.DocumentType = entity.ParseVehicleDocumentType(*request.DocumentType)
+	}
+	if request.Issuer != nil {
+		document.Issuer = *request.Issuer
+	}
+	if request.PolicyNumber != nil {
+		document.PolicyNumber = *request.PolicyNumber
+	}
+	if request.StartDate != nil {
+		startDate, err := time.Parse("2006-01-02", *request.StartDate)
+		if err != nil {
+			logger.Error(err, "Failed to parse start date")
+			return nil, errors.ErrInvalidDate
+		}
+		document.StartDate = startDate
+	}
+	if request.ExpiryDate != nil {
+		expiryDate, err := time.Parse("2006-01-02", *request.ExpiryDate)
+		if err != nil {
+			logger.Error(err, "Failed to parse expiry date")
+			return nil, errors.ErrInvalidDate
+		}
+		document.ExpiryDate = expiryDate
+	}
+	if request.Notes != nil {
+		document.Notes = *request.Notes
+	}
+	if !document.ExpiryDate.After(document.StartDate) {
+		return nil, errors.ErrVehicleDocumentExpiryBeforeStart
+	}
+
+	err = uc.vehicleDocumentRepository.UpdateVehicleDocument(ctx, document)
+	if err != nil {
+		logger.Error(err, "Failed to update vehicle document")
+		return nil, errors.ErrFailedToUpdateVehicleDocument
+	}
+
+	return uc.mapVehicleDocumentToResponse(document, time.Now()), nil
+}
+
+func (uc *vehicleDocumentUseCase) DeleteVehicleDocument(ctx context.Context, userID, vehicleID, documentID string) error {
+	document, err := uc.getOwnedVehicleDocument(ctx, userID, vehicleID, documentID)
+	if err != nil {
+		return err
+	}
+
+	if document.HasScan() {
+		err = uc.storage.Delete(ctx, document.ScanStorageKey)
+		if err != nil {
+			logger.Error(err, "Failed to delete vehicle document scan file")
+			return errors.ErrFailedToDeleteVehicleDocument
+		}
+	}
+
+	err = uc.vehicleDocumentRepository.DeleteVehicleDocument(ctx, document)
+	if err != nil {
+		logger.Error(err, "Failed to delete vehicle document")
+		return errors.ErrFailedToDeleteVehicleDocument
+	}
+	return nil
+}
+
+func (uc *vehicleDocumentUseCase) UploadVehicleDocumentScan(ctx context.Context, userID, vehicleID, documentID, fileName string, size int64, content io.Reader) (*dto.VehicleDocumentResponse, error) {
+	document, err := uc.getOwnedVehicleDocument(ctx, userID, vehicleID, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		return nil, errors.ErrAttachmentFileRequired
+	}
+	if size > uc.maxFileSize {
+		logger.Error(errors.ErrAttachmentTooLarge, "Vehicle document scan exceeds max file size")
+		return nil, errors.ErrAttachmentTooLarge
+	}
+
+	// Trust the file's bytes rather than the client supplied header
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		logger.Error(err, "Failed to read vehicle document scan")
+		return nil, errors.ErrFailedToUploadVehicleDocumentScan
+	}
+	head = head[:n]
+	contentType, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	extension, ok := allowedAttachmentTypes[contentType]
+	if !ok {
+		logger.Error(errors.ErrAttachmentTypeNotAllowed, "Vehicle document scan content type not allowed: "+contentType)
+		return nil, errors.ErrAttachmentTypeNotAllowed
+	}
+
+	// A replaced scan frees its space. The quota is checked again when the scan is saved; this spares storing
+	// a file that would not fit
+	usage, err := uc.attachmentRepository.GetUserAttachmentUsage(ctx, document.UserID)
+	if err != nil {
+		logger.Error(err, "Failed to get user attachment usage")
+		return nil, errors.ErrFailedToUploadVehicleDocumentScan
+	}
+	if usage-document.ScanSize+size > uc.userQuota {
+		logger.Error(errors.ErrAttachmentQuotaExceeded, "User attachment quota exceeded")
+		return nil, errors.ErrAttachmentQuotaExceeded
+	}
+
+	storageKey, err := newVehicleDocumentStorageKey(document, extension)
+	if err != nil {
+		logger.Error(err, "Failed to generate vehicle document scan storage key")
+		return nil, errors.ErrFailedToUploadVehicleDocumentScan
+	}
+
+	body := io.LimitReader(io.MultiReader(bytes.NewReader(head), content), size)
+	err = uc.storage.Put(ctx, storageKey, body, size, contentType)
+	if err != nil {
+		logger.Error(err, "Failed to store vehicle document scan")
+		return nil, errors.ErrFailedToUploadVehicleDocumentScan
+	}
+
+	previousStorageKey := document.ScanStorageKey
+	document.ScanFileName = fileName
+	document.ScanContentType = contentType
+	document.ScanSize = size
+	document.ScanStorageKey = storageKey
+	err = uc.vehicleDocumentRepository.UpdateVehicleDocumentScan(ctx, document, uc.userQuota)
+	if err != nil {
+		logger.Error(err, "Failed to update vehicle document")
+		if err := uc.storage.Delete(ctx, storageKey); err != nil {
+			logger.Error(err, "Failed to delete orphaned vehicle document scan file")
+		}
+		if err == errors.ErrAttachmentQuotaExceeded {
+			return nil, err
+		}
+		return nil, errors.ErrFailedToUploadVehicleDocumentScan
+	}
+	if previousStorageKey != "" {
+		if err := uc.storage.Delete(ctx, previousStorageKey); err != nil {
+			logger.Error(err, "Failed to delete replaced vehicle document scan file")
+		}
+	}
+
+	return uc.mapVehicleDocumentToResponse(document, time.Now()), nil
+}
+
+func (uc *vehicleDocumentUseCase) DownloadVehicleDocumentScan(ctx context.Context, userID, vehicleID, documentID string) (*dto.VehicleDocumentScanResponse, io.ReadCloser, error) {
+	document, err := uc.getOwnedVehicleDocument(ctx, userID, vehicleID, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !document.HasScan() {
+		return nil, nil, errors.ErrVehicleDocumentScanNotFound
+	}
+
+	content, err := uc.storage.Open(ctx, document.ScanStorageKey)
+	if err != nil {
+		logger.Error(err, "Failed to open vehicle document scan file")
+		return nil, nil, errors.ErrFailedToGetVehicleDocument
+	}
+
+	return mapVehicleDocumentScanToResponse(document), content, nil
+}
+
+func (uc *vehicleDocumentUseCase) DeleteVehicleDocumentScan(ctx context.Context, userID, vehicleID, documentID string) error {
+	document, err := uc.getOwnedVehicleDocument(ctx, userID, vehicleID, documentID)
+	if err != nil {
+		return err
+	}
+	if !document.HasScan() {
+		return errors.ErrVehicleDocumentScanNotFound
+	}
+
+	err = uc.storage.Delete(ctx, document.ScanStorageKey)
+	if err != nil {
+		logger.Error(err, "Failed to delete vehicle document scan file")
+		return errors.ErrFailedToUpdateVehicleDocument
+	}
+
+	document.ScanFileName = ""
+	document.ScanContentType = ""
+	document.ScanSize = 0
+	document.ScanStorageKey = ""
+	err = uc.vehicleDocumentRepository.UpdateVehicleDocument(ctx, document)
+	if err != nil {
+		logger.Error(err, "Failed to update vehicle document")
+		return errors.ErrFailedToUpdateVehicleDocument
+	}
+	return nil
+}
+
+// getOwnedVehicleDocument checks the user owns the vehicle and loads a document of it
+func (uc *vehicleDocumentUseCase) getOwnedVehicleDocument(ctx context.Context, userID, vehicleID, documentID string) (*entity.VehicleDocument, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+	uintDocumentID, err := strconv.ParseUint(documentID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle document id")
+		return nil, errors.ErrInvalidVehicleDocumentID
+	}
+
+	document := entity.VehicleDocument{}
+	err = uc.vehicleDocumentRepository.GetVehicleDocument(ctx, uintDocumentID, &document)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle document")
+		return nil, errors.ErrFailedToGetVehicleDocument
+	}
+	if document.UserVehicleID != userVehicle.ID {
+		logger.Error(errors.ErrVehicleDocumentNotOwned, "Vehicle document does not belong to user vehicle")
+		return nil, errors.ErrVehicleDocumentNotOwned
+	}
+	return &document, nil
+}
+
+// newVehicleDocumentStorageKey builds an unguessable key grouped by user and vehicle
+func newVehicleDocumentStorageKey(document *entity.VehicleDocument, extension string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("users/%s/vehicles/%d/documents/%s%s", document.UserID, document.UserVehicleID, hex.EncodeToString(random), extension), nil
+}
+
+// mapVehicleDocumentToResponse reports the document's expiry the same way the reminders do
+func (uc *vehicleDocumentUseCase) mapVehicleDocumentToResponse(document *entity.VehicleDocument, now time.Time) *dto.VehicleDocumentResponse {
+	reminder := vehicleDocumentReminder(document)
+	reminder.Evaluate(0, now, uc.thresholds)
+
+	response := &dto.VehicleDocumentResponse{
+		ID:            document.ID,
+		UserVehicleID: document.UserVehicleID,
+		DocumentType:  document.DocumentType.String(),
+		Issuer:        document.Issuer,
+		PolicyNumber:  document.PolicyNumber,
+		StartDate:     document.StartDate.Format("2006-01-02"),
+		ExpiryDate:    document.ExpiryDate.Format("2006-01-02"),
+		Status:        reminder.Status.String(),
+		Notes:         document.Notes,
+	}
+	if reminder.RemainingDays != nil {
+		response.RemainingDays = *reminder.RemainingDays
+	}
+	if document.HasScan() {
+		response.Scan = mapVehicleDocumentScanToResponse(document)
+	}
+	return response
+}
+
+func mapVehicleDocumentScanToResponse(document *entity.VehicleDocument) *dto.VehicleDocumentScanResponse {
+	return &dto.VehicleDocumentScanResponse{
+		FileName:    document.ScanFileName,
+		ContentType: document.ScanContentType,
+		Size:        document.ScanSize,
+		URL:         fmt.Sprintf("/api/v1/user/vehicles/%d/documents/%d/scan", document.UserVehicleID, document.ID),
+	}
+}
@@ -0,0 +1,411 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// builtInExpenseCategories are offered to every user, in display order
+var builtInExpenseCategories = []entity.ExpenseCategory{
+	entity.ParkingExpense,
+	entity.TollExpense,
+	entity.CarWashExpense,
+	entity.RegistrationExpense,
+	entity.FineExpense,
+	entity.OtherExpense,
+}
+
+type VehicleExpenseUseCase interface {
+	// Expenses of a vehicle
+	CreateVehicleExpense(ctx context.Context, userID, vehicleID string, request dto.CreateVehicleExpenseRequest) (*dto.VehicleExpenseResponse, error)
+	GetVehicleExpense(ctx context.Context, userID, vehicleID, expenseID string) (*dto.VehicleExpenseResponse, error)
+	ListVehicleExpenses(ctx context.Context, userID, vehicleID string) (*dto.ListVehicleExpensesResponse, error)
+	UpdateVehicleExpense(ctx context.Context, userID, vehicleID, expenseID string, request dto.UpdateVehicleExpenseRequest) (*dto.VehicleExpenseResponse, error)
+	DeleteVehicleExpense(ctx context.Context, userID, vehicleID, expenseID string) error
+
+	// Categories of the current user
+	ListExpenseCategories(ctx context.Context, userID string) (*dto.ListExpenseCategoriesResponse, error)
+	CreateExpenseCategory(ctx context.Context, userID string, request dto.ExpenseCategoryRequest) (*dto.ExpenseCategoryResponse, error)
+	UpdateExpenseCategory(ctx context.Context, userID, categoryID string, request dto.ExpenseCategoryRequest) (*dto.ExpenseCategoryResponse, error)
+	DeleteExpenseCategory(ctx context.Context, userID, categoryID string) error
+}
+
+type vehicleExpenseUseCase struct {
+	vehicleExpenseRepository repository.VehicleExpenseRepository
+	vehicleAccess            *vehicleAccess
+}
+
+func NewVehicleExpenseUseCase() VehicleExpenseUseCase {
+	return &vehicleExpenseUseCase{
+		vehicleExpenseRepository: repository.NewVehicleExpenseRepository(),
+		vehicleAccess:            newVehicleAccess(repository.NewVehicleRepository(), repository.NewVehicleMembershipRepository()),
+	}
+}
+
+func (uc *vehicleExpenseUseCase) CreateVehicleExpense(ctx context.Context, userID, vehicleID string, request dto.CreateVehicleExpenseRequest) (*dto.VehicleExpenseResponse, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if (request.Category == "") == (request.CustomCategoryID == nil) {
+		return nil, errors.ErrExpenseCategoryRequired
+	}
+	err = validation.ValidateVehicleExpenseCreateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle expense create request")
+		return nil, errors.ErrInvalidVehicleExpenseCreateRequest
+	}
+
+	expenseDate := time.Now().Truncate(24 * time.Hour)
+	if request.ExpenseDate != "" {
+		expenseDate, err = time.Parse("2006-01-02", request.ExpenseDate)
+		if err != nil {
+			logger.Error(err, "Failed to parse expense date")
+			return nil, errors.ErrInvalidDate
+		}
+	}
+
+	expense := entity.VehicleExpense{
+		UserID:        userVehicle.UserID,
+		UserVehicleID: userVehicle.ID,
+		Category:      entity.ParseExpenseCategory(request.Category),
+		Amount:        entity.Rial(request.Amount),
+		ExpenseDate:   expenseDate,
+		Mileage:       request.Mileage,
+		Notes:         request.Notes,
+	}
+	if request.CustomCategoryID != nil {
+		err = uc.setCustomCategory(ctx, userVehicle.UserID, &expense, *request.CustomCategoryID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = uc.vehicleExpenseRepository.CreateVehicleExpense(ctx, &expense)
+	if err != nil {
+		logger.Error(err, "Failed to create vehicle expense")
+		return nil, errors.ErrFailedToCreateVehicleExpense
+	}
+
+	return mapVehicleExpenseToResponse(&expense), nil
+}
+
+func (uc *vehicleExpenseUseCase) GetVehicleExpense(ctx context.Context, userID, vehicleID, expenseID string) (*dto.VehicleExpenseResponse, error) {
+	_, expense, err := uc.getOwnedVehicleExpense(ctx, userID, vehicleID, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	return mapVehicleExpenseToResponse(expense), nil
+}
+
+func (uc *vehicleExpenseUseCase) ListVehicleExpenses(ctx context.Context, userID, vehicleID string) (*dto.ListVehicleExpensesResponse, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses := []entity.VehicleExpense{}
+	err = uc.vehicleExpenseRepository.ListVehicleExpenses(ctx, userVehicle.ID, &expenses)
+	if err != nil {
+		logger.Error(err, "Failed to list vehicle expenses")
+		return nil, errors.ErrFailedToListVehicleExpenses
+	}
+
+	expensesResponse := []dto.VehicleExpenseResponse{}
+	for _, expense := range expenses {
+		expensesResponse = append(expensesResponse, *mapVehicleExpenseToResponse(&expense))
+	}
+
+	return &dto.ListVehicleExpensesResponse{
+		Expenses: expensesResponse,
+	}, nil
+}
+
+func (uc *vehicleExpenseUseCase) UpdateVehicleExpense(ctx context.Context, userID, vehicleID, expenseID string, request dto.UpdateVehicleExpenseRequest) (*dto.VehicleExpenseResponse, error) {
+	if request.Category != nil && request.CustomCategoryID != nil {
+		return nil, errors.ErrExpenseCategoryRequired
+	}
+	err := validation.ValidateVehicleExpenseUpdateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle expense update request")
+		return nil, errors.ErrInvalidVehicleExpenseUpdateRequest
+	}
+
+	userVehicle, expense, err := uc.getOwnedVehicleExpense(ctx, userID, vehicleID, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Category != nil {
+		expense.Category = entity.ParseExpenseCategory(*request.Category)
+		expense.UserExpenseCategoryID = nil
+		expense.UserExpenseCategory = nil
+	}
+	if request.CustomCategoryID != nil {
+		err = uc.setCustomCategory(ctx, userVehicle.UserID, expense, *request.CustomCategoryID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if request.Amount != nil {
+		expense.Amount = entity.Rial(*request.Amount)
+	}
+	if request.ExpenseDate != nil {
+		expenseDate, err := time.Parse("2006-01-02", *request.ExpenseDate)
+		if err != nil {
+			logger.Error(err, "Failed to parse expense date")
+			return nil, errors.ErrInvalidDate
+		}
+		expense.ExpenseDate = expenseDate
+	}
+	if request.Mileage != nil {
+		expense.Mileage = *request.Mileage
+	}
+	if request.Notes != nil {
+		expense.Notes = *request.Notes
+	}
+
+	err = uc.vehicleExpenseRepository.UpdateVehicleExpense(ctx, expense)
+	if err != nil {
+		logger.Error(err, "Failed to update vehicle expense")
+		return nil, errors.ErrFailedToUpdateVehicleExpense
+	}
+
+	return mapVehicleExpenseToResponse(expense), nil
+}
+
+func (uc *vehicleExpenseUseCase) DeleteVehicleExpense(ctx context.Context, userID, vehicleID, expenseID string) error {
+	_, expense, err := uc.getOwnedVehicleExpense(ctx, userID, vehicleID, expenseID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.vehicleExpenseRepository.DeleteVehicleExpense(ctx, expense)
+	if err != nil {
+		logger.Error(err, "Failed to delete vehicle expense")
+		return errors.ErrFailedToDeleteVehicleExpense
+	}
+	return nil
+}
+
+func (uc *vehicleExpenseUseCase) ListExpenseCategories(ctx context.Context, userID string) (*dto.ListExpenseCategoriesResponse, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+
+	categories := []entity.UserExpenseCategory{}
+	err = uc.vehicleExpenseRepository.ListUserExpenseCategories(ctx, uuidUserID, &categories)
+	if err != nil {
+		logger.Error(err, "Failed to list expense categories")
+		return nil, errors.ErrFailedToListExpenseCategories
+	}
+
+	response := &dto.ListExpenseCategoriesResponse{
+		BuiltIn: []dto.BuiltInExpenseCategoryResponse{},
+		Custom:  []dto.ExpenseCategoryResponse{},
+	}
+	for _, category := range builtInExpenseCategories {
+		response.BuiltIn = append(response.BuiltIn, dto.BuiltInExpenseCategoryResponse{
+			Category: category.String(),
+			Name:     category.Label(),
+		})
+	}
+	for _, category := range categories {
+		response.Custom = append(response.Custom, *mapExpenseCategoryToResponse(&category))
+	}
+	return response, nil
+}
+
+func (uc *vehicleExpenseUseCase) CreateExpenseCategory(ctx context.Context, userID string, request dto.ExpenseCategoryRequest) (*dto.ExpenseCategoryResponse, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+
+	err = validation.ValidateExpenseCategoryRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate expense category request")
+		return nil, errors.ErrInvalidExpenseCategoryRequest
+	}
+	name := strings.TrimSpace(request.Name)
+
+	existing := entity.UserExpenseCategory{}
+	err = uc.vehicleExpenseRepository.GetUserExpenseCategoryByName(ctx, uuidUserID, name, &existing)
+	if err != nil {
+		logger.Error(err, "Failed to get expense category")
+		return nil, errors.ErrFailedToCreateExpenseCategory
+	}
+	if existing.ID != 0 {
+		return nil, errors.ErrExpenseCategoryExists
+	}
+
+	category := entity.UserExpenseCategory{
+		UserID: uuidUserID,
+		Name:   name,
+	}
+	err = uc.vehicleExpenseRepository.CreateUserExpenseCategory(ctx, &category)
+	if err != nil {
+		logger.Error(err, "Failed to create expense category")
+		return nil, errors.ErrFailedToCreateExpenseCategory
+	}
+	return mapExpenseCategoryToResponse(&category), nil
+}
+
+func (uc *vehicleExpenseUseCase) UpdateExpenseCategory(ctx context.Context, userID, categoryID string, request dto.ExpenseCategoryRequest) (*dto.ExpenseCategoryResponse, error) {
+	category, err := uc.getOwnedExpenseCategory(ctx, userID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateExpenseCategoryRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate expense category request")
+		return nil, errors.ErrInvalidExpenseCategoryRequest
+	}
+	name := strings.TrimSpace(request.Name)
+
+	existing := entity.UserExpenseCategory{}
+	err = uc.vehicleExpenseRepository.GetUserExpenseCategoryByName(ctx, category.UserID, name, &existing)
+	if err != nil {
+		logger.Error(err, "Failed to get expense category")
+		return nil, errors.ErrFailedToUpdateExpenseCategory
+	}
+	if existing.ID != 0 && existing.ID != category.ID {
+		return nil, errors.ErrExpenseCategoryExists
+	}
+
+	category.Name = name
+	err = uc.vehicleExpenseRepository.UpdateUserExpenseCategory(ctx, category)
+	if err != nil {
+		logger.Error(err, "Failed to update expense category")
+		return nil, errors.ErrFailedToUpdateExpenseCategory
+	}
+	return mapExpenseCategoryToResponse(category), nil
+}
+
+func (uc *vehicleExpenseUseCase) DeleteExpenseCategory(ctx context.Context, userID, categoryID string) error {
+	category, err := uc.getOwnedExpenseCategory(ctx, userID, categoryID)
+	if err != nil {
+		return err
+	}
+
+	count, err := uc.vehicleExpenseRepository.CountUserExpenseCategoryExpenses(ctx, category.ID)
+	if err != nil {
+		logger.Error(err, "Failed to count expenses of expense category")
+		return errors.ErrFailedToDeleteExpenseCategory
+	}
+	if count > 0 {
+		return errors.ErrExpenseCategoryInUse
+	}
+
+	err = uc.vehicleExpenseRepository.DeleteUserExpenseCategory(ctx, category)
+	if err != nil {
+		logger.Error(err, "Failed to delete expense category")
+		return errors.ErrFailedToDeleteExpenseCategory
+	}
+	return nil
+}
+
+// getOwnedVehicleExpense checks the user owns the vehicle and loads an expense of it
+func (uc *vehicleExpenseUseCase) getOwnedVehicleExpense(ctx context.Context, userID, vehicleID, expenseID string) (*entity.UserVehicle, *entity.VehicleExpense, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return nil, nil, err
+	}
+	uintExpenseID, err := strconv.ParseUint(expenseID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle expense id")
+		return nil, nil, errors.ErrInvalidVehicleExpenseID
+	}
+
+	expense := entity.VehicleExpense{}
+	err = uc.vehicleExpenseRepository.GetVehicleExpense(ctx, uintExpenseID, &expense)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle expense")
+		return nil, nil, errors.ErrFailedToGetVehicleExpense
+	}
+	if expense.UserVehicleID != userVehicle.ID {
+		logger.Error(errors.ErrVehicleExpenseNotOwned, "Vehicle expense does not belong to user vehicle")
+		return nil, nil, errors.ErrVehicleExpenseNotOwned
+	}
+	return userVehicle, &expense, nil
+}
+
+// getOwnedExpenseCategory loads one of the user's own expense categories
+func (uc *vehicleExpenseUseCase) getOwnedExpenseCategory(ctx context.Context, userID, categoryID string) (*entity.UserExpenseCategory, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintCategoryID, err := strconv.ParseUint(categoryID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse expense category id")
+		return nil, errors.ErrInvalidExpenseCategoryID
+	}
+
+	category := entity.UserExpenseCategory{}
+	err = uc.vehicleExpenseRepository.GetUserExpenseCategory(ctx, uintCategoryID, &category)
+	if err != nil {
+		logger.Error(err, "Failed to get expense category")
+		return nil, errors.ErrFailedToListExpenseCategories
+	}
+	if category.ID == 0 || category.UserID != uuidUserID {
+		return nil, errors.ErrExpenseCategoryNotFound
+	}
+	return &category, nil
+}
+
+// setCustomCategory files the expense under one of the owner's own categories
+func (uc *vehicleExpenseUseCase) setCustomCategory(ctx context.Context, ownerID uuid.UUID, expense *entity.VehicleExpense, categoryID uint64) error {
+	category := entity.UserExpenseCategory{}
+	err := uc.vehicleExpenseRepository.GetUserExpenseCategory(ctx, categoryID, &category)
+	if err != nil {
+		logger.Error(err, "Failed to get expense category")
+		return errors.ErrFailedToGetVehicleExpense
+	}
+	if category.ID == 0 || category.UserID != ownerID {
+		return errors.ErrExpenseCategoryNotFound
+	}
+
+	expense.Category = entity.CustomExpense
+	expense.UserExpenseCategoryID = &category.ID
+	expense.UserExpenseCategory = &category
+	return nil
+}
+
+func mapVehicleExpenseToResponse(expense *entity.VehicleExpense) *dto.VehicleExpenseResponse {
+	return &dto.VehicleExpenseResponse{
+		ID:               expense.ID,
+		UserVehicleID:    expense.UserVehicleID,
+		Category:         expense.Category.String(),
+		CustomCategoryID: expense.UserExpenseCategoryID,
+		CategoryName:     expense.CategoryName(),
+		Amount:           int64(expense.Amount),
+		AmountDisplay:    expense.Amount.FormatToman(),
+		ExpenseDate:      expense.ExpenseDate.Format("2006-01-02"),
+		Mileage:          expense.Mileage,
+		Notes:            expense.Notes,
+	}
+}
+
+func mapExpenseCategoryToResponse(category *entity.UserExpenseCategory) *dto.ExpenseCategoryResponse {
+	return &dto.ExpenseCategoryResponse{
+		ID:   category.ID,
+		Name: category.Name,
+	}
+}
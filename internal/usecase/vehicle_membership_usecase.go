@@ -0,0 +1,415 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+type VehicleMembershipUseCase interface {
+	// Members of a vehicle, managed by its owner
+	InviteVehicleMember(ctx context.Context, userID, vehicleID string, request dto.InviteVehicleMemberRequest) (*dto.VehicleMemberResponse, error)
+	ListVehicleMembers(ctx context.Context, userID, vehicleID string) (*dto.ListVehicleMembersResponse, error)
+	UpdateVehicleMember(ctx context.Context, userID, vehicleID, membershipID string, request dto.UpdateVehicleMemberRequest) (*dto.VehicleMemberResponse, error)
+	RemoveVehicleMember(ctx context.Context, userID, vehicleID, membershipID string) error
+
+	// Vehicles shared with the current user
+	ListVehicleMemberships(ctx context.Context, userID string) (*dto.ListVehicleMembershipsResponse, error)
+	AcceptVehicleMembership(ctx context.Context, userID, membershipID string) (*dto.VehicleMembershipResponse, error)
+	LeaveVehicleMembership(ctx context.Context, userID, membershipID string) error
+}
+
+type vehicleMembershipUseCase struct {
+	vehicleMembershipRepository repository.VehicleMembershipRepository
+	authRepository              repository.AuthRepository
+	vehicleAccess               *vehicleAccess
+}
+
+func NewVehicleMembershipUseCase() VehicleMembershipUseCase {
+	vehicleMembershipRepository := repository.NewVehicleMembershipRepository()
+	return &vehicleMembershipUseCase{
+		vehicleMembershipRepository: vehicleMembershipRepository,
+		authRepository:              repository.NewAuthRepository(),
+		vehicleAccess:               newVehicleAccess(repository.NewVehicleRepository(), vehicleMembershipRepository),
+	}
+}
+
+func (uc *vehicleMembershipUseCase) InviteVehicleMember(ctx context.Context, userID, vehicleID string, request dto.InviteVehicleMemberRequest) (*dto.VehicleMemberResponse, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateInviteVehicleMemberRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle member invite request")
+		return nil, errors.ErrInvalidVehicleMembershipRequest
+	}
+
+	owner := entity.User{BaseEntity: entity.BaseEntity{ID: userVehicle.UserID}}
+	err = uc.authRepository.FindByID(ctx, &owner)
+	if err != nil {
+		logger.Error(err, "Failed to find vehicle owner")
+		return nil, errors.ErrFailedToCreateVehicleMembership
+	}
+	if owner.PhoneNumber == request.PhoneNumber {
+		return nil, errors.ErrVehicleMembershipToOwner
+	}
+
+	existing := entity.VehicleMembership{}
+	err = uc.vehicleMembershipRepository.GetVehicleMembershipByPhoneNumber(ctx, userVehicle.ID, request.PhoneNumber, &existing)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle membership")
+		return nil, errors.ErrFailedToCreateVehicleMembership
+	}
+	if existing.ID != 0 {
+		return nil, errors.ErrVehicleMembershipExists
+	}
+
+	membership := entity.VehicleMembership{
+		UserVehicleID: userVehicle.ID,
+		PhoneNumber:   request.PhoneNumber,
+		Role:          entity.ParseVehicleRole(request.Role),
+		Status:        entity.InvitedVehicleMembership,
+		InvitedByID:   userVehicle.UserID,
+	}
+	err = uc.vehicleMembershipRepository.CreateVehicleMembership(ctx, &membership)
+	if err != nil {
+		logger.Error(err, "Failed to create vehicle membership")
+		return nil, errors.ErrFailedToCreateVehicleMembership
+	}
+	return uc.convertToVehicleMemberResponse(ctx, membership), nil
+}
+
+func (uc *vehicleMembershipUseCase) ListVehicleMembers(ctx context.Context, userID, vehicleID string) (*dto.ListVehicleMembersResponse, error) {
+	userVehicle, role, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.ViewerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships := []entity.VehicleMembership{}
+	err = uc.vehicleMembershipRepository.ListVehicleMemberships(ctx, userVehicle.ID, &memberships)
+	if err != nil {
+		logger.Error(err, "Failed to list vehicle memberships")
+		return nil, errors.ErrFailedToListVehicleMemberships
+	}
+
+	owner := entity.User{BaseEntity: entity.BaseEntity{ID: userVehicle.UserID}}
+	if err := uc.authRepository.FindByID(ctx, &owner); err != nil {
+		logger.Error(err, "Failed to find vehicle owner")
+	}
+	response := &dto.ListVehicleMembersResponse{
+		Owner: dto.VehicleMemberResponse{
+			PhoneNumber: owner.PhoneNumber,
+			Name:        fullName(owner),
+			Role:        entity.OwnerVehicleRole.String(),
+			Status:      entity.AcceptedVehicleMembership.String(),
+		},
+		Members: []dto.VehicleMemberResponse{},
+	}
+	for _, membership := range memberships {
+		response.Members = append(response.Members, *uc.convertToVehicleMemberResponse(ctx, membership))
+	}
+	// Only the owner sees the phone numbers in full
+	if role != entity.OwnerVehicleRole {
+		response.Owner.PhoneNumber = maskPhoneNumber(response.Owner.PhoneNumber)
+		for i := range response.Members {
+			response.Members[i].PhoneNumber = maskPhoneNumber(response.Members[i].PhoneNumber)
+		}
+	}
+	return response, nil
+}
+
+func (uc *vehicleMembershipUseCase) UpdateVehicleMember(ctx context.Context, userID, vehicleID, membershipID string, request dto.UpdateVehicleMemberRequest) (*dto.VehicleMemberResponse, error) {
+	membership, err := uc.getVehicleMember(ctx, userID, vehicleID, membershipID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateUpdateVehicleMemberRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle member update request")
+		return nil, errors.ErrInvalidVehicleMembershipUpdateRequest
+	}
+
+	membership.Role = entity.ParseVehicleRole(request.Role)
+	err = uc.vehicleMembershipRepository.UpdateVehicleMembership(ctx, membership)
+	if err != nil {
+		logger.Error(err, "Failed to update vehicle membership")
+		return nil, errors.ErrFailedToUpdateVehicleMembership
+	}
+	return uc.convertToVehicleMemberResponse(ctx, *membership), nil
+}
+
+func (uc *vehicleMembershipUseCase) RemoveVehicleMember(ctx context.Context, userID, vehicleID, membershipID string) error {
+	membership, err := uc.getVehicleMember(ctx, userID, vehicleID, membershipID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.vehicleMembershipRepository.DeleteVehicleMembership(ctx, membership)
+	if err != nil {
+		logger.Error(err, "Failed to delete vehicle membership")
+		return errors.ErrFailedToDeleteVehicleMembership
+	}
+	return nil
+}
+
+func (uc *vehicleMembershipUseCase) ListVehicleMemberships(ctx context.Context, userID string) (*dto.ListVehicleMembershipsResponse, error) {
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships := []entity.VehicleMembership{}
+	err = uc.vehicleMembershipRepository.ListUserVehicleMemberships(ctx, user.ID, user.PhoneNumber, &memberships)
+	if err != nil {
+		logger.Error(err, "Failed to list user vehicle memberships")
+		return nil, errors.ErrFailedToListVehicleMemberships
+	}
+
+	membershipsResponse := []dto.VehicleMembershipResponse{}
+	for _, membership := range memberships {
+		response, err := uc.convertToVehicleMembershipResponse(ctx, membership)
+		if err != nil {
+			// The vehicle was deleted since it was shared
+			logger.Error(err, "Failed to get shared vehicle")
+			continue
+		}
+		membershipsResponse = append(membershipsResponse, *response)
+	}
+	return &dto.ListVehicleMembershipsResponse{Memberships: membershipsResponse}, nil
+}
+
+func (uc *vehicleMembershipUseCase) AcceptVehicleMembership(ctx context.Context, userID, membershipID string) (*dto.VehicleMembershipResponse, error) {
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	membership, err := uc.getVehicleMembership(ctx, membershipID)
+	if err != nil {
+		return nil, err
+	}
+	// Invitations to someone else are reported as missing rather than revealed
+	if membership.PhoneNumber != user.PhoneNumber {
+		return nil, errors.ErrVehicleMembershipNotFound
+	}
+	if membership.Status == entity.AcceptedVehicleMembership {
+		return nil, errors.ErrVehicleMembershipAlreadyAccepted
+	}
+
+	membership.UserID = &user.ID
+	membership.Status = entity.AcceptedVehicleMembership
+	err = uc.vehicleMembershipRepository.UpdateVehicleMembership(ctx, membership)
+	if err != nil {
+		logger.Error(err, "Failed to accept vehicle membership")
+		return nil, errors.ErrFailedToUpdateVehicleMembership
+	}
+
+	response, err := uc.convertToVehicleMembershipResponse(ctx, *membership)
+	if err != nil {
+		logger.Error(err, "Failed to get shared vehicle")
+		return nil, errors.ErrFailedToGetVehicleMembership
+	}
+	return response, nil
+}
+
+func (uc *vehicleMembershipUseCase) LeaveVehicleMembership(ctx context.Context, userID, membershipID string) error {
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	membership, err := uc.getVehicleMembership(ctx, membershipID)
+	if err != nil {
+		return err
+	}
+	addressedToUser := membership.PhoneNumber == user.PhoneNumber
+	if membership.UserID != nil {
+		addressedToUser = *membership.UserID == user.ID
+	}
+	if !addressedToUser {
+		return errors.ErrVehicleMembershipNotFound
+	}
+
+	err = uc.vehicleMembershipRepository.DeleteVehicleMembership(ctx, membership)
+	if err != nil {
+		logger.Error(err, "Failed to delete vehicle membership")
+		return errors.ErrFailedToDeleteVehicleMembership
+	}
+	return nil
+}
+
+func (uc *vehicleMembershipUseCase) getUser(ctx context.Context, userID string) (*entity.User, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	user := entity.User{BaseEntity: entity.BaseEntity{ID: uuidUserID}}
+	err = uc.authRepository.FindByID(ctx, &user)
+	if err != nil {
+		logger.Error(err, "Failed to find user")
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (uc *vehicleMembershipUseCase) getVehicleMembership(ctx context.Context, membershipID string) (*entity.VehicleMembership, error) {
+	uintMembershipID, err := strconv.ParseUint(membershipID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle membership id")
+		return nil, errors.ErrInvalidVehicleMembershipID
+	}
+	membership := entity.VehicleMembership{}
+	err = uc.vehicleMembershipRepository.GetVehicleMembership(ctx, uintMembershipID, &membership)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle membership")
+		return nil, errors.ErrFailedToGetVehicleMembership
+	}
+	if membership.ID == 0 {
+		return nil, errors.ErrVehicleMembershipNotFound
+	}
+	return &membership, nil
+}
+
+// getVehicleMember loads a membership of a vehicle for its owner to manage
+func (uc *vehicleMembershipUseCase) getVehicleMember(ctx context.Context, userID, vehicleID, membershipID string) (*entity.VehicleMembership, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+	membership, err := uc.getVehicleMembership(ctx, membershipID)
+	if err != nil {
+		return nil, err
+	}
+	if membership.UserVehicleID != userVehicle.ID {
+		return nil, errors.ErrVehicleMembershipNotFound
+	}
+	return membership, nil
+}
+
+func (uc *vehicleMembershipUseCase) convertToVehicleMemberResponse(ctx context.Context, membership entity.VehicleMembership) *dto.VehicleMemberResponse {
+	response := &dto.VehicleMemberResponse{
+		ID:          membership.ID,
+		PhoneNumber: membership.PhoneNumber,
+		Role:        membership.Role.String(),
+		Status:      membership.Status.String(),
+	}
+	if membership.UserID != nil {
+		member := entity.User{BaseEntity: entity.BaseEntity{ID: *membership.UserID}}
+		if err := uc.authRepository.FindByID(ctx, &member); err != nil {
+			logger.Error(err, "Failed to find vehicle member")
+		}
+		response.Name = fullName(member)
+	}
+	return response
+}
+
+func (uc *vehicleMembershipUseCase) convertToVehicleMembershipResponse(ctx context.Context, membership entity.VehicleMembership) (*dto.VehicleMembershipResponse, error) {
+	userVehicle := entity.UserVehicle{}
+	err := uc.vehicleMembershipRepository.GetSharedUserVehicle(ctx, membership.UserVehicleID, &userVehicle)
+	if err != nil {
+		return nil, err
+	}
+	if userVehicle.ID == 0 {
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+
+	owner := entity.User{BaseEntity: entity.BaseEntity{ID: userVehicle.UserID}}
+	if err := uc.authRepository.FindByID(ctx, &owner); err != nil {
+		logger.Error(err, "Failed to find vehicle owner")
+	}
+	return &dto.VehicleMembershipResponse{
+		ID:            membership.ID,
+		UserVehicleID: membership.UserVehicleID,
+		VehicleName:   userVehicle.Name,
+		OwnerName:     fullName(owner),
+		Role:          membership.Role.String(),
+		Status:        membership.Status.String(),
+		CreatedAt:     membership.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// maskPhoneNumber hides the middle of a phone number, e.g. 0912****567
+func maskPhoneNumber(phoneNumber string) string {
+	if len(phoneNumber) < 8 {
+		return strings.Repeat("*", len(phoneNumber))
+	}
+	return phoneNumber[:4] + strings.Repeat("*", len(phoneNumber)-7) + phoneNumber[len(phoneNumber)-3:]
+}
+
+func fullName(user entity.User) string {
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
+
+// vehicleAccess resolves what a user may do with a vehicle, as its owner or through an accepted membership.
+// It is shared by every use case that lets members work on a vehicle
+type vehicleAccess struct {
+	vehicleRepository           repository.VehicleRepository
+	vehicleMembershipRepository repository.VehicleMembershipRepository
+}
+
+func newVehicleAccess(vehicleRepository repository.VehicleRepository, vehicleMembershipRepository repository.VehicleMembershipRepository) *vehicleAccess {
+	return &vehicleAccess{
+		vehicleRepository:           vehicleRepository,
+		vehicleMembershipRepository: vehicleMembershipRepository,
+	}
+}
+
+// authorize loads the vehicle when the user's role on it includes required. Users without access to the
+// vehicle get ErrUserVehicleNotOwned and members whose role falls short get ErrVehicleRoleNotAllowed
+func (a *vehicleAccess) authorize(ctx context.Context, userID uuid.UUID, userVehicleID uint64, required entity.VehicleRole) (*entity.UserVehicle, entity.VehicleRole, error) {
+	userVehicle := entity.UserVehicle{}
+	err := a.vehicleRepository.GetUserVehicle(ctx, userID, userVehicleID, &userVehicle)
+	if err == nil {
+		return &userVehicle, entity.OwnerVehicleRole, nil
+	}
+
+	membership := entity.VehicleMembership{}
+	err = a.vehicleMembershipRepository.GetAcceptedVehicleMembership(ctx, userVehicleID, userID, &membership)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle membership")
+		return nil, 0, errors.ErrFailedToGetVehicleMembership
+	}
+	if membership.ID == 0 {
+		logger.Error(errors.ErrUserVehicleNotOwned, "User vehicle not owned by user")
+		return nil, 0, errors.ErrUserVehicleNotOwned
+	}
+	if !membership.Role.Allows(required) {
+		return nil, membership.Role, errors.ErrVehicleRoleNotAllowed
+	}
+
+	err = a.vehicleMembershipRepository.GetSharedUserVehicle(ctx, userVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "Failed to get shared user vehicle")
+		return nil, 0, errors.ErrFailedToGetUserVehicle
+	}
+	if userVehicle.ID == 0 {
+		return nil, 0, errors.ErrUserVehicleNotOwned
+	}
+	return &userVehicle, membership.Role, nil
+}
+
+// authorizeParams is authorize for the user and vehicle ids of a request path
+func (a *vehicleAccess) authorizeParams(ctx context.Context, userID, vehicleID string, required entity.VehicleRole) (*entity.UserVehicle, entity.VehicleRole, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, 0, errors.ErrInvalidUserID
+	}
+	uintUserVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse user vehicle id")
+		return nil, 0, errors.ErrInvalidUserVehicleID
+	}
+	return a.authorize(ctx, uuidUserID, uintUserVehicleID, required)
+}
@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/amirdashtii/AutoBan/pkg/persian"
+)
+
+const (
+	defaultVehicleSearchLimit = 20
+
+	vehicleSearchBrand      = "brand"
+	vehicleSearchModel      = "model"
+	vehicleSearchGeneration = "generation"
+)
+
+var vehicleSearchLevelOrder = map[string]int{
+	vehicleSearchBrand:      0,
+	vehicleSearchModel:      1,
+	vehicleSearchGeneration: 2,
+}
+
+// vehicleSearchCandidate is a brand, model or generation with the search tokens of its Persian and English
+// names and of the names of the type, brand and model above it
+type vehicleSearchCandidate struct {
+	result  dto.VehicleSearchResult
+	names   [][]string
+	parents []string
+}
+
+// SearchVehicles finds brands, models and generations whose Persian or English name matches the query. Every
+// word of the query has to match a word of the row or of the rows above it, so "پژو ۲۰۶" finds the 206 model
+// of Peugeot, and at least one word has to match the row itself. Words match exactly, as a prefix while the
+// user is still typing, or with one typo from 3 letters and two from 6; numbers have to match exactly
+func (uc *vehicleUseCase) SearchVehicles(ctx context.Context, request dto.SearchVehiclesRequest) (*dto.SearchVehiclesResponse, error) {
+	err := validation.ValidateSearchVehiclesRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle search request")
+		return nil, errors.ErrInvalidVehicleSearchRequest
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultVehicleSearchLimit
+	}
+
+	vehicleTypes, _, err := uc.getVehicleHierarchy(ctx)
+	if err != nil {
+		return nil, errors.ErrFailedToSearchVehicles
+	}
+
+	query := searchTokens(request.Query)
+	results := []dto.VehicleSearchResult{}
+	for _, candidate := range vehicleSearchCandidates(vehicleTypes) {
+		score := scoreVehicleSearch(query, candidate)
+		if score == 0 {
+			continue
+		}
+		candidate.result.Score = score
+		results = append(results, candidate.result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Level != results[j].Level {
+			return vehicleSearchLevelOrder[results[i].Level] < vehicleSearchLevelOrder[results[j].Level]
+		}
+		return results[i].NameEn < results[j].NameEn
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	for i := range results {
+		results[i].Score = math.Round(results[i].Score*100) / 100
+	}
+
+	return &dto.SearchVehiclesResponse{
+		Query:   persian.Normalize(strings.TrimSpace(request.Query)),
+		Results: results,
+	}, nil
+}
+
+func vehicleSearchCandidates(vehicleTypes []entity.VehicleType) []vehicleSearchCandidate {
+	candidates := []vehicleSearchCandidate{}
+	for _, vehicleType := range vehicleTypes {
+		typeItem := dto.VehicleCatalogPathItem{ID: vehicleType.ID, NameFa: vehicleType.NameFa, NameEn: vehicleType.NameEn}
+		typeTokens := appendSearchTokens(nil, typeItem)
+
+		for _, brand := range vehicleType.VehicleBrands {
+			brandItem := dto.VehicleCatalogPathItem{ID: brand.ID, NameFa: brand.NameFa, NameEn: brand.NameEn}
+			path := dto.VehicleSearchResult{Type: typeItem, Brand: brandItem}
+			candidates = append(candidates, newVehicleSearchCandidate(vehicleSearchBrand, brandItem, typeTokens, path))
+			brandTokens := appendSearchTokens(typeTokens, brandItem)
+
+			for _, model := range brand.VehicleModels {
+				modelItem := dto.VehicleCatalogPathItem{ID: model.ID, NameFa: model.NameFa, NameEn: model.NameEn}
+				path.Model = &modelItem
+				candidates = append(candidates, newVehicleSearchCandidate(vehicleSearchModel, modelItem, brandTokens, path))
+				modelTokens := appendSearchTokens(brandTokens, modelItem)
+
+				for _, generation := range model.VehicleGenerations {
+					generationItem := dto.VehicleCatalogPathItem{ID: generation.ID, NameFa: generation.NameFa, NameEn: generation.NameEn}
+					path.Generation = &generationItem
+					candidates = append(candidates, newVehicleSearchCandidate(vehicleSearchGeneration, generationItem, modelTokens, path))
+				}
+				path.Generation = nil
+			}
+		}
+	}
+	return candidates
+}
+
+func newVehicleSearchCandidate(level string, item dto.VehicleCatalogPathItem, parents []string, result dto.VehicleSearchResult) vehicleSearchCandidate {
+	result.Level = level
+	result.ID = item.ID
+	result.NameFa = item.NameFa
+	result.NameEn = item.NameEn
+	return vehicleSearchCandidate{
+		result:  result,
+		names:   [][]string{searchTokens(item.NameFa), searchTokens(item.NameEn)},
+		parents: parents,
+	}
+}
+
+func appendSearchTokens(tokens []string, item dto.VehicleCatalogPathItem) []string {
+	return slices.Concat(tokens, searchTokens(item.NameFa), searchTokens(item.NameEn))
+}
+
+// searchTokens normalizes Persian text and splits it into lower case words. Numbers are split from letters,
+// so "L90" and "ال۹۰" become "l 90" and "ال 90", and diacritics and tatweel are dropped
+func searchTokens(value string) []string {
+	tokens := []string{}
+	var token []rune
+	flush := func() {
+		if len(token) > 0 {
+			tokens = append(tokens, string(token))
+			token = token[:0]
+		}
+	}
+	for _, r := range strings.ToLower(persian.Normalize(value)) {
+		switch {
+		case unicode.Is(unicode.Mn, r) || r == '\u0640':
+		case unicode.IsDigit(r):
+			if len(token) > 0 && !unicode.IsDigit(token[len(token)-1]) {
+				flush()
+			}
+			token = append(token, r)
+		case unicode.IsLetter(r):
+			if len(token) > 0 && unicode.IsDigit(token[len(token)-1]) {
+				flush()
+			}
+			token = append(token, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// scoreVehicleSearch rates a candidate between 0 and 1 by how well each query word matches, and slightly
+// prefers candidates whose own name is covered by the query, so "206" ranks the model above "206 SD"
+func scoreVehicleSearch(query []string, candidate vehicleSearchCandidate) float64 {
+	if len(query) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	matchesOwnName := false
+	for _, word := range query {
+		best := 0.0
+		for _, name := range candidate.names {
+			for _, token := range name {
+				best = max(best, matchSearchToken(word, token))
+			}
+		}
+		if best > 0 {
+			matchesOwnName = true
+		}
+		for _, token := range candidate.parents {
+			best = max(best, matchSearchToken(word, token))
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	if !matchesOwnName {
+		return 0
+	}
+
+	coverage := 0.0
+	for _, name := range candidate.names {
+		if len(name) == 0 {
+			continue
+		}
+		covered := 0
+		for _, token := range name {
+			for _, word := range query {
+				if matchSearchToken(word, token) > 0 {
+					covered++
+					break
+				}
+			}
+		}
+		coverage = max(coverage, float64(covered)/float64(len(name)))
+	}
+	return total / float64(len(query)) * (0.8 + 0.2*coverage)
+}
+
+// matchSearchToken rates how well a query word matches a catalog word, 0 when it does not
+func matchSearchToken(word, token string) float64 {
+	if word == token {
+		return 1
+	}
+	w, t := []rune(word), []rune(token)
+	score := 0.0
+	if len(w) >= 2 && len(w) < len(t) && strings.HasPrefix(token, word) {
+		score = 0.7 + 0.2*float64(len(w))/float64(len(t))
+	}
+	if len(w) >= 3 && strings.Contains(token, word) {
+		score = max(score, 0.6)
+	}
+	if isSearchNumber(w) || isSearchNumber(t) {
+		return score
+	}
+
+	allowed := searchTypoAllowance(len(w))
+	if allowed == 0 {
+		return score
+	}
+	if distance := searchEditDistance(w, t); distance <= allowed {
+		score = max(score, 0.8-0.1*float64(distance-1))
+	}
+	// A typo in a word that is still being typed
+	if len(w) >= 4 && len(w) < len(t) {
+		if distance := searchEditDistance(w, t[:len(w)]); distance <= allowed {
+			score = max(score, 0.65-0.1*float64(distance-1))
+		}
+	}
+	return score
+}
+
+func searchTypoAllowance(length int) int {
+	switch {
+	case length >= 6:
+		return 2
+	case length >= 3:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isSearchNumber(word []rune) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// searchEditDistance counts the insertions, deletions, substitutions and swaps of adjacent letters that turn a into b
+func searchEditDistance(a, b []rune) int {
+	previous2 := make([]int, len(b)+1)
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = min(current[j], previous2[j-2]+1)
+			}
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+	return previous[len(b)]
+}
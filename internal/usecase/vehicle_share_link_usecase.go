@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/config"
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+)
+
+// defaultVehicleShareLinkDays is how long a share link stays valid when the owner does not choose
+const defaultVehicleShareLinkDays = 30
+
+type VehicleShareLinkUseCase interface {
+	// Links of a vehicle, managed by its owner
+	CreateVehicleShareLink(ctx context.Context, userID, vehicleID string, request dto.CreateVehicleShareLinkRequest) (*dto.VehicleShareLinkResponse, error)
+	ListVehicleShareLinks(ctx context.Context, userID, vehicleID string) (*dto.ListVehicleShareLinksResponse, error)
+	RevokeVehicleShareLink(ctx context.Context, userID, vehicleID, linkID string) error
+
+	// Public view of a link
+	GetSharedVehicleHistory(ctx context.Context, token string) (*dto.SharedVehicleHistoryResponse, error)
+}
+
+type vehicleShareLinkUseCase struct {
+	vehicleShareLinkRepository  repository.VehicleShareLinkRepository
+	vehicleMembershipRepository repository.VehicleMembershipRepository
+	serviceVisitRepository      repository.ServiceVisitRepository
+	vehicleAccess               *vehicleAccess
+	secret                      []byte
+	baseURL                     string
+}
+
+func NewVehicleShareLinkUseCase() VehicleShareLinkUseCase {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		logger.Error(err, "Failed to get config")
+		return nil
+	}
+	vehicleMembershipRepository := repository.NewVehicleMembershipRepository()
+	return &vehicleShareLinkUseCase{
+		vehicleShareLinkRepository:  repository.NewVehicleShareLinkRepository(),
+		vehicleMembershipRepository: vehicleMembershipRepository,
+		serviceVisitRepository:      repository.NewServiceVisitRepository(),
+		vehicleAccess:               newVehicleAccess(repository.NewVehicleRepository(), vehicleMembershipRepository),
+		secret:                      []byte(cfg.ShareLink.Secret),
+		baseURL:                     strings.TrimRight(cfg.ShareLink.BaseURL, "/"),
+	}
+}
+
+func (uc *vehicleShareLinkUseCase) CreateVehicleShareLink(ctx context.Context, userID, vehicleID string, request dto.CreateVehicleShareLinkRequest) (*dto.VehicleShareLinkResponse, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateCreateVehicleShareLinkRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle share link request")
+		return nil, errors.ErrInvalidVehicleShareLinkRequest
+	}
+	days := request.ExpiresInDays
+	if days == 0 {
+		days = defaultVehicleShareLinkDays
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		logger.Error(err, "Failed to generate vehicle share link nonce")
+		return nil, errors.ErrFailedToCreateVehicleShareLink
+	}
+
+	link := entity.VehicleShareLink{
+		UserVehicleID:    userVehicle.ID,
+		UserID:           userVehicle.UserID,
+		Nonce:            hex.EncodeToString(nonce),
+		ExpiresAt:        time.Now().AddDate(0, 0, days),
+		HideVIN:          request.HideVIN,
+		HideLicensePlate: request.HideLicensePlate,
+		HideCosts:        request.HideCosts,
+	}
+	err = uc.vehicleShareLinkRepository.CreateVehicleShareLink(ctx, &link)
+	if err != nil {
+		logger.Error(err, "Failed to create vehicle share link")
+		return nil, errors.ErrFailedToCreateVehicleShareLink
+	}
+	return uc.convertToVehicleShareLinkResponse(link), nil
+}
+
+func (uc *vehicleShareLinkUseCase) ListVehicleShareLinks(ctx context.Context, userID, vehicleID string) (*dto.ListVehicleShareLinksResponse, error) {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return nil, err
+	}
+
+	links := []entity.VehicleShareLink{}
+	err = uc.vehicleShareLinkRepository.ListVehicleShareLinks(ctx, userVehicle.ID, &links)
+	if err != nil {
+		logger.Error(err, "Failed to list vehicle share links")
+		return nil, errors.ErrFailedToListVehicleShareLinks
+	}
+
+	linksResponse := []dto.VehicleShareLinkResponse{}
+	for _, link := range links {
+		linksResponse = append(linksResponse, *uc.convertToVehicleShareLinkResponse(link))
+	}
+	return &dto.ListVehicleShareLinksResponse{ShareLinks: linksResponse}, nil
+}
+
+func (uc *vehicleShareLinkUseCase) RevokeVehicleShareLink(ctx context.Context, userID, vehicleID, linkID string) error {
+	userVehicle, _, err := uc.vehicleAccess.authorizeParams(ctx, userID, vehicleID, entity.OwnerVehicleRole)
+	if err != nil {
+		return err
+	}
+	uintLinkID, err := strconv.ParseUint(linkID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle share link id")
+		return errors.ErrInvalidVehicleShareLinkID
+	}
+
+	link := entity.VehicleShareLink{}
+	err = uc.vehicleShareLinkRepository.GetVehicleShareLink(ctx, uintLinkID, &link)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle share link")
+		return errors.ErrFailedToGetVehicleShareLink
+	}
+	if link.ID == 0 || link.UserVehicleID != userVehicle.ID {
+		return errors.ErrVehicleShareLinkNotFound
+	}
+	if link.RevokedAt != nil {
+		return errors.ErrVehicleShareLinkAlreadyRevoked
+	}
+
+	now := time.Now()
+	link.RevokedAt = &now
+	err = uc.vehicleShareLinkRepository.UpdateVehicleShareLink(ctx, &link)
+	if err != nil {
+		logger.Error(err, "Failed to revoke vehicle share link")
+		return errors.ErrFailedToUpdateVehicleShareLink
+	}
+	return nil
+}
+
+func (uc *vehicleShareLinkUseCase) GetSharedVehicleHistory(ctx context.Context, token string) (*dto.SharedVehicleHistoryResponse, error) {
+	// Tokens that were not signed by us are rejected before touching the database
+	nonce, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(uc.sign(nonce))) {
+		logger.Error(errors.ErrVehicleShareLinkNotFound, "Invalid vehicle share link signature")
+		return nil, errors.ErrVehicleShareLinkNotFound
+	}
+
+	link := entity.VehicleShareLink{}
+	err := uc.vehicleShareLinkRepository.GetVehicleShareLinkByNonce(ctx, nonce, &link)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle share link")
+		return nil, errors.ErrFailedToGetVehicleShareLink
+	}
+	if link.ID == 0 {
+		return nil, errors.ErrVehicleShareLinkNotFound
+	}
+	now := time.Now()
+	switch link.Status(now) {
+	case "revoked":
+		return nil, errors.ErrVehicleShareLinkRevoked
+	case "expired":
+		return nil, errors.ErrVehicleShareLinkExpired
+	}
+
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleMembershipRepository.GetSharedUserVehicle(ctx, link.UserVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "Failed to get shared user vehicle")
+		return nil, errors.ErrFailedToGetSharedVehicleHistory
+	}
+	if userVehicle.ID == 0 {
+		// The vehicle was deleted since it was shared
+		return nil, errors.ErrVehicleShareLinkNotFound
+	}
+
+	serviceVisits := []entity.ServiceVisit{}
+	err = uc.serviceVisitRepository.ListServiceVisits(ctx, strconv.FormatUint(userVehicle.ID, 10), &serviceVisits)
+	if err != nil {
+		logger.Error(err, "Failed to list shared service visits")
+		return nil, errors.ErrFailedToGetSharedVehicleHistory
+	}
+
+	response := &dto.SharedVehicleHistoryResponse{
+		Vehicle: dto.SharedVehicleResponse{
+			Name:           userVehicle.Name,
+			ProductionYear: userVehicle.ProductionYear,
+			Color:          userVehicle.Color,
+			CurrentMileage: userVehicle.CurrentMileage,
+		},
+		ServiceVisits: []dto.ServiceVisitResponse{},
+		ExpiresAt:     link.ExpiresAt.Format(time.RFC3339),
+	}
+	if !link.HideLicensePlate {
+		response.Vehicle.LicensePlate = entity.NormalizeLicensePlate(userVehicle.LicensePlate)
+	}
+	if !link.HideVIN {
+		response.Vehicle.VIN = userVehicle.VIN
+	}
+	for _, serviceVisit := range serviceVisits {
+		response.ServiceVisits = append(response.ServiceVisits, *mapSharedServiceVisitToResponse(&serviceVisit, link.HideCosts))
+	}
+
+	if err := uc.vehicleShareLinkRepository.RecordVehicleShareLinkView(ctx, link.ID, now); err != nil {
+		logger.Error(err, "Failed to record vehicle share link view")
+	}
+	return response, nil
+}
+
+// sign returns the hex HMAC of the nonce of a link, which makes up the second half of its token
+func (uc *vehicleShareLinkUseCase) sign(nonce string) string {
+	mac := hmac.New(sha256.New, uc.secret)
+	mac.Write([]byte(nonce))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (uc *vehicleShareLinkUseCase) convertToVehicleShareLinkResponse(link entity.VehicleShareLink) *dto.VehicleShareLinkResponse {
+	response := &dto.VehicleShareLinkResponse{
+		ID:               link.ID,
+		URL:              uc.baseURL + "/api/v1/shared/vehicles/" + link.Nonce + "." + uc.sign(link.Nonce),
+		ExpiresAt:        link.ExpiresAt.Format(time.RFC3339),
+		HideVIN:          link.HideVIN,
+		HideLicensePlate: link.HideLicensePlate,
+		HideCosts:        link.HideCosts,
+		ViewCount:        link.ViewCount,
+		Status:           link.Status(time.Now()),
+		CreatedAt:        link.CreatedAt.Format(time.RFC3339),
+	}
+	if link.RevokedAt != nil {
+		response.RevokedAt = link.RevokedAt.Format(time.RFC3339)
+	}
+	if link.LastViewedAt != nil {
+		response.LastViewedAt = link.LastViewedAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// mapSharedServiceVisitToResponse maps a service visit for a share link. Attachments are private to the
+// owner and left out, and so are the costs when the owner hid them
+func mapSharedServiceVisitToResponse(serviceVisit *entity.ServiceVisit, hideCosts bool) *dto.ServiceVisitResponse {
+	response := mapServiceVisitToResponse(serviceVisit)
+	response.Attachments = nil
+	if !hideCosts {
+		return response
+	}
+
+	response.Cost = nil
+	if response.OilChange != nil {
+		response.OilChange.Cost = nil
+	}
+	if response.OilFilter != nil {
+		response.OilFilter.Cost = nil
+	}
+	for i := range response.ServiceItems {
+		response.ServiceItems[i].Cost = nil
+	}
+	return response
+}
@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/config"
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/infrastructure/http"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	// vehicleTransferTTL is how long the recipient has to confirm a transfer
+	vehicleTransferTTL = 30 * time.Minute
+	// maxVehicleTransferAttempts is the number of wrong codes after which a transfer is cancelled
+	maxVehicleTransferAttempts = 5
+)
+
+type VehicleTransferUseCase interface {
+	StartVehicleTransfer(ctx context.Context, userID, vehicleID string, request dto.StartVehicleTransferRequest) (*dto.VehicleTransferResponse, error)
+	CancelVehicleTransfer(ctx context.Context, userID, vehicleID, transferID string) error
+	ListIncomingVehicleTransfers(ctx context.Context, userID string) (*dto.ListVehicleTransfersResponse, error)
+	ConfirmVehicleTransfer(ctx context.Context, userID, transferID string, request dto.ConfirmVehicleTransferRequest) (*dto.VehicleTransferResponse, error)
+	GetVehicleOwnershipHistory(ctx context.Context, userID, vehicleID string) (*dto.ListVehicleOwnershipsResponse, error)
+}
+
+type vehicleTransferUseCase struct {
+	vehicleTransferRepository repository.VehicleTransferRepository
+	vehicleRepository         repository.VehicleRepository
+	authRepository            repository.AuthRepository
+	smsService                http.SMSService
+	// transfersConfigured is false while no SMS template is set for the transfer code
+	transfersConfigured bool
+}
+
+func NewVehicleTransferUseCase() VehicleTransferUseCase {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		logger.Error(err, "Failed to get config")
+		return nil
+	}
+	return &vehicleTransferUseCase{
+		vehicleTransferRepository: repository.NewVehicleTransferRepository(),
+		vehicleRepository:         repository.NewVehicleRepository(),
+		authRepository:            repository.NewAuthRepository(),
+		smsService:                http.NewSMSService(cfg.SMS.BaseURL, cfg.SMS.XAPIKey, cfg.SMS.ReminderTemplateID, cfg.SMS.TransferTemplateID),
+		transfersConfigured:       cfg.SMS.TransferTemplateID != "",
+	}
+}
+
+func (uc *vehicleTransferUseCase) StartVehicleTransfer(ctx context.Context, userID, vehicleID string, request dto.StartVehicleTransferRequest) (*dto.VehicleTransferResponse, error) {
+	if !uc.transfersConfigured {
+		logger.Error(errors.ErrVehicleTransfersNotConfigured, "sms.transfer_template_id is not set")
+		return nil, errors.ErrVehicleTransfersNotConfigured
+	}
+
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateStartVehicleTransferRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle transfer request")
+		return nil, errors.ErrInvalidVehicleTransferRequest
+	}
+
+	recipient := entity.User{PhoneNumber: request.PhoneNumber}
+	err = uc.authRepository.FindByPhoneNumber(ctx, &recipient)
+	if err != nil {
+		logger.Error(err, "Failed to find vehicle transfer recipient")
+		if errors.Is(err, errors.ErrUserNotFound) {
+			return nil, errors.ErrVehicleTransferRecipientNotFound
+		}
+		return nil, errors.ErrFailedToCreateVehicleTransfer
+	}
+	if recipient.ID == userVehicle.UserID {
+		return nil, errors.ErrVehicleTransferToSelf
+	}
+
+	now := time.Now()
+	open := entity.VehicleTransfer{}
+	err = uc.vehicleTransferRepository.GetOpenVehicleTransfer(ctx, userVehicle.ID, now, &open)
+	if err != nil {
+		logger.Error(err, "Failed to get open vehicle transfer")
+		return nil, errors.ErrFailedToCreateVehicleTransfer
+	}
+	if open.ID != 0 {
+		return nil, errors.ErrVehicleTransferAlreadyPending
+	}
+
+	code := generateCode()
+	transfer := entity.VehicleTransfer{
+		UserVehicleID: userVehicle.ID,
+		FromUserID:    userVehicle.UserID,
+		ToUserID:      recipient.ID,
+		ToPhoneNumber: recipient.PhoneNumber,
+		Status:        entity.PendingVehicleTransfer,
+		ExpiresAt:     now.Add(vehicleTransferTTL),
+	}
+	transfer.SetCode(code)
+	err = uc.vehicleTransferRepository.CreateVehicleTransfer(ctx, &transfer)
+	if err != nil {
+		logger.Error(err, "Failed to create vehicle transfer")
+		return nil, errors.ErrFailedToCreateVehicleTransfer
+	}
+
+	err = uc.smsService.SendVehicleTransferCode(ctx, recipient.PhoneNumber, userVehicle.Name, code)
+	if err != nil {
+		logger.Error(err, "Failed to send vehicle transfer code via SMS")
+		// A transfer the recipient never got a code for must not block a retry
+		transfer.Status = entity.CancelledVehicleTransfer
+		if err := uc.vehicleTransferRepository.UpdateVehicleTransfer(ctx, &transfer); err != nil {
+			logger.Error(err, "Failed to cancel vehicle transfer")
+		}
+		return nil, errors.ErrFailedToSendVehicleTransferCode
+	}
+
+	return uc.convertToVehicleTransferResponse(transfer, userVehicle.Name), nil
+}
+
+func (uc *vehicleTransferUseCase) CancelVehicleTransfer(ctx context.Context, userID, vehicleID, transferID string) error {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return err
+	}
+	transfer, err := uc.getVehicleTransfer(ctx, transferID)
+	if err != nil {
+		return err
+	}
+	if transfer.UserVehicleID != userVehicle.ID || transfer.FromUserID != userVehicle.UserID {
+		return errors.ErrVehicleTransferNotOwned
+	}
+	if !transfer.IsOpen(time.Now()) {
+		return errors.ErrVehicleTransferNotOpen
+	}
+
+	transfer.Status = entity.CancelledVehicleTransfer
+	err = uc.vehicleTransferRepository.UpdateVehicleTransfer(ctx, transfer)
+	if err != nil {
+		logger.Error(err, "Failed to cancel vehicle transfer")
+		return errors.ErrFailedToUpdateVehicleTransfer
+	}
+	return nil
+}
+
+func (uc *vehicleTransferUseCase) ListIncomingVehicleTransfers(ctx context.Context, userID string) (*dto.ListVehicleTransfersResponse, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+
+	transfers := []entity.VehicleTransfer{}
+	err = uc.vehicleTransferRepository.ListIncomingVehicleTransfers(ctx, uuidUserID, time.Now(), &transfers)
+	if err != nil {
+		logger.Error(err, "Failed to list incoming vehicle transfers")
+		return nil, errors.ErrFailedToListVehicleTransfers
+	}
+
+	transfersResponse := []dto.VehicleTransferResponse{}
+	for _, transfer := range transfers {
+		userVehicle := entity.UserVehicle{}
+		err = uc.vehicleRepository.GetUserVehicle(ctx, transfer.FromUserID, transfer.UserVehicleID, &userVehicle)
+		if err != nil {
+			// The vehicle was deleted or changed hands since the transfer started
+			logger.Error(err, "Failed to get vehicle of transfer")
+			continue
+		}
+		transfersResponse = append(transfersResponse, *uc.convertToVehicleTransferResponse(transfer, userVehicle.Name))
+	}
+	return &dto.ListVehicleTransfersResponse{Transfers: transfersResponse}, nil
+}
+
+func (uc *vehicleTransferUseCase) ConfirmVehicleTransfer(ctx context.Context, userID, transferID string, request dto.ConfirmVehicleTransferRequest) (*dto.VehicleTransferResponse, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	err = validation.ValidateConfirmVehicleTransferRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle transfer confirmation")
+		return nil, errors.ErrInvalidVehicleTransferConfirmation
+	}
+
+	transfer, err := uc.getVehicleTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	// Transfers addressed to someone else are reported as missing rather than revealed
+	if transfer.ToUserID != uuidUserID {
+		return nil, errors.ErrVehicleTransferNotFound
+	}
+	now := time.Now()
+	if !transfer.IsOpen(now) {
+		return nil, errors.ErrVehicleTransferNotOpen
+	}
+
+	if !transfer.CheckCode(request.Code) {
+		transfer.Attempts++
+		if transfer.Attempts >= maxVehicleTransferAttempts {
+			transfer.Status = entity.CancelledVehicleTransfer
+		}
+		err = uc.vehicleTransferRepository.UpdateVehicleTransfer(ctx, transfer)
+		if err != nil {
+			logger.Error(err, "Failed to update vehicle transfer attempts")
+			return nil, errors.ErrFailedToUpdateVehicleTransfer
+		}
+		if transfer.Status == entity.CancelledVehicleTransfer {
+			return nil, errors.ErrVehicleTransferTooManyAttempts
+		}
+		return nil, errors.ErrInvalidVehicleTransferCode
+	}
+
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleRepository.GetUserVehicle(ctx, transfer.FromUserID, transfer.UserVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle of transfer")
+		return nil, errors.ErrVehicleTransferNotOpen
+	}
+
+	// Vehicles registered before ownership was tracked are credited to the sender from their purchase
+	previousOwnerSince := userVehicle.PurchaseDate
+	if previousOwnerSince.IsZero() {
+		previousOwnerSince = userVehicle.CreatedAt
+	}
+	transfer.Status = entity.CompletedVehicleTransfer
+	transfer.CompletedAt = &now
+	err = uc.vehicleTransferRepository.CompleteVehicleTransfer(ctx, transfer, previousOwnerSince)
+	if err != nil {
+		logger.Error(err, "Failed to complete vehicle transfer")
+		if errors.Is(err, errors.ErrVehicleTransferNotOpen) {
+			return nil, errors.ErrVehicleTransferNotOpen
+		}
+		return nil, errors.ErrFailedToCompleteVehicleTransfer
+	}
+
+	return uc.convertToVehicleTransferResponse(*transfer, userVehicle.Name), nil
+}
+
+func (uc *vehicleTransferUseCase) GetVehicleOwnershipHistory(ctx context.Context, userID, vehicleID string) (*dto.ListVehicleOwnershipsResponse, error) {
+	userVehicle, err := uc.getOwnedUserVehicle(ctx, userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerships := []entity.VehicleOwnership{}
+	err = uc.vehicleTransferRepository.ListVehicleOwnerships(ctx, userVehicle.ID, &ownerships)
+	if err != nil {
+		logger.Error(err, "Failed to list vehicle ownerships")
+		return nil, errors.ErrFailedToListVehicleOwnerships
+	}
+	// A vehicle that never changed hands may predate ownership tracking
+	if len(ownerships) == 0 {
+		startedAt := userVehicle.PurchaseDate
+		if startedAt.IsZero() {
+			startedAt = userVehicle.CreatedAt
+		}
+		ownerships = append(ownerships, entity.VehicleOwnership{
+			UserVehicleID: userVehicle.ID,
+			UserID:        userVehicle.UserID,
+			StartedAt:     startedAt,
+		})
+	}
+
+	owners := map[uuid.UUID]string{}
+	ownershipsResponse := []dto.VehicleOwnershipResponse{}
+	for _, ownership := range ownerships {
+		name, ok := owners[ownership.UserID]
+		if !ok {
+			owner := entity.User{BaseEntity: entity.BaseEntity{ID: ownership.UserID}}
+			if err := uc.authRepository.FindByID(ctx, &owner); err != nil {
+				logger.Error(err, "Failed to find vehicle owner")
+			}
+			name = fullName(owner)
+			owners[ownership.UserID] = name
+		}
+
+		response := dto.VehicleOwnershipResponse{
+			OwnerName:     name,
+			IsCurrentUser: ownership.UserID == userVehicle.UserID,
+			StartedAt:     ownership.StartedAt.Format("2006-01-02"),
+		}
+		if ownership.EndedAt != nil {
+			response.EndedAt = ownership.EndedAt.Format("2006-01-02")
+		}
+		ownershipsResponse = append(ownershipsResponse, response)
+	}
+	return &dto.ListVehicleOwnershipsResponse{Ownerships: ownershipsResponse}, nil
+}
+
+func (uc *vehicleTransferUseCase) getOwnedUserVehicle(ctx context.Context, userID, vehicleID string) (*entity.UserVehicle, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error(err, "Failed to parse user id")
+		return nil, errors.ErrInvalidUserID
+	}
+	uintUserVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse user vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+
+	userVehicle := entity.UserVehicle{}
+	err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &userVehicle)
+	if err != nil {
+		logger.Error(err, "User vehicle not owned by user")
+		return nil, errors.ErrUserVehicleNotOwned
+	}
+	return &userVehicle, nil
+}
+
+func (uc *vehicleTransferUseCase) getVehicleTransfer(ctx context.Context, transferID string) (*entity.VehicleTransfer, error) {
+	uintTransferID, err := strconv.ParseUint(transferID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle transfer id")
+		return nil, errors.ErrInvalidVehicleTransferID
+	}
+	transfer := entity.VehicleTransfer{}
+	err = uc.vehicleTransferRepository.GetVehicleTransfer(ctx, uintTransferID, &transfer)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle transfer")
+		return nil, errors.ErrFailedToGetVehicleTransfer
+	}
+	if transfer.ID == 0 {
+		return nil, errors.ErrVehicleTransferNotFound
+	}
+	return &transfer, nil
+}
+
+func (uc *vehicleTransferUseCase) convertToVehicleTransferResponse(transfer entity.VehicleTransfer, vehicleName string) *dto.VehicleTransferResponse {
+	response := &dto.VehicleTransferResponse{
+		ID:            transfer.ID,
+		UserVehicleID: transfer.UserVehicleID,
+		VehicleName:   vehicleName,
+		ToPhoneNumber: transfer.ToPhoneNumber,
+		Status:        transfer.Status.String(),
+		ExpiresAt:     transfer.ExpiresAt.Format(time.RFC3339),
+		CreatedAt:     transfer.CreatedAt.Format(time.RFC3339),
+	}
+	if transfer.CompletedAt != nil {
+		response.CompletedAt = transfer.CompletedAt.Format(time.RFC3339)
+	}
+	return response
+}
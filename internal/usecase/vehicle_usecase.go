@@ -3,8 +3,10 @@ package usecase
 import (
 	"context"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/amirdashtii/AutoBan/config"
 	"github.com/amirdashtii/AutoBan/internal/domain/entity"
 	"github.com/amirdashtii/AutoBan/internal/dto"
 	"github.com/amirdashtii/AutoBan/internal/errors"
@@ -20,28 +22,28 @@ type VehicleUseCase interface {
 	GetVehicleType(ctx context.Context, typeID string) (*dto.VehicleTypeResponse, error)
 	CreateVehicleType(ctx context.Context, request dto.CreateVehicleTypeRequest) (*dto.VehicleTypeResponse, error)
 	UpdateVehicleType(ctx context.Context, typeID string, request dto.UpdateVehicleTypeRequest) (*dto.VehicleTypeResponse, error)
-	DeleteVehicleType(ctx context.Context, typeID string) error
+	DeleteVehicleType(ctx context.Context, typeID string, request dto.DeleteVehicleCatalogRowRequest) error
 
 	// Brands
 	GetBrand(ctx context.Context, typeID, brandID string) (*dto.VehicleBrandResponse, error)
 	ListBrands(ctx context.Context, typeID string) (*dto.ListVehicleBrandsResponse, error)
 	CreateBrand(ctx context.Context, typeID string, request dto.CreateVehicleBrandRequest) (*dto.VehicleBrandResponse, error)
 	UpdateBrand(ctx context.Context, typeID, brandID string, request dto.UpdateVehicleBrandRequest) (*dto.VehicleBrandResponse, error)
-	DeleteBrand(ctx context.Context, typeID, brandID string) error
+	DeleteBrand(ctx context.Context, typeID, brandID string, request dto.DeleteVehicleCatalogRowRequest) error
 
 	// Models
 	GetModel(ctx context.Context, typeID, brandID, modelID string) (*dto.VehicleModelResponse, error)
 	ListModels(ctx context.Context, typeID, brandID string) (*dto.ListVehicleModelsResponse, error)
 	CreateModel(ctx context.Context, typeID, brandID string, request dto.CreateVehicleModelRequest) (*dto.VehicleModelResponse, error)
 	UpdateModel(ctx context.Context, typeID, brandID, modelID string, request dto.UpdateVehicleModelRequest) (*dto.VehicleModelResponse, error)
-	DeleteModel(ctx context.Context, typeID, brandID, modelID string) error
+	DeleteModel(ctx context.Context, typeID, brandID, modelID string, request dto.DeleteVehicleCatalogRowRequest) error
 
 	// Generations
 	GetGeneration(ctx context.Context, typeID, brandID, modelID, generationID string) (*dto.VehicleGenerationResponse, error)
 	ListGenerations(ctx context.Context, typeID, brandID, modelID string) (*dto.ListVehicleGenerationsResponse, error)
 	CreateGeneration(ctx context.Context, typeID, brandID, modelID string, request dto.CreateVehicleGenerationRequest) (*dto.VehicleGenerationResponse, error)
 	UpdateGeneration(ctx context.Context, typeID, brandID, modelID, generationID string, request dto.UpdateVehicleGenerationRequest) (*dto.VehicleGenerationResponse, error)
-	DeleteGeneration(ctx context.Context, typeID, brandID, modelID, generationID string) error
+	DeleteGeneration(ctx context.Context, typeID, brandID, modelID, generationID string, request dto.DeleteVehicleCatalogRowRequest) error
 
 	// User Vehicles
 	AddUserVehicle(ctx context.Context, userID string, request *dto.CreateUserVehicleRequest) (*dto.UserVehicleResponse, error)
@@ -49,22 +51,48 @@ type VehicleUseCase interface {
 	GetUserVehicle(ctx context.Context, userID, vehicleID string) (*dto.UserVehicleResponse, error)
 	UpdateUserVehicle(ctx context.Context, userID, vehicleID string, request *dto.UpdateUserVehicleRequest) (*dto.UserVehicleResponse, error)
 	DeleteUserVehicle(ctx context.Context, userID, vehicleID string) error
+	ExportServiceBook(ctx context.Context, userID, vehicleID string, request dto.ExportServiceBookRequest) (*dto.ExportFile, error)
 
 	// Complete hierarchy
 	GetCompleteVehicleHierarchy(ctx context.Context) (*dto.CompleteVehicleHierarchyResponse, error)
+
+	// Catalog sync
+	GetVehicleCatalogVersion(ctx context.Context) (uint64, error)
+	ListVehicleCatalogChanges(ctx context.Context, request dto.ListVehicleCatalogChangesRequest) (*dto.ListVehicleCatalogChangesResponse, error)
+
+	// Search
+	SearchVehicles(ctx context.Context, request dto.SearchVehiclesRequest) (*dto.SearchVehiclesResponse, error)
 }
 
 type vehicleUseCase struct {
-	vehicleRepository      repository.VehicleRepository
-	vehicleCacheRepository repository.VehicleCacheRepository
+	vehicleRepository         repository.VehicleRepository
+	vehicleCacheRepository    repository.VehicleCacheRepository
+	catalogRepository         repository.CatalogRepository
+	odometerTracker           *odometerTracker
+	maintenancePlanner        *maintenancePlanner
+	serviceVisitRepository    repository.ServiceVisitRepository
+	vehicleTransferRepository repository.VehicleTransferRepository
+	pdfFontPath               string
 }
 
 func NewVehicleUseCase() VehicleUseCase {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		logger.Error(err, "Failed to get config")
+		return nil
+	}
 	vehicleRepository := repository.NewVehicleRepository()
 	vehicleCacheRepository := repository.NewVehicleCacheRepository()
+	odometerReadingRepository := repository.NewOdometerReadingRepository()
 	return &vehicleUseCase{
-		vehicleRepository:      vehicleRepository,
-		vehicleCacheRepository: vehicleCacheRepository,
+		vehicleRepository:         vehicleRepository,
+		vehicleCacheRepository:    vehicleCacheRepository,
+		catalogRepository:         repository.NewCatalogRepository(),
+		odometerTracker:           newOdometerTracker(odometerReadingRepository, vehicleRepository),
+		maintenancePlanner:        newMaintenancePlanner(repository.NewMaintenanceScheduleRepository()),
+		serviceVisitRepository:    repository.NewServiceVisitRepository(),
+		vehicleTransferRepository: repository.NewVehicleTransferRepository(),
+		pdfFontPath:               cfg.Export.PDFFontPath,
 	}
 }
 
@@ -175,7 +203,7 @@ func (uc *vehicleUseCase) UpdateVehicleType(ctx context.Context, typeID string,
 	return uc.convertToVehicleTypeResponse(vehicleType), nil
 }
 
-func (uc *vehicleUseCase) DeleteVehicleType(ctx context.Context, typeID string) error {
+func (uc *vehicleUseCase) DeleteVehicleType(ctx context.Context, typeID string, request dto.DeleteVehicleCatalogRowRequest) error {
 	vehicleType := entity.VehicleType{}
 	uintTypeID, err := strconv.ParseUint(typeID, 10, 64)
 	if err != nil {
@@ -183,7 +211,11 @@ func (uc *vehicleUseCase) DeleteVehicleType(ctx context.Context, typeID string)
 		return errors.ErrInvalidVehicleTypeID
 	}
 	vehicleType.ID = uintTypeID
-	err = uc.vehicleRepository.DeleteVehicleType(ctx, &vehicleType)
+	err = uc.checkCatalogDeletion(ctx, entity.CatalogLevelType, uintTypeID, request.ReassignToGenerationID)
+	if err != nil {
+		return err
+	}
+	err = uc.vehicleRepository.DeleteVehicleType(ctx, &vehicleType, request.ReassignToGenerationID)
 	if err != nil {
 		logger.Error(err, "Failed to delete vehicle type")
 		return errors.ErrFailedToDeleteVehicleType
@@ -340,7 +372,7 @@ func (uc *vehicleUseCase) UpdateBrand(ctx context.Context, typeID, brandID strin
 	return uc.convertToVehicleBrandResponse(brand), nil
 }
 
-func (uc *vehicleUseCase) DeleteBrand(ctx context.Context, typeID, brandID string) error {
+func (uc *vehicleUseCase) DeleteBrand(ctx context.Context, typeID, brandID string, request dto.DeleteVehicleCatalogRowRequest) error {
 	brand := entity.VehicleBrand{}
 	uintTypeID, err := strconv.ParseUint(typeID, 10, 64)
 	if err != nil {
@@ -354,7 +386,11 @@ func (uc *vehicleUseCase) DeleteBrand(ctx context.Context, typeID, brandID strin
 	}
 	brand.ID = uintBrandID
 	brand.VehicleTypeID = uintTypeID
-	err = uc.vehicleRepository.DeleteBrand(ctx, &brand)
+	err = uc.checkCatalogDeletion(ctx, entity.CatalogLevelBrand, uintBrandID, request.ReassignToGenerationID)
+	if err != nil {
+		return err
+	}
+	err = uc.vehicleRepository.DeleteBrand(ctx, &brand, request.ReassignToGenerationID)
 	if err != nil {
 		logger.Error(err, "Failed to delete vehicle brand")
 		return errors.ErrFailedToDeleteVehicleBrand
@@ -515,7 +551,7 @@ func (uc *vehicleUseCase) UpdateModel(ctx context.Context, typeID, brandID, mode
 	return uc.convertToVehicleModelResponse(model), nil
 }
 
-func (uc *vehicleUseCase) DeleteModel(ctx context.Context, typeID, brandID, modelID string) error {
+func (uc *vehicleUseCase) DeleteModel(ctx context.Context, typeID, brandID, modelID string, request dto.DeleteVehicleCatalogRowRequest) error {
 	_ = typeID
 	uintBrandID, err := strconv.ParseUint(brandID, 10, 64)
 	if err != nil {
@@ -530,7 +566,11 @@ func (uc *vehicleUseCase) DeleteModel(ctx context.Context, typeID, brandID, mode
 	model := entity.VehicleModel{}
 	model.ID = uintModelID
 	model.BrandID = uintBrandID
-	err = uc.vehicleRepository.DeleteModel(ctx, &model)
+	err = uc.checkCatalogDeletion(ctx, entity.CatalogLevelModel, uintModelID, request.ReassignToGenerationID)
+	if err != nil {
+		return err
+	}
+	err = uc.vehicleRepository.DeleteModel(ctx, &model, request.ReassignToGenerationID)
 	if err != nil {
 		logger.Error(err, "Failed to delete vehicle model")
 		return errors.ErrFailedToDeleteVehicleModel
@@ -642,6 +682,11 @@ func (uc *vehicleUseCase) CreateGeneration(ctx context.Context, typeID, brandID,
 		Seller:        request.Seller,
 		AssemblyType:  request.AssemblyType,
 		Assembler:     request.Assembler,
+		TireSizes:     joinList(request.TireSizes),
+
+		OilViscosities:       joinList(request.OilViscosities),
+		OilCapacity:          request.OilCapacity,
+		OilFilterPartNumbers: joinList(request.OilFilterPartNumbers),
 	}
 	err = uc.vehicleRepository.CreateGeneration(ctx, &generation)
 	if err != nil {
@@ -736,6 +781,18 @@ func (uc *vehicleUseCase) UpdateGeneration(ctx context.Context, typeID, brandID,
 	if request.Assembler != nil {
 		generation.Assembler = *request.Assembler
 	}
+	if request.TireSizes != nil {
+		generation.TireSizes = joinList(*request.TireSizes)
+	}
+	if request.OilViscosities != nil {
+		generation.OilViscosities = joinList(*request.OilViscosities)
+	}
+	if request.OilCapacity != nil {
+		generation.OilCapacity = *request.OilCapacity
+	}
+	if request.OilFilterPartNumbers != nil {
+		generation.OilFilterPartNumbers = joinList(*request.OilFilterPartNumbers)
+	}
 
 	err = uc.vehicleRepository.UpdateGeneration(ctx, &generation)
 	if err != nil {
@@ -753,7 +810,7 @@ func (uc *vehicleUseCase) UpdateGeneration(ctx context.Context, typeID, brandID,
 	return uc.convertToVehicleGenerationResponse(generation), nil
 }
 
-func (uc *vehicleUseCase) DeleteGeneration(ctx context.Context, typeID, brandID, modelID, generationID string) error {
+func (uc *vehicleUseCase) DeleteGeneration(ctx context.Context, typeID, brandID, modelID, generationID string, request dto.DeleteVehicleCatalogRowRequest) error {
 	_ = typeID
 	_ = brandID
 	_ = modelID
@@ -765,7 +822,11 @@ func (uc *vehicleUseCase) DeleteGeneration(ctx context.Context, typeID, brandID,
 
 	generation := entity.VehicleGeneration{}
 	generation.ID = uintGenerationID
-	err = uc.vehicleRepository.DeleteGeneration(ctx, &generation)
+	err = uc.checkCatalogDeletion(ctx, entity.CatalogLevelGeneration, uintGenerationID, request.ReassignToGenerationID)
+	if err != nil {
+		return err
+	}
+	err = uc.vehicleRepository.DeleteGeneration(ctx, &generation, request.ReassignToGenerationID)
 	if err != nil {
 		logger.Error(err, "Failed to delete vehicle generation")
 		return errors.ErrFailedToDeleteVehicleGeneration
@@ -781,6 +842,38 @@ func (uc *vehicleUseCase) DeleteGeneration(ctx context.Context, typeID, brandID,
 	return nil
 }
 
+// checkCatalogDeletion refuses to delete a catalog row while user vehicles are on the generations under it, which
+// would hide their generation, unless they are reassigned to a generation that stays in the catalog
+func (uc *vehicleUseCase) checkCatalogDeletion(ctx context.Context, level string, id, reassignToGenerationID uint64) error {
+	if reassignToGenerationID != 0 {
+		vehicleType, brand, model, generation, err := uc.resolvePathForGeneration(ctx, reassignToGenerationID)
+		if err != nil {
+			logger.Error(err, "Failed to get generation to reassign user vehicles to")
+			return errors.ErrInvalidReassignGeneration
+		}
+		pathIDs := map[string]uint64{
+			entity.CatalogLevelType:       vehicleType.ID,
+			entity.CatalogLevelBrand:      brand.ID,
+			entity.CatalogLevelModel:      model.ID,
+			entity.CatalogLevelGeneration: generation.ID,
+		}
+		if pathIDs[level] == id {
+			return errors.ErrInvalidReassignGeneration
+		}
+		return nil
+	}
+
+	count, err := uc.catalogRepository.CountCatalogUserVehicles(ctx, level, id)
+	if err != nil {
+		logger.Error(err, "Failed to count user vehicles of catalog row")
+		return errors.ErrFailedToCheckVehicleCatalogUsage
+	}
+	if count > 0 {
+		return errors.Copy(errors.ErrVehicleCatalogInUse).WithDetail("user_vehicles", count)
+	}
+	return nil
+}
+
 func (uc *vehicleUseCase) convertToVehicleGenerationResponse(generation entity.VehicleGeneration) *dto.VehicleGenerationResponse {
 	return &dto.VehicleGenerationResponse{
 		ID:            generation.ID,
@@ -804,7 +897,23 @@ func (uc *vehicleUseCase) convertToVehicleGenerationResponse(generation entity.V
 		Seller:        generation.Seller,
 		AssemblyType:  generation.AssemblyType,
 		Assembler:     generation.Assembler,
+		TireSizes:     generation.TireSizeList(),
+
+		OilViscosities:       generation.OilViscosityList(),
+		OilCapacity:          generation.OilCapacity,
+		OilFilterPartNumbers: generation.OilFilterPartNumberList(),
+	}
+}
+
+// joinList stores list fields of a generation, such as its tire sizes, as a comma separated column
+func joinList(values []string) string {
+	trimmed := []string{}
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
 	}
+	return strings.Join(trimmed, ",")
 }
 
 // User Vehicles
@@ -827,22 +936,64 @@ func (uc *vehicleUseCase) AddUserVehicle(ctx context.Context, userID string, req
 		return nil, errors.ErrInvalidPurchaseDate
 	}
 
+	licensePlate := entity.NormalizeLicensePlate(request.LicensePlate)
+	err = uc.checkLicensePlate(ctx, licensePlate, 0)
+	if err != nil {
+		return nil, err
+	}
+
 	userVehicle := entity.UserVehicle{
 		UserID:         uuidUserID,
 		GenerationID:   request.GenerationID,
 		Name:           request.Name,
 		ProductionYear: request.ProductionYear,
 		Color:          request.Color,
-		LicensePlate:   request.LicensePlate,
+		LicensePlate:   licensePlate,
 		VIN:            request.VIN,
 		CurrentMileage: request.CurrentMileage,
 		PurchaseDate:   purchaseDate,
 	}
 	err = uc.vehicleRepository.CreateUserVehicle(ctx, &userVehicle)
 	if err != nil {
+		// Another vehicle took the plate between the check and the write
+		if err == errors.ErrLicensePlateAlreadyRegistered {
+			return nil, err
+		}
 		logger.Error(err, "Failed to create user vehicle")
 		return nil, errors.ErrFailedToCreateUserVehicle
 	}
+
+	// The mileage entered on registration is the vehicle's first odometer reading
+	if userVehicle.CurrentMileage > 0 {
+		odometerReading := entity.OdometerReading{
+			UserID:        userVehicle.UserID,
+			UserVehicleID: userVehicle.ID,
+			Mileage:       uint(userVehicle.CurrentMileage),
+			ReadingDate:   time.Now().Truncate(24 * time.Hour),
+			Source:        entity.ManualOdometerReading,
+		}
+		err = uc.odometerTracker.recordReading(ctx, &userVehicle, &odometerReading)
+		if err != nil {
+			logger.Error(err, "Failed to record initial odometer reading")
+		}
+	}
+
+	// Start the vehicle on its generation's manufacturer maintenance schedule
+	_, err = uc.maintenancePlanner.generatePlan(ctx, &userVehicle)
+	if err != nil {
+		logger.Error(err, "Failed to generate maintenance plan")
+	}
+
+	// The ownership history starts with the user who registered the vehicle
+	ownership := entity.VehicleOwnership{
+		UserVehicleID: userVehicle.ID,
+		UserID:        userVehicle.UserID,
+		StartedAt:     userVehicle.PurchaseDate,
+	}
+	err = uc.vehicleTransferRepository.CreateVehicleOwnership(ctx, &ownership)
+	if err != nil {
+		logger.Error(err, "Failed to create vehicle ownership")
+	}
 	return uc.convertToUserVehicleResponse(userVehicle), nil
 }
 
@@ -949,6 +1100,36 @@ func (uc *vehicleUseCase) UpdateUserVehicle(ctx context.Context, userID, vehicle
 		return nil, errors.ErrInvalidPurchaseDate
 	}
 
+	uintUserVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse user vehicle id")
+		return nil, errors.ErrInvalidUserVehicleID
+	}
+
+	// A new current mileage is stored as an odometer reading, which must not go backwards
+	var existingVehicle entity.UserVehicle
+	var odometerReading *entity.OdometerReading
+	if request.CurrentMileage != nil {
+		err = uc.vehicleRepository.GetUserVehicle(ctx, uuidUserID, uintUserVehicleID, &existingVehicle)
+		if err != nil {
+			logger.Error(err, "User vehicle not owned by user")
+			return nil, errors.ErrUserVehicleNotOwned
+		}
+		if *request.CurrentMileage != existingVehicle.CurrentMileage {
+			odometerReading = &entity.OdometerReading{
+				UserID:        uuidUserID,
+				UserVehicleID: uintUserVehicleID,
+				Mileage:       uint(*request.CurrentMileage),
+				ReadingDate:   time.Now().Truncate(24 * time.Hour),
+				Source:        entity.ManualOdometerReading,
+			}
+			err = uc.odometerTracker.validateReading(ctx, odometerReading)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	userVehicle := entity.UserVehicle{
 		UserID: uuidUserID,
 	}
@@ -967,29 +1148,37 @@ func (uc *vehicleUseCase) UpdateUserVehicle(ctx context.Context, userID, vehicle
 		userVehicle.Color = *request.Color
 	}
 	if request.LicensePlate != nil {
-		userVehicle.LicensePlate = *request.LicensePlate
+		userVehicle.LicensePlate = entity.NormalizeLicensePlate(*request.LicensePlate)
+		err = uc.checkLicensePlate(ctx, userVehicle.LicensePlate, uintUserVehicleID)
+		if err != nil {
+			return nil, err
+		}
 	}
 	if request.VIN != nil {
 		userVehicle.VIN = *request.VIN
 	}
-	if request.CurrentMileage != nil {
-		userVehicle.CurrentMileage = *request.CurrentMileage
-	}
 	if request.PurchaseDate != nil {
 		userVehicle.PurchaseDate = purchaseDate
 	}
 
-	uintUserVehicleID, err := strconv.ParseUint(vehicleID, 10, 64)
-	if err != nil {
-		logger.Error(err, "Failed to parse user vehicle id")
-		return nil, errors.ErrInvalidUserVehicleID
-	}
 	userVehicle.ID = uintUserVehicleID
 	err = uc.vehicleRepository.UpdateUserVehicle(ctx, &userVehicle)
 	if err != nil {
+		if err == errors.ErrLicensePlateAlreadyRegistered {
+			return nil, err
+		}
 		logger.Error(err, "Failed to update user vehicle")
 		return nil, errors.ErrFailedToUpdateUserVehicle
 	}
+
+	if odometerReading != nil {
+		err = uc.odometerTracker.recordReading(ctx, &existingVehicle, odometerReading)
+		if err != nil {
+			logger.Error(err, "Failed to record odometer reading")
+			return nil, errors.ErrFailedToUpdateUserVehicle
+		}
+		userVehicle.CurrentMileage = existingVehicle.CurrentMileage
+	}
 	return uc.convertToUserVehicleResponse(userVehicle), nil
 }
 
@@ -1018,8 +1207,24 @@ func (uc *vehicleUseCase) DeleteUserVehicle(ctx context.Context, userID, vehicle
 	return nil
 }
 
+// checkLicensePlate makes sure no other active vehicle is registered with the plate
+func (uc *vehicleUseCase) checkLicensePlate(ctx context.Context, licensePlate string, vehicleID uint64) error {
+	if licensePlate == "" {
+		return nil
+	}
+	registered, err := uc.vehicleRepository.IsLicensePlateRegistered(ctx, licensePlate, vehicleID)
+	if err != nil {
+		logger.Error(err, "Failed to check license plate")
+		return errors.ErrFailedToCheckLicensePlate
+	}
+	if registered {
+		return errors.ErrLicensePlateAlreadyRegistered
+	}
+	return nil
+}
+
 func (uc *vehicleUseCase) convertToUserVehicleResponse(userVehicle entity.UserVehicle) *dto.UserVehicleResponse {
-	return &dto.UserVehicleResponse{
+	response := &dto.UserVehicleResponse{
 		ID:             userVehicle.ID,
 		UserID:         userVehicle.UserID,
 		GenerationID:   userVehicle.GenerationID,
@@ -1031,14 +1236,54 @@ func (uc *vehicleUseCase) convertToUserVehicleResponse(userVehicle entity.UserVe
 		CurrentMileage: userVehicle.CurrentMileage,
 		PurchaseDate:   userVehicle.PurchaseDate,
 	}
+
+	// Plates saved before they were parsed may not read as one and are returned as they were typed
+	if plate, err := entity.ParseLicensePlate(userVehicle.LicensePlate); err == nil {
+		response.LicensePlate = plate.String()
+		response.LicensePlateDetails = convertToLicensePlateResponse(plate)
+	}
+	return response
+}
+
+func convertToLicensePlateResponse(plate entity.LicensePlate) *dto.LicensePlateResponse {
+	return &dto.LicensePlateResponse{
+		Category:      plate.Category.String(),
+		CategoryLabel: plate.Category.Label(),
+		TwoDigits:     plate.TwoDigits,
+		Letter:        plate.Letter,
+		ThreeDigits:   plate.ThreeDigits,
+		RegionCode:    plate.RegionCode,
+		Number:        plate.Number,
+		FreeZone:      plate.FreeZone,
+		Display:       plate.Display(),
+	}
 }
 
 // Complete hierarchy
 func (uc *vehicleUseCase) GetCompleteVehicleHierarchy(ctx context.Context) (*dto.CompleteVehicleHierarchyResponse, error) {
+	vehicleTypes, version, err := uc.getVehicleHierarchy(ctx)
+	if err != nil {
+		return nil, errors.ErrFailedToListVehicleTypes
+	}
+	response := uc.convertToHierarchyResponse(vehicleTypes)
+	response.Version = version
+	return response, nil
+}
+
+// getVehicleHierarchy loads every vehicle type with its brands, models and generations at the current catalog
+// version, from the cache when it is there and from the database otherwise
+func (uc *vehicleUseCase) getVehicleHierarchy(ctx context.Context) ([]entity.VehicleType, uint64, error) {
 	var vehicleTypes []entity.VehicleType
 
+	// The version is read first, so a tree read later can only be newer and the changes after it are synced again
+	version, err := uc.catalogRepository.GetCatalogVersion(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle catalog version")
+		return nil, 0, err
+	}
+
 	// Try to get from cache first
-	err := uc.vehicleCacheRepository.GetVehicleHierarchy(ctx, &vehicleTypes)
+	err = uc.vehicleCacheRepository.GetVehicleHierarchy(ctx, version, &vehicleTypes)
 	if err != nil {
 		if err.Error() == "redis: nil" {
 			logger.Error(err, "Failed to get vehicle hierarchy from Redis")
@@ -1049,9 +1294,9 @@ func (uc *vehicleUseCase) GetCompleteVehicleHierarchy(ctx context.Context) (*dto
 				}
 	} else {
 		if len(vehicleTypes) > 0 {
-			// Cache hit - convert to response
+			// Cache hit
 			logger.Info("Vehicle hierarchy retrieved from cache")
-			return uc.convertToHierarchyResponse(vehicleTypes), nil
+			return vehicleTypes, version, nil
 		}
 	}
 
@@ -1059,11 +1304,11 @@ func (uc *vehicleUseCase) GetCompleteVehicleHierarchy(ctx context.Context) (*dto
 	err = uc.vehicleRepository.GetCompleteVehicleHierarchy(ctx, &vehicleTypes)
 	if err != nil {
 		logger.Error(err, "Failed to get complete vehicle hierarchy from database")
-		return nil, errors.ErrFailedToListVehicleTypes
+		return nil, 0, err
 	}
 
 	// Cache the result
-	err = uc.vehicleCacheRepository.SetVehicleHierarchy(ctx, vehicleTypes)
+	err = uc.vehicleCacheRepository.SetVehicleHierarchy(ctx, version, vehicleTypes)
 	if err != nil {
 		if err.Error() == "json: cannot unmarshal string into Go struct field VehicleType.VehicleBrands of type []entity.VehicleBrand" {
 			logger.Error(err, "Failed to marshal vehicle hierarchy")
@@ -1075,7 +1320,7 @@ func (uc *vehicleUseCase) GetCompleteVehicleHierarchy(ctx context.Context) (*dto
 		logger.Info("Vehicle hierarchy cached successfully")
 	}
 
-	return uc.convertToHierarchyResponse(vehicleTypes), nil
+	return vehicleTypes, version, nil
 }
 
 // Helper method to convert vehicle types to response
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/amirdashtii/AutoBan/internal/errors"
+	"github.com/amirdashtii/AutoBan/internal/repository"
+	"github.com/amirdashtii/AutoBan/internal/validation"
+	"github.com/amirdashtii/AutoBan/pkg/logger"
+)
+
+type VINUseCase interface {
+	DecodeVIN(ctx context.Context, request dto.DecodeVINRequest) (*dto.DecodeVINResponse, error)
+
+	// WMI table
+	ListVehicleWMIs(ctx context.Context) (*dto.ListVehicleWMIsResponse, error)
+	CreateVehicleWMI(ctx context.Context, request dto.CreateVehicleWMIRequest) (*dto.VehicleWMIResponse, error)
+	UpdateVehicleWMI(ctx context.Context, wmiID string, request dto.UpdateVehicleWMIRequest) (*dto.VehicleWMIResponse, error)
+	DeleteVehicleWMI(ctx context.Context, wmiID string) error
+}
+
+type vinUseCase struct {
+	vehicleWMIRepository repository.VehicleWMIRepository
+	vehicleRepository    repository.VehicleRepository
+}
+
+func NewVINUseCase() VINUseCase {
+	vehicleWMIRepository := repository.NewVehicleWMIRepository()
+	vehicleRepository := repository.NewVehicleRepository()
+	return &vinUseCase{
+		vehicleWMIRepository: vehicleWMIRepository,
+		vehicleRepository:    vehicleRepository,
+	}
+}
+
+// DecodeVIN splits the VIN into its sections and looks the WMI up in the WMI table. When the table knows the
+// model, the generations produced in the model year are listed and the latest one to start production is
+// suggested, together with the model year as production year
+func (uc *vinUseCase) DecodeVIN(ctx context.Context, request dto.DecodeVINRequest) (*dto.DecodeVINResponse, error) {
+	request.VIN = entity.NormalizeVIN(request.VIN)
+	err := validation.ValidateDecodeVINRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate decode vin request")
+		return nil, errors.ErrInvalidVIN
+	}
+
+	vin := entity.VIN(request.VIN)
+	currentYear := time.Now().Year()
+	response := &dto.DecodeVINResponse{
+		VIN:             string(vin),
+		WMI:             vin.WMI(),
+		VDS:             vin.VDS(),
+		CheckDigit:      vin.CheckDigit(),
+		CheckDigitValid: vin.CheckDigitValid(),
+		VIS:             vin.VIS(),
+		ModelYearCode:   vin.ModelYearCode(),
+		ModelYear:       vin.ModelYear(currentYear + 1),
+		PlantCode:       vin.PlantCode(),
+		SerialNumber:    vin.SerialNumber(),
+		Generations:     []dto.VehicleCatalogPathItem{},
+	}
+	if response.ModelYear != 0 {
+		// Vehicles of next model year are often sold this year
+		productionYear := min(response.ModelYear, currentYear)
+		response.ProductionYear = &productionYear
+	}
+
+	wmis := []entity.VehicleWMI{}
+	err = uc.vehicleWMIRepository.ListVehicleWMIsByCode(ctx, vin.WMI(), &wmis)
+	if err != nil {
+		logger.Error(err, "Failed to list vehicle wmis")
+		return nil, errors.ErrFailedToDecodeVIN
+	}
+	var match *entity.VehicleWMI
+	for i := range wmis {
+		if wmis[i].Matches(vin) {
+			match = &wmis[i]
+			break
+		}
+	}
+	if match == nil {
+		return response, nil
+	}
+	response.Manufacturer = match.Manufacturer
+
+	brand := entity.VehicleBrand{}
+	brand.ID = match.BrandID
+	err = uc.vehicleRepository.GetBrand(ctx, &brand)
+	if err != nil {
+		// The brand was deleted from the catalog after the WMI was added
+		logger.Error(err, "Failed to get brand of vehicle wmi")
+		return response, nil
+	}
+	response.Brand = &dto.VehicleCatalogPathItem{ID: brand.ID, NameFa: brand.NameFa, NameEn: brand.NameEn}
+
+	vehicleType := entity.VehicleType{}
+	vehicleType.ID = brand.VehicleTypeID
+	err = uc.vehicleRepository.GetVehicleType(ctx, &vehicleType)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle type of vehicle wmi")
+	} else {
+		response.Type = &dto.VehicleCatalogPathItem{ID: vehicleType.ID, NameFa: vehicleType.NameFa, NameEn: vehicleType.NameEn}
+	}
+
+	if match.ModelID == nil {
+		return response, nil
+	}
+	model := entity.VehicleModel{}
+	model.ID = *match.ModelID
+	err = uc.vehicleRepository.GetModel(ctx, &model)
+	if err != nil {
+		logger.Error(err, "Failed to get model of vehicle wmi")
+		return response, nil
+	}
+	response.Model = &dto.VehicleCatalogPathItem{ID: model.ID, NameFa: model.NameFa, NameEn: model.NameEn}
+
+	generations := []entity.VehicleGeneration{}
+	err = uc.vehicleRepository.ListGenerationsByModel(ctx, &generations, model.ID)
+	if err != nil {
+		logger.Error(err, "Failed to list generations of vehicle wmi model")
+		return nil, errors.ErrFailedToDecodeVIN
+	}
+	var suggested *entity.VehicleGeneration
+	for i, generation := range generations {
+		if response.ModelYear != 0 && !generationProducedIn(&generation, response.ModelYear) {
+			continue
+		}
+		response.Generations = append(response.Generations, dto.VehicleCatalogPathItem{ID: generation.ID, NameFa: generation.NameFa, NameEn: generation.NameEn})
+		if suggested == nil || generation.StartYear > suggested.StartYear {
+			suggested = &generations[i]
+		}
+	}
+	// Without a model year only a model with a single generation can be suggested
+	if suggested != nil && (response.ModelYear != 0 || len(response.Generations) == 1) {
+		response.GenerationID = &suggested.ID
+	}
+
+	return response, nil
+}
+
+// generationProducedIn reports whether the year falls within the production years of the generation. Unknown
+// start or end years are left open
+func generationProducedIn(generation *entity.VehicleGeneration, year int) bool {
+	if generation.StartYear != 0 && year < generation.StartYear {
+		return false
+	}
+	if generation.EndYear != 0 && year > generation.EndYear {
+		return false
+	}
+	return true
+}
+
+func (uc *vinUseCase) ListVehicleWMIs(ctx context.Context) (*dto.ListVehicleWMIsResponse, error) {
+	wmis := []entity.VehicleWMI{}
+	err := uc.vehicleWMIRepository.ListVehicleWMIs(ctx, &wmis)
+	if err != nil {
+		logger.Error(err, "Failed to list vehicle wmis")
+		return nil, errors.ErrFailedToListVehicleWMIs
+	}
+
+	wmisResponse := []dto.VehicleWMIResponse{}
+	for _, wmi := range wmis {
+		wmisResponse = append(wmisResponse, *mapVehicleWMIToResponse(&wmi))
+	}
+	return &dto.ListVehicleWMIsResponse{WMIs: wmisResponse}, nil
+}
+
+func (uc *vinUseCase) CreateVehicleWMI(ctx context.Context, request dto.CreateVehicleWMIRequest) (*dto.VehicleWMIResponse, error) {
+	request.WMI = entity.NormalizeVIN(request.WMI)
+	request.VDSPrefix = entity.NormalizeVIN(request.VDSPrefix)
+	err := validation.ValidateVehicleWMICreateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle wmi create request")
+		return nil, errors.ErrInvalidVehicleWMICreateRequest
+	}
+
+	wmi := entity.VehicleWMI{
+		WMI:          request.WMI,
+		VDSPrefix:    request.VDSPrefix,
+		Manufacturer: request.Manufacturer,
+		BrandID:      request.BrandID,
+		ModelID:      request.ModelID,
+	}
+	if wmi.ModelID != nil && *wmi.ModelID == 0 {
+		wmi.ModelID = nil
+	}
+	err = uc.checkVehicleWMI(ctx, &wmi)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.vehicleWMIRepository.CreateVehicleWMI(ctx, &wmi)
+	if err != nil {
+		logger.Error(err, "Failed to create vehicle wmi")
+		return nil, errors.ErrFailedToCreateVehicleWMI
+	}
+	return mapVehicleWMIToResponse(&wmi), nil
+}
+
+func (uc *vinUseCase) UpdateVehicleWMI(ctx context.Context, wmiID string, request dto.UpdateVehicleWMIRequest) (*dto.VehicleWMIResponse, error) {
+	if request.WMI != nil {
+		normalized := entity.NormalizeVIN(*request.WMI)
+		request.WMI = &normalized
+	}
+	if request.VDSPrefix != nil {
+		normalized := entity.NormalizeVIN(*request.VDSPrefix)
+		request.VDSPrefix = &normalized
+	}
+	err := validation.ValidateVehicleWMIUpdateRequest(request)
+	if err != nil {
+		logger.Error(err, "Failed to validate vehicle wmi update request")
+		return nil, errors.ErrInvalidVehicleWMIUpdateRequest
+	}
+
+	wmi, err := uc.getVehicleWMI(ctx, wmiID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.WMI != nil {
+		wmi.WMI = *request.WMI
+	}
+	if request.VDSPrefix != nil {
+		wmi.VDSPrefix = *request.VDSPrefix
+	}
+	if request.Manufacturer != nil {
+		wmi.Manufacturer = *request.Manufacturer
+	}
+	if request.BrandID != nil {
+		wmi.BrandID = *request.BrandID
+	}
+	if request.ModelID != nil {
+		wmi.ModelID = request.ModelID
+		if *request.ModelID == 0 {
+			wmi.ModelID = nil
+		}
+	}
+	err = uc.checkVehicleWMI(ctx, wmi)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.vehicleWMIRepository.UpdateVehicleWMI(ctx, wmi)
+	if err != nil {
+		logger.Error(err, "Failed to update vehicle wmi")
+		return nil, errors.ErrFailedToUpdateVehicleWMI
+	}
+	return mapVehicleWMIToResponse(wmi), nil
+}
+
+func (uc *vinUseCase) DeleteVehicleWMI(ctx context.Context, wmiID string) error {
+	wmi, err := uc.getVehicleWMI(ctx, wmiID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.vehicleWMIRepository.DeleteVehicleWMI(ctx, wmi)
+	if err != nil {
+		logger.Error(err, "Failed to delete vehicle wmi")
+		return errors.ErrFailedToDeleteVehicleWMI
+	}
+	return nil
+}
+
+// checkVehicleWMI makes sure the brand exists, the model belongs to it and no other row has the same WMI and
+// VDS prefix
+func (uc *vinUseCase) checkVehicleWMI(ctx context.Context, wmi *entity.VehicleWMI) error {
+	brand := entity.VehicleBrand{}
+	brand.ID = wmi.BrandID
+	err := uc.vehicleRepository.GetBrand(ctx, &brand)
+	if err != nil {
+		logger.Error(err, "Failed to get brand of vehicle wmi")
+		return errors.ErrVehicleWMIBrandNotFound
+	}
+	if wmi.ModelID != nil {
+		model := entity.VehicleModel{}
+		model.ID = *wmi.ModelID
+		err = uc.vehicleRepository.GetModel(ctx, &model)
+		if err != nil {
+			logger.Error(err, "Failed to get model of vehicle wmi")
+			return errors.ErrVehicleWMIModelNotInBrand
+		}
+		if model.BrandID != brand.ID {
+			return errors.ErrVehicleWMIModelNotInBrand
+		}
+	}
+
+	existing := []entity.VehicleWMI{}
+	err = uc.vehicleWMIRepository.ListVehicleWMIsByCode(ctx, wmi.WMI, &existing)
+	if err != nil {
+		logger.Error(err, "Failed to list vehicle wmis")
+		return errors.ErrFailedToGetVehicleWMI
+	}
+	for _, other := range existing {
+		if other.ID != wmi.ID && other.VDSPrefix == wmi.VDSPrefix {
+			return errors.ErrVehicleWMIAlreadyExists
+		}
+	}
+	return nil
+}
+
+func (uc *vinUseCase) getVehicleWMI(ctx context.Context, wmiID string) (*entity.VehicleWMI, error) {
+	uintWMIID, err := strconv.ParseUint(wmiID, 10, 64)
+	if err != nil {
+		logger.Error(err, "Failed to parse vehicle wmi id")
+		return nil, errors.ErrInvalidVehicleWMIID
+	}
+
+	wmi := entity.VehicleWMI{}
+	err = uc.vehicleWMIRepository.GetVehicleWMI(ctx, uintWMIID, &wmi)
+	if err != nil {
+		logger.Error(err, "Failed to get vehicle wmi")
+		return nil, errors.ErrFailedToGetVehicleWMI
+	}
+	if wmi.ID == 0 {
+		return nil, errors.ErrVehicleWMINotFound
+	}
+
+	return &wmi, nil
+}
+
+func mapVehicleWMIToResponse(wmi *entity.VehicleWMI) *dto.VehicleWMIResponse {
+	return &dto.VehicleWMIResponse{
+		ID:           wmi.ID,
+		WMI:          wmi.WMI,
+		VDSPrefix:    wmi.VDSPrefix,
+		Manufacturer: wmi.Manufacturer,
+		BrandID:      wmi.BrandID,
+		ModelID:      wmi.ModelID,
+	}
+}
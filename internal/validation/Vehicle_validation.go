@@ -5,8 +5,10 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
 	"github.com/amirdashtii/AutoBan/internal/dto"
 	"github.com/go-playground/validator/v10"
 )
@@ -42,57 +44,8 @@ func validateDate(fl validator.FieldLevel) bool {
 }
 
 func validateIranianLicensePlate(fl validator.FieldLevel) bool {
-	licensePlate := fl.Field().String()
-
-	// پلاک موتورسیکلت
-	motorcyclePlate := regexp.MustCompile(`^\d{3}-?\d{5}$`)
-	// پلاک سفید عادی (فقط حروف مشخص شده)
-	normalWhitePlate := regexp.MustCompile(`^\d{2}-[بجدسصطلقلمنوهی]-\d{3}-?\d{2}$`)
-	// پلاک سفید با حرف گ (گذر موقت)
-	temporaryPlate := regexp.MustCompile(`^\d{2}-گ-\d{3}-?\d{2}$`)
-	// پلاک سفید با حرف ژ (معلولین و جانبازان)
-	disabledPlate := regexp.MustCompile(`^\d{2}-ژ-\d{3}-?\d{2}$`)
-	// پلاک قرمز تشریفات دولتی
-	ceremonyPlate := regexp.MustCompile(`^\d{2}-تشریفات-\d{4}$`)
-	// پلاک قرمز با حرف الف (دولتی)
-	governmentPlate := regexp.MustCompile(`^\d{2}-الف-\d{3}-?\d{2}$`)
-	// پلاک سبز با حرف پ (فراجا، راهور، پلیس مبارزه با مواد مخدر)
-	policePlate := regexp.MustCompile(`^\d{2}-پ-\d{3}-?\d{2}$`)
-	// پلاک سبز با حرف ث (سپاه پاسداران)
-	irgcPlate := regexp.MustCompile(`^\d{2}-ث-\d{3}-?\d{2}$`)
-	// پلاک زرد با حرف ک (وسایل کشاورزی)
-	agriculturalPlate := regexp.MustCompile(`^\d{2}-ک-\d{3}-?\d{2}$`)
-	// پلاک زرد با حرف ع (حمل‌ونقل عمومی)
-	publicTransportPlate := regexp.MustCompile(`^\d{2}-ع-\d{3}-?\d{2}$`)
-	// پلاک زرد با حرف ت (تاکسی)
-	taxiPlate := regexp.MustCompile(`^\d{2}-ت-\d{3}-?\d{2}$`)
-	// پلاک آبی با حرف D (دیپلمات)
-	diplomatPlate := regexp.MustCompile(`^\d{2}-T-\d{3}-\d{2}$`)
-	// پلاک آبی با حرف S (سفارت)
-	embassyPlate := regexp.MustCompile(`^\d{2}-S-\d{3}-\d{2}$`)
-	// پلاک آبی با حرف ز (وزارت دفاع)
-	defensePlate := regexp.MustCompile(`^\d{2}-ز-\d{3}-?\d{2}$`)
-	// پلاک آبی با حرف ف (ستاد کل نیروهای مسلح)
-	militaryStaffPlate := regexp.MustCompile(`^\d{2}-ف-\d{3}-?\d{2}$`)
-	// پلاک کرِم یا خاکی (ارتش)
-	armyPlate := regexp.MustCompile(`^\d{2}-ش-\d{3}-?\d{2}$`)
-
-	return motorcyclePlate.MatchString(licensePlate) ||
-		normalWhitePlate.MatchString(licensePlate) ||
-		temporaryPlate.MatchString(licensePlate) ||
-		disabledPlate.MatchString(licensePlate) ||
-		ceremonyPlate.MatchString(licensePlate) ||
-		governmentPlate.MatchString(licensePlate) ||
-		policePlate.MatchString(licensePlate) ||
-		irgcPlate.MatchString(licensePlate) ||
-		agriculturalPlate.MatchString(licensePlate) ||
-		publicTransportPlate.MatchString(licensePlate) ||
-		taxiPlate.MatchString(licensePlate) ||
-		diplomatPlate.MatchString(licensePlate) ||
-		embassyPlate.MatchString(licensePlate) ||
-		defensePlate.MatchString(licensePlate) ||
-		militaryStaffPlate.MatchString(licensePlate) ||
-		armyPlate.MatchString(licensePlate)
+	_, err := entity.ParseLicensePlate(fl.Field().String())
+	return err == nil
 }
 
 func validateIranianVin(fl validator.FieldLevel) bool {
@@ -262,6 +215,8 @@ func ValidateVehicleGenerationCreateRequest(request dto.CreateVehicleGenerationR
 					return errors.New("invalid start year format")
 				case "EndYear":
 					return errors.New("invalid end year format")
+				case "OilCapacity":
+					return errors.New("oil capacity must be between 0 and 50 litres")
 				}
 			}
 		}
@@ -277,7 +232,8 @@ func ValidateVehicleGenerationUpdateRequest(request dto.UpdateVehicleGenerationR
 		request.EngineVolume == nil && request.Cylinders == nil && request.DrivetrainFa == nil &&
 		request.DrivetrainEn == nil && request.Gearbox == nil && request.FuelType == nil &&
 		request.Battery == nil && request.Seller == nil && request.AssemblyType == nil &&
-		request.Assembler == nil {
+		request.Assembler == nil && request.TireSizes == nil && request.OilViscosities == nil &&
+		request.OilCapacity == nil && request.OilFilterPartNumbers == nil {
 		return errors.New("no fields to update")
 	}
 
@@ -301,6 +257,8 @@ func ValidateVehicleGenerationUpdateRequest(request dto.UpdateVehicleGenerationR
 					return errors.New("invalid start year format")
 				case "EndYear":
 					return errors.New("invalid end year format")
+				case "OilCapacity":
+					return errors.New("oil capacity must be between 0 and 50 litres")
 				}
 			}
 		}
@@ -395,3 +353,29 @@ func ValidateUserVehicleUpdateRequest(request dto.UpdateUserVehicleRequest) erro
 
 	return nil
 }
+
+func ValidateSearchVehiclesRequest(request dto.SearchVehiclesRequest) error {
+	if strings.TrimSpace(request.Query) == "" {
+		return errors.New("search query is required")
+	}
+
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Query":
+					return errors.New("search query must be at most 100 characters")
+				case "Limit":
+					return errors.New("limit must be between 1 and 50")
+				default:
+					return errors.New("validation failed for search field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("search validation failed")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateExportCatalogRequest(request dto.ExportCatalogRequest) error {
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Format":
+					return errors.New("format must be one of: json, csv")
+				default:
+					return errors.New("validation failed for catalog export field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("catalog export validation failed")
+	}
+	return nil
+}
+
+// ValidateCatalogEntry validates a type, brand, model or generation of an imported catalog. Its children are
+// validated on their own
+func ValidateCatalogEntry(entry any) error {
+	validate := validator.New()
+	validate.RegisterValidation("year", validateYear)
+
+	err := validate.Struct(entry)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "NameEn":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("english name is required")
+					case "max":
+						return errors.New("english name must be at most 100 characters")
+					}
+				case "NameFa":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("persian name is required")
+					case "max":
+						return errors.New("persian name must be at most 100 characters")
+					}
+				case "StartYear":
+					return errors.New("invalid start year format")
+				case "EndYear":
+					return errors.New("invalid end year format")
+				case "EngineVolume":
+					return errors.New("engine volume must not be negative")
+				case "Cylinders":
+					return errors.New("cylinders must not be negative")
+				case "OilCapacity":
+					return errors.New("oil capacity must be between 0 and 50 litres")
+				}
+				return errors.New("validation failed for catalog field: " + fieldError.Field())
+			}
+		}
+		return errors.New("catalog validation failed")
+	}
+
+	if generation, ok := entry.(dto.CatalogGeneration); ok &&
+		generation.StartYear != 0 && generation.EndYear != 0 && generation.EndYear < generation.StartYear {
+		return errors.New("end year must not be before start year")
+	}
+	return nil
+}
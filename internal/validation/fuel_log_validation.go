@@ -0,0 +1,116 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateFuelLogCreateRequest(request dto.CreateFuelLogRequest) error {
+	if request.TotalCost == 0 && request.PricePerLitre == 0 {
+		return errors.New("total cost or price per litre is required")
+	}
+
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "FillDate":
+					if fieldError.Tag() == "date" {
+						return errors.New("invalid fill date format")
+					}
+				case "Mileage":
+					if fieldError.Tag() == "required" {
+						return errors.New("mileage is required")
+					}
+				case "FuelType":
+					if fieldError.Tag() == "oneof" {
+						return errors.New("fuel type must be one of: gasoline, diesel, cng")
+					}
+				case "Litres":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("litres is required")
+					case "gt":
+						return errors.New("litres must be greater than 0")
+					}
+				case "PricePerLitre":
+					if fieldError.Tag() == "min" {
+						return errors.New("price per litre cannot be negative")
+					}
+				case "TotalCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("total cost cannot be negative")
+					}
+				case "Station":
+					if fieldError.Tag() == "max" {
+						return errors.New("station must be at most 100 characters")
+					}
+				default:
+					return errors.New("validation failed for fuel log field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("fuel log validation failed")
+	}
+	return nil
+}
+
+func ValidateFuelLogUpdateRequest(request dto.UpdateFuelLogRequest) error {
+	// Check if at least one field has a value
+	if request.FillDate == nil && request.Mileage == nil && request.FuelType == nil && request.Litres == nil &&
+		request.PricePerLitre == nil && request.TotalCost == nil && request.IsFullTank == nil &&
+		request.Station == nil && request.Notes == nil {
+		return errors.New("no fields to update")
+	}
+
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "FillDate":
+					if fieldError.Tag() == "date" {
+						return errors.New("invalid fill date format")
+					}
+				case "Mileage":
+					if fieldError.Tag() == "min" {
+						return errors.New("mileage must be greater than 0")
+					}
+				case "FuelType":
+					if fieldError.Tag() == "oneof" {
+						return errors.New("fuel type must be one of: gasoline, diesel, cng")
+					}
+				case "Litres":
+					if fieldError.Tag() == "gt" {
+						return errors.New("litres must be greater than 0")
+					}
+				case "PricePerLitre":
+					if fieldError.Tag() == "min" {
+						return errors.New("price per litre cannot be negative")
+					}
+				case "TotalCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("total cost cannot be negative")
+					}
+				case "Station":
+					if fieldError.Tag() == "max" {
+						return errors.New("station must be at most 100 characters")
+					}
+				default:
+					return errors.New("validation failed for fuel log field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("fuel log validation failed")
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+// validateMaintenanceItemType accepts oil changes, oil filters and every specific service item type
+func validateMaintenanceItemType(fl validator.FieldLevel) bool {
+	itemType := fl.Field().String()
+	return itemType == "oil_change" || itemType == "oil_filter" ||
+		entity.ParseServiceItemType(itemType) != entity.OtherServiceItem
+}
+
+func ValidateMaintenanceIntervalCreateRequest(request dto.CreateMaintenanceIntervalRequest) error {
+	if request.IntervalMileage == 0 && request.IntervalMonths == 0 {
+		return errors.New("interval mileage or interval months is required")
+	}
+
+	validate := validator.New()
+	validate.RegisterValidation("maintenance_item_type", validateMaintenanceItemType)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "ItemType":
+					if fieldError.Tag() == "required" {
+						return errors.New("item type is required")
+					}
+					if fieldError.Tag() == "maintenance_item_type" {
+						return errors.New("invalid item type")
+					}
+				case "NameFa":
+					if fieldError.Tag() == "required" {
+						return errors.New("persian name is required")
+					}
+				case "IntervalMileage":
+					if fieldError.Tag() == "min" {
+						return errors.New("interval mileage must be greater than 0")
+					}
+				case "IntervalMonths":
+					if fieldError.Tag() == "min" {
+						return errors.New("interval months must be greater than 0")
+					}
+				default:
+					return errors.New("validation failed for maintenance interval field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("maintenance interval validation failed")
+	}
+	return nil
+}
+
+func ValidateMaintenanceIntervalUpdateRequest(request dto.UpdateMaintenanceIntervalRequest) error {
+	// Check if at least one field has a value
+	if request.NameFa == nil && request.NameEn == nil && request.IntervalMileage == nil && request.IntervalMonths == nil {
+		return errors.New("no fields to update")
+	}
+
+	// If NameFa is provided, validate it's not empty
+	if request.NameFa != nil && *request.NameFa == "" {
+		return errors.New("persian name is required")
+	}
+
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "IntervalMonths":
+					if fieldError.Tag() == "min" {
+						return errors.New("interval months cannot be negative")
+					}
+				default:
+					return errors.New("validation failed for maintenance interval field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("maintenance interval validation failed")
+	}
+	return nil
+}
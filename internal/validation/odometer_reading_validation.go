@@ -0,0 +1,35 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateOdometerReadingCreateRequest(request dto.CreateOdometerReadingRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Mileage":
+					if fieldError.Tag() == "required" {
+						return errors.New("mileage is required")
+					}
+				case "ReadingDate":
+					if fieldError.Tag() == "date" {
+						return errors.New("invalid reading date format")
+					}
+				default:
+					return errors.New("validation failed for odometer reading field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("odometer reading validation failed")
+	}
+	return nil
+}
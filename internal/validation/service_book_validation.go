@@ -0,0 +1,32 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateExportServiceBookRequest(request dto.ExportServiceBookRequest) error {
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Format":
+					return errors.New("format must be one of: pdf, csv")
+				case "Calendar":
+					return errors.New("calendar must be one of: gregorian, jalali")
+				case "Language":
+					return errors.New("language must be one of: fa, en")
+				default:
+					return errors.New("validation failed for service book export field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("service book export validation failed")
+	}
+	return nil
+}
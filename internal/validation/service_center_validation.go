@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateServiceCenterCreateRequest(request dto.CreateServiceCenterRequest) error {
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Name":
+					if fieldError.Tag() == "required" {
+						return errors.New("name is required")
+					}
+				case "Latitude":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("latitude is required")
+					case "latitude":
+						return errors.New("latitude must be between -90 and 90")
+					}
+				case "Longitude":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("longitude is required")
+					case "longitude":
+						return errors.New("longitude must be between -180 and 180")
+					}
+				default:
+					return errors.New("validation failed for service center field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("service center validation failed")
+	}
+	return nil
+}
+
+func ValidateServiceCenterUpdateRequest(request dto.UpdateServiceCenterRequest) error {
+	// Check if at least one field has a value
+	if request.Name == nil && request.City == nil && request.Address == nil && request.Phone == nil &&
+		request.Latitude == nil && request.Longitude == nil {
+		return errors.New("no fields to update")
+	}
+
+	// If Name is provided, validate it's not empty
+	if request.Name != nil && *request.Name == "" {
+		return errors.New("name is required")
+	}
+
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Latitude":
+					if fieldError.Tag() == "latitude" {
+						return errors.New("latitude must be between -90 and 90")
+					}
+				case "Longitude":
+					if fieldError.Tag() == "longitude" {
+						return errors.New("longitude must be between -180 and 180")
+					}
+				default:
+					return errors.New("validation failed for service center field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("service center validation failed")
+	}
+	return nil
+}
+
+func ValidateNearbyServiceCentersRequest(request dto.NearbyServiceCentersRequest) error {
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Latitude":
+					if fieldError.Tag() == "required" {
+						return errors.New("latitude is required")
+					}
+					return errors.New("latitude must be between -90 and 90")
+				case "Longitude":
+					if fieldError.Tag() == "required" {
+						return errors.New("longitude is required")
+					}
+					return errors.New("longitude must be between -180 and 180")
+				case "RadiusKm":
+					return errors.New("radius must be greater than 0 and at most 100 km")
+				case "Limit":
+					return errors.New("limit must be between 1 and 100")
+				default:
+					return errors.New("validation failed for nearby search field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("nearby search validation failed")
+	}
+	return nil
+}
+
+func ValidateServiceCenterReviewRequest(request dto.ReviewServiceCenterRequest) error {
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Rating":
+					if fieldError.Tag() == "required" {
+						return errors.New("rating is required")
+					}
+					return errors.New("rating must be between 1 and 5")
+				case "Comment":
+					if fieldError.Tag() == "max" {
+						return errors.New("comment must be at most 1000 characters")
+					}
+				default:
+					return errors.New("validation failed for review field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("review validation failed")
+	}
+	return nil
+}
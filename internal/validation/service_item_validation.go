@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func validateServiceItemType(fl validator.FieldLevel) bool {
+	itemType := fl.Field().String()
+	return itemType == entity.OtherServiceItem.String() ||
+		entity.ParseServiceItemType(itemType) != entity.OtherServiceItem
+}
+
+func ValidateServiceItemCreateRequest(request dto.CreateServiceItemRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+	validate.RegisterValidation("service_item_type", validateServiceItemType)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "ItemType":
+					if fieldError.Tag() == "required" {
+						return errors.New("item type is required")
+					}
+					if fieldError.Tag() == "service_item_type" {
+						return errors.New("invalid item type")
+					}
+				case "Name":
+					if fieldError.Tag() == "required" {
+						return errors.New("name is required")
+					}
+				case "NextChangeMileage":
+					if fieldError.Tag() == "min" {
+						return errors.New("next change mileage must be greater than 0")
+					}
+				case "NextChangeDate":
+					if fieldError.Tag() == "date" {
+						return errors.New("invalid next change date format")
+					}
+				case "LabourCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("labour cost cannot be negative")
+					}
+				case "PartsCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("parts cost cannot be negative")
+					}
+				default:
+					return errors.New("validation failed for service item field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("service item validation failed")
+	}
+	return nil
+}
+
+func ValidateServiceItemUpdateRequest(request dto.UpdateServiceItemRequest) error {
+	// Check if at least one field has a value
+	if request.ItemType == nil && request.Name == nil && request.Brand == nil && request.PartNumber == nil &&
+		request.NextChangeMileage == nil && request.NextChangeDate == nil && request.Notes == nil &&
+		request.LabourCost == nil && request.PartsCost == nil {
+		return errors.New("no fields to update")
+	}
+
+	// If Name is provided, validate it's not empty
+	if request.Name != nil && *request.Name == "" {
+		return errors.New("name is required")
+	}
+
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+	validate.RegisterValidation("service_item_type", validateServiceItemType)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "ItemType":
+					if fieldError.Tag() == "service_item_type" {
+						return errors.New("invalid item type")
+					}
+				case "NextChangeMileage":
+					if fieldError.Tag() == "min" {
+						return errors.New("next change mileage must be greater than 0")
+					}
+				case "NextChangeDate":
+					if fieldError.Tag() == "date" {
+						return errors.New("invalid next change date format")
+					}
+				case "LabourCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("labour cost cannot be negative")
+					}
+				case "PartsCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("parts cost cannot be negative")
+					}
+				default:
+					return errors.New("validation failed for service item field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("service item validation failed")
+	}
+	return nil
+}
@@ -34,6 +34,14 @@ func ValidateServiceVisitCreateRequest(request dto.CreateServiceVisitRequest) er
 					if fieldError.Tag() == "date" {
 						return errors.New("invalid service date format")
 					}
+				case "LabourCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("labour cost cannot be negative")
+					}
+				case "PartsCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("parts cost cannot be negative")
+					}
 				default:
 					return errors.New("validation failed for field: " + fieldError.Field())
 				}
@@ -56,13 +64,21 @@ func ValidateServiceVisitCreateRequest(request dto.CreateServiceVisitRequest) er
 		}
 	}
 
+	// Validate nested service items if provided
+	for _, serviceItem := range request.ServiceItems {
+		if err := ValidateServiceItemCreateRequest(serviceItem); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func ValidateServiceVisitUpdateRequest(request dto.UpdateServiceVisitRequest) error {
 	// Check if at least one field has a value
 	if request.ServiceMileage == nil && request.ServiceDate == nil && request.ServiceCenter == nil &&
-		request.Notes == nil && request.OilChange == nil && request.OilFilter == nil {
+		request.ServiceCenterID == nil && request.Notes == nil && request.LabourCost == nil && request.PartsCost == nil &&
+		request.OilChange == nil && request.OilFilter == nil {
 		return errors.New("no fields to update")
 	}
 
@@ -82,6 +98,14 @@ func ValidateServiceVisitUpdateRequest(request dto.UpdateServiceVisitRequest) er
 					if fieldError.Tag() == "date" {
 						return errors.New("invalid service date format")
 					}
+				case "LabourCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("labour cost cannot be negative")
+					}
+				case "PartsCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("parts cost cannot be negative")
+					}
 				default:
 					return errors.New("validation failed for field: " + fieldError.Field())
 				}
@@ -128,6 +152,14 @@ func validateServiceVisitOilChange(oilChange dto.ServiceVisitOilChange) error {
 					if fieldError.Tag() == "min" {
 						return errors.New("next change mileage must be greater than 0")
 					}
+				case "LabourCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("labour cost cannot be negative")
+					}
+				case "PartsCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("parts cost cannot be negative")
+					}
 				default:
 					return errors.New("validation failed for oil change field: " + fieldError.Field())
 				}
@@ -159,6 +191,14 @@ func validateServiceVisitOilFilter(oilFilter dto.ServiceVisitOilFilter) error {
 					if fieldError.Tag() == "date" {
 						return errors.New("invalid next change date format")
 					}
+				case "LabourCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("labour cost cannot be negative")
+					}
+				case "PartsCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("parts cost cannot be negative")
+					}
 				default:
 					return errors.New("validation failed for oil filter field: " + fieldError.Field())
 				}
@@ -186,6 +226,14 @@ func validateUpdateServiceVisitOilChange(oilChange dto.UpdateServiceVisitOilChan
 					if fieldError.Tag() == "min" {
 						return errors.New("next change mileage must be greater than 0")
 					}
+				case "LabourCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("labour cost cannot be negative")
+					}
+				case "PartsCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("parts cost cannot be negative")
+					}
 				default:
 					return errors.New("validation failed for oil change field: " + fieldError.Field())
 				}
@@ -213,6 +261,14 @@ func validateUpdateServiceVisitOilFilter(oilFilter dto.UpdateServiceVisitOilFilt
 					if fieldError.Tag() == "date" {
 						return errors.New("invalid next change date format")
 					}
+				case "LabourCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("labour cost cannot be negative")
+					}
+				case "PartsCost":
+					if fieldError.Tag() == "min" {
+						return errors.New("parts cost cannot be negative")
+					}
 				default:
 					return errors.New("validation failed for oil filter field: " + fieldError.Field())
 				}
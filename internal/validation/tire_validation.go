@@ -0,0 +1,157 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateTireSetCreateRequest(request dto.CreateTireSetRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Brand":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("brand is required")
+					case "max":
+						return errors.New("brand must be at most 50 characters")
+					}
+				case "Model":
+					return errors.New("model must be at most 50 characters")
+				case "Size":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("size is required")
+					case "max":
+						return errors.New("size must be at most 30 characters")
+					}
+				case "Season":
+					return errors.New("season must be one of: all_season, summer, winter")
+				case "DOTCode":
+					return errors.New("dot code must be four digits, week and year")
+				case "PurchaseDate":
+					return errors.New("invalid purchase date format")
+				default:
+					return errors.New("validation failed for tire set field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("tire set validation failed")
+	}
+	return nil
+}
+
+func ValidateTireSetUpdateRequest(request dto.UpdateTireSetRequest) error {
+	// Check if at least one field has a value
+	if request.Brand == nil && request.Model == nil && request.Size == nil && request.Season == nil &&
+		request.DOTCode == nil && request.PurchaseDate == nil && request.PurchaseMileage == nil && request.Notes == nil {
+		return errors.New("no fields to update")
+	}
+
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Brand":
+					return errors.New("brand must be between 1 and 50 characters")
+				case "Model":
+					return errors.New("model must be at most 50 characters")
+				case "Size":
+					return errors.New("size must be between 1 and 30 characters")
+				case "Season":
+					return errors.New("season must be one of: all_season, summer, winter")
+				case "DOTCode":
+					return errors.New("dot code must be four digits, week and year")
+				case "PurchaseDate":
+					return errors.New("invalid purchase date format")
+				default:
+					return errors.New("validation failed for tire set field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("tire set validation failed")
+	}
+	return nil
+}
+
+func ValidateTireMountRequest(request dto.TireMountRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Mileage":
+					return errors.New("mileage is required")
+				case "Date":
+					return errors.New("invalid date format")
+				default:
+					return errors.New("validation failed for tire mount field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("tire mount validation failed")
+	}
+	return nil
+}
+
+func ValidateTireRotationRequest(request dto.CreateTireRotationRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Mileage":
+					return errors.New("mileage is required")
+				case "RotationDate":
+					return errors.New("invalid rotation date format")
+				case "Pattern":
+					return errors.New("pattern must be at most 50 characters")
+				default:
+					return errors.New("validation failed for tire rotation field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("tire rotation validation failed")
+	}
+	return nil
+}
+
+func ValidateTreadDepthRequest(request dto.CreateTreadDepthRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "MeasuredAt":
+					return errors.New("invalid measurement date format")
+				case "FrontLeft", "FrontRight", "RearLeft", "RearRight":
+					return errors.New("all four tread depths are required, above 0 and up to 20 mm")
+				default:
+					return errors.New("validation failed for tread depth field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("tread depth validation failed")
+	}
+	return nil
+}
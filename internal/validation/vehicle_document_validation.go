@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateVehicleDocumentCreateRequest(request dto.CreateVehicleDocumentRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "DocumentType":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("document type is required")
+					case "oneof":
+						return errors.New("document type must be one of: third_party_insurance, body_insurance, technical_inspection")
+					}
+				case "Issuer":
+					if fieldError.Tag() == "max" {
+						return errors.New("issuer must be at most 100 characters")
+					}
+				case "PolicyNumber":
+					if fieldError.Tag() == "max" {
+						return errors.New("policy number must be at most 50 characters")
+					}
+				case "StartDate":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("start date is required")
+					case "date":
+						return errors.New("invalid start date format")
+					}
+				case "ExpiryDate":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("expiry date is required")
+					case "date":
+						return errors.New("invalid expiry date format")
+					}
+				default:
+					return errors.New("validation failed for vehicle document field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("vehicle document validation failed")
+	}
+	return nil
+}
+
+func ValidateVehicleDocumentUpdateRequest(request dto.UpdateVehicleDocumentRequest) error {
+	// Check if at least one field has a value
+	if request.DocumentType == nil && request.Issuer == nil && request.PolicyNumber == nil &&
+		request.StartDate == nil && request.ExpiryDate == nil && request.Notes == nil {
+		return errors.New("no fields to update")
+	}
+
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "DocumentType":
+					if fieldError.Tag() == "oneof" {
+						return errors.New("document type must be one of: third_party_insurance, body_insurance, technical_inspection")
+					}
+				case "Issuer":
+					if fieldError.Tag() == "max" {
+						return errors.New("issuer must be at most 100 characters")
+					}
+				case "PolicyNumber":
+					if fieldError.Tag() == "max" {
+						return errors.New("policy number must be at most 50 characters")
+					}
+				case "StartDate":
+					if fieldError.Tag() == "date" {
+						return errors.New("invalid start date format")
+					}
+				case "ExpiryDate":
+					if fieldError.Tag() == "date" {
+						return errors.New("invalid expiry date format")
+					}
+				default:
+					return errors.New("validation failed for vehicle document field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("vehicle document validation failed")
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateVehicleExpenseCreateRequest(request dto.CreateVehicleExpenseRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Category":
+					if fieldError.Tag() == "oneof" {
+						return errors.New("category must be one of: parking, toll, car_wash, registration, fine, other")
+					}
+				case "Amount":
+					switch fieldError.Tag() {
+					case "required":
+						return errors.New("amount is required")
+					case "gt":
+						return errors.New("amount must be greater than 0")
+					}
+				case "ExpenseDate":
+					if fieldError.Tag() == "date" {
+						return errors.New("invalid expense date format")
+					}
+				default:
+					return errors.New("validation failed for vehicle expense field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("vehicle expense validation failed")
+	}
+	return nil
+}
+
+func ValidateVehicleExpenseUpdateRequest(request dto.UpdateVehicleExpenseRequest) error {
+	// Check if at least one field has a value
+	if request.Category == nil && request.CustomCategoryID == nil && request.Amount == nil &&
+		request.ExpenseDate == nil && request.Mileage == nil && request.Notes == nil {
+		return errors.New("no fields to update")
+	}
+
+	validate := validator.New()
+	validate.RegisterValidation("date", validateDate)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Category":
+					if fieldError.Tag() == "oneof" {
+						return errors.New("category must be one of: parking, toll, car_wash, registration, fine, other")
+					}
+				case "Amount":
+					if fieldError.Tag() == "gt" {
+						return errors.New("amount must be greater than 0")
+					}
+				case "ExpenseDate":
+					if fieldError.Tag() == "date" {
+						return errors.New("invalid expense date format")
+					}
+				default:
+					return errors.New("validation failed for vehicle expense field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("vehicle expense validation failed")
+	}
+	return nil
+}
+
+func ValidateExpenseCategoryRequest(request dto.ExpenseCategoryRequest) error {
+	if strings.TrimSpace(request.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Name":
+					if fieldError.Tag() == "max" {
+						return errors.New("name must be at most 50 characters")
+					}
+				default:
+					return errors.New("validation failed for expense category field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("expense category validation failed")
+	}
+	return nil
+}
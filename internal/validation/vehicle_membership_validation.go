@@ -0,0 +1,60 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateInviteVehicleMemberRequest(request dto.InviteVehicleMemberRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("iranphone", iranPhone)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "PhoneNumber":
+					if fieldError.Tag() == "required" {
+						return errors.New("phone number is required")
+					}
+					return errors.New("phone number must be a valid Iranian mobile number")
+				case "Role":
+					if fieldError.Tag() == "required" {
+						return errors.New("role is required")
+					}
+					return errors.New("role must be editor or viewer")
+				default:
+					return errors.New("validation failed for vehicle membership field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("vehicle membership validation failed")
+	}
+	return nil
+}
+
+func ValidateUpdateVehicleMemberRequest(request dto.UpdateVehicleMemberRequest) error {
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Role":
+					if fieldError.Tag() == "required" {
+						return errors.New("role is required")
+					}
+					return errors.New("role must be editor or viewer")
+				default:
+					return errors.New("validation failed for vehicle membership field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("vehicle membership update validation failed")
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateCreateVehicleShareLinkRequest(request dto.CreateVehicleShareLinkRequest) error {
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "ExpiresInDays":
+					return errors.New("expires in days must be between 1 and 365")
+				default:
+					return errors.New("validation failed for vehicle share link field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("vehicle share link validation failed")
+	}
+	return nil
+}
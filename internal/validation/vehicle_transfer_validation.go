@@ -0,0 +1,55 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateStartVehicleTransferRequest(request dto.StartVehicleTransferRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("iranphone", iranPhone)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "PhoneNumber":
+					if fieldError.Tag() == "required" {
+						return errors.New("phone number is required")
+					}
+					return errors.New("phone number must be a valid Iranian mobile number")
+				default:
+					return errors.New("validation failed for vehicle transfer field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("vehicle transfer validation failed")
+	}
+	return nil
+}
+
+func ValidateConfirmVehicleTransferRequest(request dto.ConfirmVehicleTransferRequest) error {
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "Code":
+					if fieldError.Tag() == "required" {
+						return errors.New("confirmation code is required")
+					}
+					return errors.New("confirmation code must be 6 digits")
+				default:
+					return errors.New("validation failed for vehicle transfer confirmation field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("vehicle transfer confirmation validation failed")
+	}
+	return nil
+}
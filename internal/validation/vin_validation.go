@@ -0,0 +1,115 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/amirdashtii/AutoBan/internal/domain/entity"
+	"github.com/amirdashtii/AutoBan/internal/dto"
+	"github.com/go-playground/validator/v10"
+)
+
+func ValidateDecodeVINRequest(request dto.DecodeVINRequest) error {
+	validate := validator.New()
+	validate.RegisterValidation("iranian_vin", validateIranianVin)
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "VIN":
+					if fieldError.Tag() == "required" {
+						return errors.New("vin is required")
+					}
+					return errors.New("vin must be 17 letters and digits without I, O and Q")
+				default:
+					return errors.New("validation failed for vin field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("vin validation failed")
+	}
+	return nil
+}
+
+func ValidateVehicleWMICreateRequest(request dto.CreateVehicleWMIRequest) error {
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "WMI":
+					return errors.New("wmi must be 3 characters")
+				case "VDSPrefix":
+					return errors.New("vds prefix must be at most 5 characters")
+				case "Manufacturer":
+					if fieldError.Tag() == "required" {
+						return errors.New("manufacturer is required")
+					}
+					return errors.New("manufacturer must be at most 100 characters")
+				case "BrandID":
+					return errors.New("brand id is required")
+				default:
+					return errors.New("validation failed for wmi field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("wmi validation failed")
+	}
+
+	return validateVINCodes(request.WMI, request.VDSPrefix)
+}
+
+func ValidateVehicleWMIUpdateRequest(request dto.UpdateVehicleWMIRequest) error {
+	if request.WMI == nil && request.VDSPrefix == nil && request.Manufacturer == nil && request.BrandID == nil && request.ModelID == nil {
+		return errors.New("at least one field must be provided for update")
+	}
+	if request.Manufacturer != nil && *request.Manufacturer == "" {
+		return errors.New("manufacturer is required")
+	}
+	if request.BrandID != nil && *request.BrandID == 0 {
+		return errors.New("brand id is required")
+	}
+
+	validate := validator.New()
+
+	err := validate.Struct(request)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, fieldError := range validationErrors {
+				switch fieldError.Field() {
+				case "WMI":
+					return errors.New("wmi must be 3 characters")
+				case "VDSPrefix":
+					return errors.New("vds prefix must be at most 5 characters")
+				case "Manufacturer":
+					return errors.New("manufacturer must be at most 100 characters")
+				default:
+					return errors.New("validation failed for wmi field: " + fieldError.Field())
+				}
+			}
+		}
+		return errors.New("wmi validation failed")
+	}
+
+	wmi, vdsPrefix := "", ""
+	if request.WMI != nil {
+		wmi = *request.WMI
+	}
+	if request.VDSPrefix != nil {
+		vdsPrefix = *request.VDSPrefix
+	}
+	return validateVINCodes(wmi, vdsPrefix)
+}
+
+func validateVINCodes(wmi, vdsPrefix string) error {
+	if !entity.IsValidVINCode(wmi) {
+		return errors.New("wmi may only use VIN characters: letters except I, O and Q, and digits")
+	}
+	if !entity.IsValidVINCode(vdsPrefix) {
+		return errors.New("vds prefix may only use VIN characters: letters except I, O and Q, and digits")
+	}
+	return nil
+}
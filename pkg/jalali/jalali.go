@@ -0,0 +1,115 @@
+// Package jalali converts Gregorian dates to the Solar Hijri (Jalali) calendar used in Iran.
+package jalali
+
+import (
+	"fmt"
+	"time"
+)
+
+// gregorianDaysBeforeMonth is the number of days in a non-leap Gregorian year before each month
+var gregorianDaysBeforeMonth = [12]int{0, 31, 59, 90, 120, 151, 181, 212, 243, 273, 304, 334}
+
+// Date is a day of the Jalali calendar
+type Date struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// FromTime converts the calendar day of t, in t's location, to the Jalali calendar
+func FromTime(t time.Time) Date {
+	gy, gm, gd := t.Date()
+	return FromGregorian(gy, int(gm), gd)
+}
+
+// FromGregorian converts a Gregorian date to the Jalali calendar using the 33-year cycle,
+// which matches the official calendar for the years in practical use
+func FromGregorian(gy, gm, gd int) Date {
+	gy2 := gy
+	if gm > 2 {
+		gy2 = gy + 1
+	}
+	days := 355666 + 365*gy + (gy2+3)/4 - (gy2+99)/100 + (gy2+399)/400 + gd + gregorianDaysBeforeMonth[gm-1]
+
+	jy := -1595 + 33*(days/12053)
+	days %= 12053
+	jy += 4 * (days / 1461)
+	days %= 1461
+	if days > 365 {
+		jy += (days - 1) / 365
+		days = (days - 1) % 365
+	}
+
+	if days < 186 {
+		return Date{Year: jy, Month: 1 + days/31, Day: 1 + days%31}
+	}
+	return Date{Year: jy, Month: 7 + (days-186)/30, Day: 1 + (days-186)%30}
+}
+
+// Valid reports whether the month and day exist in the Jalali calendar. Esfand has 30 days in leap years
+// and 29 in the others
+func (d Date) Valid() bool {
+	switch {
+	case d.Month < 1 || d.Month > 12 || d.Day < 1:
+		return false
+	case d.Month <= 6:
+		return d.Day <= 31
+	case d.Month <= 11:
+		return d.Day <= 30
+	case IsLeap(d.Year):
+		return d.Day <= 30
+	default:
+		return d.Day <= 29
+	}
+}
+
+// IsLeap reports whether the Jalali year has 366 days, using the same 33-year cycle as the conversions
+func IsLeap(year int) bool {
+	return leapDaysBefore(year+1) > leapDaysBefore(year)
+}
+
+// leapDaysBefore counts the leap days of the 33-year cycle up to the start of the Jalali year
+func leapDaysBefore(year int) int {
+	jy := year + 1595
+	return (jy/33)*8 + (jy%33+3)/4
+}
+
+// Time converts the date back to the Gregorian calendar and returns midnight of that day in loc
+func (d Date) Time(loc *time.Location) time.Time {
+	jy := d.Year + 1595
+	days := -355668 + 365*jy + leapDaysBefore(d.Year) + d.Day
+	if d.Month < 7 {
+		days += (d.Month - 1) * 31
+	} else {
+		days += (d.Month-7)*30 + 186
+	}
+
+	gy := 400 * (days / 146097)
+	days %= 146097
+	if days > 36524 {
+		days--
+		gy += 100 * (days / 36524)
+		days %= 36524
+		if days >= 365 {
+			days++
+		}
+	}
+	gy += 4 * (days / 1461)
+	days %= 1461
+	if days > 365 {
+		gy += (days - 1) / 365
+		days = (days - 1) % 365
+	}
+	// days is now the zero based day of the Gregorian year
+	return time.Date(gy, time.January, 1+days, 0, 0, 0, 0, loc)
+}
+
+// String formats the date as YYYY/MM/DD, the usual written form in Iran
+func (d Date) String() string {
+	return fmt.Sprintf("%04d/%02d/%02d", d.Year, d.Month, d.Day)
+}
+
+// Format formats the calendar day of t in the Jalali calendar as YYYY/MM/DD
+func Format(t time.Time) string {
+	return FromTime(t).String()
+}
@@ -0,0 +1,37 @@
+// Package persian normalizes Persian text typed on Persian and Arabic keyboards so it can be compared.
+package persian
+
+import "strings"
+
+const zeroWidthNonJoiner = '\u200c'
+
+// letterReplacer maps Arabic letters that look like Persian ones to their Persian form
+var letterReplacer = strings.NewReplacer(
+	"ي", "ی", // Arabic yeh to Persian yeh
+	"ى", "ی", // Arabic alef maksura to Persian yeh
+	"ك", "ک", // Arabic kaf to Persian keheh
+)
+
+// ToLatinDigits converts Persian and Arabic-Indic digits to ASCII digits and leaves everything else as is
+func ToLatinDigits(value string) string {
+	var b strings.Builder
+	b.Grow(len(value))
+	for _, r := range value {
+		switch {
+		case r >= '۰' && r <= '۹':
+			b.WriteRune('0' + r - '۰')
+		case r >= '٠' && r <= '٩':
+			b.WriteRune('0' + r - '٠')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Normalize converts Arabic yeh and kaf to their Persian forms and Persian and Arabic digits to ASCII, and
+// removes zero-width non-joiners, so "پژو ۲۰۶" and "پژو 206" or "كيا" and "کیا" compare equal
+func Normalize(value string) string {
+	value = strings.ReplaceAll(value, string(zeroWidthNonJoiner), "")
+	return ToLatinDigits(letterReplacer.Replace(value))
+}